@@ -44,11 +44,13 @@ func main() {
 	// - 依赖注入
 	// - 生命周期（启动/停止）
 	// - 优雅关闭
-	container.Run(cfg, setupMiddleware)
+	container.Run(cfg, func(router *gin.Engine, monitor *internal_utils.SimpleMonitor, logger *zap.Logger) {
+		setupMiddleware(router, monitor, logger, cfg)
+	})
 }
 
 // setupMiddleware 设置全局中间件
-func setupMiddleware(router *gin.Engine, monitor *internal_utils.SimpleMonitor, logger *zap.Logger) {
+func setupMiddleware(router *gin.Engine, monitor *internal_utils.SimpleMonitor, logger *zap.Logger, cfg *config.Config) {
 	// 请求ID中间件（最先设置，确保所有后续中间件都能使用请求ID）
 	router.Use(middleware.RequestIDMiddleware())
 
@@ -73,8 +75,10 @@ func setupMiddleware(router *gin.Engine, monitor *internal_utils.SimpleMonitor,
 	// 安全验证中间件（跳过 swagger 路径）
 	router.Use(middleware.SkipForSwagger(middleware.SecurityValidationMiddleware(logger)))
 
-	// SQL安全中间件（跳过 swagger 路径）
-	router.Use(middleware.SkipForSwagger(middleware.SQLSecurityMiddleware(logger)))
+	// SQL安全中间件（跳过 swagger 路径），按路由的参数规则校验，detect 模式下可疑参数只记录日志
+	sqlSecurityConfig := middleware.DefaultSQLSecurityConfig()
+	sqlSecurityConfig.Mode = cfg.Security.SQLFilterMode
+	router.Use(middleware.SkipForSwagger(middleware.SQLSecurityMiddlewareWithConfig(logger, sqlSecurityConfig)))
 
 	// 增强输入验证中间件（跳过 swagger 路径）
 	router.Use(middleware.SkipForSwagger(middleware.EnhancedInputValidationMiddleware()))
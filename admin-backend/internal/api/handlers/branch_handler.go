@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BranchHandler 翻译分支处理器
+type BranchHandler struct {
+	branchService domain.BranchService
+	logger        *zap.Logger
+}
+
+// NewBranchHandler 创建翻译分支处理器
+func NewBranchHandler(branchService domain.BranchService, logger *zap.Logger) *BranchHandler {
+	return &BranchHandler{
+		branchService: branchService,
+		logger:        logger,
+	}
+}
+
+// parseBranchID 解析路径中的分支ID
+func parseBranchID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的分支ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List 获取翻译分支列表
+// @Summary      获取翻译分支列表
+// @Description  获取项目的翻译分支及各分支修改的单元格数，未合并的分支在前
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {array}   domain.TranslationBranch
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id} [get]
+func (h *BranchHandler) List(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	branches, err := h.branchService.List(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取翻译分支列表失败")
+		return
+	}
+
+	response.Success(ctx, branches)
+}
+
+// Create 创建翻译分支
+// @Summary      创建翻译分支
+// @Description  创建空的翻译分支，分支上的修改在合并前不影响主干；分支名在项目内唯一
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        request     body      dto.CreateBranchRequest  true  "分支信息"
+// @Success      201         {object}  domain.TranslationBranch
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id} [post]
+func (h *BranchHandler) Create(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateBranchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	branch, err := h.branchService.Create(ctx.Request.Context(), domain.CreateBranchParams{
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "创建翻译分支失败")
+		return
+	}
+
+	h.logger.Info("Translation branch created",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("branch_id", branch.ID),
+		zap.String("name", branch.Name),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Created(ctx, branch)
+}
+
+// Get 获取翻译分支
+// @Summary      获取翻译分支
+// @Description  获取分支及其修改的所有单元格，包括分支第一次修改时主干的译文
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "分支ID"
+// @Success      200         {object}  domain.BranchDetail
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id} [get]
+func (h *BranchHandler) Get(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	detail, err := h.branchService.Get(ctx.Request.Context(), projectID, id)
+	if err != nil {
+		respondServiceError(ctx, err, "获取翻译分支失败")
+		return
+	}
+
+	response.Success(ctx, detail)
+}
+
+// GetMatrix 获取分支上的译文
+// @Summary      获取分支上的译文
+// @Description  主干当前的译文叠加分支的修改（键名 -> 语言代码 -> 译文）
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "分支ID"
+// @Success      200         {object}  map[string]map[string]string
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id}/matrix [get]
+func (h *BranchHandler) GetMatrix(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	matrix, err := h.branchService.GetMatrix(ctx.Request.Context(), projectID, id)
+	if err != nil {
+		respondServiceError(ctx, err, "获取分支译文失败")
+		return
+	}
+
+	response.Success(ctx, matrix)
+}
+
+// SetValues 修改分支译文
+// @Summary      修改分支译文
+// @Description  修改或删除分支上的单元格；deleted 为 true 且 language 为空时删除整个键。改回主干原值的单元格不再算作修改
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        id          path      int                         true  "分支ID"
+// @Param        request     body      dto.SetBranchValuesRequest  true  "修改的单元格"
+// @Success      200         {object}  domain.SetBranchValuesResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id}/values [put]
+func (h *BranchHandler) SetValues(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.SetBranchValuesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	cells := make([]domain.BranchCellInput, len(req.Cells))
+	for i, cell := range req.Cells {
+		cells[i] = domain.BranchCellInput{
+			KeyName:      cell.KeyName,
+			LanguageCode: cell.Language,
+			Value:        cell.Value,
+			Context:      cell.Context,
+			Deleted:      cell.Deleted,
+		}
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.branchService.SetValues(ctx.Request.Context(), domain.SetBranchValuesParams{
+		ProjectID: projectID,
+		BranchID:  id,
+		Cells:     cells,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "修改分支译文失败")
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// Revert 丢弃分支上的修改
+// @Summary      丢弃分支上的修改
+// @Description  丢弃分支上指定键的所有修改，这些键恢复为主干当前的译文
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        id          path      int                      true  "分支ID"
+// @Param        request     body      dto.RevertBranchRequest  true  "键名"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id}/revert [post]
+func (h *BranchHandler) Revert(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.RevertBranchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	reverted, err := h.branchService.Revert(ctx.Request.Context(), projectID, id, req.KeyNames)
+	if err != nil {
+		respondServiceError(ctx, err, "丢弃分支修改失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"reverted": reverted})
+}
+
+// Merge 合并分支
+// @Summary      合并分支
+// @Description  把分支的修改写入主干。分支修改之后主干也修改了的单元格为冲突，须在 resolutions 中逐一解决；
+// @Description  存在未解决的冲突时不做任何修改，merged 为 false 并列出冲突。dry_run 为 true 时只检查冲突
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                     true  "项目ID"
+// @Param        id          path      int                     true  "分支ID"
+// @Param        request     body      dto.MergeBranchRequest  true  "冲突的解决方式"
+// @Success      200         {object}  domain.BranchMergeResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id}/merge [post]
+func (h *BranchHandler) Merge(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeBranchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	resolutions := make([]domain.BranchResolution, len(req.Resolutions))
+	for i, resolution := range req.Resolutions {
+		resolutions[i] = domain.BranchResolution{
+			KeyName:      resolution.KeyName,
+			LanguageCode: resolution.Language,
+			Take:         resolution.Take,
+			Value:        resolution.Value,
+		}
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.branchService.Merge(ctx.Request.Context(), domain.MergeBranchParams{
+		ProjectID:   projectID,
+		BranchID:    id,
+		Resolutions: resolutions,
+		DryRun:      req.DryRun,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "合并分支失败")
+		return
+	}
+
+	if result.Merged {
+		h.logger.Info("Translation branch merged",
+			zap.Uint64("project_id", projectID),
+			zap.Uint64("branch_id", id),
+			zap.Int("updated", result.Updated),
+			zap.Int("deleted", result.Deleted),
+			zap.Uint64("operator_id", userID),
+			zap.String("operator", operatorName(ctx)),
+		)
+	}
+
+	response.Success(ctx, result)
+}
+
+// Delete 删除翻译分支
+// @Summary      删除翻译分支
+// @Description  删除分支，未合并的修改一并丢弃
+// @Tags         翻译分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "分支ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /branches/by-project/{project_id}/{id} [delete]
+func (h *BranchHandler) Delete(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseBranchID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.branchService.Delete(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "删除翻译分支失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Translation branch deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("branch_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "翻译分支删除成功"})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"i18n-flow/internal/api/response"
 	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -10,9 +11,14 @@ import (
 
 // CLIHandler CLI处理器
 type CLIHandler struct {
-	translationService domain.TranslationService
-	projectService     domain.ProjectService
-	languageService    domain.LanguageService
+	translationService   domain.TranslationService
+	projectService       domain.ProjectService
+	languageService      domain.LanguageService
+	keyUsageService      domain.KeyUsageService
+	keyExtractionService domain.KeyExtractionService
+	branchService        domain.BranchService
+	syncService          domain.SyncService
+	releaseGateService   domain.ReleaseGateService
 }
 
 // NewCLIHandler 创建CLI处理器
@@ -20,11 +26,21 @@ func NewCLIHandler(
 	translationService domain.TranslationService,
 	projectService domain.ProjectService,
 	languageService domain.LanguageService,
+	keyUsageService domain.KeyUsageService,
+	keyExtractionService domain.KeyExtractionService,
+	branchService domain.BranchService,
+	syncService domain.SyncService,
+	releaseGateService domain.ReleaseGateService,
 ) *CLIHandler {
 	return &CLIHandler{
-		translationService: translationService,
-		projectService:     projectService,
-		languageService:    languageService,
+		translationService:   translationService,
+		projectService:       projectService,
+		languageService:      languageService,
+		keyUsageService:      keyUsageService,
+		keyExtractionService: keyExtractionService,
+		branchService:        branchService,
+		syncService:          syncService,
+		releaseGateService:   releaseGateService,
 	}
 }
 
@@ -54,6 +70,15 @@ func (h *CLIHandler) Auth(ctx *gin.Context) {
 // @Produce      json
 // @Param        project_id  query     string  false  "项目ID"
 // @Param        locale      query     string  false  "语言代码"
+// @Param        placeholders query    string  false  "目标占位符语法，为空时保持项目的规范语法"
+// @Param        pseudo      query     bool    false  "附加伪本地化语言 en-XA（pseudo_rtl=true 时为 ar-XB）；locale 为伪本地化语言时自动开启"
+// @Param        pseudo_expansion query int    false  "伪本地化长度扩展百分比，0-300"  default(30)
+// @Param        pseudo_brackets  query bool   false  "伪本地化文本是否加 [ ]"  default(true)
+// @Param        pseudo_rtl  query     bool    false  "生成从右到左的伪本地化语言"
+// @Param        in_context  query     bool    false  "用零宽标记包裹每个值，供页内编辑定位键"
+// @Param        branch      query     string  false  "翻译分支名，返回主干叠加分支修改后的译文"
+// @Param        since       query     int     false  "增量同步：只返回该修订号之后变更和删除的单元格及新的修订号，0 表示全量；不能与 branch 同时使用，继承了基础项目的项目不支持"
+// @Param        provenance  query     bool    false  "返回 {translations, provenance}，provenance 列出继承自基础项目的单元格的来源项目ID"
 // @Success      200         {object}  response.APIResponse
 // @Failure      400         {object}  response.APIResponse
 // @Failure      404         {object}  response.APIResponse
@@ -87,20 +112,42 @@ func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 		return
 	}
 
-	// 获取翻译矩阵数据（不分页，获取所有数据）
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
-	if err != nil {
-		response.InternalServerError(ctx, "获取翻译数据失败")
+	// 请求伪本地化语言时，即使未指定 pseudo 参数也按默认选项生成
+	opts, ok := parseExportOptions(ctx)
+	if !ok {
+		return
+	}
+	if domain.IsPseudoLocale(locale) {
+		if opts.Pseudo == nil {
+			opts.Pseudo = &domain.PseudoLocaleOptions{Expansion: domain.DefaultPseudoExpansion, Brackets: true}
+		}
+		opts.Pseudo.RTL = locale == domain.PseudoLocaleRTL
+	}
+	opts.Branch = ctx.Query("branch")
+
+	if sinceStr, ok := ctx.GetQuery("since"); ok {
+		since, err := strconv.ParseUint(sinceStr, 10, 64)
+		if err != nil {
+			response.BadRequest(ctx, "invalid since")
+			return
+		}
+		h.getTranslationDelta(ctx, projectID, since, locale, opts)
 		return
 	}
 
-	// 转换为简单格式 (key -> language -> value)
-	simpleMatrix := make(map[string]map[string]string)
-	for key, langs := range matrix {
-		simpleMatrix[key] = make(map[string]string)
-		for lang, cell := range langs {
-			simpleMatrix[key][lang] = cell.Value
+	// 获取翻译矩阵数据（不分页，获取所有数据），按需转换占位符语法
+	simpleMatrix, _, err := h.translationService.ExportMatrix(ctx.Request.Context(), projectID, opts)
+	if err != nil {
+		if err == domain.ErrBranchNotFound {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+		if appErr, isAppErr := domain.IsAppError(err); isAppErr && (appErr.Type == domain.ErrorTypeValidation || appErr.Type == domain.ErrorTypeBadRequest) {
+			response.BadRequest(ctx, err.Error())
+			return
 		}
+		response.InternalServerError(ctx, "获取翻译数据失败")
+		return
 	}
 
 	// 如果指定了locale，只返回该语言的数据
@@ -111,7 +158,11 @@ func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 				filteredMatrix[key] = map[string]string{locale: value}
 			}
 		}
-		response.Success(ctx, filteredMatrix)
+		simpleMatrix = filteredMatrix
+	}
+
+	if provenance, _ := strconv.ParseBool(ctx.Query("provenance")); provenance {
+		h.respondWithProvenance(ctx, projectID, simpleMatrix)
 		return
 	}
 
@@ -119,12 +170,68 @@ func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 	response.Success(ctx, simpleMatrix)
 }
 
+// respondWithProvenance 返回译文及其中继承自基础项目的单元格的来源（键名 -> 语言代码 -> 基础项目ID）
+func (h *CLIHandler) respondWithProvenance(ctx *gin.Context, projectID uint64, translations map[string]map[string]string) {
+	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
+	if err != nil {
+		response.InternalServerError(ctx, "获取翻译数据失败")
+		return
+	}
+
+	provenance := make(map[string]map[string]uint64)
+	for key, values := range translations {
+		for code := range values {
+			cell, exists := matrix[key][code]
+			if !exists || cell.InheritedFrom == 0 {
+				continue
+			}
+			if provenance[key] == nil {
+				provenance[key] = make(map[string]uint64)
+			}
+			provenance[key][code] = cell.InheritedFrom
+		}
+	}
+	response.Success(ctx, gin.H{"translations": translations, "provenance": provenance})
+}
+
+// getTranslationDelta 返回修订号 since 之后变更和删除的单元格，指定了 locale 时只返回该语言
+func (h *CLIHandler) getTranslationDelta(ctx *gin.Context, projectID, since uint64, locale string, opts domain.ExportOptions) {
+	delta, _, err := h.translationService.ExportDelta(ctx.Request.Context(), projectID, since, opts)
+	if err != nil {
+		if appErr, isAppErr := domain.IsAppError(err); isAppErr && (appErr.Type == domain.ErrorTypeValidation || appErr.Type == domain.ErrorTypeBadRequest) {
+			response.BadRequest(ctx, err.Error())
+			return
+		}
+		response.InternalServerError(ctx, "获取翻译数据失败")
+		return
+	}
+
+	if locale != "" {
+		changed := make(map[string]map[string]string)
+		for key, translations := range delta.Changed {
+			if value, exists := translations[locale]; exists {
+				changed[key] = map[string]string{locale: value}
+			}
+		}
+		deleted := make([]domain.TranslationCellRef, 0, len(delta.Deleted))
+		for _, cell := range delta.Deleted {
+			if cell.LanguageCode == locale {
+				deleted = append(deleted, cell)
+			}
+		}
+		delta.Changed, delta.Deleted = changed, deleted
+	}
+	response.Success(ctx, delta)
+}
+
 // PushKeysRequest 推送键请求
 type PushKeysRequest struct {
 	ProjectID    string                       `json:"project_id" binding:"required"`
 	Keys         []string                     `json:"keys" binding:"required"`
 	Defaults     map[string]string            `json:"defaults"`     // 保持向后兼容（已废弃）
 	Translations map[string]map[string]string `json:"translations"` // 新增：语言代码 -> 键值对映射
+	Usage        *dto.KeyUsageReport          `json:"usage"`        // 可选：代码扫描结果
+	Branch       string                       `json:"branch"`       // 可选：翻译分支名，新键添加到分支
 }
 
 // PushKeysResponse 推送键响应
@@ -132,11 +239,13 @@ type PushKeysResponse struct {
 	Added   []string `json:"added"`
 	Existed []string `json:"existed"`
 	Failed  []string `json:"failed"`
+	ScanID  uint64   `json:"scan_id,omitempty"`
+	Warning string   `json:"warning,omitempty"` // 键已推送但记录代码引用失败时的原因
 }
 
 // PushKeys 推送翻译键
 // @Summary      推送翻译键
-// @Description  从CLI推送新的翻译键
+// @Description  从CLI推送新的翻译键，指定 branch 时添加到翻译分支；同时上报的代码引用记录失败时仍返回成功，原因在 warning 中
 // @Tags         CLI
 // @Accept       json
 // @Produce      json
@@ -159,109 +268,218 @@ func (h *CLIHandler) PushKeys(ctx *gin.Context) {
 		return
 	}
 
-	// 验证项目是否存在
-	_, err = h.projectService.GetByID(ctx.Request.Context(), projectID)
+	// 指定分支时新键添加到分支，合并前不影响主干
+	pushKeys := h.translationService.PushKeys
+	if req.Branch != "" {
+		pushKeys = h.branchService.PushKeys
+	}
+	pushed, err := pushKeys(ctx.Request.Context(), domain.PushKeysParams{
+		ProjectID:    projectID,
+		Keys:         req.Keys,
+		Defaults:     req.Defaults,
+		Translations: req.Translations,
+		Branch:       req.Branch,
+		UserID:       1, // 使用系统管理员ID
+	})
 	if err != nil {
-		switch err {
-		case domain.ErrProjectNotFound:
-			response.NotFound(ctx, err.Error())
-		default:
-			response.InternalServerError(ctx, "获取项目失败")
+		respondServiceError(ctx, err, "推送翻译键失败")
+		return
+	}
+
+	result := PushKeysResponse{
+		Added:   pushed.Added,
+		Existed: pushed.Existed,
+		Failed:  pushed.Failed,
+	}
+
+	// 同时上报了代码扫描结果时记录引用
+	// 键已经推送，记录引用失败时仍返回成功并附带警告，CLI 可以通过 /cli/references 重新上报扫描结果
+	if req.Usage != nil {
+		scan, err := h.keyUsageService.RecordScan(ctx.Request.Context(), toRecordScanParams(projectID, req.Usage))
+		if err != nil {
+			result.Warning = "记录代码引用失败"
+			if appErr, ok := domain.IsAppError(err); ok {
+				result.Warning += ": " + appErr.Message
+			}
+		} else {
+			result.ScanID = scan.ID
 		}
+	}
+
+	response.Success(ctx, result)
+}
+
+// SyncRequest 三方合并同步请求
+type SyncRequest struct {
+	ProjectID    string                       `json:"project_id" binding:"required"`
+	BaseRevision uint64                       `json:"base_revision"`             // 上次拉取或同步返回的修订号
+	Values       map[string]map[string]string `json:"values" binding:"required"` // 本地译文：键名 -> 语言代码 -> 译文
+	Languages    []string                     `json:"languages"`                 // 参与合并的语言，为空时为 values 中出现的语言
+	DryRun       bool                         `json:"dry_run"`                   // 只返回合并结果，不写入
+}
+
+// Sync 三方合并同步
+// @Summary      三方合并同步
+// @Description  以 base_revision 时的译文为基准，按单元格合并本地和服务端的修改：只有本地修改的写入服务端，只有服务端修改的在 changed/deleted 中返回，两边都修改且不同的作为冲突返回，不写入任何一方
+// @Tags         CLI
+// @Accept       json
+// @Produce      json
+// @Param        request  body      SyncRequest  true  "同步请求"
+// @Success      200      {object}  domain.SyncResult
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/sync [post]
+func (h *CLIHandler) Sync(ctx *gin.Context) {
+	var req SyncRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
 		return
 	}
 
-	// 获取所有语言
-	languages, err := h.languageService.GetAll(ctx.Request.Context())
+	projectID, err := strconv.ParseUint(req.ProjectID, 10, 64)
 	if err != nil {
-		response.InternalServerError(ctx, "获取语言列表失败")
+		response.BadRequest(ctx, "invalid project_id")
 		return
 	}
 
-	// 找到默认语言（通常是第一个或标记为默认的语言）
-	var defaultLanguage *domain.Language
-	for _, lang := range languages {
-		if lang.IsDefault {
-			defaultLanguage = lang
-			break
-		}
-	}
-	if defaultLanguage == nil && len(languages) > 0 {
-		defaultLanguage = languages[0] // 如果没有默认语言，使用第一个语言
+	result, err := h.syncService.Sync(ctx.Request.Context(), domain.SyncParams{
+		ProjectID:    projectID,
+		BaseRevision: req.BaseRevision,
+		Values:       req.Values,
+		Languages:    req.Languages,
+		DryRun:       req.DryRun,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "同步翻译失败")
+		return
 	}
 
-	if defaultLanguage == nil {
-		response.BadRequest(ctx, "no languages available in project")
+	response.Success(ctx, result)
+}
+
+// ReleaseCheck 发布门禁检查
+// @Summary      发布门禁检查
+// @Description  按项目的发布门禁条件检查必需语言，返回是否通过及每种语言未满足的条件和问题键。检查本身成功时总是返回 200，由 passed 表示是否可以发布
+// @Tags         CLI
+// @Accept       json
+// @Produce      json
+// @Param        project_id  query     string  true  "项目ID"
+// @Success      200         {object}  domain.ReleaseReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/release-check [get]
+func (h *CLIHandler) ReleaseCheck(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Query("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "invalid project_id")
 		return
 	}
 
-	// 获取现有的翻译键
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
+	report, err := h.releaseGateService.Check(ctx.Request.Context(), projectID)
 	if err != nil {
-		response.InternalServerError(ctx, "获取现有翻译失败")
+		respondServiceError(ctx, err, "发布门禁检查失败")
 		return
 	}
 
-	var added []string
-	var existed []string
-	var failed []string
+	response.Success(ctx, report)
+}
 
-	// 处理每个键
-	for _, key := range req.Keys {
-		if _, exists := matrix[key]; exists {
-			existed = append(existed, key)
-			continue
-		}
+// ReportReferencesRequest 上报代码引用请求
+type ReportReferencesRequest struct {
+	ProjectID string `json:"project_id" binding:"required"`
+	dto.KeyUsageReport
+}
 
-		// 为所有语言创建新的翻译记录
-		keyAdded := false
-		keyFailed := false
-
-		for _, language := range languages {
-			// 确定翻译值
-			var value string
-
-			// 优先使用新的多语言数据结构
-			if req.Translations != nil {
-				if langTranslations, exists := req.Translations[language.Code]; exists {
-					value = langTranslations[key]
-				}
-			} else {
-				// 向后兼容：使用旧的 Defaults 字段
-				if language.Code == defaultLanguage.Code {
-					value = req.Defaults[key]
-				}
-			}
+// ReportReferences 上报代码扫描结果
+// @Summary      上报代码引用
+// @Description  上报CLI扫描到的翻译键引用（文件、行号、仓库、分支、提交），替换该仓库分支之前的扫描结果。被引用的已废弃键会自动恢复
+// @Tags         CLI
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ReportReferencesRequest  true  "代码引用"
+// @Success      200      {object}  domain.KeyScan
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/references [post]
+func (h *CLIHandler) ReportReferences(ctx *gin.Context) {
+	var req ReportReferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
 
-			// DTO -> Domain params
-			input := domain.TranslationInput{
-				ProjectID:  projectID,
-				KeyName:    key,
-				LanguageID: language.ID,
-				Value:      value,
-			}
+	projectID, err := strconv.ParseUint(req.ProjectID, 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "invalid project_id")
+		return
+	}
 
-			_, err := h.translationService.Create(ctx.Request.Context(), input, 1) // 使用系统管理员ID
-			if err != nil {
-				keyFailed = true
-			} else if !keyAdded {
-				keyAdded = true
-			}
-		}
+	scan, err := h.keyUsageService.RecordScan(ctx.Request.Context(), toRecordScanParams(projectID, &req.KeyUsageReport))
+	if err != nil {
+		respondServiceError(ctx, err, "记录代码引用失败")
+		return
+	}
 
-		// 记录结果
-		if keyFailed && !keyAdded {
-			failed = append(failed, key)
-		} else if keyAdded {
-			added = append(added, key)
-		}
+	response.Success(ctx, scan)
+}
+
+// ExtractKeys 从源码压缩包提取翻译键
+// @Summary      从源码提取翻译键
+// @Description  供无法运行CLI的仓库在CI中上传源码压缩包（zip、tar、tar.gz）提取翻译键并与项目对比，push 为 true 时按推送键的方式添加新键
+// @Tags         CLI
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        project_id  formData  string  true   "项目ID"
+// @Param        archive     formData  file    true   "源码压缩包"
+// @Param        extractors  formData  string  false  "提取器配置JSON数组"
+// @Param        push        formData  bool    false  "是否推送新键"
+// @Success      200         {object}  domain.KeyExtractionResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/extract [post]
+func (h *CLIHandler) ExtractKeys(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.PostForm("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "invalid project_id")
+		return
 	}
 
-	result := PushKeysResponse{
-		Added:   added,
-		Existed: existed,
-		Failed:  failed,
+	params, ok := bindExtractKeysParams(ctx)
+	if !ok {
+		return
+	}
+	params.ProjectID = projectID
+	params.UserID = 1 // 使用系统管理员ID
+
+	result, err := h.keyExtractionService.ExtractFromArchive(ctx.Request.Context(), params)
+	if err != nil {
+		respondServiceError(ctx, err, "提取翻译键失败")
+		return
 	}
 
 	response.Success(ctx, result)
 }
+
+// toRecordScanParams 将CLI上报的扫描结果转换为服务参数
+func toRecordScanParams(projectID uint64, report *dto.KeyUsageReport) domain.RecordScanParams {
+	references := make(map[string][]domain.KeyReferenceInput, len(report.References))
+	for keyName, locations := range report.References {
+		inputs := make([]domain.KeyReferenceInput, len(locations))
+		for i, location := range locations {
+			inputs[i] = domain.KeyReferenceInput{FilePath: location.File, Line: location.Line}
+		}
+		references[keyName] = inputs
+	}
+
+	return domain.RecordScanParams{
+		ProjectID:  projectID,
+		Repository: report.Repository,
+		Branch:     report.Branch,
+		Commit:     report.Commit,
+		References: references,
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ConsistencyHandler 翻译一致性检查处理器
+type ConsistencyHandler struct {
+	consistencyService domain.ConsistencyService
+	logger             *zap.Logger
+}
+
+// NewConsistencyHandler 创建翻译一致性检查处理器
+func NewConsistencyHandler(consistencyService domain.ConsistencyService, logger *zap.Logger) *ConsistencyHandler {
+	return &ConsistencyHandler{
+		consistencyService: consistencyService,
+		logger:             logger,
+	}
+}
+
+// Analyze 检查重复键和不一致的译文
+// @Summary      检查重复键和不一致的译文
+// @Description  默认语言原文相同（忽略首尾和连续空白）的有效键分为一组，列出各组在其他语言下不同的译文；不一致的组排在前面
+// @Tags         一致性检查
+// @Accept       json
+// @Produce      json
+// @Param        project_id         path      int   true   "项目ID"
+// @Param        inconsistent_only  query     bool  false  "只返回存在不一致译文的组"
+// @Success      200                {object}  domain.ConsistencyReport
+// @Failure      400                {object}  response.APIResponse
+// @Failure      404                {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /consistency/by-project/{project_id} [get]
+func (h *ConsistencyHandler) Analyze(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.consistencyService.Analyze(ctx.Request.Context(), projectID, ctx.Query("inconsistent_only") == "true")
+	if err != nil {
+		respondServiceError(ctx, err, "检查翻译一致性失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// MergeKeys 合并重复键
+// @Summary      合并重复键
+// @Description  source_keys 合并到 target_key：目标键缺少的译文从被合并的键补充，标签、代码引用和截图标注改为目标键，被合并的键移入回收站。
+// @Description  所有键在默认语言下的原文必须相同；与目标键不同而被丢弃的译文在结果中列出
+// @Tags         一致性检查
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        request     body      dto.MergeKeysRequest  true  "合并请求"
+// @Success      200         {object}  domain.MergeKeysResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /consistency/by-project/{project_id}/merge [post]
+func (h *ConsistencyHandler) MergeKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeKeysRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.consistencyService.MergeKeys(ctx.Request.Context(), domain.MergeKeysParams{
+		ProjectID:  projectID,
+		TargetKey:  req.TargetKey,
+		SourceKeys: req.SourceKeys,
+		UserID:     userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "合并翻译键失败")
+		return
+	}
+
+	h.logger.Info("Translation keys merged",
+		zap.Uint64("project_id", projectID),
+		zap.String("target_key", result.TargetKey),
+		zap.Strings("merged_keys", result.MergedKeys),
+		zap.Int("discarded", len(result.Discarded)),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// Harmonize 统一重复键的译文
+// @Summary      统一重复键的译文
+// @Description  把原文相同的一组键在指定语言下的译文统一为 value，value 为空时使用 from_key 在该语言下的译文
+// @Tags         一致性检查
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        request     body      dto.HarmonizeRequest  true  "统一译文请求"
+// @Success      200         {object}  domain.HarmonizeResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /consistency/by-project/{project_id}/harmonize [post]
+func (h *ConsistencyHandler) Harmonize(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.HarmonizeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.consistencyService.Harmonize(ctx.Request.Context(), domain.HarmonizeParams{
+		ProjectID: projectID,
+		KeyNames:  req.KeyNames,
+		Language:  req.Language,
+		Value:     req.Value,
+		FromKey:   req.FromKey,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "统一译文失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Translations harmonized",
+		zap.Uint64("project_id", projectID),
+		zap.String("language", result.Language),
+		zap.Strings("updated_keys", result.Updated),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, result)
+}
@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"fmt"
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"net/http"
+	"strconv"
+
+	internal_utils "i18n-flow/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DistributionHandler 译文分发处理器
+type DistributionHandler struct {
+	distributionService domain.DistributionService
+	cfg                 config.DistributionConfig
+	logger              *zap.Logger
+}
+
+// NewDistributionHandler 创建译文分发处理器
+func NewDistributionHandler(distributionService domain.DistributionService, cfg *config.Config, logger *zap.Logger) *DistributionHandler {
+	return &DistributionHandler{
+		distributionService: distributionService,
+		cfg:                 cfg.Distribution,
+		logger:              logger,
+	}
+}
+
+// ListTokens 获取分发令牌列表
+// @Summary      获取分发令牌列表
+// @Description  列表只包含令牌前缀，完整令牌只在创建时返回一次
+// @Tags         译文分发
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {array}   domain.DistributionToken
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /distribution/by-project/{project_id}/tokens [get]
+func (h *DistributionHandler) ListTokens(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.distributionService.ListTokens(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取分发令牌列表失败")
+		return
+	}
+
+	response.Success(ctx, tokens)
+}
+
+// CreateToken 创建分发令牌
+// @Summary      创建分发令牌
+// @Description  为项目的一个环境创建可公开的只读令牌，客户端用它获取该环境最新发布的译文
+// @Tags         译文分发
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                                 true  "项目ID"
+// @Param        request     body      dto.CreateDistributionTokenRequest  true  "令牌信息"
+// @Success      201         {object}  domain.DistributionToken
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /distribution/by-project/{project_id}/tokens [post]
+func (h *DistributionHandler) CreateToken(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateDistributionTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	token, err := h.distributionService.CreateToken(ctx.Request.Context(), domain.CreateDistributionTokenParams{
+		ProjectID:   projectID,
+		Environment: req.Environment,
+		Name:        req.Name,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "创建分发令牌失败")
+		return
+	}
+
+	h.logger.Info("Distribution token created",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("token_id", token.ID),
+		zap.String("environment", token.Environment),
+		zap.String("token_prefix", token.TokenPrefix),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Created(ctx, token)
+}
+
+// DeleteToken 删除分发令牌
+// @Summary      删除分发令牌
+// @Tags         译文分发
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "令牌ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /distribution/by-project/{project_id}/tokens/{id} [delete]
+func (h *DistributionHandler) DeleteToken(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的令牌ID")
+		return
+	}
+
+	if err := h.distributionService.DeleteToken(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "删除分发令牌失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Distribution token deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("token_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "分发令牌删除成功"})
+}
+
+// ListReleases 获取发布记录
+// @Summary      获取发布记录
+// @Description  分页获取项目的发布记录，按发布时间倒序，可按环境过滤
+// @Tags         译文分发
+// @Accept       json
+// @Produce      json
+// @Param        project_id   path      int     true   "项目ID"
+// @Param        environment  query     string  false  "环境"
+// @Param        page         query     int     false  "页码"  default(1)
+// @Param        page_size    query     int     false  "每页数量"  default(10)
+// @Success      200          {array}   domain.DistributionRelease
+// @Failure      400          {object}  response.APIResponse
+// @Failure      404          {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /distribution/by-project/{project_id}/releases [get]
+func (h *DistributionHandler) ListReleases(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	releases, total, err := h.distributionService.ListReleases(ctx.Request.Context(), projectID, ctx.Query("environment"), pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取发布记录失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, releases, newPageMeta(page, pageSize, total))
+}
+
+// Publish 发布译文
+// @Summary      发布译文
+// @Description  把版本快照（snapshot_id）或当前的译文发布到环境，生成各语言的译文包和签名清单
+// @Tags         译文分发
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                             true  "项目ID"
+// @Param        request     body      dto.PublishDistributionRequest  true  "发布信息"
+// @Success      201         {object}  domain.DistributionRelease
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /distribution/by-project/{project_id}/releases [post]
+func (h *DistributionHandler) Publish(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PublishDistributionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	release, err := h.distributionService.Publish(ctx.Request.Context(), domain.PublishDistributionParams{
+		ProjectID:   projectID,
+		Environment: req.Environment,
+		SnapshotID:  req.SnapshotID,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "发布译文失败")
+		return
+	}
+
+	h.logger.Info("Translations published",
+		zap.Uint64("project_id", projectID),
+		zap.String("environment", release.Environment),
+		zap.Int("version", release.Version),
+		zap.Int("bundles", len(release.Bundles)),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Created(ctx, release)
+}
+
+// PublicKey 获取清单签名公钥
+// @Summary      获取清单签名公钥
+// @Description  客户端用该公钥（Ed25519，base64）验证清单响应头 X-Signature 中的签名
+// @Tags         译文分发
+// @Produce      json
+// @Success      200  {object}  domain.DistributionPublicKey
+// @Failure      400  {object}  response.APIResponse
+// @Router       /ota/public-key [get]
+func (h *DistributionHandler) PublicKey(ctx *gin.Context) {
+	publicKey, err := h.distributionService.GetPublicKey()
+	if err != nil {
+		respondServiceError(ctx, err, "获取公钥失败")
+		return
+	}
+
+	response.Success(ctx, publicKey)
+}
+
+// Manifest 获取发布清单
+// @Summary      获取发布清单
+// @Description  返回令牌所属环境最新发布的清单，签名在 X-Signature 响应头中；支持 If-None-Match
+// @Tags         译文分发
+// @Produce      json
+// @Param        token  path  string  true  "分发令牌"
+// @Success      200    {object}  domain.DistributionManifest
+// @Success      304
+// @Failure      401    {object}  response.APIResponse
+// @Failure      404    {object}  response.APIResponse
+// @Router       /ota/{token}/manifest [get]
+func (h *DistributionHandler) Manifest(ctx *gin.Context) {
+	file, err := h.distributionService.GetManifest(ctx.Request.Context(), ctx.Param("token"))
+	if err != nil {
+		respondServiceError(ctx, err, "获取发布清单失败")
+		return
+	}
+
+	ctx.Header("X-Signature", file.Signature)
+	ctx.Header("X-Signature-Key-Id", file.KeyID)
+	h.serveFile(ctx, file, fmt.Sprintf("public, max-age=%d", h.cfg.ManifestMaxAge))
+}
+
+// LocaleBundle 获取语言的最新译文包
+// @Summary      获取语言的最新译文包
+// @Description  返回令牌所属环境最新发布中一种语言的译文（键名 -> 译文），按 Accept-Encoding 返回 br 或 gzip 压缩；支持 If-None-Match
+// @Tags         译文分发
+// @Produce      json
+// @Param        token   path  string  true  "分发令牌"
+// @Param        locale  path  string  true  "语言代码"
+// @Success      200     {object}  map[string]string
+// @Success      304
+// @Failure      401     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Router       /ota/{token}/locales/{locale} [get]
+func (h *DistributionHandler) LocaleBundle(ctx *gin.Context) {
+	encoding := internal_utils.NegotiateEncoding(ctx.GetHeader("Accept-Encoding"))
+	file, err := h.distributionService.GetLocaleBundle(ctx.Request.Context(), ctx.Param("token"), ctx.Param("locale"), encoding)
+	if err != nil {
+		respondServiceError(ctx, err, "获取译文包失败")
+		return
+	}
+
+	h.serveFile(ctx, file, fmt.Sprintf("public, max-age=%d", h.cfg.ManifestMaxAge))
+}
+
+// Bundle 按哈希获取译文包
+// @Summary      按哈希获取译文包
+// @Description  清单中列出的译文包地址，内容由哈希确定，永久缓存
+// @Tags         译文分发
+// @Produce      json
+// @Param        token  path  string  true  "分发令牌"
+// @Param        hash   path  string  true  "译文包 SHA-256"
+// @Success      200    {object}  map[string]string
+// @Success      304
+// @Failure      401    {object}  response.APIResponse
+// @Failure      404    {object}  response.APIResponse
+// @Router       /ota/{token}/bundles/{hash} [get]
+func (h *DistributionHandler) Bundle(ctx *gin.Context) {
+	encoding := internal_utils.NegotiateEncoding(ctx.GetHeader("Accept-Encoding"))
+	file, err := h.distributionService.GetBundle(ctx.Request.Context(), ctx.Param("token"), ctx.Param("hash"), encoding)
+	if err != nil {
+		respondServiceError(ctx, err, "获取译文包失败")
+		return
+	}
+
+	h.serveFile(ctx, file, fmt.Sprintf("public, max-age=%d, immutable", h.cfg.BundleMaxAge))
+}
+
+// serveFile 写入缓存相关的响应头，If-None-Match 匹配时返回 304
+func (h *DistributionHandler) serveFile(ctx *gin.Context, file *domain.DistributionFile, cacheControl string) {
+	etag := internal_utils.StrongETag(file.Hash, file.Encoding)
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", cacheControl)
+	ctx.Header("Vary", "Accept-Encoding")
+	if internal_utils.ETagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	if file.Encoding != internal_utils.EncodingIdentity {
+		ctx.Header("Content-Encoding", file.Encoding)
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", file.Data)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GitSyncHandler git 仓库同步处理器
+type GitSyncHandler struct {
+	gitSyncService domain.GitSyncService
+	logger         *zap.Logger
+}
+
+// NewGitSyncHandler 创建 git 仓库同步处理器
+func NewGitSyncHandler(gitSyncService domain.GitSyncService, logger *zap.Logger) *GitSyncHandler {
+	return &GitSyncHandler{
+		gitSyncService: gitSyncService,
+		logger:         logger,
+	}
+}
+
+// Get 获取 git 仓库同步设置
+// @Summary      获取 git 仓库同步设置
+// @Description  返回同步设置和最近一次同步的状态、提交、失败原因和冲突
+// @Tags         git 仓库同步
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.GitSync
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /git-sync/by-project/{project_id} [get]
+func (h *GitSyncHandler) Get(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	gitSync, err := h.gitSyncService.Get(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取 git 仓库同步设置失败")
+		return
+	}
+
+	response.Success(ctx, gitSync)
+}
+
+// Save 保存 git 仓库同步设置
+// @Summary      保存 git 仓库同步设置
+// @Description  创建或整体替换项目的同步设置。path_template 中的 {lang} 替换为语言代码，language_mapping 把文件中的语言代码映射到语言代码；interval_minutes 为 0 时只手动同步，auto_export 为 true 时定时同步也会提交导出。改变仓库、分支、路径、格式或映射后重新建立基准。仅管理员可用
+// @Tags         git 仓库同步
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                     true  "项目ID"
+// @Param        request     body      dto.SaveGitSyncRequest  true  "同步设置"
+// @Success      200         {object}  domain.GitSync
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /git-sync/by-project/{project_id} [put]
+func (h *GitSyncHandler) Save(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveGitSyncRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	gitSync, err := h.gitSyncService.Save(ctx.Request.Context(), domain.SaveGitSyncParams{
+		ProjectID:       projectID,
+		RepositoryURL:   req.RepositoryURL,
+		Branch:          req.Branch,
+		PathTemplate:    req.PathTemplate,
+		Format:          req.Format,
+		Languages:       req.Languages,
+		LanguageMapping: req.LanguageMapping,
+		IntervalMinutes: req.IntervalMinutes,
+		AutoExport:      req.AutoExport,
+		UserID:          userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "保存 git 仓库同步设置失败")
+		return
+	}
+
+	h.logger.Info("Git sync saved",
+		zap.Uint64("project_id", projectID),
+		zap.String("repository_url", gitSync.RepositoryURL),
+		zap.String("branch", gitSync.Branch),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, gitSync)
+}
+
+// Delete 删除 git 仓库同步设置
+// @Summary      删除 git 仓库同步设置
+// @Description  删除同步设置和服务端的工作副本，仓库本身不受影响。仅管理员可用
+// @Tags         git 仓库同步
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /git-sync/by-project/{project_id} [delete]
+func (h *GitSyncHandler) Delete(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.gitSyncService.Delete(ctx.Request.Context(), projectID); err != nil {
+		respondServiceError(ctx, err, "删除 git 仓库同步设置失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Git sync deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, gin.H{"message": "git 仓库同步设置已删除"})
+}
+
+// Import 从仓库导入
+// @Summary      从仓库导入
+// @Description  检出分支，把翻译文件与项目三方合并：仓库中修改的译文写入项目，项目和仓库都修改了的单元格作为冲突返回，两边都不覆盖。不修改仓库
+// @Tags         git 仓库同步
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.GitSyncResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /git-sync/by-project/{project_id}/import [post]
+func (h *GitSyncHandler) Import(ctx *gin.Context) {
+	h.run(ctx, false)
+}
+
+// Export 导出到仓库
+// @Summary      导出到仓库
+// @Description  先按导入合并仓库中的修改，再把项目的译文写入翻译文件，提交并推送到分支；冲突的单元格保留仓库中的值。推送期间分支有新的提交时重新合并一次，仍然失败返回 409，不会覆盖其他人的提交
+// @Tags         git 仓库同步
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.GitSyncResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /git-sync/by-project/{project_id}/export [post]
+func (h *GitSyncHandler) Export(ctx *gin.Context) {
+	h.run(ctx, true)
+}
+
+// run 执行导入或导出
+func (h *GitSyncHandler) run(ctx *gin.Context, export bool) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	run, message := h.gitSyncService.Import, "从仓库导入失败"
+	if export {
+		run, message = h.gitSyncService.Export, "导出到仓库失败"
+	}
+	result, err := run(ctx.Request.Context(), projectID, userID)
+	if err != nil {
+		respondServiceError(ctx, err, message)
+		return
+	}
+
+	h.logger.Info("Git sync completed",
+		zap.Uint64("project_id", projectID),
+		zap.Bool("export", export),
+		zap.String("status", result.Status),
+		zap.String("commit", result.Commit),
+		zap.Int("imported", result.Imported),
+		zap.Int("removed", result.Removed),
+		zap.Int("files", len(result.Files)),
+		zap.Int("conflicts", len(result.Conflicts)),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, result)
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"fmt"
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GlossaryHandler 术语表处理器
+// 项目术语表的路由带 project_id 参数，全局术语表的路由不带，此时 projectID 为0
+type GlossaryHandler struct {
+	glossaryService domain.GlossaryService
+	logger          *zap.Logger
+}
+
+// NewGlossaryHandler 创建术语表处理器
+func NewGlossaryHandler(glossaryService domain.GlossaryService, logger *zap.Logger) *GlossaryHandler {
+	return &GlossaryHandler{
+		glossaryService: glossaryService,
+		logger:          logger,
+	}
+}
+
+// glossaryScope 解析术语表所属项目，全局术语表返回0
+func glossaryScope(ctx *gin.Context) (uint64, bool) {
+	if ctx.Param("project_id") == "" {
+		return 0, true
+	}
+	return parseProjectID(ctx)
+}
+
+// parseGlossaryEntryID 解析路径中的术语ID
+func parseGlossaryEntryID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的术语ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List 获取术语列表
+// @Summary      获取术语列表
+// @Description  分页获取项目术语表或全局术语表，keyword 匹配术语、说明和译法
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID（全局术语表无此参数）"
+// @Param        keyword     query     string  false  "关键词"
+// @Param        page        query     int     false  "页码"  default(1)
+// @Param        page_size   query     int     false  "每页数量"  default(10)
+// @Success      200         {array}   domain.GlossaryEntry
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id} [get]
+// @Router       /glossary/global [get]
+func (h *GlossaryHandler) List(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	entries, total, err := h.glossaryService.List(ctx.Request.Context(), projectID, ctx.Query("keyword"), pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取术语表失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, entries, newPageMeta(page, pageSize, total))
+}
+
+// Create 创建术语
+// @Summary      创建术语
+// @Description  术语以默认语言书写；forbidden 为 true 的译法为禁用译法，do_not_translate 的术语在译文中须原样保留
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                       true  "项目ID（全局术语表无此参数）"
+// @Param        request     body      dto.GlossaryEntryRequest  true  "术语"
+// @Success      201         {object}  domain.GlossaryEntry
+// @Failure      400         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id} [post]
+// @Router       /glossary/global [post]
+func (h *GlossaryHandler) Create(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.GlossaryEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	entry, err := h.glossaryService.Create(ctx.Request.Context(), domain.CreateGlossaryEntryParams{
+		ProjectID:      projectID,
+		Term:           req.Term,
+		Description:    req.Description,
+		CaseSensitive:  req.CaseSensitive,
+		DoNotTranslate: req.DoNotTranslate,
+		Translations:   req.Translations,
+		UserID:         userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "创建术语失败")
+		return
+	}
+
+	response.Created(ctx, entry)
+}
+
+// Update 更新术语
+// @Summary      更新术语
+// @Description  更新术语，translations 会整体替换已有译法
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                       true  "项目ID（全局术语表无此参数）"
+// @Param        id          path      int                       true  "术语ID"
+// @Param        request     body      dto.GlossaryEntryRequest  true  "术语"
+// @Success      200         {object}  domain.GlossaryEntry
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id}/{id} [put]
+// @Router       /glossary/global/{id} [put]
+func (h *GlossaryHandler) Update(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseGlossaryEntryID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.GlossaryEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	entry, err := h.glossaryService.Update(ctx.Request.Context(), id, domain.UpdateGlossaryEntryParams{
+		ProjectID:      projectID,
+		Term:           req.Term,
+		Description:    req.Description,
+		CaseSensitive:  req.CaseSensitive,
+		DoNotTranslate: req.DoNotTranslate,
+		Translations:   req.Translations,
+		UserID:         userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "更新术语失败")
+		return
+	}
+
+	response.Success(ctx, entry)
+}
+
+// Delete 删除术语
+// @Summary      删除术语
+// @Description  删除术语及其全部译法
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID（全局术语表无此参数）"
+// @Param        id          path      int  true  "术语ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id}/{id} [delete]
+// @Router       /glossary/global/{id} [delete]
+func (h *GlossaryHandler) Delete(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseGlossaryEntryID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.glossaryService.Delete(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "删除术语失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Glossary entry deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("entry_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "术语删除成功"})
+}
+
+// Check 检查项目翻译的术语使用
+// @Summary      术语检查
+// @Description  以键在默认语言下的翻译为原文，检查译文是否使用了批准的译法、是否出现禁用的译法、不可翻译的术语是否原样保留。项目术语与全局术语相同时以项目术语为准
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        languages   query     string  false  "只检查这些语言，逗号分隔"
+// @Param        key_names   query     string  false  "只检查这些键，逗号分隔"
+// @Success      200         {object}  domain.GlossaryCheckReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id}/check [get]
+func (h *GlossaryHandler) Check(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.glossaryService.Check(ctx.Request.Context(), domain.GlossaryCheckParams{
+		ProjectID: projectID,
+		Languages: splitQueryList(ctx.Query("languages")),
+		KeyNames:  splitQueryList(ctx.Query("key_names")),
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "术语检查失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// splitQueryList 解析逗号分隔的查询参数，忽略空项
+func splitQueryList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ImportTBX 导入 TBX
+// @Summary      导入 TBX
+// @Description  导入 TBX v2/v3 文件，默认语言下的术语已存在时更新其译法。preferred/admitted 为批准的译法，deprecated/superseded 为禁用的译法
+// @Tags         术语表
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        project_id  path      int   true  "项目ID（全局术语表无此参数）"
+// @Param        file        formData  file  true  "TBX 文件"
+// @Success      200         {object}  domain.ImportTBXResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id}/import [post]
+// @Router       /glossary/global/import [post]
+func (h *GlossaryHandler) ImportTBX(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		response.BadRequest(ctx, "请上传 TBX 文件（file）")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "读取 TBX 文件失败")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.BadRequest(ctx, "读取 TBX 文件失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.glossaryService.ImportTBX(ctx.Request.Context(), domain.ImportTBXParams{
+		ProjectID: projectID,
+		Data:      data,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "导入 TBX 失败")
+		return
+	}
+
+	h.logger.Info("Glossary imported",
+		zap.Uint64("project_id", projectID),
+		zap.String("file", fileHeader.Filename),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("skipped", result.Skipped),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// ExportTBX 导出 TBX
+// @Summary      导出 TBX
+// @Description  将术语表导出为 TBX v2（TBX-Basic）文件
+// @Tags         术语表
+// @Produce      application/xml
+// @Param        project_id  path      int  true  "项目ID（全局术语表无此参数）"
+// @Success      200         {file}    file
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /glossary/by-project/{project_id}/export [get]
+// @Router       /glossary/global/export [get]
+func (h *GlossaryHandler) ExportTBX(ctx *gin.Context) {
+	projectID, ok := glossaryScope(ctx)
+	if !ok {
+		return
+	}
+
+	data, err := h.glossaryService.ExportTBX(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "导出 TBX 失败")
+		return
+	}
+
+	scope := "global"
+	if projectID != 0 {
+		scope = fmt.Sprintf("project-%d", projectID)
+	}
+	filename := fmt.Sprintf("glossary-%s-%s.tbx", scope, time.Now().Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "application/x-tbx+xml; charset=utf-8", data)
+}
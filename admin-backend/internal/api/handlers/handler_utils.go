@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondServiceError 将服务层错误转换为统一的HTTP错误响应
+// AppError 按类型映射状态码，其他错误返回 fallbackMessage
+func respondServiceError(ctx *gin.Context, err error, fallbackMessage string) {
+	if appErr, ok := domain.IsAppError(err); ok {
+		switch appErr.Type {
+		case domain.ErrorTypeNotFound:
+			response.NotFound(ctx, appErr.Message)
+		case domain.ErrorTypeConflict:
+			response.Conflict(ctx, appErr.Message)
+		case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+			if appErr.Details != "" {
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			} else {
+				response.BadRequest(ctx, appErr.Message)
+			}
+		case domain.ErrorTypeUnauthorized:
+			response.Unauthorized(ctx, appErr.Message)
+		case domain.ErrorTypeForbidden:
+			if appErr.Details != "" {
+				response.ForbiddenWithDetails(ctx, appErr.Message, appErr.Details)
+			} else {
+				response.Forbidden(ctx, appErr.Message)
+			}
+		default:
+			response.InternalServerError(ctx, fallbackMessage)
+		}
+		return
+	}
+
+	response.InternalServerError(ctx, fallbackMessage)
+}
+
+// operatorName 获取当前操作者用户名，用于审计日志
+func operatorName(ctx *gin.Context) string {
+	if opUser, ok := ctx.Get("username"); ok {
+		if op, ok := opUser.(string); ok {
+			return op
+		}
+	}
+	return "unknown"
+}
+
+// currentUserID 获取当前登录用户ID，未登录时返回 false
+func currentUserID(ctx *gin.Context) (uint64, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		return 0, false
+	}
+	id, ok := userID.(uint64)
+	return id, ok
+}
+
+// parseProjectID 解析路径中的项目ID
+func parseProjectID(ctx *gin.Context) (uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return 0, false
+	}
+	return projectID, true
+}
+
+// parsePagination 解析分页参数，返回 page、pageSize 和 offset
+func parsePagination(ctx *gin.Context) (int, int, int) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return page, pageSize, (page - 1) * pageSize
+}
+
+// newPageMeta 构建分页元数据
+func newPageMeta(page, pageSize int, total int64) *response.Meta {
+	return &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+}
+
+// parseExportOptions 解析导出相关查询参数：placeholders、伪本地化参数和 in_context
+func parseExportOptions(ctx *gin.Context) (domain.ExportOptions, bool) {
+	opts := domain.ExportOptions{Placeholders: ctx.Query("placeholders")}
+
+	pseudo, ok := parsePseudoOptions(ctx)
+	if !ok {
+		return opts, false
+	}
+	opts.Pseudo = pseudo
+
+	if raw := ctx.Query("in_context"); raw != "" {
+		inContext, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ValidationError(ctx, "in_context 必须是布尔值")
+			return opts, false
+		}
+		opts.InContext = inContext
+	}
+	return opts, true
+}
+
+// parsePseudoOptions 解析伪本地化查询参数，未请求伪本地化时返回 nil
+// pseudo=true 开启，pseudo_expansion 为长度扩展百分比（默认 30），pseudo_brackets 默认开启，pseudo_rtl 生成从右到左的语言
+func parsePseudoOptions(ctx *gin.Context) (*domain.PseudoLocaleOptions, bool) {
+	raw := ctx.Query("pseudo")
+	if raw == "" {
+		return nil, true
+	}
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		response.ValidationError(ctx, "pseudo 必须是布尔值")
+		return nil, false
+	}
+	if !enabled {
+		return nil, true
+	}
+
+	opts := &domain.PseudoLocaleOptions{Expansion: domain.DefaultPseudoExpansion, Brackets: true}
+	if raw := ctx.Query("pseudo_expansion"); raw != "" {
+		if opts.Expansion, err = strconv.Atoi(raw); err != nil {
+			response.ValidationError(ctx, "pseudo_expansion 必须是整数")
+			return nil, false
+		}
+	}
+	if raw := ctx.Query("pseudo_brackets"); raw != "" {
+		if opts.Brackets, err = strconv.ParseBool(raw); err != nil {
+			response.ValidationError(ctx, "pseudo_brackets 必须是布尔值")
+			return nil, false
+		}
+	}
+	if raw := ctx.Query("pseudo_rtl"); raw != "" {
+		if opts.RTL, err = strconv.ParseBool(raw); err != nil {
+			response.ValidationError(ctx, "pseudo_rtl 必须是布尔值")
+			return nil, false
+		}
+	}
+	return opts, true
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// EditorTokenHeader 页内编辑令牌请求头
+const EditorTokenHeader = "X-Editor-Token"
+
+// InContextHandler 页内编辑处理器
+type InContextHandler struct {
+	inContextService domain.InContextService
+	logger           *zap.Logger
+}
+
+// NewInContextHandler 创建页内编辑处理器
+func NewInContextHandler(inContextService domain.InContextService, logger *zap.Logger) *InContextHandler {
+	return &InContextHandler{
+		inContextService: inContextService,
+		logger:           logger,
+	}
+}
+
+// IssueEditorToken 签发页内编辑令牌
+// @Summary      签发页内编辑令牌
+// @Description  签发绑定到项目的短期令牌，供预发布环境页面调用标记解析接口。令牌不能用于其他接口
+// @Tags         页内编辑
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      201         {object}  domain.EditorToken
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /in-context/by-project/{project_id}/token [post]
+func (h *InContextHandler) IssueEditorToken(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "用户未登录")
+		return
+	}
+
+	token, err := h.inContextService.IssueEditorToken(ctx.Request.Context(), projectID, userID)
+	if err != nil {
+		respondServiceError(ctx, err, "签发编辑令牌失败")
+		return
+	}
+
+	h.logger.Info("In-context editor token issued",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+		zap.Time("expires_at", token.ExpiresAt),
+	)
+
+	response.Created(ctx, token)
+}
+
+// Resolve 解析页内编辑标记
+// @Summary      解析页内编辑标记
+// @Description  从带零宽标记的文本中解析出键，返回各键所有语言的翻译和令牌所属用户的编辑权限。使用 X-Editor-Token 请求头中的编辑令牌认证，只解析令牌绑定项目的标记
+// @Tags         页内编辑
+// @Accept       json
+// @Produce      json
+// @Param        X-Editor-Token  header    string                           true  "编辑令牌"
+// @Param        request         body      dto.ResolveInContextRequest      true  "带标记的文本"
+// @Success      200             {object}  domain.InContextResolution
+// @Failure      400             {object}  response.APIResponse
+// @Failure      401             {object}  response.APIResponse
+// @Failure      403             {object}  response.APIResponse
+// @Router       /in-context/resolve [post]
+func (h *InContextHandler) Resolve(ctx *gin.Context) {
+	var req dto.ResolveInContextRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	resolution, err := h.inContextService.Resolve(ctx.Request.Context(), ctx.GetHeader(EditorTokenHeader), req.Text)
+	if err != nil {
+		respondServiceError(ctx, err, "解析页内编辑标记失败")
+		return
+	}
+
+	response.Success(ctx, resolution)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// InheritanceHandler 项目继承处理器
+type InheritanceHandler struct {
+	inheritanceService domain.InheritanceService
+	logger             *zap.Logger
+}
+
+// NewInheritanceHandler 创建项目继承处理器
+func NewInheritanceHandler(inheritanceService domain.InheritanceService, logger *zap.Logger) *InheritanceHandler {
+	return &InheritanceHandler{
+		inheritanceService: inheritanceService,
+		logger:             logger,
+	}
+}
+
+// Get 获取项目继承关系
+// @Summary      获取项目继承关系
+// @Description  返回直接继承的基础项目、按查找顺序排列的所有被继承项目，以及直接继承当前项目的项目
+// @Tags         项目继承
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ProjectInheritance
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /inheritance/by-project/{project_id} [get]
+func (h *InheritanceHandler) Get(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	inheritance, err := h.inheritanceService.Get(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取项目继承关系失败")
+		return
+	}
+
+	response.Success(ctx, inheritance)
+}
+
+// SetBases 设置基础项目
+// @Summary      设置基础项目
+// @Description  整体替换项目的基础项目，排在前面的优先。项目继承基础项目的所有键，本地翻译覆盖继承的翻译；需要能查看每个基础项目，不能形成循环继承
+// @Tags         项目继承
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        request     body      dto.SetBaseProjectsRequest  true  "基础项目"
+// @Success      200         {object}  domain.ProjectInheritance
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /inheritance/by-project/{project_id} [put]
+func (h *InheritanceHandler) SetBases(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.SetBaseProjectsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	inheritance, err := h.inheritanceService.SetBases(ctx.Request.Context(), domain.SetBaseProjectsParams{
+		ProjectID:      projectID,
+		BaseProjectIDs: req.BaseProjectIDs,
+		UserID:         userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "设置基础项目失败")
+		return
+	}
+
+	baseIDs := make([]uint64, 0, len(inheritance.Bases))
+	for _, base := range inheritance.Bases {
+		baseIDs = append(baseIDs, base.ID)
+	}
+	h.logger.Info("Base projects updated",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64s("base_project_ids", baseIDs),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, inheritance)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyExtractionHandler 源码翻译键提取处理器
+type KeyExtractionHandler struct {
+	keyExtractionService domain.KeyExtractionService
+	logger               *zap.Logger
+}
+
+// NewKeyExtractionHandler 创建源码翻译键提取处理器
+func NewKeyExtractionHandler(keyExtractionService domain.KeyExtractionService, logger *zap.Logger) *KeyExtractionHandler {
+	return &KeyExtractionHandler{
+		keyExtractionService: keyExtractionService,
+		logger:               logger,
+	}
+}
+
+// ExtractKeys 从源码压缩包提取翻译键
+// @Summary      从源码提取翻译键
+// @Description  上传源码压缩包（zip、tar、tar.gz），按文件扩展名使用提取器（go、regex、i18next）提取翻译键并与项目现有键对比。push 为 true 时推送新键
+// @Tags         源码提取
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        archive     formData  file    true   "源码压缩包"
+// @Param        extractors  formData  string  false  "提取器配置JSON数组，如 [{\"type\":\"go\",\"extensions\":[\".go\"],\"functions\":[\"i18n.T\"]}]"
+// @Param        push        formData  bool    false  "是否推送新键"
+// @Success      200         {object}  domain.KeyExtractionResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /key-extraction/by-project/{project_id} [post]
+func (h *KeyExtractionHandler) ExtractKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	params, ok := bindExtractKeysParams(ctx)
+	if !ok {
+		return
+	}
+	params.ProjectID = projectID
+	params.UserID, _ = currentUserID(ctx)
+
+	result, err := h.keyExtractionService.ExtractFromArchive(ctx.Request.Context(), params)
+	if err != nil {
+		respondServiceError(ctx, err, "提取翻译键失败")
+		return
+	}
+
+	logExtraction(h.logger, ctx, projectID, params.Push, result)
+	response.Success(ctx, result)
+}
+
+// bindExtractKeysParams 解析源码提取的表单参数，失败时已写入响应
+func bindExtractKeysParams(ctx *gin.Context) (domain.ExtractKeysParams, bool) {
+	var params domain.ExtractKeysParams
+
+	fileHeader, err := ctx.FormFile("archive")
+	if err != nil {
+		response.BadRequest(ctx, "请上传源码压缩包（archive）")
+		return params, false
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "读取源码压缩包失败")
+		return params, false
+	}
+	defer file.Close()
+
+	params.Archive, err = io.ReadAll(file)
+	if err != nil {
+		response.BadRequest(ctx, "读取源码压缩包失败")
+		return params, false
+	}
+
+	if raw := ctx.PostForm("extractors"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params.Extractors); err != nil {
+			response.ValidationError(ctx, "extractors 必须是提取器配置的JSON数组")
+			return params, false
+		}
+	}
+
+	if raw := ctx.PostForm("push"); raw != "" {
+		params.Push, err = strconv.ParseBool(raw)
+		if err != nil {
+			response.ValidationError(ctx, "push 必须是布尔值")
+			return params, false
+		}
+	}
+
+	return params, true
+}
+
+// logExtraction 记录源码提取日志
+func logExtraction(logger *zap.Logger, ctx *gin.Context, projectID uint64, push bool, result *domain.KeyExtractionResult) {
+	fields := []zap.Field{
+		zap.Uint64("project_id", projectID),
+		zap.Int("files", result.FilesScanned),
+		zap.Int("keys", len(result.Keys)),
+		zap.Int("new_keys", len(result.NewKeys)),
+		zap.Bool("push", push),
+		zap.String("operator", operatorName(ctx)),
+	}
+	if result.Pushed != nil {
+		fields = append(fields, zap.Int("added", len(result.Pushed.Added)))
+	}
+	logger.Info("Translation keys extracted from source archive", fields...)
+}
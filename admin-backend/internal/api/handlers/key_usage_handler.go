@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyUsageHandler 翻译键代码引用处理器
+type KeyUsageHandler struct {
+	keyUsageService domain.KeyUsageService
+	logger          *zap.Logger
+}
+
+// NewKeyUsageHandler 创建翻译键代码引用处理器
+func NewKeyUsageHandler(keyUsageService domain.KeyUsageService, logger *zap.Logger) *KeyUsageHandler {
+	return &KeyUsageHandler{
+		keyUsageService: keyUsageService,
+		logger:          logger,
+	}
+}
+
+// GetKeyUsage 获取翻译键的代码引用
+// @Summary      获取翻译键代码引用
+// @Description  列出翻译键在各仓库分支最近一次扫描中的引用位置（文件、行号、仓库、提交）
+// @Tags         代码引用
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        key_name    query     string  true  "键名"
+// @Success      200         {object}  domain.KeyUsageDetail
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /key-usage/by-project/{project_id} [get]
+func (h *KeyUsageHandler) GetKeyUsage(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	keyName := ctx.Query("key_name")
+	if keyName == "" {
+		response.BadRequest(ctx, "key_name is required")
+		return
+	}
+
+	detail, err := h.keyUsageService.GetKeyUsage(ctx.Request.Context(), projectID, keyName)
+	if err != nil {
+		respondServiceError(ctx, err, "获取代码引用失败")
+		return
+	}
+
+	response.Success(ctx, detail)
+}
+
+// ListUnusedKeys 获取未使用的翻译键
+// @Summary      获取未使用的翻译键
+// @Description  列出在所有仓库分支最近一次扫描中都未被引用的键，unused_since 为开始未被引用的时间
+// @Tags         代码引用
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.UnusedKeysReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /key-usage/by-project/{project_id}/unused [get]
+func (h *KeyUsageHandler) ListUnusedKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.keyUsageService.ListUnusedKeys(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取未使用的键失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// DeprecateUnusedKeys 废弃未使用的翻译键
+// @Summary      废弃未使用的翻译键
+// @Description  将未被引用超过宽限期的键标记为 deprecated，废弃的键不再出现在导出和CLI数据中，代码重新引用后自动恢复
+// @Tags         代码引用
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                             true  "项目ID"
+// @Param        request     body      dto.DeprecateUnusedKeysRequest  false  "废弃请求"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /key-usage/by-project/{project_id}/deprecate [post]
+func (h *KeyUsageHandler) DeprecateUnusedKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.DeprecateUnusedKeysRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(ctx, err.Error())
+			return
+		}
+	}
+
+	deprecated, err := h.keyUsageService.DeprecateUnusedKeys(ctx.Request.Context(), projectID, req.GraceDays)
+	if err != nil {
+		respondServiceError(ctx, err, "废弃未使用的键失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Unused translation keys deprecated",
+		zap.Uint64("project_id", projectID),
+		zap.Int("grace_days", req.GraceDays),
+		zap.Strings("keys", deprecated),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"deprecated": deprecated})
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LockHandler 字符串冻结和翻译锁定处理器
+type LockHandler struct {
+	lockService domain.LockService
+	logger      *zap.Logger
+}
+
+// NewLockHandler 创建字符串冻结和翻译锁定处理器
+func NewLockHandler(lockService domain.LockService, logger *zap.Logger) *LockHandler {
+	return &LockHandler{
+		lockService: lockService,
+		logger:      logger,
+	}
+}
+
+// List 获取字符串冻结状态和锁定
+// @Summary      获取字符串冻结状态和锁定
+// @Description  返回项目是否处于字符串冻结状态及所有锁定；key_name 为空的锁定锁住整个语言，language_id 为0的锁定锁住键的所有语言
+// @Tags         字符串冻结
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ProjectLocks
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /locks/by-project/{project_id} [get]
+func (h *LockHandler) List(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	locks, err := h.lockService.List(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取锁定失败")
+		return
+	}
+
+	response.Success(ctx, locks)
+}
+
+// Create 创建锁定
+// @Summary      创建锁定
+// @Description  锁定键、语言或单个单元格，锁定的单元格不能通过任何途径修改或删除，只有项目所有者可以解除
+// @Tags         字符串冻结
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                    true  "项目ID"
+// @Param        request     body      dto.CreateLockRequest  true  "锁定"
+// @Success      201         {object}  domain.TranslationLock
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /locks/by-project/{project_id} [post]
+func (h *LockHandler) Create(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateLockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	lock, err := h.lockService.Create(ctx.Request.Context(), domain.CreateLockParams{
+		ProjectID:    projectID,
+		KeyName:      req.KeyName,
+		LanguageCode: req.LanguageCode,
+		Reason:       req.Reason,
+		UserID:       userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "创建锁定失败")
+		return
+	}
+
+	h.logger.Info("Translation lock created",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("lock_id", lock.ID),
+		zap.String("key_name", lock.KeyName),
+		zap.String("language", lock.LanguageCode),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Created(ctx, lock)
+}
+
+// Delete 解除锁定
+// @Summary      解除锁定
+// @Tags         字符串冻结
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "锁定ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /locks/by-project/{project_id}/{id} [delete]
+func (h *LockHandler) Delete(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的锁定ID")
+		return
+	}
+
+	if err := h.lockService.Delete(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "解除锁定失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Translation lock deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("lock_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "锁定已解除"})
+}
+
+// SetStringFreeze 开启或解除字符串冻结
+// @Summary      开启或解除字符串冻结
+// @Description  冻结期间不能新增、重命名、修改或删除默认语言的键，其他语言仍可翻译
+// @Tags         字符串冻结
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        request     body      dto.StringFreezeRequest  true  "冻结状态"
+// @Success      200         {object}  domain.Project
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /locks/by-project/{project_id}/freeze [put]
+func (h *LockHandler) SetStringFreeze(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.StringFreezeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	project, err := h.lockService.SetStringFreeze(ctx.Request.Context(), projectID, *req.Enabled, userID)
+	if err != nil {
+		respondServiceError(ctx, err, "修改字符串冻结状态失败")
+		return
+	}
+
+	h.logger.Info("String freeze changed",
+		zap.Uint64("project_id", projectID),
+		zap.Bool("enabled", project.StringFreeze),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, project)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MachineTranslationHandler 机器翻译处理器
+type MachineTranslationHandler struct {
+	mtService domain.MachineTranslationService
+	logger    *zap.Logger
+}
+
+// NewMachineTranslationHandler 创建机器翻译处理器
+func NewMachineTranslationHandler(mtService domain.MachineTranslationService, logger *zap.Logger) *MachineTranslationHandler {
+	return &MachineTranslationHandler{
+		mtService: mtService,
+		logger:    logger,
+	}
+}
+
+// PreTranslate 机器预翻译
+// @Summary      机器预翻译
+// @Description  用机器翻译填充指定语言中的空单元格，结果标记为机器翻译草稿（machine_translated），人工保存后清除标记。占位符和 HTML 标签不会发送给提供方翻译。额度用完或提供方出错时提前停止，已完成的部分会保留
+// @Tags         机器翻译
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        request     body      dto.PreTranslateRequest  true  "预翻译请求"
+// @Success      200         {object}  domain.PreTranslateResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /machine-translation/by-project/{project_id}/pre-translate [post]
+func (h *MachineTranslationHandler) PreTranslate(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PreTranslateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.mtService.PreTranslate(ctx.Request.Context(), domain.PreTranslateParams{
+		ProjectID: projectID,
+		Languages: req.Languages,
+		KeyNames:  req.KeyNames,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "机器预翻译失败")
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Uint64("project_id", projectID),
+		zap.Strings("languages", req.Languages),
+		zap.String("provider", result.Provider),
+		zap.Int("translated", result.Translated),
+		zap.Int64("characters", result.Characters),
+		zap.Int("failed", len(result.Failed)),
+		zap.Bool("quota_exceeded", result.QuotaExceeded),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	}
+	if result.ProviderError != "" {
+		h.logger.Warn("Machine translation provider failed", append(fields, zap.String("error", result.ProviderError))...)
+	} else {
+		h.logger.Info("Project pre-translated", fields...)
+	}
+
+	response.Success(ctx, result)
+}
+
+// GetUsage 获取机器翻译用量
+// @Summary      获取机器翻译用量
+// @Description  获取项目本月已使用的机器翻译字符数和额度，quota 为 0 表示不限制，provider 为空表示未启用机器翻译
+// @Tags         机器翻译
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.MachineTranslationUsageReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /machine-translation/by-project/{project_id}/usage [get]
+func (h *MachineTranslationHandler) GetUsage(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.mtService.GetUsage(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取机器翻译用量失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
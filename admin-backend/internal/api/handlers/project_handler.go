@@ -26,7 +26,7 @@ func NewProjectHandler(projectService domain.ProjectService, logger *zap.Logger)
 
 // Create 创建项目
 // @Summary      创建项目
-// @Description  创建新的翻译项目
+// @Description  创建新的翻译项目。指定 template_id 时按项目模板设置基础项目、创建初始键和添加默认成员
 // @Tags         项目管理
 // @Accept       json
 // @Produce      json
@@ -53,19 +53,22 @@ func (h *ProjectHandler) Create(ctx *gin.Context) {
 
 	// DTO -> Domain params
 	params := domain.CreateProjectParams{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:              req.Name,
+		Description:       req.Description,
+		PlaceholderFormat: req.PlaceholderFormat,
+		ValueType:         req.ValueType,
+		TemplateID:        req.TemplateID,
 	}
 
 	project, err := h.projectService.Create(ctx.Request.Context(), params, userID.(uint64))
 	if err != nil {
 		switch err {
-		case domain.ErrProjectExists:
+		case domain.ErrProjectExists, domain.ErrProjectInTrash:
 			response.Conflict(ctx, err.Error())
-		case domain.ErrInvalidSlug:
+		case domain.ErrInvalidSlug, domain.ErrInvalidValueType:
 			response.BadRequest(ctx, err.Error())
 		default:
-			response.InternalServerError(ctx, "创建项目失败")
+			respondServiceError(ctx, err, "创建项目失败")
 		}
 		return
 	}
@@ -88,6 +91,59 @@ func (h *ProjectHandler) Create(ctx *gin.Context) {
 	response.Created(ctx, project)
 }
 
+// Clone 克隆项目
+// @Summary      克隆项目
+// @Description  以现有项目为蓝本创建新项目。keys 为 keys 时只复制键，为 values 时复制键和译文；可选复制成员和设置（占位符语法、值类型、基础项目、发布门禁条件）
+// @Tags         项目管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "源项目ID"
+// @Param        request     body      dto.CloneProjectRequest  true  "克隆选项"
+// @Success      201         {object}  domain.CloneProjectResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/clone [post]
+func (h *ProjectHandler) Clone(ctx *gin.Context) {
+	sourceID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CloneProjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.projectService.Clone(ctx.Request.Context(), domain.CloneProjectParams{
+		SourceID:        sourceID,
+		Name:            req.Name,
+		Description:     req.Description,
+		Keys:            req.Keys,
+		IncludeMembers:  req.IncludeMembers,
+		IncludeSettings: req.IncludeSettings,
+	}, userID)
+	if err != nil {
+		respondServiceError(ctx, err, "克隆项目失败")
+		return
+	}
+
+	h.logger.Info("Project cloned",
+		zap.Uint64("source_project_id", sourceID),
+		zap.Uint64("project_id", result.Project.ID),
+		zap.String("project_name", result.Project.Name),
+		zap.Int("keys", result.Keys),
+		zap.Int("translations", result.Translations),
+		zap.Int("members", result.Members),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Created(ctx, result)
+}
+
 // GetByID 根据ID获取项目
 // @Summary      获取项目详情
 // @Description  根据项目ID获取项目详细信息
@@ -253,9 +309,11 @@ func (h *ProjectHandler) Update(ctx *gin.Context) {
 
 	// DTO -> Domain params
 	params := domain.UpdateProjectParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Status:      req.Status,
+		Name:              req.Name,
+		Description:       req.Description,
+		Status:            req.Status,
+		PlaceholderFormat: req.PlaceholderFormat,
+		ValueType:         req.ValueType,
 	}
 
 	project, err := h.projectService.Update(ctx.Request.Context(), id, params, userID.(uint64))
@@ -263,10 +321,10 @@ func (h *ProjectHandler) Update(ctx *gin.Context) {
 		switch err {
 		case domain.ErrProjectNotFound:
 			response.NotFound(ctx, err.Error())
-		case domain.ErrProjectExists, domain.ErrInvalidInput:
+		case domain.ErrProjectExists, domain.ErrInvalidInput, domain.ErrInvalidValueType:
 			response.BadRequest(ctx, err.Error())
 		default:
-			response.InternalServerError(ctx, "更新项目失败")
+			respondServiceError(ctx, err, "更新项目失败")
 		}
 		return
 	}
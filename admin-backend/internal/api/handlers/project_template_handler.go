@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProjectTemplateHandler 项目模板处理器
+type ProjectTemplateHandler struct {
+	templateService domain.ProjectTemplateService
+	logger          *zap.Logger
+}
+
+// NewProjectTemplateHandler 创建项目模板处理器
+func NewProjectTemplateHandler(templateService domain.ProjectTemplateService, logger *zap.Logger) *ProjectTemplateHandler {
+	return &ProjectTemplateHandler{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+// List 获取项目模板列表
+// @Summary      获取项目模板列表
+// @Tags         项目模板
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.ProjectTemplate
+// @Security     BearerAuth
+// @Router       /project-templates [get]
+func (h *ProjectTemplateHandler) List(ctx *gin.Context) {
+	templates, err := h.templateService.List(ctx.Request.Context())
+	if err != nil {
+		respondServiceError(ctx, err, "获取项目模板失败")
+		return
+	}
+
+	response.Success(ctx, templates)
+}
+
+// GetByID 获取项目模板详情
+// @Summary      获取项目模板详情
+// @Tags         项目模板
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "模板ID"
+// @Success      200  {object}  domain.ProjectTemplate
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /project-templates/{id} [get]
+func (h *ProjectTemplateHandler) GetByID(ctx *gin.Context) {
+	id, ok := parseTemplateID(ctx)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		respondServiceError(ctx, err, "获取项目模板失败")
+		return
+	}
+
+	response.Success(ctx, template)
+}
+
+// Create 创建项目模板
+// @Summary      创建项目模板
+// @Description  模板包含占位符语法、值类型、语言、基础项目、初始键和默认成员，创建项目时通过 template_id 使用。仅管理员可用
+// @Tags         项目模板
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ProjectTemplateRequest  true  "模板内容"
+// @Success      201      {object}  domain.ProjectTemplate
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /project-templates [post]
+func (h *ProjectTemplateHandler) Create(ctx *gin.Context) {
+	var req dto.ProjectTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	template, err := h.templateService.Create(ctx.Request.Context(), templateParams(req, userID))
+	if err != nil {
+		respondServiceError(ctx, err, "创建项目模板失败")
+		return
+	}
+
+	h.logger.Info("Project template created",
+		zap.Uint64("template_id", template.ID),
+		zap.String("template_name", template.Name),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Created(ctx, template)
+}
+
+// Update 更新项目模板
+// @Summary      更新项目模板
+// @Description  整体替换模板内容，已用模板创建的项目不受影响。仅管理员可用
+// @Tags         项目模板
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                         true  "模板ID"
+// @Param        request  body      dto.ProjectTemplateRequest  true  "模板内容"
+// @Success      200      {object}  domain.ProjectTemplate
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /project-templates/{id} [put]
+func (h *ProjectTemplateHandler) Update(ctx *gin.Context) {
+	id, ok := parseTemplateID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ProjectTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	template, err := h.templateService.Update(ctx.Request.Context(), id, templateParams(req, userID))
+	if err != nil {
+		respondServiceError(ctx, err, "更新项目模板失败")
+		return
+	}
+
+	h.logger.Info("Project template updated",
+		zap.Uint64("template_id", template.ID),
+		zap.String("template_name", template.Name),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, template)
+}
+
+// Delete 删除项目模板
+// @Summary      删除项目模板
+// @Description  已用模板创建的项目不受影响。仅管理员可用
+// @Tags         项目模板
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "模板ID"
+// @Success      200  {object}  response.APIResponse
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /project-templates/{id} [delete]
+func (h *ProjectTemplateHandler) Delete(ctx *gin.Context) {
+	id, ok := parseTemplateID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.Delete(ctx.Request.Context(), id); err != nil {
+		respondServiceError(ctx, err, "删除项目模板失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Project template deleted",
+		zap.Uint64("template_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, gin.H{"message": "项目模板已删除"})
+}
+
+// parseTemplateID 解析路径中的模板ID
+func parseTemplateID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的模板ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// templateParams DTO -> Domain params
+func templateParams(req dto.ProjectTemplateRequest, userID uint64) domain.ProjectTemplateParams {
+	keys := make([]domain.TemplateKey, 0, len(req.StarterKeys))
+	for _, key := range req.StarterKeys {
+		keys = append(keys, domain.TemplateKey{KeyName: key.KeyName, Context: key.Context, Values: key.Values})
+	}
+	members := make([]domain.TemplateMember, 0, len(req.DefaultMembers))
+	for _, member := range req.DefaultMembers {
+		members = append(members, domain.TemplateMember{UserID: member.UserID, Role: member.Role})
+	}
+	return domain.ProjectTemplateParams{
+		Name:              req.Name,
+		Description:       req.Description,
+		PlaceholderFormat: req.PlaceholderFormat,
+		ValueType:         req.ValueType,
+		Languages:         req.Languages,
+		BaseProjectIDs:    req.BaseProjectIDs,
+		StarterKeys:       keys,
+		DefaultMembers:    members,
+		UserID:            userID,
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReleaseGateHandler 发布门禁处理器
+type ReleaseGateHandler struct {
+	releaseGateService domain.ReleaseGateService
+	logger             *zap.Logger
+}
+
+// NewReleaseGateHandler 创建发布门禁处理器
+func NewReleaseGateHandler(releaseGateService domain.ReleaseGateService, logger *zap.Logger) *ReleaseGateHandler {
+	return &ReleaseGateHandler{
+		releaseGateService: releaseGateService,
+		logger:             logger,
+	}
+}
+
+// GetCriteria 获取发布门禁条件
+// @Summary      获取发布门禁条件
+// @Description  项目没有保存条件时返回默认条件：所有启用的目标语言 100% 完成，且没有过期译文、未确认的机器翻译和质量问题
+// @Tags         发布门禁
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ReleaseCriteria
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /release-gate/by-project/{project_id}/criteria [get]
+func (h *ReleaseGateHandler) GetCriteria(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	criteria, err := h.releaseGateService.GetCriteria(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "获取发布门禁条件失败")
+		return
+	}
+
+	response.Success(ctx, criteria)
+}
+
+// UpdateCriteria 更新发布门禁条件
+// @Summary      更新发布门禁条件
+// @Description  整体替换项目的发布门禁条件；language_minimums 按语言覆盖 min_completion，涉及的语言必须是启用的目标语言
+// @Tags         发布门禁
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                               true  "项目ID"
+// @Param        request     body      dto.UpdateReleaseCriteriaRequest  true  "发布门禁条件"
+// @Success      200         {object}  domain.ReleaseCriteria
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /release-gate/by-project/{project_id}/criteria [put]
+func (h *ReleaseGateHandler) UpdateCriteria(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateReleaseCriteriaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	criteria, err := h.releaseGateService.UpdateCriteria(ctx.Request.Context(), domain.UpdateReleaseCriteriaParams{
+		ProjectID:         projectID,
+		RequiredLanguages: req.RequiredLanguages,
+		MinCompletion:     *req.MinCompletion,
+		LanguageMinimums:  req.LanguageMinimums,
+		AllowOutdated:     req.AllowOutdated,
+		AllowUnapproved:   req.AllowUnapproved,
+		AllowQAErrors:     req.AllowQAErrors,
+		UserID:            userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "更新发布门禁条件失败")
+		return
+	}
+
+	h.logger.Info("Release criteria updated",
+		zap.Uint64("project_id", projectID),
+		zap.Strings("required_languages", criteria.RequiredLanguages),
+		zap.Float64("min_completion", criteria.MinCompletion),
+		zap.String("operator", operatorName(ctx)),
+	)
+	response.Success(ctx, criteria)
+}
+
+// Check 发布门禁检查
+// @Summary      发布门禁检查
+// @Description  按项目的发布门禁条件检查必需语言，返回是否通过及每种语言未满足的条件和问题键。CI 使用 CLI API Key 调用 /cli/release-check
+// @Tags         发布门禁
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ReleaseReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /release-gate/by-project/{project_id}/check [get]
+func (h *ReleaseGateHandler) Check(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := h.releaseGateService.Check(ctx.Request.Context(), projectID)
+	if err != nil {
+		respondServiceError(ctx, err, "发布门禁检查失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
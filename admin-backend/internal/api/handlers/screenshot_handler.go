@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ScreenshotHandler 截图处理器
+type ScreenshotHandler struct {
+	screenshotService domain.ScreenshotService
+	logger            *zap.Logger
+}
+
+// NewScreenshotHandler 创建截图处理器
+func NewScreenshotHandler(screenshotService domain.ScreenshotService, logger *zap.Logger) *ScreenshotHandler {
+	return &ScreenshotHandler{
+		screenshotService: screenshotService,
+		logger:            logger,
+	}
+}
+
+// parseScreenshotID 解析路径中的截图ID
+func parseScreenshotID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的截图ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List 获取截图列表
+// @Summary      获取截图列表
+// @Description  分页获取项目截图，key_name 不为空时只返回标注了该键的截图
+// @Tags         截图
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        key_name    query     string  false  "翻译键"
+// @Param        page        query     int     false  "页码"  default(1)
+// @Param        page_size   query     int     false  "每页数量"  default(10)
+// @Success      200         {array}   domain.Screenshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id} [get]
+func (h *ScreenshotHandler) List(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	screenshots, total, err := h.screenshotService.List(ctx.Request.Context(), projectID, ctx.Query("key_name"), pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取截图列表失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, screenshots, newPageMeta(page, pageSize, total))
+}
+
+// Upload 上传截图
+// @Summary      上传截图
+// @Description  上传 PNG、JPEG 或 GIF 截图，服务端生成缩略图；name 为空时使用文件名
+// @Tags         截图
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        file        formData  file    true   "截图文件"
+// @Param        name        formData  string  false  "截图名称"
+// @Success      201         {object}  domain.Screenshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id} [post]
+func (h *ScreenshotHandler) Upload(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		response.BadRequest(ctx, "请上传截图文件（file）")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "读取截图文件失败")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.BadRequest(ctx, "读取截图文件失败")
+		return
+	}
+
+	name := strings.TrimSpace(ctx.PostForm("name"))
+	if name == "" {
+		name = filepath.Base(fileHeader.Filename)
+	}
+
+	userID, _ := currentUserID(ctx)
+	screenshot, err := h.screenshotService.Upload(ctx.Request.Context(), domain.UploadScreenshotParams{
+		ProjectID: projectID,
+		Name:      name,
+		Data:      data,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "上传截图失败")
+		return
+	}
+
+	h.logger.Info("Screenshot uploaded",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("screenshot_id", screenshot.ID),
+		zap.String("file", fileHeader.Filename),
+		zap.Int64("size", screenshot.Size),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Created(ctx, screenshot)
+}
+
+// Get 获取截图
+// @Summary      获取截图
+// @Description  获取截图信息及其区域和标注的键
+// @Tags         截图
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "截图ID"
+// @Success      200         {object}  domain.Screenshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id}/{id} [get]
+func (h *ScreenshotHandler) Get(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseScreenshotID(ctx)
+	if !ok {
+		return
+	}
+
+	screenshot, err := h.screenshotService.Get(ctx.Request.Context(), projectID, id)
+	if err != nil {
+		respondServiceError(ctx, err, "获取截图失败")
+		return
+	}
+
+	response.Success(ctx, screenshot)
+}
+
+// UpdateRegions 更新截图区域
+// @Summary      更新截图区域
+// @Description  整体替换截图的矩形区域，坐标以原图像素为单位，每个区域可标注多个已存在的键
+// @Tags         截图
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                                 true  "项目ID"
+// @Param        id          path      int                                 true  "截图ID"
+// @Param        request     body      dto.UpdateScreenshotRegionsRequest  true  "区域"
+// @Success      200         {object}  domain.Screenshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id}/{id}/regions [put]
+func (h *ScreenshotHandler) UpdateRegions(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseScreenshotID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateScreenshotRegionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	screenshot, err := h.screenshotService.UpdateRegions(ctx.Request.Context(), projectID, id, req.Regions)
+	if err != nil {
+		respondServiceError(ctx, err, "更新截图区域失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Screenshot regions updated",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("screenshot_id", id),
+		zap.Int("regions", len(screenshot.Regions)),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, screenshot)
+}
+
+// Delete 删除截图
+// @Summary      删除截图
+// @Description  删除截图、区域和存储中的图片文件
+// @Tags         截图
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "截图ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id}/{id} [delete]
+func (h *ScreenshotHandler) Delete(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseScreenshotID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.screenshotService.Delete(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "删除截图失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Screenshot deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("screenshot_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "截图删除成功"})
+}
+
+// Image 获取截图原图
+// @Summary      获取截图原图
+// @Tags         截图
+// @Produce      image/png,image/jpeg,image/gif
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "截图ID"
+// @Success      200         {file}    file
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id}/{id}/image [get]
+func (h *ScreenshotHandler) Image(ctx *gin.Context) {
+	h.serveImage(ctx, false)
+}
+
+// Thumbnail 获取截图缩略图
+// @Summary      获取截图缩略图
+// @Tags         截图
+// @Produce      image/png,image/jpeg
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "截图ID"
+// @Success      200         {file}    file
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /screenshots/by-project/{project_id}/{id}/thumbnail [get]
+func (h *ScreenshotHandler) Thumbnail(ctx *gin.Context) {
+	h.serveImage(ctx, true)
+}
+
+// serveImage 输出截图图片，图片上传后不会改变，允许客户端私有缓存
+func (h *ScreenshotHandler) serveImage(ctx *gin.Context, thumbnail bool) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseScreenshotID(ctx)
+	if !ok {
+		return
+	}
+
+	image, err := h.screenshotService.GetImage(ctx.Request.Context(), projectID, id, thumbnail)
+	if err != nil {
+		respondServiceError(ctx, err, "获取截图图片失败")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=86400")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, image.ContentType, image.Data)
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"fmt"
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SnapshotHandler 版本快照处理器
+type SnapshotHandler struct {
+	snapshotService domain.SnapshotService
+	logger          *zap.Logger
+}
+
+// NewSnapshotHandler 创建版本快照处理器
+func NewSnapshotHandler(snapshotService domain.SnapshotService, logger *zap.Logger) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: snapshotService,
+		logger:          logger,
+	}
+}
+
+// parseSnapshotID 解析路径中的快照ID
+func parseSnapshotID(ctx *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的快照ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// List 获取版本快照列表
+// @Summary      获取版本快照列表
+// @Description  分页获取项目的版本快照，按创建时间倒序
+// @Tags         版本快照
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        page        query     int  false  "页码"  default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {array}   domain.Snapshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id} [get]
+func (h *SnapshotHandler) List(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	snapshots, total, err := h.snapshotService.List(ctx.Request.Context(), projectID, pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取版本快照列表失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, snapshots, newPageMeta(page, pageSize, total))
+}
+
+// Create 创建版本快照
+// @Summary      创建版本快照
+// @Description  保存项目当前所有有效键的非空译文，快照创建后不可修改；版本名在项目内唯一
+// @Tags         版本快照
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true  "项目ID"
+// @Param        request     body      dto.CreateSnapshotRequest  true  "快照信息"
+// @Success      201         {object}  domain.Snapshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id} [post]
+func (h *SnapshotHandler) Create(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateSnapshotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	snapshot, err := h.snapshotService.Create(ctx.Request.Context(), domain.CreateSnapshotParams{
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "创建版本快照失败")
+		return
+	}
+
+	h.logger.Info("Snapshot created",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("snapshot_id", snapshot.ID),
+		zap.String("name", snapshot.Name),
+		zap.Int("keys", snapshot.KeyCount),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Created(ctx, snapshot)
+}
+
+// Get 获取版本快照
+// @Summary      获取版本快照
+// @Tags         版本快照
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "快照ID"
+// @Success      200         {object}  domain.Snapshot
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id}/{id} [get]
+func (h *SnapshotHandler) Get(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseSnapshotID(ctx)
+	if !ok {
+		return
+	}
+
+	snapshot, err := h.snapshotService.Get(ctx.Request.Context(), projectID, id)
+	if err != nil {
+		respondServiceError(ctx, err, "获取版本快照失败")
+		return
+	}
+
+	response.Success(ctx, snapshot)
+}
+
+// Export 导出版本快照
+// @Summary      导出版本快照
+// @Description  按指定格式导出快照中的译文，格式与翻译导出相同
+// @Tags         版本快照
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        id          path      int     true   "快照ID"
+// @Param        format      query     string  false  "导出格式"  default(json)
+// @Success      200         {file}    file
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id}/{id}/export [get]
+func (h *SnapshotHandler) Export(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseSnapshotID(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	data, err := h.snapshotService.Export(ctx.Request.Context(), projectID, id, format)
+	if err != nil {
+		respondExportError(ctx, err)
+		return
+	}
+
+	filename := fmt.Sprintf("snapshot-%d-%d.%s", projectID, id, format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// Diff 比较版本快照
+// @Summary      比较版本快照
+// @Description  比较快照与另一个快照（against）或项目当前的译文（不传 against），列出新增和删除的键，以及各语言新增、删除和修改的译文
+// @Tags         版本快照
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        id          path      int  true   "快照ID"
+// @Param        against     query     int  false  "比较的快照ID，默认与当前译文比较"
+// @Success      200         {object}  domain.SnapshotDiff
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id}/{id}/diff [get]
+func (h *SnapshotHandler) Diff(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseSnapshotID(ctx)
+	if !ok {
+		return
+	}
+
+	var against uint64
+	if raw := ctx.Query("against"); raw != "" {
+		var err error
+		if against, err = strconv.ParseUint(raw, 10, 64); err != nil || against == 0 {
+			response.BadRequest(ctx, "无效的 against 参数")
+			return
+		}
+	}
+
+	diff, err := h.snapshotService.Diff(ctx.Request.Context(), projectID, id, against)
+	if err != nil {
+		respondServiceError(ctx, err, "比较版本快照失败")
+		return
+	}
+
+	response.Success(ctx, diff)
+}
+
+// Delete 删除版本快照
+// @Summary      删除版本快照
+// @Description  删除快照，不再被其他快照引用的译文内容一并清理
+// @Tags         版本快照
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        id          path      int  true  "快照ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /snapshots/by-project/{project_id}/{id} [delete]
+func (h *SnapshotHandler) Delete(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+	id, ok := parseSnapshotID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.snapshotService.Delete(ctx.Request.Context(), projectID, id); err != nil {
+		respondServiceError(ctx, err, "删除版本快照失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	h.logger.Info("Snapshot deleted",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("snapshot_id", id),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "版本快照删除成功"})
+}
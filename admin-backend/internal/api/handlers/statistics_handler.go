@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatisticsHandler 字数统计处理器
+type StatisticsHandler struct {
+	statisticsService domain.StatisticsService
+}
+
+// NewStatisticsHandler 创建字数统计处理器
+func NewStatisticsHandler(statisticsService domain.StatisticsService) *StatisticsHandler {
+	return &StatisticsHandler{
+		statisticsService: statisticsService,
+	}
+}
+
+// GetProjectStatistics 获取项目字数统计
+// @Summary      获取项目字数统计
+// @Description  按语言统计总数、已翻译、未翻译和过期的键数、原文词数和字符数；汉字和假名每字计一词。
+// @Description  since 为日期（2006-01-02）或 RFC3339 时间，统计此后新增或修改的原文；since_version 为版本快照名，统计相对该版本新增或修改的原文；cost=true 时按配置的每词单价和翻译记忆匹配折扣估算费用
+// @Tags         字数统计
+// @Accept       json
+// @Produce      json
+// @Param        project_id     path      int     true   "项目ID"
+// @Param        since          query     string  false  "起始时间"
+// @Param        since_version  query     string  false  "起始版本快照名"
+// @Param        cost           query     bool    false  "是否估算费用"
+// @Success      200            {object}  domain.ProjectStatistics
+// @Failure      400            {object}  response.APIResponse
+// @Failure      404            {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /statistics/by-project/{project_id} [get]
+func (h *StatisticsHandler) GetProjectStatistics(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	params := domain.ProjectStatisticsParams{
+		ProjectID: projectID,
+		Cost:      ctx.Query("cost") == "true",
+	}
+	if since := ctx.Query("since"); since != "" {
+		parsed, err := parseSince(since)
+		if err != nil {
+			response.BadRequest(ctx, "无效的 since 参数，应为日期（2006-01-02）或 RFC3339 时间")
+			return
+		}
+		params.Since = &parsed
+	}
+	params.SinceVersion = ctx.Query("since_version")
+	params.UserID, _ = currentUserID(ctx)
+
+	statistics, err := h.statisticsService.GetProjectStatistics(ctx.Request.Context(), params)
+	if err != nil {
+		respondServiceError(ctx, err, "获取字数统计失败")
+		return
+	}
+
+	response.Success(ctx, statistics)
+}
+
+// parseSince 解析日期或 RFC3339 时间，日期按 UTC 零点计
+func parseSince(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -4,6 +4,7 @@ import (
 	"i18n-flow/internal/api/response"
 	"i18n-flow/internal/domain"
 	"i18n-flow/internal/dto"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -13,17 +14,25 @@ import (
 // TranslationHandler 翻译处理器
 type TranslationHandler struct {
 	translationService domain.TranslationService
+	keyUsageService    domain.KeyUsageService
 	logger             *zap.Logger
 }
 
 // NewTranslationHandler 创建翻译处理器
-func NewTranslationHandler(translationService domain.TranslationService, logger *zap.Logger) *TranslationHandler {
+func NewTranslationHandler(translationService domain.TranslationService, keyUsageService domain.KeyUsageService, logger *zap.Logger) *TranslationHandler {
 	return &TranslationHandler{
 		translationService: translationService,
+		keyUsageService:    keyUsageService,
 		logger:             logger,
 	}
 }
 
+// TranslationDetailResponse 翻译详情响应
+type TranslationDetailResponse struct {
+	*domain.Translation
+	Usage *domain.KeyUsageDetail `json:"usage,omitempty"` // 键的代码引用和标注了该键的截图
+}
+
 // Create 创建翻译
 // @Summary      创建翻译
 // @Description  创建新的翻译
@@ -68,7 +77,17 @@ func (h *TranslationHandler) Create(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				if appErr.Details != "" {
+					response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.BadRequest(ctx, appErr.Message)
+				}
+			case domain.ErrorTypeForbidden:
+				if appErr.Details != "" {
+					response.ForbiddenWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.Forbidden(ctx, appErr.Message)
+				}
 			default:
 				response.InternalServerError(ctx, "创建翻译失败")
 			}
@@ -137,7 +156,17 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 				case domain.ErrorTypeConflict:
 					response.Conflict(ctx, appErr.Message)
 				case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-					response.BadRequest(ctx, appErr.Message)
+					if appErr.Details != "" {
+						response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+					} else {
+						response.BadRequest(ctx, appErr.Message)
+					}
+				case domain.ErrorTypeForbidden:
+					if appErr.Details != "" {
+						response.ForbiddenWithDetails(ctx, appErr.Message, appErr.Details)
+					} else {
+						response.Forbidden(ctx, appErr.Message)
+					}
 				default:
 					response.InternalServerError(ctx, "批量创建翻译失败")
 				}
@@ -186,7 +215,17 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				if appErr.Details != "" {
+					response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.BadRequest(ctx, appErr.Message)
+				}
+			case domain.ErrorTypeForbidden:
+				if appErr.Details != "" {
+					response.ForbiddenWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.Forbidden(ctx, appErr.Message)
+				}
 			default:
 				response.InternalServerError(ctx, "批量创建翻译失败")
 			}
@@ -322,12 +361,12 @@ func (h *TranslationHandler) GetMatrix(ctx *gin.Context) {
 
 // GetByID 根据ID获取翻译
 // @Summary      获取翻译详情
-// @Description  根据翻译ID获取翻译详细信息
+// @Description  根据翻译ID获取翻译详细信息，usage 为该键在代码中的引用
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "翻译ID"
-// @Success      200  {object}  domain.Translation
+// @Success      200  {object}  TranslationDetailResponse
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Security     BearerAuth
@@ -351,12 +390,24 @@ func (h *TranslationHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	response.Success(ctx, translation)
+	// 代码引用只是附加信息，获取失败时仍返回翻译
+	detail := TranslationDetailResponse{Translation: translation}
+	usage, err := h.keyUsageService.GetKeyUsage(ctx.Request.Context(), translation.ProjectID, translation.KeyName)
+	if err != nil {
+		h.logger.Warn("Failed to get key usage",
+			zap.Uint64("translation_id", id),
+			zap.Error(err),
+		)
+	} else {
+		detail.Usage = usage
+	}
+
+	response.Success(ctx, detail)
 }
 
 // Update 更新翻译
 // @Summary      更新翻译
-// @Description  更新翻译信息
+// @Description  更新翻译信息；project_id 为继承了该翻译所属基础项目的项目时，在该项目中创建本地覆盖，基础项目不变
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
@@ -406,7 +457,17 @@ func (h *TranslationHandler) Update(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				if appErr.Details != "" {
+					response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.BadRequest(ctx, appErr.Message)
+				}
+			case domain.ErrorTypeForbidden:
+				if appErr.Details != "" {
+					response.ForbiddenWithDetails(ctx, appErr.Message, appErr.Details)
+				} else {
+					response.Forbidden(ctx, appErr.Message)
+				}
 			default:
 				response.InternalServerError(ctx, "更新翻译失败")
 			}
@@ -465,12 +526,7 @@ func (h *TranslationHandler) Delete(ctx *gin.Context) {
 
 	err = h.translationService.Delete(ctx.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case domain.ErrTranslationNotFound:
-			response.NotFound(ctx, err.Error())
-		default:
-			response.InternalServerError(ctx, "删除翻译失败")
-		}
+		respondServiceError(ctx, err, "删除翻译失败")
 		return
 	}
 
@@ -515,7 +571,7 @@ func (h *TranslationHandler) DeleteBatch(ctx *gin.Context) {
 
 	err := h.translationService.DeleteBatch(ctx.Request.Context(), ids)
 	if err != nil {
-		response.InternalServerError(ctx, "批量删除翻译失败")
+		respondServiceError(ctx, err, "批量删除翻译失败")
 		return
 	}
 
@@ -541,14 +597,21 @@ func (h *TranslationHandler) DeleteBatch(ctx *gin.Context) {
 
 // Export 导出翻译
 // @Summary      导出翻译
-// @Description  导出项目翻译数据
+// @Description  导出项目翻译数据。未指定 placeholders、pseudo 和 in_context 时返回翻译矩阵；否则返回导出文件，无法转换的占位符数量见 X-Untranslatable-Placeholders 响应头。pseudo=true 时附加由默认语言生成的伪本地化语言 en-XA（pseudo_rtl=true 时为 ar-XB），占位符、ICU 结构和 HTML 标签保持不变
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
-// @Param        project_id  path      int     true   "项目ID"
-// @Success      200         {object}  response.APIResponse
-// @Failure      400         {object}  response.APIResponse
-// @Failure      404         {object}  response.APIResponse
+// @Param        project_id    path      int     true   "项目ID"
+// @Param        placeholders  query     string  false  "目标占位符语法：brace, double_brace, android, ios, gettext"
+// @Param        pseudo        query     bool    false  "附加伪本地化语言"
+// @Param        pseudo_expansion query  int     false  "伪本地化长度扩展百分比，0-300"  default(30)
+// @Param        pseudo_brackets  query  bool    false  "伪本地化文本是否加 [ ]"  default(true)
+// @Param        pseudo_rtl    query     bool    false  "生成从右到左的伪本地化语言"
+// @Param        in_context    query     bool    false  "用零宽标记包裹每个值，供页内编辑定位键"
+// @Param        format        query     string  false  "导出格式"  default(json)
+// @Success      200           {object}  response.APIResponse
+// @Failure      400           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
 // @Security     BearerAuth
 // @Router       /exports/project/{project_id} [get]
 func (h *TranslationHandler) Export(ctx *gin.Context) {
@@ -559,6 +622,20 @@ func (h *TranslationHandler) Export(ctx *gin.Context) {
 		return
 	}
 
+	opts, ok := parseExportOptions(ctx)
+	if !ok {
+		return
+	}
+	if opts.Placeholders != "" || opts.Pseudo != nil || opts.InContext {
+		data, untranslatable, err := h.translationService.Export(ctx.Request.Context(), projectID, ctx.DefaultQuery("format", "json"), opts)
+		if err != nil {
+			respondExportError(ctx, err)
+			return
+		}
+		writeExportFile(ctx, data, untranslatable)
+		return
+	}
+
 	// 获取翻译矩阵数据
 	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
 	if err != nil {
@@ -584,6 +661,7 @@ func (h *TranslationHandler) Export(ctx *gin.Context) {
 // @Param        project_id  path      int                                       true  "项目ID"
 // @Param        data        body      map[string]map[string]string             true  "翻译数据，格式为 {\"key1\": {\"en\": \"value1\", \"zh\": \"值1\"}}"
 // @Param        format      query     string                                   false "导入格式" default("json")
+// @Param        placeholders query    string                                   false "导入数据的占位符语法，导入时转换为项目的规范语法"
 // @Success      200         {object}  response.APIResponse
 // @Failure      400         {object}  response.APIResponse
 // @Failure      404         {object}  response.APIResponse
@@ -606,13 +684,22 @@ func (h *TranslationHandler) Import(ctx *gin.Context) {
 		return
 	}
 
-	err = h.translationService.Import(ctx.Request.Context(), projectID, data, format)
+	opts := domain.ImportOptions{Placeholders: ctx.Query("placeholders")}
+	untranslatable, err := h.translationService.Import(ctx.Request.Context(), projectID, data, format, opts)
 	if err != nil {
 		switch err {
 		case domain.ErrProjectNotFound:
 			response.NotFound(ctx, err.Error())
+		case domain.ErrInvalidPlaceholderFormat:
+			response.BadRequest(ctx, err.Error())
 		default:
-			response.InternalServerError(ctx, "导入翻译失败: "+err.Error())
+			if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			} else if ok && appErr.Type == domain.ErrorTypeForbidden {
+				respondServiceError(ctx, err, "导入翻译失败")
+			} else {
+				response.InternalServerError(ctx, "导入翻译失败: "+err.Error())
+			}
 		}
 		return
 	}
@@ -632,9 +719,208 @@ func (h *TranslationHandler) Import(ctx *gin.Context) {
 		zap.Uint64("project_id", projectID),
 		zap.String("format", format),
 		zap.Int("data_size", len(data)),
+		zap.Int("untranslatable", len(untranslatable)),
 		zap.Uint64("operator_id", operatorID.(uint64)),
 		zap.String("operator", operatorName),
 	)
 
-	response.Success(ctx, gin.H{"message": "导入翻译成功"})
+	response.Success(ctx, gin.H{"message": "导入翻译成功", "untranslatable": untranslatable})
+}
+
+// GetKeyTree 获取键树
+// @Summary      获取键树
+// @Description  将点号分隔的键名视为文件夹，返回指定路径下的子文件夹和键，包含各文件夹的键数和各语言完成度
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        path        query     string  false  "文件夹路径，如 common.buttons，为空表示根目录"
+// @Param        page        query     int     false  "键的页码"  default(1)
+// @Param        page_size   query     int     false  "每页键数量"  default(10)
+// @Success      200         {object}  domain.KeyTree
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/tree/by-project/{project_id} [get]
+func (h *TranslationHandler) GetKeyTree(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	path := ctx.Query("path")
+
+	tree, err := h.translationService.GetKeyTree(ctx.Request.Context(), projectID, path, pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取键树失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, tree, newPageMeta(page, pageSize, tree.TotalKeys))
+}
+
+// DeleteSubtree 删除键树文件夹
+// @Summary      删除键树文件夹
+// @Description  删除指定文件夹下（含子文件夹）的所有键
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        path        query     string  true  "文件夹路径"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/tree/by-project/{project_id} [delete]
+func (h *TranslationHandler) DeleteSubtree(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	path := ctx.Query("path")
+	deleted, err := h.translationService.DeleteSubtree(ctx.Request.Context(), projectID, path)
+	if err != nil {
+		respondServiceError(ctx, err, "删除文件夹失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Translation subtree deleted",
+		zap.Uint64("project_id", projectID),
+		zap.String("path", path),
+		zap.Int64("deleted_count", deleted),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"deleted": deleted})
+}
+
+// TagSubtree 为键树文件夹添加标签
+// @Summary      为键树文件夹添加标签
+// @Description  为指定文件夹下（含子文件夹）的所有键添加标签
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                    true  "项目ID"
+// @Param        request     body      dto.TagSubtreeRequest  true  "文件夹路径和标签"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/tree/by-project/{project_id}/tags [post]
+func (h *TranslationHandler) TagSubtree(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.TagSubtreeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	tagged, err := h.translationService.TagSubtree(ctx.Request.Context(), projectID, req.Path, req.Tags, userID)
+	if err != nil {
+		respondServiceError(ctx, err, "添加标签失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"tagged_keys": tagged})
+}
+
+// ExportSubtree 导出键树文件夹
+// @Summary      导出键树文件夹
+// @Description  导出指定文件夹下（含子文件夹）的翻译数据
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        path        query     string  false  "文件夹路径"
+// @Param        format      query     string  false  "导出格式"  default(json)
+// @Param        placeholders query    string  false  "目标占位符语法，为空时保持项目的规范语法"
+// @Param        pseudo      query     bool    false  "附加伪本地化语言，选项同导出接口"
+// @Param        in_context  query     bool    false  "用零宽标记包裹每个值，供页内编辑定位键"
+// @Success      200         {object}  map[string]map[string]string
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /exports/project/{project_id}/tree [get]
+func (h *TranslationHandler) ExportSubtree(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	opts, ok := parseExportOptions(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	data, untranslatable, err := h.translationService.ExportSubtree(ctx.Request.Context(), projectID, ctx.Query("path"), format, opts)
+	if err != nil {
+		respondExportError(ctx, err)
+		return
+	}
+
+	writeExportFile(ctx, data, untranslatable)
+}
+
+// CheckPlaceholders 检查占位符转换
+// @Summary      检查占位符转换
+// @Description  列出将项目翻译转换为指定占位符语法时无法无损转换的占位符
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id    path      int     true  "项目ID"
+// @Param        placeholders  query     string  true  "目标占位符语法：brace, double_brace, android, ios, gettext"
+// @Success      200           {array}   domain.UntranslatablePlaceholder
+// @Failure      400           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /exports/project/{project_id}/placeholders [get]
+func (h *TranslationHandler) CheckPlaceholders(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	placeholders := ctx.Query("placeholders")
+	if placeholders == "" {
+		response.BadRequest(ctx, "缺少目标占位符语法")
+		return
+	}
+
+	_, untranslatable, err := h.translationService.ExportMatrix(ctx.Request.Context(), projectID, domain.ExportOptions{Placeholders: placeholders})
+	if err != nil {
+		respondServiceError(ctx, err, "检查占位符失败")
+		return
+	}
+
+	response.Success(ctx, untranslatable)
+}
+
+// respondExportError 输出导出错误，不支持的导出格式视为请求错误
+func respondExportError(ctx *gin.Context, err error) {
+	if _, isAppErr := domain.IsAppError(err); isAppErr {
+		respondServiceError(ctx, err, "导出翻译失败")
+		return
+	}
+	response.BadRequest(ctx, err.Error())
+}
+
+// writeExportFile 输出导出文件，并通过响应头告知无法转换的占位符数量
+func writeExportFile(ctx *gin.Context, data []byte, untranslatable []*domain.UntranslatablePlaceholder) {
+	ctx.Header("X-Untranslatable-Placeholders", strconv.Itoa(len(untranslatable)))
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", data)
 }
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"fmt"
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TranslationMemoryHandler 翻译记忆处理器
+type TranslationMemoryHandler struct {
+	tmService domain.TranslationMemoryService
+	logger    *zap.Logger
+}
+
+// NewTranslationMemoryHandler 创建翻译记忆处理器
+func NewTranslationMemoryHandler(tmService domain.TranslationMemoryService, logger *zap.Logger) *TranslationMemoryHandler {
+	return &TranslationMemoryHandler{
+		tmService: tmService,
+		logger:    logger,
+	}
+}
+
+// Lookup 查询翻译记忆
+// @Summary      查询翻译记忆
+// @Description  在当前用户可查看的项目翻译和导入的 TMX 记忆中查找原文的完全匹配和模糊匹配，score 为基于编辑距离的相似度
+// @Tags         翻译记忆
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TranslationMemoryLookupRequest  true  "查询请求"
+// @Success      200      {array}   domain.TranslationMemoryMatch
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translation-memory/lookup [post]
+func (h *TranslationMemoryHandler) Lookup(ctx *gin.Context) {
+	var req dto.TranslationMemoryLookupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	matches, err := h.tmService.Lookup(ctx.Request.Context(), domain.TranslationMemoryLookupParams{
+		UserID:         userID,
+		Source:         req.Source,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		MinScore:       req.MinScore,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "查询翻译记忆失败")
+		return
+	}
+
+	response.Success(ctx, matches)
+}
+
+// Suggest 获取翻译记忆建议
+// @Summary      获取翻译记忆建议
+// @Description  编辑翻译矩阵单元格时，以该键在默认语言下的翻译为原文查找目标语言的翻译记忆
+// @Tags         翻译记忆
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        key_name    query     string  true   "键名"
+// @Param        language    query     string  true   "目标语言代码"
+// @Param        min_score   query     number  false  "最低相似度"  default(0.7)
+// @Param        limit       query     int     false  "返回的匹配数"  default(10)
+// @Success      200         {object}  domain.TranslationMemorySuggestions
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translation-memory/suggestions/by-project/{project_id} [get]
+func (h *TranslationMemoryHandler) Suggest(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	keyName := ctx.Query("key_name")
+	language := ctx.Query("language")
+	if keyName == "" || language == "" {
+		response.BadRequest(ctx, "key_name and language are required")
+		return
+	}
+
+	params := domain.TranslationMemorySuggestParams{
+		ProjectID:      projectID,
+		KeyName:        keyName,
+		TargetLanguage: language,
+	}
+	params.UserID, _ = currentUserID(ctx)
+	if raw := ctx.Query("min_score"); raw != "" {
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil || score <= 0 || score > 1 {
+			response.ValidationError(ctx, "min_score 必须在 0 到 1 之间")
+			return
+		}
+		params.MinScore = score
+	}
+	if raw := ctx.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			response.ValidationError(ctx, "limit 必须是正整数")
+			return
+		}
+		params.Limit = limit
+	}
+
+	suggestions, err := h.tmService.Suggest(ctx.Request.Context(), params)
+	if err != nil {
+		respondServiceError(ctx, err, "获取翻译记忆建议失败")
+		return
+	}
+
+	response.Success(ctx, suggestions)
+}
+
+// ImportTMX 导入 TMX
+// @Summary      导入 TMX
+// @Description  导入 TMX 文件作为翻译记忆，对所有用户可见。语言代码按系统语言匹配（en-US 可匹配 en），已导入过的翻译单元会被跳过
+// @Tags         翻译记忆
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "TMX 文件"
+// @Success      200   {object}  domain.ImportTMXResult
+// @Failure      400   {object}  response.APIResponse
+// @Failure      403   {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translation-memory/import [post]
+func (h *TranslationMemoryHandler) ImportTMX(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		response.BadRequest(ctx, "请上传 TMX 文件（file）")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.BadRequest(ctx, "读取 TMX 文件失败")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.BadRequest(ctx, "读取 TMX 文件失败")
+		return
+	}
+
+	userID, _ := currentUserID(ctx)
+	result, err := h.tmService.ImportTMX(ctx.Request.Context(), domain.ImportTMXParams{
+		Data:   data,
+		Origin: filepath.Base(fileHeader.Filename),
+		UserID: userID,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "导入 TMX 失败")
+		return
+	}
+
+	h.logger.Info("Translation memory imported",
+		zap.String("file", fileHeader.Filename),
+		zap.Int("units", result.Units),
+		zap.Int("imported", result.Imported),
+		zap.Int("duplicates", result.Duplicates),
+		zap.Int("skipped", result.Skipped),
+		zap.Uint64("operator_id", userID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// ExportTMX 导出 TMX
+// @Summary      导出 TMX
+// @Description  将当前用户可查看的项目翻译导出为 TMX 1.4 文件，每个项目中的键为一个翻译单元
+// @Tags         翻译记忆
+// @Produce      application/xml
+// @Param        source_language   query     string  false  "源语言代码，默认为默认语言"
+// @Param        project_id        query     int     false  "只导出指定项目"
+// @Param        include_imported  query     bool    false  "是否包含导入的记忆"  default(false)
+// @Success      200               {file}    file
+// @Failure      400               {object}  response.APIResponse
+// @Failure      403               {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translation-memory/export [get]
+func (h *TranslationMemoryHandler) ExportTMX(ctx *gin.Context) {
+	params := domain.ExportTMXParams{SourceLanguage: ctx.Query("source_language")}
+	params.UserID, _ = currentUserID(ctx)
+
+	if raw := ctx.Query("project_id"); raw != "" {
+		projectID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(ctx, "无效的项目ID")
+			return
+		}
+		params.ProjectID = projectID
+	}
+	if raw := ctx.Query("include_imported"); raw != "" {
+		includeImported, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.ValidationError(ctx, "include_imported 必须是布尔值")
+			return
+		}
+		params.IncludeImported = includeImported
+	}
+
+	data, err := h.tmService.ExportTMX(ctx.Request.Context(), params)
+	if err != nil {
+		respondServiceError(ctx, err, "导出 TMX 失败")
+		return
+	}
+
+	filename := fmt.Sprintf("translation-memory-%s.tmx", time.Now().Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Data(http.StatusOK, "application/x-tmx+xml; charset=utf-8", data)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"i18n-flow/internal/api/response"
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/dto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TrashHandler 回收站处理器
+type TrashHandler struct {
+	trashService domain.TrashService
+	logger       *zap.Logger
+}
+
+// NewTrashHandler 创建回收站处理器
+func NewTrashHandler(trashService domain.TrashService, logger *zap.Logger) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+		logger:       logger,
+	}
+}
+
+// ListDeletedKeys 获取项目回收站中的翻译键
+// @Summary      获取项目回收站
+// @Description  按键名分组列出项目中已删除的翻译，conflict 表示项目中已存在同名键
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        keyword     query     string  false  "键名关键词"
+// @Param        page        query     int     false  "页码"  default(1)
+// @Param        page_size   query     int     false  "每页数量"  default(10)
+// @Success      200         {array}   domain.TrashedKey
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/by-project/{project_id} [get]
+func (h *TrashHandler) ListDeletedKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	page, pageSize, offset := parsePagination(ctx)
+	keys, total, err := h.trashService.ListDeletedKeys(ctx.Request.Context(), projectID, ctx.Query("keyword"), pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取回收站失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, keys, newPageMeta(page, pageSize, total))
+}
+
+// RestoreKeys 恢复回收站中的翻译键
+// @Summary      恢复翻译键
+// @Description  恢复回收站中的翻译键。与现有键冲突时按策略处理：skip 跳过，overwrite 覆盖（现有翻译移入回收站），rename 以新键名恢复
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                     true  "项目ID"
+// @Param        request     body      dto.RestoreKeysRequest  true  "恢复请求"
+// @Success      200         {object}  domain.RestoreKeysResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/by-project/{project_id}/restore [post]
+func (h *TrashHandler) RestoreKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.RestoreKeysRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.trashService.RestoreKeys(ctx.Request.Context(), projectID, domain.RestoreKeysParams{
+		KeyNames: req.KeyNames,
+		Strategy: req.Strategy,
+	})
+	if err != nil {
+		respondServiceError(ctx, err, "恢复翻译失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Translation keys restored from trash",
+		zap.Uint64("project_id", projectID),
+		zap.Strings("keys", result.Restored),
+		zap.Int("translations", result.Translations),
+		zap.String("strategy", req.Strategy),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// PurgeKeys 彻底删除回收站中的翻译键
+// @Summary      彻底删除翻译键
+// @Description  彻底删除回收站中指定的翻译键，all 为 true 时清空整个项目回收站，操作不可恢复
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        request     body      dto.PurgeKeysRequest  true  "删除请求"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/by-project/{project_id}/purge [post]
+func (h *TrashHandler) PurgeKeys(ctx *gin.Context) {
+	projectID, ok := parseProjectID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PurgeKeysRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+	if !req.All && len(req.KeyNames) == 0 {
+		response.ValidationError(ctx, "请指定要彻底删除的键，或设置 all 为 true 清空回收站")
+		return
+	}
+
+	keyNames := req.KeyNames
+	if req.All {
+		keyNames = nil
+	}
+
+	purged, err := h.trashService.PurgeKeys(ctx.Request.Context(), projectID, keyNames)
+	if err != nil {
+		respondServiceError(ctx, err, "彻底删除翻译失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Translation keys purged from trash",
+		zap.Uint64("project_id", projectID),
+		zap.Bool("all", req.All),
+		zap.Int("requested_keys", len(req.KeyNames)),
+		zap.Int64("purged_count", purged),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"purged": purged})
+}
+
+// ListDeletedProjects 获取回收站中的项目
+// @Summary      获取已删除项目
+// @Description  管理员查看回收站中的项目
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        keyword    query     string  false  "项目名称或标识关键词"
+// @Param        page       query     int     false  "页码"  default(1)
+// @Param        page_size  query     int     false  "每页数量"  default(10)
+// @Success      200        {array}   domain.TrashedProject
+// @Failure      403        {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/projects [get]
+func (h *TrashHandler) ListDeletedProjects(ctx *gin.Context) {
+	page, pageSize, offset := parsePagination(ctx)
+	projects, total, err := h.trashService.ListDeletedProjects(ctx.Request.Context(), ctx.Query("keyword"), pageSize, offset)
+	if err != nil {
+		respondServiceError(ctx, err, "获取已删除项目失败")
+		return
+	}
+
+	response.SuccessWithMeta(ctx, projects, newPageMeta(page, pageSize, total))
+}
+
+// RestoreProject 恢复回收站中的项目
+// @Summary      恢复项目
+// @Description  管理员恢复回收站中的项目，项目的翻译和成员随之可见
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "项目ID"
+// @Success      200  {object}  domain.Project
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/projects/{id}/restore [post]
+func (h *TrashHandler) RestoreProject(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	project, err := h.trashService.RestoreProject(ctx.Request.Context(), id)
+	if err != nil {
+		respondServiceError(ctx, err, "恢复项目失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Project restored from trash",
+		zap.Uint64("project_id", id),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, project)
+}
+
+// PurgeProject 彻底删除回收站中的项目
+// @Summary      彻底删除项目
+// @Description  管理员彻底删除回收站中的项目及其全部翻译和成员，操作不可恢复
+// @Tags         回收站
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "项目ID"
+// @Success      200  {object}  response.APIResponse
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /trash/projects/{id} [delete]
+func (h *TrashHandler) PurgeProject(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	if err := h.trashService.PurgeProject(ctx.Request.Context(), id); err != nil {
+		respondServiceError(ctx, err, "彻底删除项目失败")
+		return
+	}
+
+	operatorID, _ := currentUserID(ctx)
+	h.logger.Info("Project purged from trash",
+		zap.Uint64("project_id", id),
+		zap.Uint64("operator_id", operatorID),
+		zap.String("operator", operatorName(ctx)),
+	)
+
+	response.Success(ctx, gin.H{"message": "项目已彻底删除"})
+}
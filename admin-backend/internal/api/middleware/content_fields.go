@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// translationContentFields 各路由请求体中的翻译内容字段，键为“方法 路由模板”
+// 字段路径以 . 分隔，* 匹配任意对象键或数组下标
+// 翻译内容可能包含 HTML、Markdown 或 i18next 的 <0> 标签，不经过全局的 XSS 清理和输入改写，
+// 改由翻译服务按项目的值类型校验，并在输出时按需转义
+// 新增接收请求体的路由时需在这里登记翻译内容字段，或在路由测试中声明为不含翻译内容
+var translationContentFields = map[string][]string{
+	"POST /api/translations":                                 {"value"},
+	"PUT /api/translations/:id":                              {"value"},
+	"POST /api/translations/batch":                           {"translations.*", "*.value"},
+	"POST /api/imports/project/:project_id":                  {"*.*"},
+	"POST /api/cli/keys":                                     {"defaults.*", "translations.*.*"},
+	"POST /api/cli/sync":                                     {"values.*.*"},
+	"PUT /api/branches/by-project/:project_id/:id/values":    {"cells.*.value"},
+	"POST /api/branches/by-project/:project_id/:id/merge":    {"resolutions.*.value"},
+	"POST /api/consistency/by-project/:project_id/harmonize": {"value"},
+	"POST /api/translation-memory/lookup":                    {"source"},
+	"POST /api/in-context/resolve":                           {"text"},
+	"POST /api/project-templates":                            {"starter_keys.*.values.*"},
+	"PUT /api/project-templates/:id":                         {"starter_keys.*.values.*"},
+}
+
+// TranslationContentRoutes 返回登记了翻译内容字段的路由（“方法 路由模板”），按字母排序
+func TranslationContentRoutes() []string {
+	routes := make([]string, 0, len(translationContentFields))
+	for route := range translationContentFields {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// contentFieldsFor 返回当前路由的翻译内容字段，未匹配路由时返回 nil
+func contentFieldsFor(c *gin.Context) []string {
+	if c.FullPath() == "" {
+		return nil
+	}
+	return translationContentFields[c.Request.Method+" "+c.FullPath()]
+}
+
+// isContentField 判断字段路径是否为翻译内容字段
+func isContentField(fields []string, path []string) bool {
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		if len(parts) != len(path) {
+			continue
+		}
+		matched := true
+		for i, part := range parts {
+			if part != "*" && part != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
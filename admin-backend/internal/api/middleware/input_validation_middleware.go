@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -94,7 +95,7 @@ func EnhancedInputValidationMiddlewareWithConfig(config InputValidationConfig) g
 		}
 
 		// 递归验证和清理JSON数据
-		cleanedData, err := validateAndCleanJSON(jsonData, config, policy, forbiddenRegexps)
+		cleanedData, err := validateAndCleanJSON(jsonData, config, policy, forbiddenRegexps, contentFieldsFor(c), nil)
 		if err != nil {
 			response.BadRequest(c, fmt.Sprintf("输入验证失败: %s", err.Error()))
 			return
@@ -115,10 +116,14 @@ func EnhancedInputValidationMiddlewareWithConfig(config InputValidationConfig) g
 	}
 }
 
-// validateAndCleanJSON 递归验证和清理JSON数据
-func validateAndCleanJSON(data interface{}, config InputValidationConfig, policy *bluemonday.Policy, forbiddenRegexps []*regexp.Regexp) (interface{}, error) {
+// validateAndCleanJSON 递归验证和清理JSON数据，path 为当前值的字段路径
+// contentFields 中的翻译内容字段只检查长度和编码，不做改写
+func validateAndCleanJSON(data interface{}, config InputValidationConfig, policy *bluemonday.Policy, forbiddenRegexps []*regexp.Regexp, contentFields []string, path []string) (interface{}, error) {
 	switch v := data.(type) {
 	case string:
+		if isContentField(contentFields, path) {
+			return validateContentString(v, config)
+		}
 		return validateAndCleanString(v, config, policy, forbiddenRegexps)
 	case map[string]interface{}:
 		cleaned := make(map[string]interface{})
@@ -130,7 +135,7 @@ func validateAndCleanJSON(data interface{}, config InputValidationConfig, policy
 			}
 
 			// 递归验证值
-			cleanValue, err := validateAndCleanJSON(value, config, policy, forbiddenRegexps)
+			cleanValue, err := validateAndCleanJSON(value, config, policy, forbiddenRegexps, contentFields, append(path[:len(path):len(path)], key))
 			if err != nil {
 				return nil, err
 			}
@@ -141,7 +146,7 @@ func validateAndCleanJSON(data interface{}, config InputValidationConfig, policy
 	case []interface{}:
 		cleaned := make([]interface{}, len(v))
 		for i, item := range v {
-			cleanItem, err := validateAndCleanJSON(item, config, policy, forbiddenRegexps)
+			cleanItem, err := validateAndCleanJSON(item, config, policy, forbiddenRegexps, contentFields, append(path[:len(path):len(path)], strconv.Itoa(i)))
 			if err != nil {
 				return nil, err
 			}
@@ -156,14 +161,8 @@ func validateAndCleanJSON(data interface{}, config InputValidationConfig, policy
 
 // validateAndCleanString 验证和清理字符串
 func validateAndCleanString(s string, config InputValidationConfig, policy *bluemonday.Policy, forbiddenRegexps []*regexp.Regexp) (interface{}, error) {
-	// 检查字符串长度
-	if len(s) > config.MaxStringLength {
-		return nil, fmt.Errorf("字符串长度超过限制 (%d)", config.MaxStringLength)
-	}
-
-	// 检查UTF-8编码有效性
-	if !utf8.ValidString(s) {
-		return nil, fmt.Errorf("无效的UTF-8编码")
+	if _, err := validateContentString(s, config); err != nil {
+		return nil, err
 	}
 
 	// 检查危险模式
@@ -184,6 +183,21 @@ func validateAndCleanString(s string, config InputValidationConfig, policy *blue
 	return s, nil
 }
 
+// validateContentString 检查字符串长度和UTF-8编码，不改写内容
+func validateContentString(s string, config InputValidationConfig) (interface{}, error) {
+	// 检查字符串长度
+	if len(s) > config.MaxStringLength {
+		return nil, fmt.Errorf("字符串长度超过限制 (%d)", config.MaxStringLength)
+	}
+
+	// 检查UTF-8编码有效性
+	if !utf8.ValidString(s) {
+		return nil, fmt.Errorf("无效的UTF-8编码")
+	}
+
+	return s, nil
+}
+
 // ValidateEmailFormat 验证邮箱格式
 func ValidateEmailFormat(email string) bool {
 	return govalidator.IsEmail(email)
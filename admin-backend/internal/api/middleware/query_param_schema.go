@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryParamType 查询参数类型
+type QueryParamType string
+
+const (
+	QueryParamInt   QueryParamType = "int"   // 整数，可限制范围
+	QueryParamFloat QueryParamType = "float" // 小数，可限制范围
+	QueryParamBool  QueryParamType = "bool"  // 布尔值，按 strconv.ParseBool 解析
+	QueryParamEnum  QueryParamType = "enum"  // 枚举值
+	QueryParamToken QueryParamType = "token" // 标识符，如格式、语言代码，只允许字母、数字和 _ . -
+	QueryParamText  QueryParamType = "text"  // 自由文本，如搜索关键词、键名，只限制长度，不做 SQL 关键词检测
+)
+
+// QueryParamRule 查询参数规则
+type QueryParamRule struct {
+	Type      QueryParamType
+	Enum      []string
+	MaxLength int     // 最大字符数，0 表示使用全局限制
+	Min, Max  float64 // 数值范围，Min 和 Max 都为 0 时不限制
+}
+
+// QueryParamSchema 路由的查询参数规则，键为参数名
+type QueryParamSchema map[string]QueryParamRule
+
+var queryParamTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// 常用参数规则
+var (
+	pageParam     = QueryParamRule{Type: QueryParamInt, Min: 1, Max: 10000}
+	pageSizeParam = QueryParamRule{Type: QueryParamInt, Min: 1, Max: 100}
+	keywordParam  = QueryParamRule{Type: QueryParamText, MaxLength: 200}
+	keyNameParam  = QueryParamRule{Type: QueryParamText, MaxLength: 255}
+	localeParam   = QueryParamRule{Type: QueryParamToken, MaxLength: 35}
+	formatParam   = QueryParamRule{Type: QueryParamToken, MaxLength: 20}
+	boolParam     = QueryParamRule{Type: QueryParamBool}
+	idParam       = QueryParamRule{Type: QueryParamInt, Min: 1}
+
+	placeholdersParam = QueryParamRule{Type: QueryParamEnum, Enum: []string{"brace", "double_brace", "android", "ios", "gettext"}}
+)
+
+// withParams 合并多组参数规则
+func withParams(schemas ...QueryParamSchema) QueryParamSchema {
+	merged := make(QueryParamSchema)
+	for _, schema := range schemas {
+		for name, rule := range schema {
+			merged[name] = rule
+		}
+	}
+	return merged
+}
+
+var (
+	paginationParams = QueryParamSchema{"page": pageParam, "page_size": pageSizeParam}
+	searchParams     = withParams(paginationParams, QueryParamSchema{"keyword": keywordParam})
+	exportParams     = QueryParamSchema{
+		"format":           formatParam,
+		"placeholders":     placeholdersParam,
+		"pseudo":           boolParam,
+		"pseudo_expansion": QueryParamRule{Type: QueryParamInt, Min: 0, Max: 300},
+		"pseudo_brackets":  boolParam,
+		"pseudo_rtl":       boolParam,
+		"in_context":       boolParam,
+	}
+)
+
+// DefaultQueryParamSchemas 各路由的查询参数规则，键为“方法 路由模板”
+// 有规则的参数按类型校验，未声明的参数和没有规则的路由仍做 SQL 注入模式检测
+func DefaultQueryParamSchemas() map[string]QueryParamSchema {
+	return map[string]QueryParamSchema{
+		"GET /api/projects":            searchParams,
+		"GET /api/projects/accessible": searchParams,
+		"GET /api/users":               searchParams,
+		"GET /api/invitations":         paginationParams,
+		"GET /api/projects/:project_id/members/:user_id/permission": {
+			"required_role": {Type: QueryParamEnum, Enum: []string{"owner", "editor", "viewer"}},
+		},
+
+		"GET /api/translations/by-project/:project_id":        paginationParams,
+		"GET /api/translations/matrix/by-project/:project_id": searchParams,
+		"GET /api/translations/tree/by-project/:project_id": withParams(paginationParams, QueryParamSchema{
+			"path": keyNameParam,
+		}),
+		"DELETE /api/translations/tree/by-project/:project_id": {"path": keyNameParam},
+
+		"GET /api/exports/project/:project_id":              exportParams,
+		"GET /api/exports/project/:project_id/tree":         withParams(exportParams, QueryParamSchema{"path": keyNameParam}),
+		"GET /api/exports/project/:project_id/placeholders": {"placeholders": placeholdersParam},
+		"POST /api/imports/project/:project_id":             {"format": formatParam, "placeholders": placeholdersParam},
+		"GET /api/cli/translations": withParams(exportParams, QueryParamSchema{
+			"project_id": idParam,
+			"locale":     localeParam,
+			"branch":     {Type: QueryParamText, MaxLength: 100},
+			"since":      {Type: QueryParamInt},
+			"provenance": boolParam,
+		}),
+		"GET /api/cli/release-check": {"project_id": idParam},
+
+		"GET /api/glossary/by-project/:project_id": searchParams,
+		"GET /api/glossary/global":                 searchParams,
+		"GET /api/glossary/by-project/:project_id/check": {
+			"languages": {Type: QueryParamText, MaxLength: 500},
+			"key_names": {Type: QueryParamText, MaxLength: 1000},
+		},
+		"GET /api/key-usage/by-project/:project_id": {"key_name": keyNameParam},
+		"GET /api/translation-memory/suggestions/by-project/:project_id": {
+			"key_name":  keyNameParam,
+			"language":  localeParam,
+			"min_score": {Type: QueryParamFloat, Min: 0, Max: 1},
+			"limit":     {Type: QueryParamInt, Min: 1, Max: 100},
+		},
+		"GET /api/translation-memory/export": {
+			"source_language":  localeParam,
+			"project_id":       idParam,
+			"include_imported": boolParam,
+		},
+		"GET /api/screenshots/by-project/:project_id": withParams(paginationParams, QueryParamSchema{"key_name": keyNameParam}),
+		"GET /api/statistics/by-project/:project_id": {
+			"since":         {Type: QueryParamText, MaxLength: 40},
+			"since_version": {Type: QueryParamText, MaxLength: 100},
+			"cost":          boolParam,
+		},
+		"GET /api/consistency/by-project/:project_id":          {"inconsistent_only": boolParam},
+		"GET /api/snapshots/by-project/:project_id":            paginationParams,
+		"GET /api/snapshots/by-project/:project_id/:id/export": {"format": formatParam},
+		"GET /api/snapshots/by-project/:project_id/:id/diff":   {"against": idParam},
+		"GET /api/distribution/by-project/:project_id/releases": withParams(paginationParams, QueryParamSchema{
+			"environment": {Type: QueryParamToken, MaxLength: 32},
+		}),
+
+		"GET /api/trash/by-project/:project_id": searchParams,
+		"GET /api/trash/projects":               searchParams,
+	}
+}
+
+// querySchemaFor 返回当前路由的查询参数规则，未匹配路由时返回 nil
+func querySchemaFor(c *gin.Context, schemas map[string]QueryParamSchema) QueryParamSchema {
+	if c.FullPath() == "" {
+		return nil
+	}
+	return schemas[c.Request.Method+" "+c.FullPath()]
+}
+
+// validate 按规则校验参数值
+func (rule QueryParamRule) validate(value string, maxLength int) error {
+	if rule.MaxLength > 0 {
+		maxLength = rule.MaxLength
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		return fmt.Errorf("长度超过限制 (%d)", maxLength)
+	}
+
+	switch rule.Type {
+	case QueryParamInt:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("必须是整数")
+		}
+		return rule.checkRange(float64(number))
+	case QueryParamFloat:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("必须是数字")
+		}
+		return rule.checkRange(number)
+	case QueryParamBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("必须是布尔值")
+		}
+	case QueryParamEnum:
+		for _, allowed := range rule.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("必须是以下值之一: %v", rule.Enum)
+	case QueryParamToken:
+		if value != "" && !queryParamTokenPattern.MatchString(value) {
+			return fmt.Errorf("只能包含字母、数字和 _ . -")
+		}
+	}
+	return nil
+}
+
+// checkRange 检查数值范围
+func (rule QueryParamRule) checkRange(number float64) error {
+	if rule.Min == 0 && rule.Max == 0 {
+		return nil
+	}
+	if number < rule.Min || (rule.Max > 0 && number > rule.Max) {
+		if rule.Max > 0 {
+			return fmt.Errorf("必须在 %v 到 %v 之间", rule.Min, rule.Max)
+		}
+		return fmt.Errorf("不能小于 %v", rule.Min)
+	}
+	return nil
+}
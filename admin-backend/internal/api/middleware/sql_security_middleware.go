@@ -12,17 +12,26 @@ import (
 	"go.uber.org/zap"
 )
 
+// SQL 过滤模式
+const (
+	SQLFilterModeBlock  = "block"  // 检测到可疑参数时拒绝请求
+	SQLFilterModeDetect = "detect" // 只记录日志，不拒绝请求
+)
+
 // SQLSecurityConfig SQL安全配置
 type SQLSecurityConfig struct {
-	MaxQueryLength    int      // 最大查询长度
-	AllowedSortFields []string // 允许的排序字段
-	AllowedOperators  []string // 允许的操作符
-	ForbiddenKeywords []string // 禁止的关键词
+	Mode              string                      // 过滤模式：block, detect
+	MaxQueryLength    int                         // 最大查询长度
+	AllowedSortFields []string                    // 允许的排序字段
+	AllowedOperators  []string                    // 允许的操作符
+	InjectionPatterns []string                    // SQL 注入模式，只匹配语句结构，不匹配单独的关键词
+	QuerySchemas      map[string]QueryParamSchema // 各路由的查询参数规则
 }
 
 // DefaultSQLSecurityConfig 默认SQL安全配置
 func DefaultSQLSecurityConfig() SQLSecurityConfig {
 	return SQLSecurityConfig{
+		Mode:           SQLFilterModeBlock,
 		MaxQueryLength: 1000,
 		AllowedSortFields: []string{
 			"id", "name", "created_at", "updated_at", "status",
@@ -30,12 +39,27 @@ func DefaultSQLSecurityConfig() SQLSecurityConfig {
 			"key_name", "value", "context",
 		},
 		AllowedOperators: []string{"=", "!=", ">", "<", ">=", "<=", "LIKE", "IN"},
-		ForbiddenKeywords: []string{
-			"DROP", "DELETE", "TRUNCATE", "ALTER", "CREATE", "INSERT",
-			"UPDATE", "EXEC", "EXECUTE", "UNION", "SCRIPT", "DECLARE",
-			"CAST", "CONVERT", "SUBSTRING", "CHAR", "ASCII", "WAITFOR",
-			"BENCHMARK", "SLEEP", "LOAD_FILE", "INTO OUTFILE", "INTO DUMPFILE",
+		// 数据访问都通过 GORM 参数化，这里只拦截明显的注入语句；
+		// "button.update"、"Create account" 这类包含关键词的正常文本不会匹配
+		InjectionPatterns: []string{
+			`\bUNION\s+(ALL\s+)?SELECT\b`,
+			`;\s*(DROP|DELETE|INSERT|UPDATE|ALTER|CREATE|TRUNCATE|EXEC|EXECUTE|DECLARE|SHUTDOWN)\b`,
+			`\bDROP\s+(TABLE|DATABASE|SCHEMA|VIEW|INDEX)\b`,
+			`\bDELETE\s+FROM\b`,
+			`\bINSERT\s+INTO\b`,
+			`\bUPDATE\s+\S+\s+SET\b`,
+			`\bTRUNCATE\s+TABLE\b`,
+			`\bALTER\s+TABLE\b`,
+			`\bCREATE\s+(TABLE|DATABASE|USER|PROCEDURE|FUNCTION|TRIGGER)\b`,
+			`\bEXEC(UTE)?\s*(\(|XP_|SP_)`,
+			`\b(SLEEP|BENCHMARK|LOAD_FILE|CHAR|ASCII|SUBSTRING|CAST|CONVERT)\s*\(`,
+			`\bWAITFOR\s+DELAY\b`,
+			`\bINTO\s+(OUTFILE|DUMPFILE)\b`,
+			`'\s*(OR|AND)\s+\S+\s*(=|LIKE\b)`,
+			`'\s*(--|#|/\*)`,
+			`/\*.*\*/`,
 		},
+		QuerySchemas: DefaultQueryParamSchemas(),
 	}
 }
 
@@ -45,16 +69,22 @@ func SQLSecurityMiddleware(logger *zap.Logger) gin.HandlerFunc {
 }
 
 // SQLSecurityMiddlewareWithConfig 带配置的SQL安全中间件
+// 注入模式无法编译时 panic，使配置错误在启动时暴露，而不是静默跳过该模式
 func SQLSecurityMiddlewareWithConfig(logger *zap.Logger, config SQLSecurityConfig) gin.HandlerFunc {
+	guard := &sqlGuard{config: config, logger: logger}
+	for _, pattern := range config.InjectionPatterns {
+		guard.patterns = append(guard.patterns, regexp.MustCompile("(?i)"+pattern))
+	}
+
 	return func(c *gin.Context) {
 		// 验证查询参数
-		if err := validateQueryParams(c, config, logger); err != nil {
+		if err := guard.validateQueryParams(c); err != nil {
 			response.BadRequest(c, fmt.Sprintf("查询参数验证失败: %s", err.Error()))
 			return
 		}
 
 		// 验证路径参数
-		if err := validatePathParams(c, config, logger); err != nil {
+		if err := guard.validatePathParams(c); err != nil {
 			response.BadRequest(c, fmt.Sprintf("路径参数验证失败: %s", err.Error()))
 			return
 		}
@@ -63,32 +93,49 @@ func SQLSecurityMiddlewareWithConfig(logger *zap.Logger, config SQLSecurityConfi
 	}
 }
 
+// sqlGuard SQL安全检查
+type sqlGuard struct {
+	config   SQLSecurityConfig
+	patterns []*regexp.Regexp
+	logger   *zap.Logger
+}
+
 // validateQueryParams 验证查询参数
-func validateQueryParams(c *gin.Context, config SQLSecurityConfig, logger *zap.Logger) error {
+// 路由声明了规则的参数按类型校验，自由文本参数不做注入检测；其余参数检测注入模式
+func (g *sqlGuard) validateQueryParams(c *gin.Context) error {
+	schema := querySchemaFor(c, g.config.QuerySchemas)
 	queryParams := c.Request.URL.Query()
 
 	for key, values := range queryParams {
+		rule, declared := schema[key]
 		for _, value := range values {
+			if declared {
+				if err := rule.validate(value, g.config.MaxQueryLength); err != nil {
+					return fmt.Errorf("参数 %s %s", key, err.Error())
+				}
+				if rule.Type == QueryParamText {
+					continue
+				}
+			}
+
 			// 检查参数长度
-			if len(value) > config.MaxQueryLength {
+			if len(value) > g.config.MaxQueryLength {
 				return fmt.Errorf("参数 %s 长度超过限制", key)
 			}
 
-			// 检查危险关键词
-			if containsForbiddenKeywords(value, config.ForbiddenKeywords) {
-				logger.Error("Suspicious query parameter detected",
-					zap.String("param", key),
-					zap.String("value", log_utils.SanitizeLogValue(value)),
-					zap.String("ip", c.ClientIP()),
-					zap.String("path", c.Request.URL.Path),
-				)
+			// 检查注入模式
+			if g.suspicious(c, "query", key, value) {
 				return fmt.Errorf("参数 %s 包含不允许的内容", key)
 			}
 
+			if declared {
+				continue
+			}
+
 			// 特殊参数验证
 			switch key {
 			case "sort", "order_by":
-				if !isAllowedSortField(value, config.AllowedSortFields) {
+				if !isAllowedSortField(value, g.config.AllowedSortFields) {
 					return fmt.Errorf("不允许的排序字段: %s", value)
 				}
 			case "limit":
@@ -107,24 +154,15 @@ func validateQueryParams(c *gin.Context, config SQLSecurityConfig, logger *zap.L
 }
 
 // validatePathParams 验证路径参数
-func validatePathParams(c *gin.Context, config SQLSecurityConfig, logger *zap.Logger) error {
-	// 获取路径参数
-	params := c.Params
-
-	for _, param := range params {
+func (g *sqlGuard) validatePathParams(c *gin.Context) error {
+	for _, param := range c.Params {
 		// 检查参数长度
-		if len(param.Value) > config.MaxQueryLength {
+		if len(param.Value) > g.config.MaxQueryLength {
 			return fmt.Errorf("路径参数 %s 长度超过限制", param.Key)
 		}
 
-		// 检查危险关键词
-		if containsForbiddenKeywords(param.Value, config.ForbiddenKeywords) {
-			logger.Error("Suspicious path parameter detected",
-				zap.String("param", param.Key),
-				zap.String("value", log_utils.SanitizeLogValue(param.Value)),
-				zap.String("ip", c.ClientIP()),
-				zap.String("path", c.Request.URL.Path),
-			)
+		// 检查注入模式
+		if g.suspicious(c, "path", param.Key, param.Value) {
 			return fmt.Errorf("路径参数 %s 包含不允许的内容", param.Key)
 		}
 
@@ -139,36 +177,38 @@ func validatePathParams(c *gin.Context, config SQLSecurityConfig, logger *zap.Lo
 	return nil
 }
 
-// containsForbiddenKeywords 检查是否包含禁止的关键词
-func containsForbiddenKeywords(input string, keywords []string) bool {
-	inputUpper := strings.ToUpper(input)
-
-	for _, keyword := range keywords {
-		// 使用单词边界匹配，避免误判
-		pattern := `\b` + regexp.QuoteMeta(strings.ToUpper(keyword)) + `\b`
-		if matched, _ := regexp.MatchString(pattern, inputUpper); matched {
-			return true
-		}
+// suspicious 检测参数是否匹配注入模式并记录日志，只有拦截模式下返回 true
+func (g *sqlGuard) suspicious(c *gin.Context, source, key, value string) bool {
+	pattern := matchInjectionPattern(value, g.patterns)
+	if pattern == "" {
+		return false
 	}
 
-	// 检查SQL注入常见模式
-	sqlInjectionPatterns := []string{
-		`'.*OR.*'.*'`,
-		`'.*AND.*'.*'`,
-		`'.*UNION.*SELECT`,
-		`'.*;\s*(DROP|DELETE|INSERT|UPDATE)`,
-		`--.*`,
-		`/\*.*\*/`,
-		`'.*'.*=.*'.*'`,
+	fields := []zap.Field{
+		zap.String("source", source),
+		zap.String("param", key),
+		zap.String("value", log_utils.SanitizeLogValue(value)),
+		zap.String("pattern", pattern),
+		zap.String("ip", c.ClientIP()),
+		zap.String("path", c.Request.URL.Path),
+		zap.String("route", c.FullPath()),
 	}
+	if g.config.Mode == SQLFilterModeDetect {
+		g.logger.Warn("Suspicious parameter detected (detect mode, not blocked)", fields...)
+		return false
+	}
+	g.logger.Error("Suspicious parameter detected", fields...)
+	return true
+}
 
-	for _, pattern := range sqlInjectionPatterns {
-		if matched, _ := regexp.MatchString(pattern, inputUpper); matched {
-			return true
+// matchInjectionPattern 返回第一个匹配的注入模式，未匹配时返回空字符串
+func matchInjectionPattern(input string, patterns []*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		if pattern.MatchString(input) {
+			return pattern.String()
 		}
 	}
-
-	return false
+	return ""
 }
 
 // isAllowedSortField 检查是否为允许的排序字段
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"i18n-flow/internal/api/response"
 	"i18n-flow/utils"
+	"mime"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,8 +16,10 @@ func RequestValidationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 检查Content-Type（对于POST、PUT请求）
 		if c.Request.Method == http.MethodPost || c.Request.Method == http.MethodPut {
+			// multipart 请求的 Content-Type 带有 boundary 参数，只比较媒体类型
 			contentType := c.GetHeader("Content-Type")
-			if contentType != "" && contentType != "application/json" && contentType != "multipart/form-data" {
+			mediaType, _, _ := mime.ParseMediaType(contentType)
+			if contentType != "" && mediaType != "application/json" && mediaType != "multipart/form-data" {
 				response.BadRequest(c, fmt.Sprintf("不支持的Content-Type: %s", contentType))
 				return
 			}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -123,7 +124,7 @@ func processJSONRequest(c *gin.Context, policy *bluemonday.Policy, xssPatterns [
 	}
 
 	// 检测和清理XSS
-	cleanedData, hasXSS, err := sanitizeJSONData(jsonData, policy, xssPatterns, config)
+	cleanedData, hasXSS, err := sanitizeJSONData(jsonData, policy, xssPatterns, config, contentFieldsFor(c), nil)
 	if err != nil {
 		return err
 	}
@@ -150,12 +151,15 @@ func processJSONRequest(c *gin.Context, policy *bluemonday.Policy, xssPatterns [
 	return nil
 }
 
-// sanitizeJSONData 递归清理JSON数据
-func sanitizeJSONData(data interface{}, policy *bluemonday.Policy, xssPatterns []*regexp.Regexp, config XSSProtectionConfig) (interface{}, bool, error) {
+// sanitizeJSONData 递归清理JSON数据，path 为当前值的字段路径，contentFields 中的翻译内容字段保持原样
+func sanitizeJSONData(data interface{}, policy *bluemonday.Policy, xssPatterns []*regexp.Regexp, config XSSProtectionConfig, contentFields []string, path []string) (interface{}, bool, error) {
 	hasXSS := false
 
 	switch v := data.(type) {
 	case string:
+		if isContentField(contentFields, path) {
+			return v, false, nil
+		}
 		cleaned, xssDetected := sanitizeString(v, policy, xssPatterns)
 		return cleaned, xssDetected, nil
 	case map[string]interface{}:
@@ -168,7 +172,7 @@ func sanitizeJSONData(data interface{}, policy *bluemonday.Policy, xssPatterns [
 			}
 
 			// 递归清理值
-			cleanValue, valueXSS, err := sanitizeJSONData(value, policy, xssPatterns, config)
+			cleanValue, valueXSS, err := sanitizeJSONData(value, policy, xssPatterns, config, contentFields, append(path[:len(path):len(path)], key))
 			if err != nil {
 				return nil, false, err
 			}
@@ -182,7 +186,7 @@ func sanitizeJSONData(data interface{}, policy *bluemonday.Policy, xssPatterns [
 	case []interface{}:
 		cleaned := make([]interface{}, len(v))
 		for i, item := range v {
-			cleanItem, itemXSS, err := sanitizeJSONData(item, policy, xssPatterns, config)
+			cleanItem, itemXSS, err := sanitizeJSONData(item, policy, xssPatterns, config, contentFields, append(path[:len(path):len(path)], strconv.Itoa(i)))
 			if err != nil {
 				return nil, false, err
 			}
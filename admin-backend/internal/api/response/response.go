@@ -96,6 +96,10 @@ func Forbidden(c *gin.Context, message string) {
 	Error(c, http.StatusForbidden, "FORBIDDEN", message)
 }
 
+func ForbiddenWithDetails(c *gin.Context, message, details string) {
+	ErrorWithDetails(c, http.StatusForbidden, "FORBIDDEN", message, details)
+}
+
 func NotFound(c *gin.Context, message string) {
 	Error(c, http.StatusNotFound, "NOT_FOUND", message)
 }
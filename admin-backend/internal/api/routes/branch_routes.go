@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBranchRoutes 设置翻译分支相关路由
+func (r *Router) setupBranchRoutes(authRoutes *gin.RouterGroup) {
+	branchRoutes := authRoutes.Group("/branches")
+	{
+		// 分支查看
+		branchViewRoutes := branchRoutes.Group("/by-project/:project_id")
+		branchViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			branchViewRoutes.GET("", r.BranchHandler.List)
+			branchViewRoutes.GET("/:id", r.BranchHandler.Get)
+			branchViewRoutes.GET("/:id/matrix", r.BranchHandler.GetMatrix)
+		}
+
+		// 分支的创建、修改和删除
+		branchEditRoutes := branchRoutes.Group("/by-project/:project_id")
+		branchEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			branchEditRoutes.POST("", r.BranchHandler.Create)
+			branchEditRoutes.PUT("/:id/values", r.BranchHandler.SetValues)
+			branchEditRoutes.POST("/:id/revert", r.BranchHandler.Revert)
+			branchEditRoutes.DELETE("/:id", r.BranchHandler.Delete)
+		}
+
+		// 合并会批量写入主干，应用批量操作限流
+		branchMergeRoutes := branchRoutes.Group("/by-project/:project_id")
+		branchMergeRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		branchMergeRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			branchMergeRoutes.POST("/:id/merge", r.BranchHandler.Merge)
+		}
+	}
+}
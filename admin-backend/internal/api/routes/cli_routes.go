@@ -18,6 +18,9 @@ func (r *Router) setupCLIRoutes(rg *gin.RouterGroup) {
 
 		// 获取翻译数据
 		cliRoutes.GET("/translations", r.CLIHandler.GetTranslations)
+
+		// 发布门禁检查，供 CI 在发布前调用
+		cliRoutes.GET("/release-check", r.CLIHandler.ReleaseCheck)
 	}
 
 	// 推送翻译键（批量操作，应用批量操作限流）
@@ -26,5 +29,14 @@ func (r *Router) setupCLIRoutes(rg *gin.RouterGroup) {
 	batchCliRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
 	{
 		batchCliRoutes.POST("/keys", r.CLIHandler.PushKeys)
+
+		// 三方合并同步本地译文
+		batchCliRoutes.POST("/sync", r.CLIHandler.Sync)
+
+		// 上报代码扫描结果
+		batchCliRoutes.POST("/references", r.CLIHandler.ReportReferences)
+
+		// 上传源码压缩包提取翻译键
+		batchCliRoutes.POST("/extract", r.CLIHandler.ExtractKeys)
 	}
 }
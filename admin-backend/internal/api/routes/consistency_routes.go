@@ -0,0 +1,23 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupConsistencyRoutes 设置翻译一致性检查相关路由
+func (r *Router) setupConsistencyRoutes(authRoutes *gin.RouterGroup) {
+	consistencyRoutes := authRoutes.Group("/consistency")
+	{
+		consistencyViewRoutes := consistencyRoutes.Group("/by-project/:project_id")
+		consistencyViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			consistencyViewRoutes.GET("", r.ConsistencyHandler.Analyze)
+		}
+
+		// 合并键和统一译文需要编辑权限
+		consistencyEditRoutes := consistencyRoutes.Group("/by-project/:project_id")
+		consistencyEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			consistencyEditRoutes.POST("/merge", r.ConsistencyHandler.MergeKeys)
+			consistencyEditRoutes.POST("/harmonize", r.ConsistencyHandler.Harmonize)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDistributionRoutes 设置译文分发管理路由
+func (r *Router) setupDistributionRoutes(authRoutes *gin.RouterGroup) {
+	distributionRoutes := authRoutes.Group("/distribution")
+	{
+		// 发布记录查看
+		distributionViewRoutes := distributionRoutes.Group("/by-project/:project_id")
+		distributionViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			distributionViewRoutes.GET("/releases", r.DistributionHandler.ListReleases)
+		}
+
+		// 令牌管理和发布影响线上客户端，只有项目所有者可以操作
+		distributionOwnerRoutes := distributionRoutes.Group("/by-project/:project_id")
+		distributionOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			distributionOwnerRoutes.GET("/tokens", r.DistributionHandler.ListTokens)
+			distributionOwnerRoutes.POST("/tokens", r.DistributionHandler.CreateToken)
+			distributionOwnerRoutes.DELETE("/tokens/:id", r.DistributionHandler.DeleteToken)
+		}
+
+		// 发布需要生成并压缩所有语言的译文包，应用批量操作限流
+		distributionPublishRoutes := distributionRoutes.Group("/by-project/:project_id")
+		distributionPublishRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		distributionPublishRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			distributionPublishRoutes.POST("/releases", r.DistributionHandler.Publish)
+		}
+	}
+}
+
+// setupPublicDistributionRoutes 设置公开的译文分发路由
+func (r *Router) setupPublicDistributionRoutes(rg *gin.RouterGroup) {
+	// 移动端和网页客户端使用分发令牌获取译文，不需要登录
+	otaRoutes := rg.Group("/ota")
+	otaRoutes.Use(middleware.TollboothAPIRateLimitMiddleware())
+	{
+		otaRoutes.GET("/public-key", r.DistributionHandler.PublicKey)
+		otaRoutes.GET("/:token/manifest", r.DistributionHandler.Manifest)
+		otaRoutes.GET("/:token/locales/:locale", r.DistributionHandler.LocaleBundle)
+		otaRoutes.GET("/:token/bundles/:hash", r.DistributionHandler.Bundle)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupGitSyncRoutes 设置 git 仓库同步相关路由
+func (r *Router) setupGitSyncRoutes(authRoutes *gin.RouterGroup) {
+	gitSyncRoutes := authRoutes.Group("/git-sync")
+	{
+		gitSyncViewRoutes := gitSyncRoutes.Group("/by-project/:project_id")
+		gitSyncViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			gitSyncViewRoutes.GET("", r.GitSyncHandler.Get)
+		}
+
+		gitSyncEditRoutes := gitSyncRoutes.Group("/by-project/:project_id")
+		gitSyncEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			gitSyncEditRoutes.POST("/import", r.GitSyncHandler.Import)
+			gitSyncEditRoutes.POST("/export", r.GitSyncHandler.Export)
+		}
+
+		// 服务端使用自己的凭据访问仓库，只有管理员可以配置仓库
+		gitSyncAdminRoutes := gitSyncRoutes.Group("/by-project/:project_id")
+		gitSyncAdminRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		{
+			gitSyncAdminRoutes.PUT("", r.GitSyncHandler.Save)
+			gitSyncAdminRoutes.DELETE("", r.GitSyncHandler.Delete)
+		}
+	}
+}
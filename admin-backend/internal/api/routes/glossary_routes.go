@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupGlossaryRoutes 设置术语表相关路由
+func (r *Router) setupGlossaryRoutes(authRoutes *gin.RouterGroup) {
+	glossaryRoutes := authRoutes.Group("/glossary")
+	{
+		// 项目术语表查看和术语检查
+		glossaryViewRoutes := glossaryRoutes.Group("/by-project/:project_id")
+		glossaryViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			glossaryViewRoutes.GET("", r.GlossaryHandler.List)
+			glossaryViewRoutes.GET("/check", r.GlossaryHandler.Check)
+			glossaryViewRoutes.GET("/export", r.GlossaryHandler.ExportTBX)
+		}
+
+		// 项目术语表维护需要编辑权限
+		glossaryEditRoutes := glossaryRoutes.Group("/by-project/:project_id")
+		glossaryEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			glossaryEditRoutes.POST("", r.GlossaryHandler.Create)
+			glossaryEditRoutes.PUT("/:id", r.GlossaryHandler.Update)
+			glossaryEditRoutes.DELETE("/:id", r.GlossaryHandler.Delete)
+		}
+
+		// TBX 导入开销较大，应用批量操作限流
+		glossaryImportRoutes := glossaryRoutes.Group("/by-project/:project_id")
+		glossaryImportRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		glossaryImportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			glossaryImportRoutes.POST("/import", r.GlossaryHandler.ImportTBX)
+		}
+
+		// 全局术语表作用于所有项目，所有用户可查看
+		glossaryRoutes.GET("/global", r.GlossaryHandler.List)
+		glossaryRoutes.GET("/global/export", r.GlossaryHandler.ExportTBX)
+
+		// 全局术语表维护（管理员功能）
+		glossaryGlobalRoutes := glossaryRoutes.Group("/global")
+		glossaryGlobalRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		{
+			glossaryGlobalRoutes.POST("", r.GlossaryHandler.Create)
+			glossaryGlobalRoutes.PUT("/:id", r.GlossaryHandler.Update)
+			glossaryGlobalRoutes.DELETE("/:id", r.GlossaryHandler.Delete)
+		}
+
+		glossaryGlobalImportRoutes := glossaryRoutes.Group("/global")
+		glossaryGlobalImportRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		glossaryGlobalImportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			glossaryGlobalImportRoutes.POST("/import", r.GlossaryHandler.ImportTBX)
+		}
+	}
+}
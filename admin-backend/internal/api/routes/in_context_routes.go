@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupInContextRoutes 设置页内编辑相关路由
+func (r *Router) setupInContextRoutes(authRoutes *gin.RouterGroup) {
+	inContextRoutes := authRoutes.Group("/in-context")
+	inContextRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		inContextRoutes.POST("/by-project/:project_id/token", r.InContextHandler.IssueEditorToken)
+	}
+}
+
+// setupPublicInContextRoutes 设置公开的页内编辑路由
+func (r *Router) setupPublicInContextRoutes(rg *gin.RouterGroup) {
+	// 标记解析由预发布环境页面调用，使用编辑令牌认证而不是登录令牌
+	publicInContextRoutes := rg.Group("/in-context")
+	publicInContextRoutes.Use(middleware.TollboothAPIRateLimitMiddleware())
+	{
+		publicInContextRoutes.POST("/resolve", r.InContextHandler.Resolve)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupInheritanceRoutes 设置项目继承相关路由
+func (r *Router) setupInheritanceRoutes(authRoutes *gin.RouterGroup) {
+	inheritanceRoutes := authRoutes.Group("/inheritance")
+	{
+		inheritanceViewRoutes := inheritanceRoutes.Group("/by-project/:project_id")
+		inheritanceViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			inheritanceViewRoutes.GET("", r.InheritanceHandler.Get)
+		}
+
+		// 只有项目所有者可以修改继承关系
+		inheritanceOwnerRoutes := inheritanceRoutes.Group("/by-project/:project_id")
+		inheritanceOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			inheritanceOwnerRoutes.PUT("", r.InheritanceHandler.SetBases)
+		}
+	}
+}
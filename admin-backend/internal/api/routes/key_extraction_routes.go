@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupKeyExtractionRoutes 设置源码翻译键提取相关路由
+func (r *Router) setupKeyExtractionRoutes(authRoutes *gin.RouterGroup) {
+	// 上传和解析源码压缩包开销较大，需要编辑权限并应用批量操作限流
+	keyExtractionRoutes := authRoutes.Group("/key-extraction")
+	keyExtractionRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	keyExtractionRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	{
+		keyExtractionRoutes.POST("/by-project/:project_id", r.KeyExtractionHandler.ExtractKeys)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupKeyUsageRoutes 设置翻译键代码引用相关路由
+func (r *Router) setupKeyUsageRoutes(authRoutes *gin.RouterGroup) {
+	keyUsageRoutes := authRoutes.Group("/key-usage")
+	{
+		// 查看代码引用和未使用的键
+		keyUsageViewRoutes := keyUsageRoutes.Group("")
+		keyUsageViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			keyUsageViewRoutes.GET("/by-project/:project_id", r.KeyUsageHandler.GetKeyUsage)
+			keyUsageViewRoutes.GET("/by-project/:project_id/unused", r.KeyUsageHandler.ListUnusedKeys)
+		}
+
+		// 废弃未使用的键需要编辑权限
+		keyUsageEditRoutes := keyUsageRoutes.Group("")
+		keyUsageEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			keyUsageEditRoutes.POST("/by-project/:project_id/deprecate", r.KeyUsageHandler.DeprecateUnusedKeys)
+		}
+	}
+}
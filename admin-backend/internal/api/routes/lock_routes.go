@@ -0,0 +1,30 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupLockRoutes 设置字符串冻结和翻译锁定相关路由
+func (r *Router) setupLockRoutes(authRoutes *gin.RouterGroup) {
+	lockRoutes := authRoutes.Group("/locks")
+	{
+		lockViewRoutes := lockRoutes.Group("/by-project/:project_id")
+		lockViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			lockViewRoutes.GET("", r.LockHandler.List)
+		}
+
+		// 编辑者可以锁定，保护内容不被其他人修改
+		lockEditRoutes := lockRoutes.Group("/by-project/:project_id")
+		lockEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			lockEditRoutes.POST("", r.LockHandler.Create)
+		}
+
+		// 只有项目所有者可以解除锁定和修改字符串冻结
+		lockOwnerRoutes := lockRoutes.Group("/by-project/:project_id")
+		lockOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			lockOwnerRoutes.DELETE("/:id", r.LockHandler.Delete)
+			lockOwnerRoutes.PUT("/freeze", r.LockHandler.SetStringFreeze)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupMachineTranslationRoutes 设置机器翻译相关路由
+func (r *Router) setupMachineTranslationRoutes(authRoutes *gin.RouterGroup) {
+	mtRoutes := authRoutes.Group("/machine-translation")
+	{
+		// 用量查看
+		mtViewRoutes := mtRoutes.Group("")
+		mtViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			mtViewRoutes.GET("/by-project/:project_id/usage", r.MachineTranslationHandler.GetUsage)
+		}
+
+		// 预翻译写入翻译且调用外部服务，需要编辑权限并应用批量操作限流
+		mtEditRoutes := mtRoutes.Group("")
+		mtEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		mtEditRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			mtEditRoutes.POST("/by-project/:project_id/pre-translate", r.MachineTranslationHandler.PreTranslate)
+		}
+	}
+}
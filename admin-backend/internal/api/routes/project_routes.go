@@ -32,6 +32,7 @@ func (r *Router) setupProjectRoutes(authRoutes *gin.RouterGroup) {
 		projectOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
 		{
 			projectOwnerRoutes.DELETE("/delete/:id", r.ProjectHandler.Delete)
+			projectOwnerRoutes.POST("/:project_id/clone", r.ProjectHandler.Clone)
 			projectOwnerRoutes.POST("/:project_id/members", r.ProjectMemberHandler.AddMember)
 			projectOwnerRoutes.PUT("/:project_id/members/:user_id", r.ProjectMemberHandler.UpdateMemberRole)
 			projectOwnerRoutes.DELETE("/:project_id/members/:user_id", r.ProjectMemberHandler.RemoveMember)
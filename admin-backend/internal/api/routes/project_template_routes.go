@@ -0,0 +1,22 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupProjectTemplateRoutes 设置项目模板相关路由
+func (r *Router) setupProjectTemplateRoutes(authRoutes *gin.RouterGroup) {
+	templateRoutes := authRoutes.Group("/project-templates")
+	{
+		// 所有登录用户都可以查看模板，创建项目时选用
+		templateRoutes.GET("", r.ProjectTemplateHandler.List)
+		templateRoutes.GET("/:id", r.ProjectTemplateHandler.GetByID)
+
+		// 只有管理员可以维护模板
+		templateAdminRoutes := templateRoutes.Group("")
+		templateAdminRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		{
+			templateAdminRoutes.POST("", r.ProjectTemplateHandler.Create)
+			templateAdminRoutes.PUT("/:id", r.ProjectTemplateHandler.Update)
+			templateAdminRoutes.DELETE("/:id", r.ProjectTemplateHandler.Delete)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupReleaseGateRoutes 设置发布门禁相关路由
+func (r *Router) setupReleaseGateRoutes(authRoutes *gin.RouterGroup) {
+	releaseGateRoutes := authRoutes.Group("/release-gate")
+	{
+		releaseGateViewRoutes := releaseGateRoutes.Group("/by-project/:project_id")
+		releaseGateViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			releaseGateViewRoutes.GET("/criteria", r.ReleaseGateHandler.GetCriteria)
+			releaseGateViewRoutes.GET("/check", r.ReleaseGateHandler.Check)
+		}
+
+		// 发布条件决定 CI 能否发布，只有项目所有者可以修改
+		releaseGateOwnerRoutes := releaseGateRoutes.Group("/by-project/:project_id")
+		releaseGateOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			releaseGateOwnerRoutes.PUT("/criteria", r.ReleaseGateHandler.UpdateCriteria)
+		}
+	}
+}
@@ -16,46 +16,100 @@ import (
 
 // Router 路由器
 type Router struct {
-	UserHandler          *handlers.UserHandler
-	ProjectHandler       *handlers.ProjectHandler
-	LanguageHandler      *handlers.LanguageHandler
-	TranslationHandler   *handlers.TranslationHandler
-	DashboardHandler     *handlers.DashboardHandler
-	ProjectMemberHandler *handlers.ProjectMemberHandler
-	CLIHandler           *handlers.CLIHandler
-	InvitationHandler    *handlers.InvitationHandler
-	middlewareFactory    *middleware.MiddlewareFactory
-	Logger               *zap.Logger
+	UserHandler               *handlers.UserHandler
+	ProjectHandler            *handlers.ProjectHandler
+	LanguageHandler           *handlers.LanguageHandler
+	TranslationHandler        *handlers.TranslationHandler
+	DashboardHandler          *handlers.DashboardHandler
+	ProjectMemberHandler      *handlers.ProjectMemberHandler
+	CLIHandler                *handlers.CLIHandler
+	InvitationHandler         *handlers.InvitationHandler
+	TrashHandler              *handlers.TrashHandler
+	KeyUsageHandler           *handlers.KeyUsageHandler
+	KeyExtractionHandler      *handlers.KeyExtractionHandler
+	TranslationMemoryHandler  *handlers.TranslationMemoryHandler
+	GlossaryHandler           *handlers.GlossaryHandler
+	MachineTranslationHandler *handlers.MachineTranslationHandler
+	InContextHandler          *handlers.InContextHandler
+	ScreenshotHandler         *handlers.ScreenshotHandler
+	StatisticsHandler         *handlers.StatisticsHandler
+	ConsistencyHandler        *handlers.ConsistencyHandler
+	SnapshotHandler           *handlers.SnapshotHandler
+	DistributionHandler       *handlers.DistributionHandler
+	BranchHandler             *handlers.BranchHandler
+	ReleaseGateHandler        *handlers.ReleaseGateHandler
+	LockHandler               *handlers.LockHandler
+	InheritanceHandler        *handlers.InheritanceHandler
+	ProjectTemplateHandler    *handlers.ProjectTemplateHandler
+	GitSyncHandler            *handlers.GitSyncHandler
+	middlewareFactory         *middleware.MiddlewareFactory
+	Logger                    *zap.Logger
 }
 
 // RouterDeps 定义 Router 的依赖（用于 fx.In）
 type RouterDeps struct {
 	fx.In
-	UserHandler          *handlers.UserHandler
-	ProjectHandler       *handlers.ProjectHandler
-	LanguageHandler      *handlers.LanguageHandler
-	TranslationHandler   *handlers.TranslationHandler
-	DashboardHandler     *handlers.DashboardHandler
-	ProjectMemberHandler *handlers.ProjectMemberHandler
-	CLIHandler           *handlers.CLIHandler
-	InvitationHandler    *handlers.InvitationHandler
-	AuthService          domain.AuthService
-	UserService          domain.UserService
-	ProjectMemberService domain.ProjectMemberService
-	Logger               *zap.Logger
+	UserHandler               *handlers.UserHandler
+	ProjectHandler            *handlers.ProjectHandler
+	LanguageHandler           *handlers.LanguageHandler
+	TranslationHandler        *handlers.TranslationHandler
+	DashboardHandler          *handlers.DashboardHandler
+	ProjectMemberHandler      *handlers.ProjectMemberHandler
+	CLIHandler                *handlers.CLIHandler
+	InvitationHandler         *handlers.InvitationHandler
+	TrashHandler              *handlers.TrashHandler
+	KeyUsageHandler           *handlers.KeyUsageHandler
+	KeyExtractionHandler      *handlers.KeyExtractionHandler
+	TranslationMemoryHandler  *handlers.TranslationMemoryHandler
+	GlossaryHandler           *handlers.GlossaryHandler
+	MachineTranslationHandler *handlers.MachineTranslationHandler
+	InContextHandler          *handlers.InContextHandler
+	ScreenshotHandler         *handlers.ScreenshotHandler
+	StatisticsHandler         *handlers.StatisticsHandler
+	ConsistencyHandler        *handlers.ConsistencyHandler
+	SnapshotHandler           *handlers.SnapshotHandler
+	DistributionHandler       *handlers.DistributionHandler
+	BranchHandler             *handlers.BranchHandler
+	ReleaseGateHandler        *handlers.ReleaseGateHandler
+	LockHandler               *handlers.LockHandler
+	InheritanceHandler        *handlers.InheritanceHandler
+	ProjectTemplateHandler    *handlers.ProjectTemplateHandler
+	GitSyncHandler            *handlers.GitSyncHandler
+	AuthService               domain.AuthService
+	UserService               domain.UserService
+	ProjectMemberService      domain.ProjectMemberService
+	Logger                    *zap.Logger
 }
 
 // NewRouter 创建路由器
 func NewRouter(deps RouterDeps) *Router {
 	return &Router{
-		UserHandler:          deps.UserHandler,
-		ProjectHandler:       deps.ProjectHandler,
-		LanguageHandler:      deps.LanguageHandler,
-		TranslationHandler:   deps.TranslationHandler,
-		DashboardHandler:     deps.DashboardHandler,
-		ProjectMemberHandler: deps.ProjectMemberHandler,
-		CLIHandler:           deps.CLIHandler,
-		InvitationHandler:    deps.InvitationHandler,
+		UserHandler:               deps.UserHandler,
+		ProjectHandler:            deps.ProjectHandler,
+		LanguageHandler:           deps.LanguageHandler,
+		TranslationHandler:        deps.TranslationHandler,
+		DashboardHandler:          deps.DashboardHandler,
+		ProjectMemberHandler:      deps.ProjectMemberHandler,
+		CLIHandler:                deps.CLIHandler,
+		InvitationHandler:         deps.InvitationHandler,
+		TrashHandler:              deps.TrashHandler,
+		KeyUsageHandler:           deps.KeyUsageHandler,
+		KeyExtractionHandler:      deps.KeyExtractionHandler,
+		TranslationMemoryHandler:  deps.TranslationMemoryHandler,
+		GlossaryHandler:           deps.GlossaryHandler,
+		MachineTranslationHandler: deps.MachineTranslationHandler,
+		InContextHandler:          deps.InContextHandler,
+		ScreenshotHandler:         deps.ScreenshotHandler,
+		StatisticsHandler:         deps.StatisticsHandler,
+		ConsistencyHandler:        deps.ConsistencyHandler,
+		SnapshotHandler:           deps.SnapshotHandler,
+		DistributionHandler:       deps.DistributionHandler,
+		BranchHandler:             deps.BranchHandler,
+		ReleaseGateHandler:        deps.ReleaseGateHandler,
+		LockHandler:               deps.LockHandler,
+		InheritanceHandler:        deps.InheritanceHandler,
+		ProjectTemplateHandler:    deps.ProjectTemplateHandler,
+		GitSyncHandler:            deps.GitSyncHandler,
 		middlewareFactory: middleware.NewMiddlewareFactory(
 			deps.AuthService,
 			deps.UserService,
@@ -84,6 +138,8 @@ func (r *Router) SetupRoutes(engine *gin.Engine, monitor *internal_utils.SimpleM
 		r.setupPublicRoutes(api)
 		r.setupPublicInvitationRoutes(api)
 		r.setupPublicRegisterRoutes(api)
+		r.setupPublicInContextRoutes(api)
+		r.setupPublicDistributionRoutes(api)
 		r.setupAuthenticatedRoutes(api)
 		r.setupCLIRoutes(api)
 	}
@@ -113,6 +169,60 @@ func (r *Router) setupAuthenticatedRoutes(rg *gin.RouterGroup) {
 
 	// 邀请管理路由
 	r.setupInvitationRoutes(authRoutes)
+
+	// 回收站路由
+	r.setupTrashRoutes(authRoutes)
+
+	// 翻译键代码引用路由
+	r.setupKeyUsageRoutes(authRoutes)
+
+	// 源码翻译键提取路由
+	r.setupKeyExtractionRoutes(authRoutes)
+
+	// 翻译记忆路由
+	r.setupTranslationMemoryRoutes(authRoutes)
+
+	// 术语表路由
+	r.setupGlossaryRoutes(authRoutes)
+
+	// 机器翻译路由
+	r.setupMachineTranslationRoutes(authRoutes)
+
+	// 页内编辑路由
+	r.setupInContextRoutes(authRoutes)
+
+	// 截图路由
+	r.setupScreenshotRoutes(authRoutes)
+
+	// 字数统计路由
+	r.setupStatisticsRoutes(authRoutes)
+
+	// 翻译一致性检查
+	r.setupConsistencyRoutes(authRoutes)
+
+	// 版本快照
+	r.setupSnapshotRoutes(authRoutes)
+
+	// 译文分发管理路由
+	r.setupDistributionRoutes(authRoutes)
+
+	// 翻译分支相关路由
+	r.setupBranchRoutes(authRoutes)
+
+	// 发布门禁相关路由
+	r.setupReleaseGateRoutes(authRoutes)
+
+	// 字符串冻结和翻译锁定相关路由
+	r.setupLockRoutes(authRoutes)
+
+	// 项目继承相关路由
+	r.setupInheritanceRoutes(authRoutes)
+
+	// 项目模板路由
+	r.setupProjectTemplateRoutes(authRoutes)
+
+	// git 仓库同步路由
+	r.setupGitSyncRoutes(authRoutes)
 }
 
 // RouterModule 定义路由模块
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupScreenshotRoutes 设置截图相关路由
+func (r *Router) setupScreenshotRoutes(authRoutes *gin.RouterGroup) {
+	screenshotRoutes := authRoutes.Group("/screenshots")
+	{
+		// 截图查看
+		screenshotViewRoutes := screenshotRoutes.Group("/by-project/:project_id")
+		screenshotViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			screenshotViewRoutes.GET("", r.ScreenshotHandler.List)
+			screenshotViewRoutes.GET("/:id", r.ScreenshotHandler.Get)
+			screenshotViewRoutes.GET("/:id/image", r.ScreenshotHandler.Image)
+			screenshotViewRoutes.GET("/:id/thumbnail", r.ScreenshotHandler.Thumbnail)
+		}
+
+		// 区域标注和删除需要编辑权限
+		screenshotEditRoutes := screenshotRoutes.Group("/by-project/:project_id")
+		screenshotEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			screenshotEditRoutes.PUT("/:id/regions", r.ScreenshotHandler.UpdateRegions)
+			screenshotEditRoutes.DELETE("/:id", r.ScreenshotHandler.Delete)
+		}
+
+		// 上传需要解码图片和生成缩略图，应用批量操作限流
+		screenshotUploadRoutes := screenshotRoutes.Group("/by-project/:project_id")
+		screenshotUploadRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		screenshotUploadRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			screenshotUploadRoutes.POST("", r.ScreenshotHandler.Upload)
+		}
+	}
+}
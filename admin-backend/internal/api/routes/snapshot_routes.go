@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSnapshotRoutes 设置版本快照相关路由
+func (r *Router) setupSnapshotRoutes(authRoutes *gin.RouterGroup) {
+	snapshotRoutes := authRoutes.Group("/snapshots")
+	{
+		// 快照查看、导出和比较
+		snapshotViewRoutes := snapshotRoutes.Group("/by-project/:project_id")
+		snapshotViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			snapshotViewRoutes.GET("", r.SnapshotHandler.List)
+			snapshotViewRoutes.GET("/:id", r.SnapshotHandler.Get)
+			snapshotViewRoutes.GET("/:id/export", r.SnapshotHandler.Export)
+			snapshotViewRoutes.GET("/:id/diff", r.SnapshotHandler.Diff)
+		}
+
+		// 创建快照需要复制项目的所有译文，应用批量操作限流
+		snapshotCreateRoutes := snapshotRoutes.Group("/by-project/:project_id")
+		snapshotCreateRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		snapshotCreateRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			snapshotCreateRoutes.POST("", r.SnapshotHandler.Create)
+		}
+
+		// 快照记录已发布的版本，只有项目所有者可以删除
+		snapshotOwnerRoutes := snapshotRoutes.Group("/by-project/:project_id")
+		snapshotOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			snapshotOwnerRoutes.DELETE("/:id", r.SnapshotHandler.Delete)
+		}
+	}
+}
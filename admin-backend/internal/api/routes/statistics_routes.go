@@ -0,0 +1,12 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupStatisticsRoutes 设置字数统计相关路由
+func (r *Router) setupStatisticsRoutes(authRoutes *gin.RouterGroup) {
+	statisticsRoutes := authRoutes.Group("/statistics")
+	statisticsRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		statisticsRoutes.GET("/by-project/:project_id", r.StatisticsHandler.GetProjectStatistics)
+	}
+}
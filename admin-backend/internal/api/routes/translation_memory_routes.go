@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"i18n-flow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTranslationMemoryRoutes 设置翻译记忆相关路由
+func (r *Router) setupTranslationMemoryRoutes(authRoutes *gin.RouterGroup) {
+	tmRoutes := authRoutes.Group("/translation-memory")
+	{
+		// 查询只返回当前用户可查看的项目中的翻译
+		tmRoutes.POST("/lookup", r.TranslationMemoryHandler.Lookup)
+
+		// 编辑单元格时的建议需要项目查看权限
+		tmSuggestRoutes := tmRoutes.Group("")
+		tmSuggestRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			tmSuggestRoutes.GET("/suggestions/by-project/:project_id", r.TranslationMemoryHandler.Suggest)
+		}
+
+		// 导出开销较大，应用批量操作限流
+		tmExportRoutes := tmRoutes.Group("")
+		tmExportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			tmExportRoutes.GET("/export", r.TranslationMemoryHandler.ExportTMX)
+		}
+
+		// 导入的记忆对所有用户可见，仅管理员可导入
+		tmImportRoutes := tmRoutes.Group("")
+		tmImportRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		tmImportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+		{
+			tmImportRoutes.POST("/import", r.TranslationMemoryHandler.ImportTMX)
+		}
+	}
+}
@@ -16,6 +16,7 @@ func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup) {
 		{
 			translationViewRoutes.GET("/by-project/:project_id", r.TranslationHandler.GetByProjectID)
 			translationViewRoutes.GET("/matrix/by-project/:project_id", r.TranslationHandler.GetMatrix)
+			translationViewRoutes.GET("/tree/by-project/:project_id", r.TranslationHandler.GetKeyTree)
 			translationViewRoutes.GET("/:id", r.TranslationHandler.GetByID)
 		}
 
@@ -26,6 +27,8 @@ func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup) {
 			translationEditRoutes.POST("", r.TranslationHandler.Create)
 			translationEditRoutes.PUT("/:id", r.TranslationHandler.Update)
 			translationEditRoutes.DELETE("/:id", r.TranslationHandler.Delete)
+			translationEditRoutes.DELETE("/tree/by-project/:project_id", r.TranslationHandler.DeleteSubtree)
+			translationEditRoutes.POST("/tree/by-project/:project_id/tags", r.TranslationHandler.TagSubtree)
 		}
 	}
 
@@ -44,6 +47,8 @@ func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup) {
 	exportRoutes.Use(r.middlewareFactory.RequireProjectViewer()) // 导出只需要查看权限
 	{
 		exportRoutes.GET("/project/:project_id", r.TranslationHandler.Export)
+		exportRoutes.GET("/project/:project_id/tree", r.TranslationHandler.ExportSubtree)
+		exportRoutes.GET("/project/:project_id/placeholders", r.TranslationHandler.CheckPlaceholders)
 	}
 
 	// 导入路由（应用批量操作限流中间件和项目编辑权限）
@@ -0,0 +1,39 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupTrashRoutes 设置回收站相关路由
+func (r *Router) setupTrashRoutes(authRoutes *gin.RouterGroup) {
+	trashRoutes := authRoutes.Group("/trash")
+	{
+		// 项目回收站查看
+		trashViewRoutes := trashRoutes.Group("")
+		trashViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+		{
+			trashViewRoutes.GET("/by-project/:project_id", r.TrashHandler.ListDeletedKeys)
+		}
+
+		// 项目回收站恢复需要编辑权限
+		trashEditRoutes := trashRoutes.Group("")
+		trashEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			trashEditRoutes.POST("/by-project/:project_id/restore", r.TrashHandler.RestoreKeys)
+		}
+
+		// 彻底删除需要项目所有者权限
+		trashOwnerRoutes := trashRoutes.Group("")
+		trashOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+		{
+			trashOwnerRoutes.POST("/by-project/:project_id/purge", r.TrashHandler.PurgeKeys)
+		}
+
+		// 已删除项目的管理（管理员功能）
+		trashProjectRoutes := trashRoutes.Group("/projects")
+		trashProjectRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		{
+			trashProjectRoutes.GET("", r.TrashHandler.ListDeletedProjects)
+			trashProjectRoutes.POST("/:id/restore", r.TrashHandler.RestoreProject)
+			trashProjectRoutes.DELETE("/:id", r.TrashHandler.PurgeProject)
+		}
+	}
+}
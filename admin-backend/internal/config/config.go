@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -42,6 +44,82 @@ type CLIConfig struct {
 	APIKey string
 }
 
+// TrashConfig 回收站配置
+type TrashConfig struct {
+	RetentionDays int // 回收站保留天数，0表示不自动清理
+}
+
+// KeyUsageConfig 翻译键代码引用配置
+type KeyUsageConfig struct {
+	DeprecationGraceDays int // 未被引用的键自动废弃前的宽限天数，0表示不自动废弃
+}
+
+// MachineTranslationConfig 机器翻译配置
+type MachineTranslationConfig struct {
+	Provider       string // 提供方：deepl, google, openai, fake，为空表示不启用
+	APIKey         string
+	Endpoint       string // 接口地址，为空时使用提供方的默认地址
+	Model          string // OpenAI 兼容接口使用的模型
+	MonthlyQuota   int    // 每个项目每月可机器翻译的字符数，0表示不限制
+	TimeoutSeconds int    // 单次请求超时秒数
+}
+
+// InContextConfig 页内编辑配置
+type InContextConfig struct {
+	TokenTTLMinutes int // 编辑令牌有效期（分钟）
+}
+
+// StorageConfig 文件存储配置
+type StorageConfig struct {
+	Driver      string // 存储后端：local, s3
+	LocalDir    string // 本地存储目录
+	S3Endpoint  string // S3 兼容服务地址，如 https://s3.amazonaws.com 或 http://localhost:9000
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool // 使用路径风格的地址（endpoint/bucket/key），MinIO 等自建服务通常需要开启
+}
+
+// ScreenshotConfig 截图配置
+type ScreenshotConfig struct {
+	MaxSizeMB      int // 上传截图的最大大小（MB）
+	ThumbnailWidth int // 缩略图最大宽度（像素）
+}
+
+// CostConfig 翻译费用估算配置
+type CostConfig struct {
+	Currency      string
+	RatePerWord   float64            // 默认每个原文词的单价
+	LanguageRates map[string]float64 // 语言代码 -> 每词单价，覆盖默认单价
+	TMDiscounts   map[int]float64    // 翻译记忆匹配度下限（百分比）-> 按单价计费的比例
+}
+
+// DistributionConfig 译文分发配置
+type DistributionConfig struct {
+	SigningKey     string // 清单签名的 Ed25519 私钥（base64 编码的 32 字节种子或 64 字节私钥）
+	ManifestMaxAge int    // 清单和按语言获取的译文包的缓存秒数，决定客户端最迟多久看到新发布
+	BundleMaxAge   int    // 按哈希获取的译文包的缓存秒数，内容不会改变
+}
+
+// GitSyncConfig git 仓库同步配置
+type GitSyncConfig struct {
+	WorkDir        string // 工作副本目录，每个项目一个子目录
+	SSHKeyFile     string // SSH 私钥文件，为空时使用系统的 SSH 配置
+	HTTPSHost      string // HTTPS 令牌只发送给以此开头的仓库地址，如 https://github.com/
+	HTTPSUsername  string
+	HTTPSToken     string
+	AuthorName     string // 导出提交的作者
+	AuthorEmail    string
+	AllowLocal     bool // 允许使用服务器上的本地路径作为仓库，只应在单机部署或测试时开启
+	TimeoutSeconds int  // 单次 git 命令的超时秒数
+}
+
+// SecurityConfig 请求安全配置
+type SecurityConfig struct {
+	SQLFilterMode string // 查询参数 SQL 注入检测模式：block 拒绝请求，detect 只记录日志
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Level      string `json:"level"`       // 全局日志级别
@@ -63,6 +141,23 @@ type Config struct {
 	CLI   CLIConfig
 	Log   LogConfig
 	Redis RedisConfig
+	Trash TrashConfig
+
+	KeyUsage KeyUsageConfig
+
+	MachineTranslation MachineTranslationConfig
+
+	InContext InContextConfig
+
+	Security SecurityConfig
+
+	Storage    StorageConfig
+	Screenshot ScreenshotConfig
+	Cost       CostConfig
+
+	Distribution DistributionConfig
+
+	GitSync GitSyncConfig
 }
 
 // Load 加载配置
@@ -73,6 +168,15 @@ func Load() (*Config, error) {
 		log.Println("警告: .env文件未找到，将使用默认配置或环境变量")
 	}
 
+	languageRates, err := parseRates(getEnv("COST_LANGUAGE_RATES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("COST_LANGUAGE_RATES: %w", err)
+	}
+	tmDiscounts, err := parseTMDiscounts(getEnv("COST_TM_DISCOUNTS", "100:0.25,95:0.3,85:0.6,75:0.8"))
+	if err != nil {
+		return nil, fmt.Errorf("COST_TM_DISCOUNTS: %w", err)
+	}
+
 	config := &Config{
 		Env: getEnv("ENV", "development"),
 		DB: DBConfig{
@@ -98,6 +202,62 @@ func Load() (*Config, error) {
 			DB:       getEnvAsInt("REDIS_DB", 0),
 			Prefix:   getEnv("REDIS_PREFIX", "i18n_flow:"),
 		},
+		Trash: TrashConfig{
+			RetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+		},
+		KeyUsage: KeyUsageConfig{
+			DeprecationGraceDays: getEnvAsInt("KEY_DEPRECATION_GRACE_DAYS", 0),
+		},
+		MachineTranslation: MachineTranslationConfig{
+			Provider:       getEnv("MT_PROVIDER", ""),
+			APIKey:         getEnv("MT_API_KEY", ""),
+			Endpoint:       getEnv("MT_ENDPOINT", ""),
+			Model:          getEnv("MT_MODEL", "gpt-4o-mini"),
+			MonthlyQuota:   getEnvAsInt("MT_PROJECT_MONTHLY_QUOTA", 0),
+			TimeoutSeconds: getEnvAsInt("MT_TIMEOUT_SECONDS", 30),
+		},
+		InContext: InContextConfig{
+			TokenTTLMinutes: getEnvAsInt("IN_CONTEXT_TOKEN_TTL_MINUTES", 60),
+		},
+		Security: SecurityConfig{
+			SQLFilterMode: getEnv("SQL_FILTER_MODE", "block"),
+		},
+		Storage: StorageConfig{
+			Driver:      getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:    getEnv("STORAGE_LOCAL_DIR", "storage"),
+			S3Endpoint:  getEnv("S3_ENDPOINT", ""),
+			S3Region:    getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    getEnv("S3_BUCKET", ""),
+			S3AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey: getEnv("S3_SECRET_KEY", ""),
+			S3PathStyle: getEnvAsBool("S3_PATH_STYLE", true),
+		},
+		Screenshot: ScreenshotConfig{
+			MaxSizeMB:      getEnvAsInt("SCREENSHOT_MAX_SIZE_MB", 10),
+			ThumbnailWidth: getEnvAsInt("SCREENSHOT_THUMBNAIL_WIDTH", 320),
+		},
+		Cost: CostConfig{
+			Currency:      getEnv("COST_CURRENCY", "USD"),
+			RatePerWord:   getEnvAsFloat("COST_RATE_PER_WORD", 0.1),
+			LanguageRates: languageRates,
+			TMDiscounts:   tmDiscounts,
+		},
+		Distribution: DistributionConfig{
+			SigningKey:     getEnv("DISTRIBUTION_SIGNING_KEY", ""),
+			ManifestMaxAge: getEnvAsInt("DISTRIBUTION_MANIFEST_MAX_AGE", 60),
+			BundleMaxAge:   getEnvAsInt("DISTRIBUTION_BUNDLE_MAX_AGE", 31536000),
+		},
+		GitSync: GitSyncConfig{
+			WorkDir:        getEnv("GIT_SYNC_DIR", "storage/git"),
+			SSHKeyFile:     getEnv("GIT_SYNC_SSH_KEY_FILE", ""),
+			HTTPSHost:      getEnv("GIT_SYNC_HTTPS_HOST", ""),
+			HTTPSUsername:  getEnv("GIT_SYNC_HTTPS_USERNAME", "x-access-token"),
+			HTTPSToken:     getEnv("GIT_SYNC_HTTPS_TOKEN", ""),
+			AuthorName:     getEnv("GIT_SYNC_AUTHOR_NAME", "i18n-flow"),
+			AuthorEmail:    getEnv("GIT_SYNC_AUTHOR_EMAIL", "i18n-flow@localhost"),
+			AllowLocal:     getEnvAsBool("GIT_SYNC_ALLOW_LOCAL", false),
+			TimeoutSeconds: getEnvAsInt("GIT_SYNC_TIMEOUT_SECONDS", 120),
+		},
 		Log: LogConfig{
 			Level:      getEnv("LOG_LEVEL", "info"),
 			Format:     getEnv("LOG_FORMAT", "console"),
@@ -198,6 +358,103 @@ func (c *Config) Validate() error {
 		return errors.New("Redis DB must be between 0 and 15")
 	}
 
+	// 回收站配置验证
+	if c.Trash.RetentionDays < 0 || c.Trash.RetentionDays > 3650 {
+		return errors.New("trash retention days must be between 0 and 3650")
+	}
+
+	// 翻译键废弃宽限期验证
+	if c.KeyUsage.DeprecationGraceDays < 0 || c.KeyUsage.DeprecationGraceDays > 3650 {
+		return errors.New("key deprecation grace days must be between 0 and 3650")
+	}
+
+	// 机器翻译配置验证
+	validMTProviders := map[string]bool{"": true, "deepl": true, "google": true, "openai": true, "fake": true}
+	if !validMTProviders[c.MachineTranslation.Provider] {
+		return errors.New("machine translation provider must be one of: deepl, google, openai, fake")
+	}
+	if c.MachineTranslation.Provider != "" && c.MachineTranslation.Provider != "fake" && c.MachineTranslation.APIKey == "" {
+		return errors.New("machine translation API key must be set")
+	}
+	if c.MachineTranslation.MonthlyQuota < 0 {
+		return errors.New("machine translation monthly quota must not be negative")
+	}
+	if c.MachineTranslation.TimeoutSeconds <= 0 || c.MachineTranslation.TimeoutSeconds > 300 {
+		return errors.New("machine translation timeout must be between 1 and 300 seconds")
+	}
+
+	// 页内编辑令牌有效期验证
+	if c.InContext.TokenTTLMinutes <= 0 || c.InContext.TokenTTLMinutes > 1440 {
+		return errors.New("in-context editor token TTL must be between 1 and 1440 minutes")
+	}
+
+	// SQL 注入检测模式验证
+	if c.Security.SQLFilterMode != "block" && c.Security.SQLFilterMode != "detect" {
+		return errors.New("SQL filter mode must be one of: block, detect")
+	}
+
+	// 文件存储配置验证
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.LocalDir == "" {
+			return errors.New("local storage directory is required")
+		}
+	case "s3":
+		if c.Storage.S3Endpoint == "" || c.Storage.S3Bucket == "" {
+			return errors.New("S3 storage requires S3_ENDPOINT and S3_BUCKET")
+		}
+	default:
+		return errors.New("storage driver must be one of: local, s3")
+	}
+
+	// 截图配置验证
+	if c.Screenshot.MaxSizeMB <= 0 || c.Screenshot.MaxSizeMB > 30 {
+		return errors.New("screenshot max size must be between 1 and 30 MB")
+	}
+	if c.Screenshot.ThumbnailWidth < 32 || c.Screenshot.ThumbnailWidth > 2048 {
+		return errors.New("screenshot thumbnail width must be between 32 and 2048 pixels")
+	}
+
+	// 费用估算配置验证
+	if c.Cost.RatePerWord < 0 {
+		return errors.New("cost rate per word must not be negative")
+	}
+	for code, rate := range c.Cost.LanguageRates {
+		if rate < 0 {
+			return fmt.Errorf("cost rate for %s must not be negative", code)
+		}
+	}
+	for percent, factor := range c.Cost.TMDiscounts {
+		if percent < 1 || percent > 100 || factor < 0 || factor > 1 {
+			return errors.New("TM discounts must map match percentages (1-100) to factors between 0 and 1")
+		}
+	}
+
+	// 译文分发配置验证
+	if c.Distribution.SigningKey != "" {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.Distribution.SigningKey))
+		if err != nil || (len(key) != ed25519.SeedSize && len(key) != ed25519.PrivateKeySize) {
+			return errors.New("distribution signing key must be a base64 Ed25519 seed (32 bytes) or private key (64 bytes)")
+		}
+	}
+	if c.Distribution.ManifestMaxAge < 0 || c.Distribution.BundleMaxAge < 0 {
+		return errors.New("distribution cache max ages must not be negative")
+	}
+
+	// git 仓库同步配置验证
+	if c.GitSync.WorkDir == "" {
+		return errors.New("git sync directory is required")
+	}
+	if c.GitSync.HTTPSToken != "" && !strings.HasPrefix(c.GitSync.HTTPSHost, "https://") {
+		return errors.New("git sync HTTPS token requires GIT_SYNC_HTTPS_HOST, e.g. https://github.com/")
+	}
+	if c.GitSync.AuthorName == "" || c.GitSync.AuthorEmail == "" {
+		return errors.New("git sync author name and email are required")
+	}
+	if c.GitSync.TimeoutSeconds <= 0 || c.GitSync.TimeoutSeconds > 3600 {
+		return errors.New("git sync timeout must be between 1 and 3600 seconds")
+	}
+
 	// 日志配置验证
 	validLogLevels := map[string]bool{
 		"debug": true, "info": true, "warn": true, "error": true, "fatal": true,
@@ -257,6 +514,49 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parseRates 解析 "key:value,key:value" 格式的数值表
+func parseRates(value string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, number, ok := strings.Cut(item, ":")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if !ok || strings.TrimSpace(key) == "" || err != nil {
+			return nil, fmt.Errorf("invalid entry %q, expected key:number", item)
+		}
+		rates[strings.TrimSpace(key)] = rate
+	}
+	return rates, nil
+}
+
+// parseTMDiscounts 解析 "匹配度:比例" 格式的翻译记忆折扣表
+func parseTMDiscounts(value string) (map[int]float64, error) {
+	rates, err := parseRates(value)
+	if err != nil {
+		return nil, err
+	}
+	discounts := make(map[int]float64, len(rates))
+	for key, factor := range rates {
+		percent, err := strconv.Atoi(strings.TrimSuffix(key, "%"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid match percentage %q", key)
+		}
+		discounts[percent] = factor
+	}
+	return discounts, nil
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	value := getEnv(key, "")
 	if value == "" {
@@ -0,0 +1,102 @@
+package di
+
+import (
+	"context"
+	"time"
+
+	"i18n-flow/internal/domain"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// trashPurgeInterval 回收站自动清理的执行间隔
+	trashPurgeInterval = time.Hour
+	// keyDeprecationInterval 未使用键自动废弃的执行间隔
+	keyDeprecationInterval = 6 * time.Hour
+	// gitSyncInterval 检查到期的 git 仓库定时同步的间隔，每个项目按自己的同步间隔执行
+	gitSyncInterval = time.Minute
+)
+
+// RegisterTrashPurgeJob 注册回收站自动清理任务，按保留期定时彻底删除过期内容
+func RegisterTrashPurgeJob(lc fx.Lifecycle, trashService domain.TrashService, logger *zap.Logger) {
+	registerPeriodicJob(lc, trashPurgeInterval, func(ctx context.Context) {
+		result, err := trashService.PurgeExpired(ctx)
+		if err != nil {
+			logger.Error("Trash purge failed", zap.Error(err))
+			return
+		}
+		if result.Translations > 0 || result.Projects > 0 {
+			logger.Info("Expired trash purged",
+				zap.Int64("translations", result.Translations),
+				zap.Int64("projects", result.Projects),
+			)
+		}
+	})
+}
+
+// RegisterKeyDeprecationJob 注册未使用键自动废弃任务，按宽限期定时废弃代码中不再引用的键
+func RegisterKeyDeprecationJob(lc fx.Lifecycle, keyUsageService domain.KeyUsageService, logger *zap.Logger) {
+	registerPeriodicJob(lc, keyDeprecationInterval, func(ctx context.Context) {
+		deprecated, err := keyUsageService.DeprecateAllUnusedKeys(ctx)
+		if err != nil {
+			logger.Error("Unused key deprecation failed", zap.Error(err))
+			return
+		}
+		if deprecated > 0 {
+			logger.Info("Unused keys deprecated", zap.Int("keys", deprecated))
+		}
+	})
+}
+
+// RegisterGitSyncJob 注册 git 仓库定时同步任务，按项目的同步间隔从仓库导入，开启自动导出的项目同时提交导出
+func RegisterGitSyncJob(lc fx.Lifecycle, gitSyncService domain.GitSyncService, logger *zap.Logger) {
+	registerPeriodicJob(lc, gitSyncInterval, func(ctx context.Context) {
+		synced, failed, err := gitSyncService.RunScheduled(ctx)
+		if err != nil {
+			logger.Error("Scheduled git sync failed", zap.Error(err))
+			return
+		}
+		if failed > 0 {
+			logger.Warn("Scheduled git sync finished with failures", zap.Int("synced", synced), zap.Int("failed", failed))
+		} else if synced > 0 {
+			logger.Info("Scheduled git sync finished", zap.Int("synced", synced))
+		}
+	})
+}
+
+// registerPeriodicJob 在应用启动时立即执行一次任务，之后按间隔定时执行，应用停止时退出
+func registerPeriodicJob(lc fx.Lifecycle, interval time.Duration, run func(ctx context.Context)) {
+	jobCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				run(jobCtx)
+				for {
+					select {
+					case <-ticker.C:
+						run(jobCtx)
+					case <-jobCtx.Done():
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
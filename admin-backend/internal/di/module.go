@@ -24,8 +24,27 @@ var AppModule = fx.Module("app",
 	fx.Provide(NewProjectRepository),
 	fx.Provide(NewLanguageRepository),
 	fx.Provide(NewTranslationRepository),
+	fx.Provide(NewKeyTagRepository),
+	fx.Provide(NewTrashRepository),
+	fx.Provide(NewKeyUsageRepository),
+	fx.Provide(NewTranslationMemoryRepository),
+	fx.Provide(NewGlossaryRepository),
+	fx.Provide(NewMachineTranslationUsageRepository),
 	fx.Provide(NewProjectMemberRepository),
 	fx.Provide(NewInvitationRepository),
+	fx.Provide(NewScreenshotRepository),
+	fx.Provide(NewSnapshotRepository),
+	fx.Provide(NewKeyMergeRepository),
+	fx.Provide(NewBranchRepository),
+	fx.Provide(NewReleaseCriteriaRepository),
+	fx.Provide(NewTranslationLockRepository),
+	fx.Provide(NewProjectBaseRepository),
+	fx.Provide(NewProjectTemplateRepository),
+	fx.Provide(NewDistributionRepository),
+	fx.Provide(NewGitSyncRepository),
+
+	// 文件存储
+	fx.Provide(NewFileStorage),
 
 	// Auth Service (无缓存)
 	fx.Provide(NewAuthService),
@@ -38,6 +57,25 @@ var AppModule = fx.Module("app",
 	fx.Provide(NewDashboardService),
 	fx.Provide(NewProjectMemberService),
 	fx.Provide(NewInvitationService),
+	fx.Provide(NewTrashService),
+	fx.Provide(NewKeyUsageService),
+	fx.Provide(NewKeyExtractionService),
+	fx.Provide(NewTranslationMemoryService),
+	fx.Provide(NewGlossaryService),
+	fx.Provide(NewMachineTranslationService),
+	fx.Provide(NewInContextService),
+	fx.Provide(NewScreenshotService),
+	fx.Provide(NewStatisticsService),
+	fx.Provide(NewSnapshotService),
+	fx.Provide(NewConsistencyService),
+	fx.Provide(NewBranchService),
+	fx.Provide(NewSyncService),
+	fx.Provide(NewReleaseGateService),
+	fx.Provide(NewLockService),
+	fx.Provide(NewInheritanceService),
+	fx.Provide(NewProjectTemplateService),
+	fx.Provide(NewDistributionService),
+	fx.Provide(NewGitSyncService),
 
 	// Handlers
 	fx.Provide(handlers.NewUserHandler),
@@ -48,6 +86,24 @@ var AppModule = fx.Module("app",
 	fx.Provide(handlers.NewCLIHandler),
 	fx.Provide(handlers.NewDashboardHandler),
 	fx.Provide(handlers.NewInvitationHandler),
+	fx.Provide(handlers.NewTrashHandler),
+	fx.Provide(handlers.NewKeyUsageHandler),
+	fx.Provide(handlers.NewKeyExtractionHandler),
+	fx.Provide(handlers.NewTranslationMemoryHandler),
+	fx.Provide(handlers.NewGlossaryHandler),
+	fx.Provide(handlers.NewMachineTranslationHandler),
+	fx.Provide(handlers.NewInContextHandler),
+	fx.Provide(handlers.NewScreenshotHandler),
+	fx.Provide(handlers.NewStatisticsHandler),
+	fx.Provide(handlers.NewSnapshotHandler),
+	fx.Provide(handlers.NewConsistencyHandler),
+	fx.Provide(handlers.NewDistributionHandler),
+	fx.Provide(handlers.NewBranchHandler),
+	fx.Provide(handlers.NewReleaseGateHandler),
+	fx.Provide(handlers.NewLockHandler),
+	fx.Provide(handlers.NewInheritanceHandler),
+	fx.Provide(handlers.NewProjectTemplateHandler),
+	fx.Provide(handlers.NewGitSyncHandler),
 
 	// Router
 	fx.Provide(routes.NewRouter),
@@ -57,4 +113,9 @@ var AppModule = fx.Module("app",
 
 	// DB Security Monitor
 	fx.Provide(NewDBSecurityMonitor),
+
+	// 后台任务
+	fx.Invoke(RegisterTrashPurgeJob),
+	fx.Invoke(RegisterKeyDeprecationJob),
+	fx.Invoke(RegisterGitSyncJob),
 )
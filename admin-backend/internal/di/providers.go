@@ -1,6 +1,7 @@
 package di
 
 import (
+	"crypto/ed25519"
 	"fmt"
 
 	"i18n-flow/internal/config"
@@ -54,6 +55,91 @@ func NewTranslationRepository(db *gorm.DB) domain.TranslationRepository {
 	return repository.NewTranslationRepository(db)
 }
 
+// NewKeyTagRepository 提供翻译键标签仓储
+func NewKeyTagRepository(db *gorm.DB) domain.KeyTagRepository {
+	return repository.NewKeyTagRepository(db)
+}
+
+// NewTrashRepository 提供回收站仓储
+func NewTrashRepository(db *gorm.DB) domain.TrashRepository {
+	return repository.NewTrashRepository(db)
+}
+
+// NewScreenshotRepository 提供截图仓储
+func NewScreenshotRepository(db *gorm.DB) domain.ScreenshotRepository {
+	return repository.NewScreenshotRepository(db)
+}
+
+// NewSnapshotRepository 提供版本快照仓储
+func NewSnapshotRepository(db *gorm.DB) domain.SnapshotRepository {
+	return repository.NewSnapshotRepository(db)
+}
+
+// NewKeyMergeRepository 提供键合并仓储
+func NewKeyMergeRepository(db *gorm.DB) domain.KeyMergeRepository {
+	return repository.NewKeyMergeRepository(db)
+}
+
+// NewBranchRepository 提供翻译分支仓储
+func NewBranchRepository(db *gorm.DB) domain.BranchRepository {
+	return repository.NewBranchRepository(db)
+}
+
+// NewTranslationLockRepository 提供翻译锁定仓储
+func NewTranslationLockRepository(db *gorm.DB) domain.TranslationLockRepository {
+	return repository.NewTranslationLockRepository(db)
+}
+
+// NewProjectBaseRepository 提供项目继承关系仓储
+func NewProjectBaseRepository(db *gorm.DB) domain.ProjectBaseRepository {
+	return repository.NewProjectBaseRepository(db)
+}
+
+// NewProjectTemplateRepository 提供项目模板仓储
+func NewProjectTemplateRepository(db *gorm.DB) domain.ProjectTemplateRepository {
+	return repository.NewProjectTemplateRepository(db)
+}
+
+// NewGitSyncRepository 提供 git 仓库同步设置仓储
+func NewGitSyncRepository(db *gorm.DB) domain.GitSyncRepository {
+	return repository.NewGitSyncRepository(db)
+}
+
+// NewReleaseCriteriaRepository 提供发布门禁条件仓储
+func NewReleaseCriteriaRepository(db *gorm.DB) domain.ReleaseCriteriaRepository {
+	return repository.NewReleaseCriteriaRepository(db)
+}
+
+// NewDistributionRepository 提供译文分发仓储
+func NewDistributionRepository(db *gorm.DB) domain.DistributionRepository {
+	return repository.NewDistributionRepository(db)
+}
+
+// NewFileStorage 提供文件存储后端
+func NewFileStorage(cfg *config.Config) domain.FileStorage {
+	return service.NewFileStorage(cfg.Storage)
+}
+
+// NewKeyUsageRepository 提供翻译键代码引用仓储
+func NewKeyUsageRepository(db *gorm.DB) domain.KeyUsageRepository {
+	return repository.NewKeyUsageRepository(db)
+}
+
+// NewTranslationMemoryRepository 提供翻译记忆仓储
+func NewTranslationMemoryRepository(db *gorm.DB) domain.TranslationMemoryRepository {
+	return repository.NewTranslationMemoryRepository(db)
+}
+
+// NewGlossaryRepository 提供术语表仓储
+func NewGlossaryRepository(db *gorm.DB) domain.GlossaryRepository {
+	return repository.NewGlossaryRepository(db)
+}
+
+// NewMachineTranslationUsageRepository 提供机器翻译用量仓储
+func NewMachineTranslationUsageRepository(db *gorm.DB) domain.MachineTranslationUsageRepository {
+	return repository.NewMachineTranslationUsageRepository(db)
+}
+
 // NewProjectMemberRepository 提供项目成员仓储
 func NewProjectMemberRepository(db *gorm.DB) domain.ProjectMemberRepository {
 	return repository.NewProjectMemberRepository(db)
@@ -87,9 +173,14 @@ func NewProjectService(
 	projectRepo domain.ProjectRepository,
 	userRepo domain.UserRepository,
 	memberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	templateRepo domain.ProjectTemplateRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	criteriaRepo domain.ReleaseCriteriaRepository,
 	cache domain.CacheService,
 ) domain.ProjectService {
-	base := service.NewProjectService(projectRepo, userRepo, memberRepo)
+	base := service.NewProjectService(projectRepo, userRepo, memberRepo, languageRepo, translationRepo, templateRepo, projectBaseRepo, criteriaRepo)
 	if cache != nil {
 		return service.NewCachedProjectService(base, cache)
 	}
@@ -113,9 +204,13 @@ func NewTranslationService(
 	translationRepo domain.TranslationRepository,
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
+	keyTagRepo domain.KeyTagRepository,
+	branchRepo domain.BranchRepository,
+	lockRepo domain.TranslationLockRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
 	cache domain.CacheService,
 ) domain.TranslationService {
-	base := service.NewTranslationService(translationRepo, projectRepo, languageRepo)
+	base := service.NewTranslationService(translationRepo, projectRepo, languageRepo, keyTagRepo, branchRepo, lockRepo, projectBaseRepo)
 	if cache != nil {
 		return service.NewCachedTranslationService(base, cache)
 	}
@@ -136,6 +231,251 @@ func NewDashboardService(
 	return base
 }
 
+// NewTrashService 提供回收站服务 (带缓存失效装饰器)
+func NewTrashService(
+	trashRepo domain.TrashRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+	storage domain.FileStorage,
+	cache domain.CacheService,
+	cfg *config.Config,
+) domain.TrashService {
+	base := service.NewTrashService(trashRepo, translationRepo, projectRepo, translationService, storage, cfg.Trash.RetentionDays)
+	if cache != nil {
+		return service.NewCachedTrashService(base, cache)
+	}
+	return base
+}
+
+// NewKeyUsageService 提供翻译键代码引用服务 (带缓存失效装饰器)
+func NewKeyUsageService(
+	keyUsageRepo domain.KeyUsageRepository,
+	projectRepo domain.ProjectRepository,
+	screenshotService domain.ScreenshotService,
+	cache domain.CacheService,
+	cfg *config.Config,
+) domain.KeyUsageService {
+	base := service.NewKeyUsageService(keyUsageRepo, projectRepo, screenshotService, cfg.KeyUsage.DeprecationGraceDays)
+	if cache != nil {
+		return service.NewCachedKeyUsageService(base, cache)
+	}
+	return base
+}
+
+// NewKeyExtractionService 提供源码翻译键提取服务
+func NewKeyExtractionService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+) domain.KeyExtractionService {
+	return service.NewKeyExtractionService(translationRepo, projectRepo, translationService)
+}
+
+// NewTranslationMemoryService 提供翻译记忆服务
+func NewTranslationMemoryService(
+	tmRepo domain.TranslationMemoryRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+) domain.TranslationMemoryService {
+	return service.NewTranslationMemoryService(tmRepo, translationRepo, projectRepo, languageRepo, userRepo, projectMemberRepo)
+}
+
+// NewGlossaryService 提供术语表服务
+func NewGlossaryService(
+	glossaryRepo domain.GlossaryRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+) domain.GlossaryService {
+	return service.NewGlossaryService(glossaryRepo, translationRepo, projectRepo, languageRepo)
+}
+
+// NewMachineTranslationService 提供机器翻译服务 (带缓存失效装饰器)
+// 未配置提供方时服务仍可查询用量，预翻译返回未配置错误
+func NewMachineTranslationService(
+	usageRepo domain.MachineTranslationUsageRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	lockRepo domain.TranslationLockRepository,
+	cache domain.CacheService,
+	cfg *config.Config,
+) domain.MachineTranslationService {
+	translator := service.NewMachineTranslator(cfg.MachineTranslation)
+	base := service.NewMachineTranslationService(translator, usageRepo, translationRepo, projectRepo, languageRepo, lockRepo, cfg.MachineTranslation.MonthlyQuota)
+	if cache != nil {
+		return service.NewCachedMachineTranslationService(base, cache)
+	}
+	return base
+}
+
+// NewInContextService 提供页内编辑服务
+func NewInContextService(
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	projectMemberService domain.ProjectMemberService,
+	screenshotService domain.ScreenshotService,
+	cfg *config.Config,
+) domain.InContextService {
+	return service.NewInContextService(cfg.JWT, cfg.InContext, projectRepo, translationRepo, projectMemberService, screenshotService)
+}
+
+// NewScreenshotService 提供截图服务
+func NewScreenshotService(
+	screenshotRepo domain.ScreenshotRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	storage domain.FileStorage,
+	cfg *config.Config,
+) domain.ScreenshotService {
+	return service.NewScreenshotService(screenshotRepo, projectRepo, translationRepo, storage, cfg.Screenshot)
+}
+
+// NewStatisticsService 提供字数统计服务
+func NewStatisticsService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	snapshotRepo domain.SnapshotRepository,
+	tmService domain.TranslationMemoryService,
+	cfg *config.Config,
+) domain.StatisticsService {
+	return service.NewStatisticsService(projectRepo, languageRepo, translationRepo, snapshotRepo, tmService, cfg.Cost)
+}
+
+// NewSnapshotService 提供版本快照服务
+func NewSnapshotService(
+	snapshotRepo domain.SnapshotRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+) domain.SnapshotService {
+	return service.NewSnapshotService(snapshotRepo, projectRepo, translationService)
+}
+
+// NewConsistencyService 提供翻译一致性检查服务 (带缓存失效装饰器)
+// 统一译文通过带缓存的翻译服务写入，合并键在仓储的事务中写入，由装饰器清除缓存
+func NewConsistencyService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	keyMergeRepo domain.KeyMergeRepository,
+	translationService domain.TranslationService,
+	cache domain.CacheService,
+) domain.ConsistencyService {
+	base := service.NewConsistencyService(projectRepo, languageRepo, translationRepo, keyMergeRepo, translationService)
+	if cache != nil {
+		return service.NewCachedConsistencyService(base, cache)
+	}
+	return base
+}
+
+// NewBranchService 提供翻译分支服务
+// 合并时通过带缓存的翻译服务写入主干，缓存随之失效
+func NewBranchService(
+	branchRepo domain.BranchRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+) domain.BranchService {
+	return service.NewBranchService(branchRepo, projectRepo, languageRepo, translationRepo, translationService)
+}
+
+// NewSyncService 提供 CLI 三方合并同步服务
+// 通过带缓存的翻译服务写入客户端的修改，缓存随之失效
+func NewSyncService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	projectBaseRepo domain.ProjectBaseRepository,
+) domain.SyncService {
+	return service.NewSyncService(projectRepo, languageRepo, translationRepo, translationService, projectBaseRepo)
+}
+
+// NewLockService 提供字符串冻结和翻译锁定服务
+// 通过带缓存的项目服务修改冻结状态，缓存随之失效
+func NewLockService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	lockRepo domain.TranslationLockRepository,
+	projectService domain.ProjectService,
+) domain.LockService {
+	return service.NewLockService(projectRepo, languageRepo, lockRepo, projectService)
+}
+
+// NewInheritanceService 提供项目继承服务
+func NewInheritanceService(
+	projectRepo domain.ProjectRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	gitSyncRepo domain.GitSyncRepository,
+	projectMemberService domain.ProjectMemberService,
+) domain.InheritanceService {
+	return service.NewInheritanceService(projectRepo, projectBaseRepo, gitSyncRepo, projectMemberService)
+}
+
+// NewProjectTemplateService 提供项目模板服务
+func NewProjectTemplateService(
+	templateRepo domain.ProjectTemplateRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) domain.ProjectTemplateService {
+	return service.NewProjectTemplateService(templateRepo, projectRepo, languageRepo, userRepo)
+}
+
+// NewGitSyncService 提供 git 仓库同步服务
+// 通过三方合并同步服务写入仓库中的修改，缓存随之失效
+func NewGitSyncService(
+	gitSyncRepo domain.GitSyncRepository,
+	projectRepo domain.ProjectRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	languageRepo domain.LanguageRepository,
+	translationService domain.TranslationService,
+	userRepo domain.UserRepository,
+	syncService domain.SyncService,
+	cfg *config.Config,
+) domain.GitSyncService {
+	return service.NewGitSyncService(gitSyncRepo, projectRepo, projectBaseRepo, languageRepo, translationService, userRepo, syncService,
+		service.NewGitClient(cfg.GitSync))
+}
+
+// NewReleaseGateService 提供发布门禁服务
+func NewReleaseGateService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	criteriaRepo domain.ReleaseCriteriaRepository,
+	glossaryService domain.GlossaryService,
+) domain.ReleaseGateService {
+	return service.NewReleaseGateService(projectRepo, languageRepo, translationRepo, criteriaRepo, glossaryService)
+}
+
+// NewDistributionService 提供译文分发服务
+// 未配置签名私钥时仍可管理令牌，但不能发布
+func NewDistributionService(
+	distributionRepo domain.DistributionRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+	snapshotRepo domain.SnapshotRepository,
+	storage domain.FileStorage,
+	cfg *config.Config,
+) (domain.DistributionService, error) {
+	var signingKey ed25519.PrivateKey
+	if cfg.Distribution.SigningKey != "" {
+		key, err := internal_utils.ParseEd25519PrivateKey(cfg.Distribution.SigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("解析译文分发签名私钥失败: %w", err)
+		}
+		signingKey = key
+	}
+	return service.NewDistributionService(distributionRepo, projectRepo, translationService, snapshotRepo, storage, signingKey), nil
+}
+
 // NewProjectMemberService 提供项目成员服务
 func NewProjectMemberService(
 	memberRepo domain.ProjectMemberRepository,
@@ -122,16 +122,120 @@ var (
 	ErrProjectNotFound = NewAppError(ErrorTypeNotFound, "PROJECT_NOT_FOUND", "项目不存在")
 	ErrProjectExists   = NewAppError(ErrorTypeConflict, "PROJECT_EXISTS", "项目已存在")
 	ErrInvalidSlug     = NewAppError(ErrorTypeValidation, "INVALID_SLUG", "无效的项目标识")
+	ErrProjectInTrash  = NewAppError(ErrorTypeConflict, "PROJECT_IN_TRASH", "回收站中存在同名项目，请先恢复或彻底删除")
+
+	// 项目模板相关错误
+	ErrTemplateNotFound = NewAppError(ErrorTypeNotFound, "TEMPLATE_NOT_FOUND", "项目模板不存在")
+	ErrTemplateExists   = NewAppError(ErrorTypeConflict, "TEMPLATE_EXISTS", "同名的项目模板已存在")
+
+	// 占位符相关错误
+	ErrInvalidPlaceholderFormat = NewAppError(ErrorTypeValidation, "INVALID_PLACEHOLDER_FORMAT", "无效的占位符语法，可选 brace、double_brace、android、ios、gettext")
+
+	// 翻译值类型相关错误
+	ErrInvalidValueType = NewAppError(ErrorTypeValidation, "INVALID_VALUE_TYPE", "无效的翻译值类型，可选 plain、html_subset、markdown")
+
+	// 伪本地化相关错误
+	ErrInvalidPseudoOptions = NewAppError(ErrorTypeValidation, "INVALID_PSEUDO_OPTIONS", "无效的伪本地化选项，长度扩展百分比需在 0 到 300 之间")
+	ErrPseudoNoSource       = NewAppError(ErrorTypeBadRequest, "PSEUDO_NO_SOURCE", "未设置默认语言，无法生成伪本地化语言")
 
 	// 语言相关错误
 	ErrLanguageNotFound = NewAppError(ErrorTypeNotFound, "LANGUAGE_NOT_FOUND", "语言不存在")
 	ErrLanguageExists   = NewAppError(ErrorTypeConflict, "LANGUAGE_EXISTS", "语言已存在")
 	ErrInvalidLanguage  = NewAppError(ErrorTypeValidation, "INVALID_LANGUAGE", "无效的语言代码")
+	ErrNoLanguages      = NewAppError(ErrorTypeBadRequest, "NO_LANGUAGES", "系统中没有可用的语言")
 
 	// 翻译相关错误
 	ErrTranslationNotFound = NewAppError(ErrorTypeNotFound, "TRANSLATION_NOT_FOUND", "翻译不存在")
 	ErrTranslationExists   = NewAppError(ErrorTypeConflict, "TRANSLATION_EXISTS", "翻译已存在")
 	ErrInvalidKey          = NewAppError(ErrorTypeValidation, "INVALID_KEY", "无效的翻译键")
+	ErrInvalidKeyPath      = NewAppError(ErrorTypeValidation, "INVALID_KEY_PATH", "无效的键路径")
+
+	// 回收站相关错误
+	ErrTrashItemNotFound    = NewAppError(ErrorTypeNotFound, "TRASH_ITEM_NOT_FOUND", "回收站中不存在该项")
+	ErrInvalidRestoreOption = NewAppError(ErrorTypeValidation, "INVALID_RESTORE_STRATEGY", "无效的恢复策略")
+
+	// 代码引用相关错误
+	ErrNoKeyScans = NewAppError(ErrorTypeBadRequest, "NO_KEY_SCANS", "项目尚未上报代码扫描结果")
+
+	// 键提取相关错误
+	ErrInvalidArchive      = NewAppError(ErrorTypeValidation, "INVALID_ARCHIVE", "无效的源码压缩包，仅支持 zip、tar 和 tar.gz")
+	ErrArchiveTooLarge     = NewAppError(ErrorTypeValidation, "ARCHIVE_TOO_LARGE", "源码压缩包的文件数或解压后大小超出限制")
+	ErrInvalidKeyExtractor = NewAppError(ErrorTypeValidation, "INVALID_KEY_EXTRACTOR", "无效的键提取器配置")
+
+	// 翻译记忆相关错误
+	ErrInvalidTMX    = NewAppError(ErrorTypeValidation, "INVALID_TMX", "无效的 TMX 文件")
+	ErrEmptyTMSource = NewAppError(ErrorTypeValidation, "EMPTY_TM_SOURCE", "原文不能为空")
+	ErrSameLanguages = NewAppError(ErrorTypeValidation, "SAME_LANGUAGES", "源语言和目标语言不能相同")
+
+	// 术语表相关错误
+	ErrGlossaryEntryNotFound = NewAppError(ErrorTypeNotFound, "GLOSSARY_ENTRY_NOT_FOUND", "术语不存在")
+	ErrGlossaryTermExists    = NewAppError(ErrorTypeConflict, "GLOSSARY_TERM_EXISTS", "术语已存在")
+	ErrInvalidTBX            = NewAppError(ErrorTypeValidation, "INVALID_TBX", "无效的 TBX 文件")
+
+	// 机器翻译相关错误
+	ErrMTNotConfigured   = NewAppError(ErrorTypeBadRequest, "MT_NOT_CONFIGURED", "未配置机器翻译")
+	ErrMTQuotaExceeded   = NewAppError(ErrorTypeForbidden, "MT_QUOTA_EXCEEDED", "项目本月的机器翻译额度已用完")
+	ErrMTDefaultLanguage = NewAppError(ErrorTypeValidation, "MT_DEFAULT_LANGUAGE", "不能机器翻译到默认语言")
+
+	// 页内编辑相关错误
+	ErrInvalidEditorToken  = NewAppError(ErrorTypeUnauthorized, "INVALID_EDITOR_TOKEN", "编辑令牌无效或已过期")
+	ErrNoInContextMarkers  = NewAppError(ErrorTypeValidation, "NO_IN_CONTEXT_MARKERS", "文本中没有页内编辑标记")
+	ErrInContextWrongScope = NewAppError(ErrorTypeForbidden, "IN_CONTEXT_WRONG_PROJECT", "标记不属于编辑令牌绑定的项目")
+
+	// 截图相关错误
+	ErrScreenshotNotFound = NewAppError(ErrorTypeNotFound, "SCREENSHOT_NOT_FOUND", "截图不存在")
+	ErrInvalidScreenshot  = NewAppError(ErrorTypeValidation, "INVALID_SCREENSHOT", "无效的截图，仅支持 PNG、JPEG 和 GIF")
+	ErrScreenshotTooLarge = NewAppError(ErrorTypeValidation, "SCREENSHOT_TOO_LARGE", "截图文件过大")
+	ErrInvalidRegion      = NewAppError(ErrorTypeValidation, "INVALID_SCREENSHOT_REGION", "截图区域超出图片范围")
+	ErrStoredFileNotFound = NewAppError(ErrorTypeNotFound, "STORED_FILE_NOT_FOUND", "存储中的文件不存在")
+
+	// 版本快照相关错误
+	ErrSnapshotNotFound = NewAppError(ErrorTypeNotFound, "SNAPSHOT_NOT_FOUND", "版本快照不存在")
+	ErrSnapshotExists   = NewAppError(ErrorTypeConflict, "SNAPSHOT_EXISTS", "同名的版本快照已存在")
+
+	// 翻译分支相关错误
+	ErrBranchNotFound    = NewAppError(ErrorTypeNotFound, "BRANCH_NOT_FOUND", "翻译分支不存在")
+	ErrBranchExists      = NewAppError(ErrorTypeConflict, "BRANCH_EXISTS", "同名的翻译分支已存在")
+	ErrBranchMerged      = NewAppError(ErrorTypeBadRequest, "BRANCH_MERGED", "分支已合并，不能再修改")
+	ErrInvalidBranchName = NewAppError(ErrorTypeValidation, "INVALID_BRANCH_NAME", "分支名只能包含字母、数字、. _ - 和 /，最长 100 个字符")
+
+	// 增量同步相关错误
+	ErrRevisionAhead   = NewAppError(ErrorTypeBadRequest, "REVISION_AHEAD", "修订号大于项目当前的修订号，请重新全量同步")
+	ErrDeltaWithBranch = NewAppError(ErrorTypeBadRequest, "DELTA_WITH_BRANCH", "增量同步不支持指定分支")
+
+	// 字符串冻结和锁定相关错误
+	ErrStringFrozen      = NewAppError(ErrorTypeForbidden, "STRING_FROZEN", "项目处于字符串冻结状态，不能新增、重命名或修改默认语言的键")
+	ErrTranslationLocked = NewAppError(ErrorTypeForbidden, "TRANSLATION_LOCKED", "翻译已被锁定，只有项目所有者可以解除锁定")
+	ErrLockNotFound      = NewAppError(ErrorTypeNotFound, "LOCK_NOT_FOUND", "锁定不存在")
+	ErrLockExists        = NewAppError(ErrorTypeConflict, "LOCK_EXISTS", "相同的锁定已存在")
+
+	// 项目继承相关错误
+	ErrInheritanceCycle     = NewAppError(ErrorTypeBadRequest, "INHERITANCE_CYCLE", "基础项目不能直接或间接继承当前项目")
+	ErrBaseProjectForbidden = NewAppError(ErrorTypeForbidden, "BASE_PROJECT_FORBIDDEN", "没有基础项目的查看权限")
+	ErrInheritedRevision    = NewAppError(ErrorTypeBadRequest, "INHERITED_REVISION", "继承基础项目的项目不支持按修订号同步，请全量拉取")
+	ErrInheritanceGitSync   = NewAppError(ErrorTypeConflict, "INHERITANCE_GIT_SYNC", "配置了 git 仓库同步的项目不能继承基础项目，请先删除同步设置或基础项目")
+
+	// 译文分发相关错误
+	ErrInvalidDistributionToken    = NewAppError(ErrorTypeUnauthorized, "INVALID_DISTRIBUTION_TOKEN", "分发令牌无效")
+	ErrDistributionTokenNotFound   = NewAppError(ErrorTypeNotFound, "DISTRIBUTION_TOKEN_NOT_FOUND", "分发令牌不存在")
+	ErrDistributionNotPublished    = NewAppError(ErrorTypeNotFound, "DISTRIBUTION_NOT_PUBLISHED", "该环境尚未发布译文")
+	ErrDistributionBundleNotFound  = NewAppError(ErrorTypeNotFound, "DISTRIBUTION_BUNDLE_NOT_FOUND", "译文包不存在")
+	ErrDistributionSigningDisabled = NewAppError(ErrorTypeBadRequest, "DISTRIBUTION_SIGNING_NOT_CONFIGURED", "未配置分发清单的签名密钥")
+	ErrInvalidEnvironment          = NewAppError(ErrorTypeValidation, "INVALID_ENVIRONMENT", "环境名只能包含小写字母、数字、- 和 _，最长 32 个字符")
+
+	// git 仓库同步相关错误
+	ErrGitSyncNotConfigured = NewAppError(ErrorTypeNotFound, "GIT_SYNC_NOT_CONFIGURED", "项目未配置 git 仓库同步")
+	ErrGitSyncRunning       = NewAppError(ErrorTypeConflict, "GIT_SYNC_RUNNING", "项目的 git 仓库同步正在进行，请稍后再试")
+	ErrGitPushRejected      = NewAppError(ErrorTypeConflict, "GIT_PUSH_REJECTED", "仓库在同步期间有新的提交，导出未推送，请重新同步")
+	ErrInvalidRepositoryURL = NewAppError(ErrorTypeValidation, "INVALID_REPOSITORY_URL", "仓库地址必须是 SSH 或 HTTPS 地址，不能包含凭据；本地路径需要服务端允许")
+	ErrInvalidGitBranch     = NewAppError(ErrorTypeValidation, "INVALID_GIT_BRANCH", "无效的分支名")
+	ErrInvalidPathTemplate  = NewAppError(ErrorTypeValidation, "INVALID_PATH_TEMPLATE", "文件路径模板必须是仓库内的相对路径，并且包含 {lang}")
+	ErrInvalidLocaleFormat  = NewAppError(ErrorTypeValidation, "INVALID_LOCALE_FORMAT", "无效的翻译文件格式，可选 json、json_flat")
+	ErrGitSymlinkPath       = NewAppError(ErrorTypeValidation, "GIT_SYMLINK_PATH", "仓库中的翻译文件路径包含符号链接，已拒绝读写")
+
+	// 一致性检查相关错误
+	ErrKeysNotDuplicates = NewAppError(ErrorTypeValidation, "KEYS_NOT_DUPLICATES", "所选的键在默认语言下的原文不同")
+	ErrHarmonizeNoValue  = NewAppError(ErrorTypeValidation, "HARMONIZE_NO_VALUE", "未提供译文，且来源键在该语言下没有译文")
 
 	// 项目成员相关错误
 	ErrMemberNotFound    = NewAppError(ErrorTypeNotFound, "MEMBER_NOT_FOUND", "项目成员不存在")
@@ -22,17 +22,21 @@ type User struct {
 
 // Project 项目领域模型
 type Project struct {
-	ID           uint64         `gorm:"primaryKey" json:"id"`
-	Name         string         `gorm:"size:100;not null;unique;index:idx_project_search" json:"name"` // 项目名称
-	Description  string         `gorm:"size:500;index:idx_project_search" json:"description"`          // 项目描述
-	Slug         string         `gorm:"size:100;not null;unique;index" json:"slug"`                    // 项目标识，用于URL
-	Status       string         `gorm:"size:20;default:active;index:idx_project_status" json:"status"` // 项目状态：active, archived
-	CreatedBy    uint64         `json:"created_by"`
-	UpdatedBy    uint64         `json:"updated_by"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
-	Translations []Translation  `gorm:"foreignKey:ProjectID" json:"-"` // 关联的翻译
+	ID                uint64         `gorm:"primaryKey" json:"id"`
+	Name              string         `gorm:"size:100;not null;unique;index:idx_project_search" json:"name"` // 项目名称
+	Description       string         `gorm:"size:500;index:idx_project_search" json:"description"`          // 项目描述
+	Slug              string         `gorm:"size:100;not null;unique;index" json:"slug"`                    // 项目标识，用于URL
+	Status            string         `gorm:"size:20;default:active;index:idx_project_status" json:"status"` // 项目状态：active, archived
+	PlaceholderFormat string         `gorm:"size:20;not null;default:brace" json:"placeholder_format"`      // 占位符规范语法：brace, double_brace, android, ios, gettext
+	ValueType         string         `gorm:"size:20;not null;default:plain" json:"value_type"`              // 翻译值类型：plain, html_subset, markdown
+	Revision          uint64         `gorm:"not null;default:0" json:"revision"`                            // 修订号，项目内每次写入翻译时递增
+	StringFreeze      bool           `gorm:"not null;default:false" json:"string_freeze"`                   // 字符串冻结：不能新增、重命名或修改默认语言的键，仍可翻译
+	CreatedBy         uint64         `json:"created_by"`
+	UpdatedBy         uint64         `json:"updated_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+	Translations      []Translation  `gorm:"foreignKey:ProjectID" json:"-"` // 关联的翻译
 }
 
 // Language 语言领域模型
@@ -51,23 +55,383 @@ type Language struct {
 
 // Translation 翻译领域模型
 type Translation struct {
-	ID         uint64         `gorm:"primaryKey" json:"id"`
-	ProjectID  uint64         `gorm:"not null;index:idx_translation_project;uniqueIndex:idx_translation_unique,priority:1" json:"project_id"`    // 关联的项目ID
-	KeyName    string         `gorm:"size:255;not null;index:idx_translation_key;uniqueIndex:idx_translation_unique,priority:2" json:"key_name"` // 翻译键名
-	Context    string         `gorm:"size:500" json:"context"`                                                                                   // 上下文说明
-	LanguageID uint64         `gorm:"not null;index:idx_translation_language;uniqueIndex:idx_translation_unique,priority:3" json:"language_id"`  // 语言ID
-	Value      string         `gorm:"type:text" json:"value"`                                                                                    // 翻译值
-	Status     string         `gorm:"size:20;default:active;index:idx_translation_status" json:"status"`                                         // 状态：active, deprecated
-	CreatedBy  uint64         `json:"created_by"`
-	UpdatedBy  uint64         `json:"updated_by"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint64         `gorm:"primaryKey" json:"id"`
+	ProjectID         uint64         `gorm:"not null;index:idx_translation_project;index:idx_translation_revision,priority:1;uniqueIndex:idx_translation_active_unique,priority:1" json:"project_id"` // 关联的项目ID
+	KeyName           string         `gorm:"size:255;not null;index:idx_translation_key;uniqueIndex:idx_translation_active_unique,priority:2" json:"key_name"`                                        // 翻译键名
+	Context           string         `gorm:"size:500" json:"context"`                                                                                                                                 // 上下文说明
+	LanguageID        uint64         `gorm:"not null;index:idx_translation_language;uniqueIndex:idx_translation_active_unique,priority:3" json:"language_id"`                                         // 语言ID
+	Value             string         `gorm:"type:text" json:"value"`                                                                                                                                  // 翻译值
+	Status            string         `gorm:"size:20;default:active;index:idx_translation_status" json:"status"`                                                                                       // 状态：active, deprecated
+	MachineTranslated bool           `gorm:"default:false" json:"machine_translated"`                                                                                                                 // 是否为未经人工确认的机器翻译草稿
+	Revision          uint64         `gorm:"not null;default:0;index:idx_translation_revision,priority:2" json:"revision"`                                                                            // 最后一次写入时的项目修订号
+	CreatedBy         uint64         `json:"created_by"`
+	UpdatedBy         uint64         `json:"updated_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// LiveFlag 未删除时为1，删除后为NULL，使回收站中的翻译不占用唯一索引
+	LiveFlag *uint8 `gorm:"->;type:tinyint GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) STORED;uniqueIndex:idx_translation_active_unique,priority:4" json:"-"`
 
 	Project  Project  `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`   // 关联的项目
 	Language Language `gorm:"foreignKey:LanguageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"` // 关联的语言
 }
 
+// TranslationTombstone 翻译删除标记，记录单元格被删除或废弃时的项目修订号，供增量同步下发删除
+// 同一单元格只保留最后一次删除的标记，单元格重新写入后以翻译的修订号为准
+type TranslationTombstone struct {
+	ProjectID  uint64    `gorm:"primaryKey;autoIncrement:false;index:idx_tombstone_revision,priority:1" json:"project_id"`
+	KeyName    string    `gorm:"primaryKey;size:255" json:"key_name"`
+	LanguageID uint64    `gorm:"primaryKey;autoIncrement:false" json:"language_id"`
+	Revision   uint64    `gorm:"not null;index:idx_tombstone_revision,priority:2" json:"revision"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+// TranslationVersion 翻译单元格在某个修订号写入后的状态，三方合并时用于还原客户端基准修订号下的译文
+// 同一事务内可能多次写入同一单元格，以 ID 最大的一条为准
+type TranslationVersion struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID  uint64    `gorm:"not null;index:idx_translation_version_revision,priority:1" json:"project_id"`
+	KeyName    string    `gorm:"size:255;not null" json:"key_name"`
+	LanguageID uint64    `gorm:"not null" json:"language_id"`
+	Revision   uint64    `gorm:"not null;index:idx_translation_version_revision,priority:2" json:"revision"`
+	Value      string    `gorm:"type:text" json:"value"`
+	Deleted    bool      `gorm:"not null;default:false" json:"deleted"` // 删除或废弃
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// KeyTag 翻译键标签模型
+type KeyTag struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID uint64    `gorm:"not null;index:idx_key_tag_project;uniqueIndex:idx_key_tag_unique,priority:1" json:"project_id"` // 关联的项目ID
+	KeyName   string    `gorm:"size:255;not null;uniqueIndex:idx_key_tag_unique,priority:2" json:"key_name"`                    // 翻译键名
+	Tag       string    `gorm:"size:50;not null;index:idx_key_tag_tag;uniqueIndex:idx_key_tag_unique,priority:3" json:"tag"`    // 标签
+	CreatedBy uint64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KeyScan 代码扫描记录，每次CLI上报扫描结果时创建
+type KeyScan struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID      uint64    `gorm:"not null;index:idx_key_scan_branch,priority:1" json:"project_id"`
+	Repository     string    `gorm:"size:255;not null;default:'';index:idx_key_scan_branch,priority:2" json:"repository"` // 代码仓库
+	Branch         string    `gorm:"size:255;not null;default:'';index:idx_key_scan_branch,priority:3" json:"branch"`     // 分支
+	Commit         string    `gorm:"size:64" json:"commit"`                                                               // 提交哈希
+	KeyCount       int       `json:"key_count"`                                                                           // 引用到的键数
+	ReferenceCount int       `json:"reference_count"`                                                                     // 引用总数
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// KeyReference 翻译键在代码中的引用位置，只保留每个仓库分支最近一次扫描的结果
+type KeyReference struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID  uint64    `gorm:"not null;index:idx_key_reference_key,priority:1;index:idx_key_reference_branch,priority:1" json:"project_id"`
+	KeyName    string    `gorm:"size:255;not null;index:idx_key_reference_key,priority:2" json:"key_name"`
+	ScanID     uint64    `gorm:"not null;index" json:"scan_id"`
+	Repository string    `gorm:"size:255;not null;default:'';index:idx_key_reference_branch,priority:2" json:"repository"`
+	Branch     string    `gorm:"size:255;not null;default:'';index:idx_key_reference_branch,priority:3" json:"branch"`
+	Commit     string    `gorm:"size:64" json:"commit"`
+	FilePath   string    `gorm:"size:500;not null" json:"file_path"`
+	Line       int       `json:"line"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// KeyUsage 翻译键最近一次在扫描中被引用的时间，用于判断未使用的键何时可以废弃
+type KeyUsage struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID  uint64    `gorm:"not null;uniqueIndex:idx_key_usage_unique,priority:1" json:"project_id"`
+	KeyName    string    `gorm:"size:255;not null;uniqueIndex:idx_key_usage_unique,priority:2" json:"key_name"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// TranslationMemoryUnit 从 TMX 导入的翻译记忆单元，包含同一句话在多种语言下的文本
+// 项目中的翻译直接作为翻译记忆使用，不复制到此表
+type TranslationMemoryUnit struct {
+	ID          uint64                      `gorm:"primaryKey" json:"id"`
+	Origin      string                      `gorm:"size:255;index" json:"origin"`                                  // 来源，如导入的文件名
+	ContentHash string                      `gorm:"size:64;not null;uniqueIndex" json:"-"`                         // 各语言文本的哈希，用于导入去重
+	Segments    []*TranslationMemorySegment `gorm:"foreignKey:UnitID;constraint:OnDelete:CASCADE" json:"segments"` // 各语言的文本
+	CreatedBy   uint64                      `json:"created_by"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+// TranslationMemorySegment 翻译记忆单元在单个语言下的文本
+type TranslationMemorySegment struct {
+	ID       uint64 `gorm:"primaryKey" json:"-"`
+	UnitID   uint64 `gorm:"not null;index" json:"-"`
+	Language string `gorm:"size:10;not null;index:idx_tm_segment_text,priority:1" json:"language"` // 语言代码
+	Text     string `gorm:"type:text;not null" json:"text"`
+	TextHash string `gorm:"size:64;not null;index:idx_tm_segment_text,priority:2" json:"-"` // 规范化文本的哈希，用于完全匹配
+}
+
+// GlossaryEntry 术语表条目，ProjectID 为0时为全局术语，作用于所有项目
+type GlossaryEntry struct {
+	ID             uint64                 `gorm:"primaryKey" json:"id"`
+	ProjectID      uint64                 `gorm:"not null;uniqueIndex:idx_glossary_term,priority:1" json:"project_id"`    // 所属项目，0 为全局
+	Term           string                 `gorm:"size:255;not null;uniqueIndex:idx_glossary_term,priority:2" json:"term"` // 默认语言下的术语
+	Description    string                 `gorm:"size:500" json:"description"`                                            // 术语说明
+	CaseSensitive  bool                   `gorm:"default:false" json:"case_sensitive"`                                    // 是否区分大小写
+	DoNotTranslate bool                   `gorm:"default:false" json:"do_not_translate"`                                  // 是否不可翻译，如品牌名
+	Translations   []*GlossaryTranslation `gorm:"foreignKey:EntryID;constraint:OnDelete:CASCADE" json:"translations"`     // 各语言的译法
+	CreatedBy      uint64                 `json:"created_by"`
+	UpdatedBy      uint64                 `json:"updated_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// GlossaryTranslation 术语在某种语言下的译法
+type GlossaryTranslation struct {
+	ID       uint64 `gorm:"primaryKey" json:"-"`
+	EntryID  uint64 `gorm:"not null;index" json:"-"`
+	Language string `gorm:"size:10;not null" json:"language"`       // 语言代码
+	Value    string `gorm:"size:255;not null" json:"value"`         // 译法
+	Status   string `gorm:"size:20;default:approved" json:"status"` // 状态：approved, forbidden
+}
+
+// MachineTranslationUsage 项目每月的机器翻译字符用量
+type MachineTranslationUsage struct {
+	ID         uint64    `gorm:"primaryKey" json:"-"`
+	ProjectID  uint64    `gorm:"not null;uniqueIndex:idx_mt_usage_period,priority:1" json:"project_id"`
+	Period     string    `gorm:"size:7;not null;uniqueIndex:idx_mt_usage_period,priority:2" json:"period"` // 月份，如 2024-01
+	Characters int64     `gorm:"not null;default:0" json:"characters"`                                     // 发送给机器翻译的原文字符数
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Screenshot 项目截图，用矩形区域标注翻译键在界面中出现的位置
+// 原图和缩略图保存在文件存储中，数据库只记录存储路径
+type Screenshot struct {
+	ID           uint64              `gorm:"primaryKey" json:"id"`
+	ProjectID    uint64              `gorm:"not null;index" json:"project_id"`
+	Name         string              `gorm:"size:255;not null" json:"name"`
+	StorageKey   string              `gorm:"size:255;not null" json:"-"` // 原图在文件存储中的路径
+	ThumbnailKey string              `gorm:"size:255;not null" json:"-"` // 缩略图在文件存储中的路径
+	ContentType  string              `gorm:"size:50;not null" json:"content_type"`
+	Width        int                 `json:"width"`
+	Height       int                 `json:"height"`
+	Size         int64               `json:"size"` // 原图字节数
+	Regions      []*ScreenshotRegion `gorm:"foreignKey:ScreenshotID;constraint:OnDelete:CASCADE" json:"regions"`
+	ImageURL     string              `gorm:"-" json:"image_url"`
+	ThumbnailURL string              `gorm:"-" json:"thumbnail_url"`
+	CreatedBy    uint64              `json:"created_by"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// ScreenshotRegion 截图上的矩形区域，坐标以原图像素为单位，一个区域可标注多个键
+type ScreenshotRegion struct {
+	ID           uint64           `gorm:"primaryKey" json:"id"`
+	ScreenshotID uint64           `gorm:"not null;index" json:"-"`
+	X            int              `json:"x"`
+	Y            int              `json:"y"`
+	Width        int              `json:"width"`
+	Height       int              `json:"height"`
+	Keys         []*ScreenshotKey `gorm:"foreignKey:RegionID;constraint:OnDelete:CASCADE" json:"keys"`
+}
+
+// ScreenshotKey 区域标注的翻译键，冗余项目和截图ID，便于按键查找截图
+type ScreenshotKey struct {
+	ID           uint64 `gorm:"primaryKey" json:"-"`
+	RegionID     uint64 `gorm:"not null;index" json:"-"`
+	ScreenshotID uint64 `gorm:"not null;index" json:"-"`
+	ProjectID    uint64 `gorm:"not null;index:idx_screenshot_key,priority:1" json:"-"`
+	KeyName      string `gorm:"size:255;not null;index:idx_screenshot_key,priority:2" json:"key_name"`
+}
+
+// Snapshot 项目在某一时刻所有有效非空译文的只读版本，如某个发布版本实际使用的译文，创建后不可修改
+type Snapshot struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64    `gorm:"not null;uniqueIndex:idx_snapshot_name,priority:1" json:"project_id"`
+	Name        string    `gorm:"size:100;not null;uniqueIndex:idx_snapshot_name,priority:2" json:"name"` // 版本名，如 3.2.0
+	Description string    `gorm:"size:500" json:"description"`
+	KeyCount    int       `json:"key_count"`
+	ValueCount  int       `json:"value_count"` // 译文数（键 × 语言）
+	CreatedBy   uint64    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DistributionToken 可公开的只读分发令牌，嵌入移动应用中获取某个环境已发布的译文，只保存令牌的哈希
+type DistributionToken struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64    `gorm:"not null;index" json:"project_id"`
+	Environment string    `gorm:"size:32;not null" json:"environment"` // 发布环境，如 production、staging
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	TokenHash   string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	TokenPrefix string    `gorm:"size:16;not null" json:"token_prefix"` // 令牌前几位，用于识别
+	Token       string    `gorm:"-" json:"token,omitempty"`             // 完整令牌，只在创建时返回
+	CreatedBy   uint64    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DistributionRelease 一次发布：某环境在发布时各语言的译文包和签名清单，客户端总是获取环境的最新发布
+type DistributionRelease struct {
+	ID          uint64                `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64                `gorm:"not null;uniqueIndex:idx_distribution_release,priority:1" json:"project_id"`
+	Environment string                `gorm:"size:32;not null;uniqueIndex:idx_distribution_release,priority:2" json:"environment"`
+	Version     int                   `gorm:"not null;uniqueIndex:idx_distribution_release,priority:3" json:"version"` // 环境内递增的发布版本
+	SnapshotID  *uint64               `json:"snapshot_id,omitempty"`                                                   // 发布的版本快照，为空时发布的是当时的译文
+	Manifest    string                `gorm:"type:text;not null" json:"-"`                                             // 签名的清单 JSON
+	Signature   string                `gorm:"size:128;not null" json:"signature"`                                      // 清单的 Ed25519 签名（base64）
+	KeyID       string                `gorm:"size:16;not null" json:"key_id"`
+	Bundles     []*DistributionBundle `gorm:"foreignKey:ReleaseID;constraint:OnDelete:CASCADE" json:"bundles"`
+	CreatedBy   uint64                `json:"created_by"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// DistributionBundle 发布中一种语言的译文包（键 -> 译文的 JSON），原文及 gzip、brotli 压缩版本按内容哈希保存在文件存储中
+type DistributionBundle struct {
+	ID         uint64 `gorm:"primaryKey" json:"-"`
+	ReleaseID  uint64 `gorm:"not null;index" json:"-"`
+	Locale     string `gorm:"size:10;not null" json:"locale"`
+	Hash       string `gorm:"size:64;not null;index" json:"hash"` // 未压缩内容的 SHA-256
+	Keys       int    `json:"keys"`
+	Size       int64  `json:"size"`
+	GzipSize   int64  `json:"gzip_size"`
+	BrotliSize int64  `json:"brotli_size"`
+}
+
+// SnapshotEntry 快照中一个键在一种语言下的译文，译文内容保存在 SnapshotValue 中
+type SnapshotEntry struct {
+	SnapshotID   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	KeyName      string `gorm:"primaryKey;size:255"`
+	LanguageCode string `gorm:"primaryKey;size:10"`
+	ValueID      uint64 `gorm:"not null;index"`
+}
+
+// SnapshotValue 快照译文内容，按内容哈希去重，未修改的译文在各个快照之间只保存一份
+type SnapshotValue struct {
+	ID    uint64 `gorm:"primaryKey"`
+	Hash  string `gorm:"size:64;not null;uniqueIndex"` // 内容的 SHA-256
+	Value string `gorm:"type:text;not null"`
+}
+
+// 翻译分支状态
+const (
+	BranchStatusOpen   = "open"
+	BranchStatusMerged = "merged"
+)
+
+// TranslationBranch 翻译分支，保存相对主干的修改，读取时叠加在主干当前的译文上，合并前不影响主干
+type TranslationBranch struct {
+	ID          uint64     `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64     `gorm:"not null;uniqueIndex:idx_branch_name,priority:1" json:"project_id"`
+	Name        string     `gorm:"size:100;not null;uniqueIndex:idx_branch_name,priority:2" json:"name"` // 分支名，如 feature/checkout
+	Description string     `gorm:"size:500" json:"description"`
+	Status      string     `gorm:"size:20;not null;default:open" json:"status"` // open, merged
+	ChangeCount int        `gorm:"-" json:"change_count"`
+	CreatedBy   uint64     `json:"created_by"`
+	MergedBy    uint64     `json:"merged_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	MergedAt    *time.Time `json:"merged_at,omitempty"`
+}
+
+// BranchChange 分支对一个单元格（键 × 语言）的修改
+// BaseValue 和 BaseExists 是分支第一次修改该单元格时主干的状态，合并时据此判断主干是否也修改了该单元格
+type BranchChange struct {
+	BranchID     uint64    `gorm:"primaryKey;autoIncrement:false" json:"-"`
+	KeyName      string    `gorm:"primaryKey;size:255" json:"key_name"`
+	LanguageCode string    `gorm:"primaryKey;size:10" json:"language_code"`
+	Value        string    `gorm:"type:text" json:"value"`
+	Context      string    `gorm:"size:500" json:"context,omitempty"` // 分支上新增键的上下文说明
+	Deleted      bool      `gorm:"not null;default:false" json:"deleted"`
+	BaseValue    string    `gorm:"type:text" json:"base_value"`
+	BaseExists   bool      `gorm:"not null;default:false" json:"base_exists"`
+	UpdatedBy    uint64    `json:"updated_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TranslationLock 翻译锁定，锁定的单元格不能写入或删除，只有项目所有者可以解除
+// KeyName 为空时锁定整个语言，LanguageID 为0时锁定键的所有语言，两者都设置时只锁定一个单元格
+type TranslationLock struct {
+	ID           uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID    uint64    `gorm:"not null;uniqueIndex:idx_translation_lock,priority:1" json:"project_id"`
+	KeyName      string    `gorm:"size:255;not null;default:'';uniqueIndex:idx_translation_lock,priority:2" json:"key_name"`
+	LanguageID   uint64    `gorm:"not null;default:0;uniqueIndex:idx_translation_lock,priority:3" json:"language_id"`
+	LanguageCode string    `gorm:"-" json:"language_code,omitempty"`
+	Reason       string    `gorm:"size:500" json:"reason"`
+	CreatedBy    uint64    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ProjectBase 项目继承的基础项目，项目继承基础项目的所有键，本地翻译覆盖继承的翻译
+// 有多个基础项目时按 Position 从小到大优先，基础项目自己的基础项目同样被继承
+type ProjectBase struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID     uint64    `gorm:"not null;uniqueIndex:idx_project_base,priority:1" json:"project_id"`
+	BaseProjectID uint64    `gorm:"not null;uniqueIndex:idx_project_base,priority:2;index" json:"base_project_id"`
+	Position      int       `gorm:"not null;default:0" json:"position"`
+	CreatedBy     uint64    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ProjectTemplate 项目模板，创建项目时提供设置、初始键和默认成员
+// 语言是全局的，模板的语言决定初始键在哪些语言下创建
+type ProjectTemplate struct {
+	ID                uint64           `gorm:"primaryKey" json:"id"`
+	Name              string           `gorm:"size:100;not null;unique" json:"name"`
+	Description       string           `gorm:"size:500" json:"description"`
+	PlaceholderFormat string           `gorm:"size:20;not null;default:brace" json:"placeholder_format"`
+	ValueType         string           `gorm:"size:20;not null;default:plain" json:"value_type"`
+	Languages         []string         `gorm:"type:text;serializer:json" json:"languages"`          // 初始键创建的语言代码，为空时为所有启用的语言
+	BaseProjectIDs    []uint64         `gorm:"type:text;serializer:json" json:"base_project_ids"`   // 新项目继承的基础项目，按优先级排列
+	StarterKeys       []TemplateKey    `gorm:"type:mediumtext;serializer:json" json:"starter_keys"` // 初始键
+	DefaultMembers    []TemplateMember `gorm:"type:text;serializer:json" json:"default_members"`    // 默认成员
+	CreatedBy         uint64           `json:"created_by"`
+	UpdatedBy         uint64           `json:"updated_by"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// TemplateKey 模板的初始键
+type TemplateKey struct {
+	KeyName string            `json:"key_name"`
+	Context string            `json:"context,omitempty"`
+	Values  map[string]string `json:"values,omitempty"` // 语言代码 -> 初始译文
+}
+
+// TemplateMember 模板的默认成员
+type TemplateMember struct {
+	UserID uint64 `json:"user_id"`
+	Role   string `json:"role"` // owner, editor, viewer
+}
+
+// GitSync 项目的 git 仓库同步设置和最近一次同步的结果
+// LastRevision 是上次同步后仓库文件与之一致的项目修订号，作为三方合并的基准；仍有冲突时不前移，冲突在下一次同步时继续报告
+type GitSync struct {
+	ID              uint64            `gorm:"primaryKey" json:"id"`
+	ProjectID       uint64            `gorm:"not null;uniqueIndex" json:"project_id"`
+	RepositoryURL   string            `gorm:"size:500;not null" json:"repository_url"`               // SSH、HTTPS 地址或服务器上的本地路径
+	Branch          string            `gorm:"size:255;not null" json:"branch"`                       // 读取和提交导出的分支
+	PathTemplate    string            `gorm:"size:500;not null" json:"path_template"`                // 翻译文件路径模板，如 locales/{lang}.json
+	Format          string            `gorm:"size:20;not null" json:"format"`                        // 文件格式：json（嵌套）、json_flat
+	Languages       []string          `gorm:"type:text;serializer:json" json:"languages"`            // 同步的语言，为空时为所有启用的语言
+	LanguageMapping map[string]string `gorm:"type:text;serializer:json" json:"language_mapping"`     // 文件路径中的语言代码 -> 语言代码
+	IntervalMinutes int               `gorm:"not null;default:0" json:"interval_minutes"`            // 定时同步间隔（分钟），0 表示只手动同步
+	AutoExport      bool              `gorm:"not null;default:false" json:"auto_export"`             // 定时同步时同时把译文提交回仓库
+	LastRevision    uint64            `gorm:"not null;default:0" json:"last_revision"`               // 三方合并的基准修订号，0 表示还没有基准
+	LastCommit      string            `gorm:"size:64" json:"last_commit"`                            // 最近一次同步时分支的提交
+	LastSyncAt      *time.Time        `json:"last_sync_at"`                                          // 最近一次同步的时间
+	LastStatus      string            `gorm:"size:20" json:"last_status"`                            // 最近一次同步的结果：success, conflict, failed
+	LastError       string            `gorm:"size:1000" json:"last_error"`                           // 最近一次同步失败的原因
+	LastConflicts   []*SyncConflict   `gorm:"type:mediumtext;serializer:json" json:"last_conflicts"` // 最近一次同步的冲突单元格
+	CreatedBy       uint64            `json:"created_by"`
+	UpdatedBy       uint64            `json:"updated_by"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// ReleaseCriteria 项目的发布门禁条件，项目没有保存条件时使用默认条件（所有启用的目标语言 100% 完成且没有任何问题）
+type ReleaseCriteria struct {
+	ProjectID         uint64             `gorm:"primaryKey;autoIncrement:false" json:"project_id"`
+	RequiredLanguages []string           `gorm:"type:text;serializer:json" json:"required_languages"` // 必需的目标语言，为空时为所有启用的非默认语言
+	MinCompletion     float64            `gorm:"not null" json:"min_completion"`                      // 必需语言的最低完成度（百分比）
+	LanguageMinimums  map[string]float64 `gorm:"type:text;serializer:json" json:"language_minimums"`  // 按语言覆盖最低完成度
+	AllowOutdated     bool               `gorm:"not null;default:false" json:"allow_outdated"`        // 允许原文修改后未更新的译文
+	AllowUnapproved   bool               `gorm:"not null;default:false" json:"allow_unapproved"`      // 允许未经人工确认的机器翻译
+	AllowQAErrors     bool               `gorm:"not null;default:false" json:"allow_qa_errors"`       // 允许阻断级的质量问题
+	UpdatedBy         uint64             `json:"updated_by"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
 // ProjectMember 项目成员关联模型
 type ProjectMember struct {
 	ID        uint64         `gorm:"primaryKey" json:"id"`
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository 用户数据访问接口
 type UserRepository interface {
@@ -20,6 +23,7 @@ type ProjectRepository interface {
 	GetByIDs(ctx context.Context, ids []uint64) ([]*Project, error)
 	GetBySlug(ctx context.Context, slug string) (*Project, error)
 	GetAll(ctx context.Context, limit, offset int, keyword string) ([]*Project, int64, error)
+	FindDeletedByNameOrSlug(ctx context.Context, name, slug string) (*Project, error)
 	Create(ctx context.Context, project *Project) error
 	Update(ctx context.Context, project *Project) error
 	Delete(ctx context.Context, id uint64) error
@@ -40,11 +44,13 @@ type LanguageRepository interface {
 // TranslationRepository 翻译数据访问接口
 type TranslationRepository interface {
 	GetByID(ctx context.Context, id uint64) (*Translation, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]*Translation, error)
 	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Translation, int64, error)
 	GetByProjectAndLanguage(ctx context.Context, projectID, languageID uint64) ([]*Translation, error)
 	GetByProjectKeyLanguage(ctx context.Context, projectID uint64, keyName string, languageID uint64) (*Translation, error)
 	GetByProjectKeyLanguages(ctx context.Context, keys []TranslationKey) ([]*Translation, error)
 	GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]TranslationCell, int64, error)
+	GetKeyNames(ctx context.Context, projectIDs []uint64, keyword string) ([]string, error)
 	GetStats(ctx context.Context) (totalTranslations int, totalKeys int, err error)
 	Create(ctx context.Context, translation *Translation) error
 	CreateBatch(ctx context.Context, translations []*Translation) error
@@ -52,6 +58,83 @@ type TranslationRepository interface {
 	Update(ctx context.Context, translation *Translation) error
 	Delete(ctx context.Context, id uint64) error
 	DeleteBatch(ctx context.Context, ids []uint64) error
+
+	// 键树（按点号分隔的键名层级）
+	GetKeyLanguageStats(ctx context.Context, projectID uint64, prefix string) ([]KeyLanguageStat, error)
+	GetCellsByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]TranslationCell, error)
+	DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error)
+
+	// 增量同步
+	GetChangesSince(ctx context.Context, projectID, since uint64) (*TranslationChanges, error)
+	GetValuesAt(ctx context.Context, projectID, revision uint64) (map[string]map[string]string, error)
+}
+
+// KeyTagRepository 翻译键标签数据访问接口
+type KeyTagRepository interface {
+	GetByProjectAndKeys(ctx context.Context, projectID uint64, keyNames []string) ([]*KeyTag, error)
+	CreateBatch(ctx context.Context, tags []*KeyTag) error
+	DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) error
+}
+
+// TrashRepository 回收站数据访问接口（已软删除的翻译和项目）
+type TrashRepository interface {
+	ListDeletedKeys(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*TrashedKey, int64, error)
+	GetDeletedTranslations(ctx context.Context, projectID uint64, keyNames []string) ([]*Translation, error)
+	GetExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error)
+	RestoreTranslations(ctx context.Context, restores []TranslationRestore, displaceIDs []uint64) error
+	PurgeDeletedKeys(ctx context.Context, projectID uint64, keyNames []string) (int64, error)
+	ListDeletedProjects(ctx context.Context, keyword string, limit, offset int) ([]*TrashedProject, int64, error)
+	RestoreProject(ctx context.Context, id uint64) error
+	PurgeProject(ctx context.Context, id uint64) (*PurgedProjectFiles, error)
+	PurgeTranslationsDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	GetProjectIDsDeletedBefore(ctx context.Context, before time.Time) ([]uint64, error)
+}
+
+// PurgedProjectFiles 彻底删除项目后不再被引用、需要从文件存储中删除的文件
+type PurgedProjectFiles struct {
+	StorageKeys  []string // 截图原图和缩略图
+	BundleHashes []string // 分发译文包的内容哈希，每个哈希对应各编码的文件
+}
+
+// KeyUsageRepository 翻译键代码引用数据访问接口
+type KeyUsageRepository interface {
+	SaveScan(ctx context.Context, scan *KeyScan, references []*KeyReference) error
+	GetReferences(ctx context.Context, projectID uint64, keyName string) ([]*KeyReference, error)
+	GetUsage(ctx context.Context, projectID uint64, keyName string) (*KeyUsage, error)
+	GetLatestScans(ctx context.Context, projectID uint64) ([]*KeyScan, error)
+	GetFirstScanAt(ctx context.Context, projectID uint64) (*time.Time, error)
+	GetUnreferencedKeys(ctx context.Context, projectID uint64) ([]*UnusedKey, error)
+	MarkKeysDeprecated(ctx context.Context, projectID uint64, keyNames []string) (int64, error)
+	GetScannedProjectIDs(ctx context.Context) ([]uint64, error)
+}
+
+// TranslationMemoryRepository 翻译记忆数据访问接口
+type TranslationMemoryRepository interface {
+	FindProjectCandidates(ctx context.Context, query TranslationMemoryQuery) ([]*TranslationMemoryMatch, error)
+	FindImportedCandidates(ctx context.Context, query TranslationMemoryQuery) ([]*TranslationMemoryMatch, error)
+	ListProjectSegments(ctx context.Context, projectIDs []uint64) ([]*TranslationMemorySegmentRow, error)
+	ListImportedUnits(ctx context.Context, limit, offset int) ([]*TranslationMemoryUnit, error)
+	GetExistingUnitHashes(ctx context.Context, hashes []string) ([]string, error)
+	CreateUnits(ctx context.Context, units []*TranslationMemoryUnit) error
+}
+
+// GlossaryRepository 术语表数据访问接口
+type GlossaryRepository interface {
+	List(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*GlossaryEntry, int64, error)
+	ListForProject(ctx context.Context, projectID uint64) ([]*GlossaryEntry, error)
+	GetByID(ctx context.Context, id uint64) (*GlossaryEntry, error)
+	GetByTerm(ctx context.Context, projectID uint64, term string) (*GlossaryEntry, error)
+	Create(ctx context.Context, entry *GlossaryEntry) error
+	Update(ctx context.Context, entry *GlossaryEntry) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// KeyLanguageStat 单个键在单个语言下的翻译状态
+type KeyLanguageStat struct {
+	KeyName      string
+	LanguageCode string
+	Translated   bool
+	UpdatedAt    time.Time
 }
 
 // TranslationKey 用于批量查询的翻译键
@@ -63,8 +146,129 @@ type TranslationKey struct {
 
 // TranslationCell 翻译矩阵单元格数据
 type TranslationCell struct {
-	ID    uint64 `json:"id"`
-	Value string `json:"value"`
+	ID                uint64 `json:"id"`
+	Value             string `json:"value"`
+	MachineTranslated bool   `json:"machine_translated,omitempty"` // 未经人工确认的机器翻译草稿
+	InheritedFrom     uint64 `json:"inherited_from,omitempty"`     // 继承自的基础项目ID，本地翻译为0
+}
+
+// TranslationChanges 项目在某个修订号之后的翻译变更
+type TranslationChanges struct {
+	Revision uint64                                // 读取时项目的当前修订号
+	Changed  map[string]map[string]TranslationCell // 键名 -> 语言代码 -> 写入过的有效翻译
+	Deleted  []TranslationCellRef                  // 被删除或废弃且没有重新写入的单元格
+}
+
+// TranslationCellRef 翻译单元格位置
+type TranslationCellRef struct {
+	KeyName      string `json:"key_name"`
+	LanguageCode string `json:"language_code"`
+}
+
+// MachineTranslationUsageRepository 机器翻译用量数据访问接口
+type MachineTranslationUsageRepository interface {
+	GetCharacters(ctx context.Context, projectID uint64, period string) (int64, error)
+	AddCharacters(ctx context.Context, projectID uint64, period string, characters int64) error
+}
+
+// SnapshotRepository 版本快照数据访问接口
+type SnapshotRepository interface {
+	List(ctx context.Context, projectID uint64, limit, offset int) ([]*Snapshot, int64, error)
+	GetByID(ctx context.Context, id uint64) (*Snapshot, error)
+	GetByName(ctx context.Context, projectID uint64, name string) (*Snapshot, error)
+	Create(ctx context.Context, snapshot *Snapshot, values map[string]map[string]string) error
+	GetValues(ctx context.Context, snapshotID uint64, languageCode string) (map[string]map[string]string, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+// BranchRepository 翻译分支数据访问接口
+type BranchRepository interface {
+	List(ctx context.Context, projectID uint64) ([]*TranslationBranch, error)
+	GetByID(ctx context.Context, id uint64) (*TranslationBranch, error)
+	GetByName(ctx context.Context, projectID uint64, name string) (*TranslationBranch, error)
+	Create(ctx context.Context, branch *TranslationBranch) error
+	MarkMerged(ctx context.Context, id, userID uint64) error
+	Delete(ctx context.Context, id uint64) error
+
+	GetChanges(ctx context.Context, branchID uint64) ([]*BranchChange, error)
+	SaveChanges(ctx context.Context, changes []*BranchChange) error
+	DeleteChanges(ctx context.Context, branchID uint64, cells []*BranchChange) error
+}
+
+// TranslationLockRepository 翻译锁定数据访问接口
+type TranslationLockRepository interface {
+	ListByProject(ctx context.Context, projectID uint64) ([]*TranslationLock, error)
+	GetByID(ctx context.Context, id uint64) (*TranslationLock, error)
+	Create(ctx context.Context, lock *TranslationLock) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// ProjectBaseRepository 项目继承关系数据访问接口
+type ProjectBaseRepository interface {
+	ListByProject(ctx context.Context, projectID uint64) ([]*ProjectBase, error)
+	ListByBase(ctx context.Context, baseProjectID uint64) ([]*ProjectBase, error)
+	Replace(ctx context.Context, projectID uint64, bases []*ProjectBase) error
+}
+
+// ProjectTemplateRepository 项目模板数据访问接口
+type ProjectTemplateRepository interface {
+	List(ctx context.Context) ([]*ProjectTemplate, error)
+	GetByID(ctx context.Context, id uint64) (*ProjectTemplate, error)
+	Create(ctx context.Context, template *ProjectTemplate) error
+	Update(ctx context.Context, template *ProjectTemplate) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// GitSyncRepository git 仓库同步设置数据访问接口
+type GitSyncRepository interface {
+	GetByProject(ctx context.Context, projectID uint64) (*GitSync, error)
+	ListScheduled(ctx context.Context) ([]*GitSync, error)
+	Save(ctx context.Context, gitSync *GitSync) error
+	Delete(ctx context.Context, projectID uint64) error
+}
+
+// ReleaseCriteriaRepository 发布门禁条件数据访问接口
+type ReleaseCriteriaRepository interface {
+	Get(ctx context.Context, projectID uint64) (*ReleaseCriteria, error)
+	Save(ctx context.Context, criteria *ReleaseCriteria) error
+}
+
+// DistributionRepository 译文分发数据访问接口
+type DistributionRepository interface {
+	ListTokens(ctx context.Context, projectID uint64) ([]*DistributionToken, error)
+	GetTokenByID(ctx context.Context, id uint64) (*DistributionToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*DistributionToken, error)
+	CreateToken(ctx context.Context, token *DistributionToken) error
+	DeleteToken(ctx context.Context, id uint64) error
+
+	ListReleases(ctx context.Context, projectID uint64, environment string, limit, offset int) ([]*DistributionRelease, int64, error)
+	GetLatestRelease(ctx context.Context, projectID uint64, environment string) (*DistributionRelease, error)
+	CreateRelease(ctx context.Context, release *DistributionRelease, sign func(release *DistributionRelease) error) error // 在事务中分配版本号，sign 生成签名后写入
+	FindBundle(ctx context.Context, projectID uint64, environment, hash string) (*DistributionBundle, error)
+}
+
+// KeyMergeRepository 合并翻译键数据访问接口
+type KeyMergeRepository interface {
+	MergeKeys(ctx context.Context, merge KeyMerge) error
+}
+
+// KeyMerge 一次键合并的全部写入
+type KeyMerge struct {
+	ProjectID  uint64
+	TargetKey  string
+	SourceKeys []string
+	Fills      []*Translation // 目标键补充的译文
+	DeleteIDs  []uint64       // 被合并的键的翻译，移入回收站
+}
+
+// ScreenshotRepository 截图数据访问接口
+type ScreenshotRepository interface {
+	List(ctx context.Context, projectID uint64, keyName string, limit, offset int) ([]*Screenshot, int64, error)
+	ListByKeys(ctx context.Context, projectID uint64, keyNames []string) ([]*Screenshot, error)
+	GetByID(ctx context.Context, id uint64) (*Screenshot, error)
+	Create(ctx context.Context, screenshot *Screenshot) error
+	ReplaceRegions(ctx context.Context, screenshot *Screenshot, regions []*ScreenshotRegion) error
+	Delete(ctx context.Context, id uint64) error
 }
 
 // ProjectMemberRepository 项目成员数据访问接口
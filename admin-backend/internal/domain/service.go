@@ -29,6 +29,16 @@ type ProjectService interface {
 	GetAccessibleProjects(ctx context.Context, userID uint64, limit, offset int, keyword string) ([]*Project, int64, error)
 	Update(ctx context.Context, id uint64, params UpdateProjectParams, userID uint64) (*Project, error)
 	Delete(ctx context.Context, id uint64) error
+	Clone(ctx context.Context, params CloneProjectParams, userID uint64) (*CloneProjectResult, error)
+}
+
+// ProjectTemplateService 项目模板服务接口
+type ProjectTemplateService interface {
+	List(ctx context.Context) ([]*ProjectTemplate, error)
+	GetByID(ctx context.Context, id uint64) (*ProjectTemplate, error)
+	Create(ctx context.Context, params ProjectTemplateParams) (*ProjectTemplate, error)
+	Update(ctx context.Context, id uint64, params ProjectTemplateParams) (*ProjectTemplate, error)
+	Delete(ctx context.Context, id uint64) error
 }
 
 // LanguageService 语言服务接口
@@ -52,8 +62,20 @@ type TranslationService interface {
 	Update(ctx context.Context, id uint64, input TranslationInput, userID uint64) (*Translation, error)
 	Delete(ctx context.Context, id uint64) error
 	DeleteBatch(ctx context.Context, ids []uint64) error
-	Export(ctx context.Context, projectID uint64, format string) ([]byte, error)
-	Import(ctx context.Context, projectID uint64, data []byte, format string) error
+	Export(ctx context.Context, projectID uint64, format string, opts ExportOptions) ([]byte, []*UntranslatablePlaceholder, error)
+	ExportMatrix(ctx context.Context, projectID uint64, opts ExportOptions) (map[string]map[string]string, []*UntranslatablePlaceholder, error)
+	ExportDelta(ctx context.Context, projectID, since uint64, opts ExportOptions) (*TranslationDelta, []*UntranslatablePlaceholder, error)
+	Import(ctx context.Context, projectID uint64, data []byte, format string, opts ImportOptions) ([]*UntranslatablePlaceholder, error)
+	PushKeys(ctx context.Context, params PushKeysParams) (*PushKeysResult, error)
+
+	// 键树（按点号分隔的键名层级）
+	GetKeyTree(ctx context.Context, projectID uint64, path string, limit, offset int) (*KeyTree, error)
+	DeleteSubtree(ctx context.Context, projectID uint64, path string) (int64, error)
+	TagSubtree(ctx context.Context, projectID uint64, path string, tags []string, userID uint64) (int, error)
+	ExportSubtree(ctx context.Context, projectID uint64, path string, format string, opts ExportOptions) ([]byte, []*UntranslatablePlaceholder, error)
+
+	// 写入检查（字符串冻结和锁定）
+	CheckWrites(ctx context.Context, projectID uint64, writes []CellWrite) error
 }
 
 // DashboardService 仪表板服务接口
@@ -80,6 +102,176 @@ type ProjectMemberService interface {
 	GetMemberRole(ctx context.Context, userID, projectID uint64) (string, error)
 }
 
+// TrashService 回收站服务接口
+type TrashService interface {
+	ListDeletedKeys(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*TrashedKey, int64, error)
+	RestoreKeys(ctx context.Context, projectID uint64, params RestoreKeysParams) (*RestoreKeysResult, error)
+	PurgeKeys(ctx context.Context, projectID uint64, keyNames []string) (int64, error)
+	ListDeletedProjects(ctx context.Context, keyword string, limit, offset int) ([]*TrashedProject, int64, error)
+	RestoreProject(ctx context.Context, id uint64) (*Project, error)
+	PurgeProject(ctx context.Context, id uint64) error
+	PurgeExpired(ctx context.Context) (*TrashPurgeResult, error)
+}
+
+// KeyUsageService 翻译键代码引用服务接口
+type KeyUsageService interface {
+	RecordScan(ctx context.Context, params RecordScanParams) (*KeyScan, error)
+	GetKeyUsage(ctx context.Context, projectID uint64, keyName string) (*KeyUsageDetail, error)
+	ListUnusedKeys(ctx context.Context, projectID uint64) (*UnusedKeysReport, error)
+	DeprecateUnusedKeys(ctx context.Context, projectID uint64, graceDays int) ([]string, error)
+	DeprecateAllUnusedKeys(ctx context.Context) (int, error)
+}
+
+// KeyExtractionService 源码翻译键提取服务接口
+type KeyExtractionService interface {
+	ExtractFromArchive(ctx context.Context, params ExtractKeysParams) (*KeyExtractionResult, error)
+}
+
+// TranslationMemoryService 翻译记忆服务接口
+type TranslationMemoryService interface {
+	Lookup(ctx context.Context, params TranslationMemoryLookupParams) ([]*TranslationMemoryMatch, error)
+	Suggest(ctx context.Context, params TranslationMemorySuggestParams) (*TranslationMemorySuggestions, error)
+	ImportTMX(ctx context.Context, params ImportTMXParams) (*ImportTMXResult, error)
+	ExportTMX(ctx context.Context, params ExportTMXParams) ([]byte, error)
+	Leverage(ctx context.Context, params TranslationMemoryLeverageParams) (map[string]float64, error)
+}
+
+// SnapshotService 版本快照服务接口
+type SnapshotService interface {
+	Create(ctx context.Context, params CreateSnapshotParams) (*Snapshot, error)
+	List(ctx context.Context, projectID uint64, limit, offset int) ([]*Snapshot, int64, error)
+	Get(ctx context.Context, projectID, id uint64) (*Snapshot, error)
+	Export(ctx context.Context, projectID, id uint64, format string) ([]byte, error)
+	Diff(ctx context.Context, projectID, fromID, toID uint64) (*SnapshotDiff, error)
+	Delete(ctx context.Context, projectID, id uint64) error
+}
+
+// BranchService 翻译分支服务接口
+type BranchService interface {
+	List(ctx context.Context, projectID uint64) ([]*TranslationBranch, error)
+	Create(ctx context.Context, params CreateBranchParams) (*TranslationBranch, error)
+	Get(ctx context.Context, projectID, id uint64) (*BranchDetail, error)
+	Delete(ctx context.Context, projectID, id uint64) error
+	GetMatrix(ctx context.Context, projectID, id uint64) (map[string]map[string]string, error)
+	SetValues(ctx context.Context, params SetBranchValuesParams) (*SetBranchValuesResult, error)
+	Revert(ctx context.Context, projectID, id uint64, keyNames []string) (int, error)
+	Merge(ctx context.Context, params MergeBranchParams) (*BranchMergeResult, error)
+	PushKeys(ctx context.Context, params PushKeysParams) (*PushKeysResult, error)
+}
+
+// SyncService CLI 三方合并同步服务接口
+type SyncService interface {
+	Sync(ctx context.Context, params SyncParams) (*SyncResult, error)
+}
+
+// GitSyncService git 仓库同步服务接口
+type GitSyncService interface {
+	Get(ctx context.Context, projectID uint64) (*GitSync, error)
+	Save(ctx context.Context, params SaveGitSyncParams) (*GitSync, error)
+	Delete(ctx context.Context, projectID uint64) error
+	Import(ctx context.Context, projectID, userID uint64) (*GitSyncResult, error)
+	Export(ctx context.Context, projectID, userID uint64) (*GitSyncResult, error)
+	RunScheduled(ctx context.Context) (synced, failed int, err error)
+}
+
+// LockService 字符串冻结和翻译锁定服务接口
+type LockService interface {
+	List(ctx context.Context, projectID uint64) (*ProjectLocks, error)
+	Create(ctx context.Context, params CreateLockParams) (*TranslationLock, error)
+	Delete(ctx context.Context, projectID, id uint64) error
+	SetStringFreeze(ctx context.Context, projectID uint64, enabled bool, userID uint64) (*Project, error)
+}
+
+// InheritanceService 项目继承服务接口
+type InheritanceService interface {
+	Get(ctx context.Context, projectID uint64) (*ProjectInheritance, error)
+	SetBases(ctx context.Context, params SetBaseProjectsParams) (*ProjectInheritance, error)
+}
+
+// ReleaseGateService 发布门禁服务接口
+type ReleaseGateService interface {
+	GetCriteria(ctx context.Context, projectID uint64) (*ReleaseCriteria, error)
+	UpdateCriteria(ctx context.Context, params UpdateReleaseCriteriaParams) (*ReleaseCriteria, error)
+	Check(ctx context.Context, projectID uint64) (*ReleaseReport, error)
+}
+
+// DistributionService 译文分发服务接口
+// 管理接口供登录用户使用，Get 开头的方法供移动应用以分发令牌公开访问
+type DistributionService interface {
+	ListTokens(ctx context.Context, projectID uint64) ([]*DistributionToken, error)
+	CreateToken(ctx context.Context, params CreateDistributionTokenParams) (*DistributionToken, error)
+	DeleteToken(ctx context.Context, projectID, id uint64) error
+	ListReleases(ctx context.Context, projectID uint64, environment string, limit, offset int) ([]*DistributionRelease, int64, error)
+	Publish(ctx context.Context, params PublishDistributionParams) (*DistributionRelease, error)
+
+	GetPublicKey() (*DistributionPublicKey, error)
+	GetManifest(ctx context.Context, token string) (*DistributionFile, error)
+	GetLocaleBundle(ctx context.Context, token, locale, encoding string) (*DistributionFile, error)
+	GetBundle(ctx context.Context, token, hash, encoding string) (*DistributionFile, error)
+}
+
+// ConsistencyService 翻译一致性检查服务接口
+type ConsistencyService interface {
+	Analyze(ctx context.Context, projectID uint64, inconsistentOnly bool) (*ConsistencyReport, error)
+	MergeKeys(ctx context.Context, params MergeKeysParams) (*MergeKeysResult, error)
+	Harmonize(ctx context.Context, params HarmonizeParams) (*HarmonizeResult, error)
+}
+
+// StatisticsService 字数统计和费用估算服务接口
+type StatisticsService interface {
+	GetProjectStatistics(ctx context.Context, params ProjectStatisticsParams) (*ProjectStatistics, error)
+}
+
+// GlossaryService 术语表服务接口
+type GlossaryService interface {
+	List(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*GlossaryEntry, int64, error)
+	Get(ctx context.Context, projectID, id uint64) (*GlossaryEntry, error)
+	Create(ctx context.Context, params CreateGlossaryEntryParams) (*GlossaryEntry, error)
+	Update(ctx context.Context, id uint64, params UpdateGlossaryEntryParams) (*GlossaryEntry, error)
+	Delete(ctx context.Context, projectID, id uint64) error
+	Check(ctx context.Context, params GlossaryCheckParams) (*GlossaryCheckReport, error)
+	ImportTBX(ctx context.Context, params ImportTBXParams) (*ImportTBXResult, error)
+	ExportTBX(ctx context.Context, projectID uint64) ([]byte, error)
+}
+
+// MachineTranslator 机器翻译提供方接口
+// texts 中的占位符和 HTML 标签已替换为 <x id="n"/> 标记并做了 XML 转义，返回的译文须与 texts 一一对应并保留这些标记
+type MachineTranslator interface {
+	Name() string
+	Translate(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error)
+}
+
+// FileStorage 文件存储接口，截图等二进制文件通过它读写，key 为以 / 分隔的相对路径
+type FileStorage interface {
+	Name() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// MachineTranslationService 机器翻译服务接口
+type MachineTranslationService interface {
+	PreTranslate(ctx context.Context, params PreTranslateParams) (*PreTranslateResult, error)
+	GetUsage(ctx context.Context, projectID uint64) (*MachineTranslationUsageReport, error)
+}
+
+// InContextService 页内编辑服务接口
+type InContextService interface {
+	IssueEditorToken(ctx context.Context, projectID, userID uint64) (*EditorToken, error)
+	Resolve(ctx context.Context, token string, text string) (*InContextResolution, error)
+}
+
+// ScreenshotService 截图服务接口
+type ScreenshotService interface {
+	Upload(ctx context.Context, params UploadScreenshotParams) (*Screenshot, error)
+	List(ctx context.Context, projectID uint64, keyName string, limit, offset int) ([]*Screenshot, int64, error)
+	Get(ctx context.Context, projectID, id uint64) (*Screenshot, error)
+	UpdateRegions(ctx context.Context, projectID, id uint64, regions []ScreenshotRegionInput) (*Screenshot, error)
+	Delete(ctx context.Context, projectID, id uint64) error
+	GetImage(ctx context.Context, projectID, id uint64, thumbnail bool) (*ScreenshotImage, error)
+	ListByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string][]*Screenshot, error)
+}
+
 // InvitationService 邀请码服务接口
 type InvitationService interface {
 	CreateInvitation(ctx context.Context, inviterID uint64, params CreateInvitationParams) (*Invitation, string, error)
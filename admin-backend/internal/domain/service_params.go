@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // ========== User Service Params ==========
 
 // LoginParams 登录参数
@@ -41,17 +43,77 @@ type ChangePasswordParams struct {
 
 // CreateProjectParams 创建项目参数
 type CreateProjectParams struct {
-	Name        string
-	Description string
+	Name              string
+	Description       string
+	PlaceholderFormat string // 为空时使用模板的设置，没有模板时使用 brace
+	ValueType         string // 为空时使用模板的设置，没有模板时使用 plain
+	TemplateID        uint64 // 不为0时按模板设置基础项目、创建初始键和默认成员
+}
+
+// 克隆项目时复制键的方式
+const (
+	CloneKeysNone   = ""       // 不复制键
+	CloneKeysOnly   = "keys"   // 只复制键名和上下文，译文为空
+	CloneKeysValues = "values" // 复制键和译文
+)
+
+// CloneProjectParams 克隆项目参数
+type CloneProjectParams struct {
+	SourceID        uint64
+	Name            string
+	Description     string // 为空时使用源项目的描述
+	Keys            string // CloneKeysNone、CloneKeysOnly 或 CloneKeysValues
+	IncludeMembers  bool   // 复制成员及其角色
+	IncludeSettings bool   // 复制占位符语法、值类型、基础项目和发布门禁条件；复制译文时总是复制占位符语法和值类型
+}
+
+// CloneProjectResult 克隆项目结果
+type CloneProjectResult struct {
+	Project      *Project `json:"project"`
+	Keys         int      `json:"keys"`
+	Translations int      `json:"translations"`
+	Members      int      `json:"members"`
+}
+
+// ProjectTemplateParams 创建或整体替换项目模板参数
+type ProjectTemplateParams struct {
+	Name              string
+	Description       string
+	PlaceholderFormat string // 为空时使用 brace
+	ValueType         string // 为空时使用 plain
+	Languages         []string
+	BaseProjectIDs    []uint64
+	StarterKeys       []TemplateKey
+	DefaultMembers    []TemplateMember
+	UserID            uint64
 }
 
 // UpdateProjectParams 更新项目参数
 type UpdateProjectParams struct {
-	Name        string
-	Description string
-	Status      string
+	Name              string
+	Description       string
+	Status            string
+	PlaceholderFormat string
+	ValueType         string
+	StringFreeze      *bool // 为 nil 时不修改
 }
 
+// 占位符语法
+const (
+	PlaceholderFormatBrace       = "brace"        // {name}
+	PlaceholderFormatDoubleBrace = "double_brace" // {{name}}
+	PlaceholderFormatAndroid     = "android"      // %1$s
+	PlaceholderFormatIOS         = "ios"          // %@、%1$@
+	PlaceholderFormatGettext     = "gettext"      // %(name)s
+)
+
+// 翻译值类型
+const (
+	ValueTypePlain      = "plain"       // 纯文本，输出时按文本转义
+	ValueTypeHTMLSubset = "html_subset" // 允许有限的 HTML 标签和属性
+	ValueTypeMarkdown   = "markdown"    // Markdown，不允许内嵌 HTML
+)
+
 // ========== Language Service Params ==========
 
 // CreateLanguageParams 创建语言参数
@@ -80,6 +142,1017 @@ type BatchTranslationParams struct {
 	Translations map[string]string // language_code -> value
 }
 
+// KeyTreeSeparator 键名层级分隔符
+const KeyTreeSeparator = "."
+
+// KeyTree 键树中某一层级的视图
+type KeyTree struct {
+	Path       string                        `json:"path"`
+	Folders    []*KeyTreeFolder              `json:"folders"`
+	Keys       []*KeyTreeKey                 `json:"keys"`
+	TotalKeys  int64                         `json:"total_keys"` // 当前层级直接包含的键数
+	Completion map[string]LanguageCompletion `json:"completion"` // 当前路径下所有键的各语言完成度
+}
+
+// KeyTreeFolder 键树文件夹
+type KeyTreeFolder struct {
+	Name       string                        `json:"name"`
+	Path       string                        `json:"path"`
+	KeyCount   int                           `json:"key_count"` // 文件夹下（含子文件夹）的键数
+	Completion map[string]LanguageCompletion `json:"completion"`
+}
+
+// KeyTreeKey 键树中的叶子键
+type KeyTreeKey struct {
+	Name         string                     `json:"name"`
+	KeyName      string                     `json:"key_name"`
+	Tags         []string                   `json:"tags"`
+	Translations map[string]TranslationCell `json:"translations"`
+}
+
+// LanguageCompletion 语言完成度
+type LanguageCompletion struct {
+	Translated int     `json:"translated"`
+	Total      int     `json:"total"`
+	Percent    float64 `json:"percent"`
+}
+
+// ExportOptions 导出选项
+type ExportOptions struct {
+	Placeholders string               // 目标占位符语法，为空时保持项目的规范语法
+	Pseudo       *PseudoLocaleOptions // 不为 nil 时附加由默认语言生成的伪本地化语言
+	InContext    bool                 // 用零宽标记包裹每个值，供页内编辑定位键
+	Branch       string               // 分支名，不为空时导出分支的译文（主干叠加分支的修改）
+}
+
+// TranslationDelta 增量同步结果，客户端保存 Revision 作为下一次同步的 since
+type TranslationDelta struct {
+	Since    uint64                       `json:"since"`
+	Revision uint64                       `json:"revision"`
+	Changed  map[string]map[string]string `json:"changed"` // 键名 -> 语言代码 -> 新的译文
+	Deleted  []TranslationCellRef         `json:"deleted"` // 应从本地删除的单元格
+}
+
+// 伪本地化语言代码，只在导出时生成，不保存到数据库
+const (
+	PseudoLocale    = "en-XA" // 带重音字母的伪本地化语言
+	PseudoLocaleRTL = "ar-XB" // 从右到左的伪本地化语言
+
+	DefaultPseudoExpansion = 30  // 默认长度扩展百分比
+	MaxPseudoExpansion     = 300 // 最大长度扩展百分比
+)
+
+// PseudoLocaleOptions 伪本地化选项
+type PseudoLocaleOptions struct {
+	Expansion int  // 长度扩展百分比，0 表示不扩展
+	Brackets  bool // 用 [ ] 包裹文本
+	RTL       bool // 模拟从右到左的语言
+}
+
+// Locale 伪本地化语言代码
+func (o *PseudoLocaleOptions) Locale() string {
+	if o.RTL {
+		return PseudoLocaleRTL
+	}
+	return PseudoLocale
+}
+
+// IsPseudoLocale 判断是否为伪本地化语言代码
+func IsPseudoLocale(code string) bool {
+	return code == PseudoLocale || code == PseudoLocaleRTL
+}
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	Placeholders string // 导入数据的占位符语法，为空时视为项目的规范语法
+}
+
+// UntranslatablePlaceholder 无法在语法之间无损转换的占位符
+type UntranslatablePlaceholder struct {
+	KeyName     string `json:"key_name"`
+	Language    string `json:"language"`
+	Placeholder string `json:"placeholder"`
+}
+
+// PushKeysParams 推送新键参数，已存在的键不做修改
+type PushKeysParams struct {
+	ProjectID    uint64
+	Keys         []string
+	Defaults     map[string]string            // 默认语言的键值对（兼容旧版CLI）
+	Translations map[string]map[string]string // 语言代码 -> 键值对，提供时忽略 Defaults
+	Branch       string                       // 分支名，不为空时把新键添加到分支
+	UserID       uint64
+}
+
+// PushKeysResult 推送新键结果
+type PushKeysResult struct {
+	Added   []string `json:"added"`
+	Existed []string `json:"existed"`
+	Failed  []string `json:"failed"`
+}
+
+// ========== Trash Service Params ==========
+
+// 回收站恢复时的冲突处理策略
+const (
+	RestoreStrategySkip      = "skip"      // 跳过与现有键冲突的键
+	RestoreStrategyOverwrite = "overwrite" // 用回收站中的翻译覆盖现有翻译，现有翻译移入回收站
+	RestoreStrategyRename    = "rename"    // 以新键名恢复
+)
+
+// RestoredKeySuffix 以新键名恢复时追加的后缀
+const RestoredKeySuffix = "_restored"
+
+// TrashedKey 回收站中的翻译键
+type TrashedKey struct {
+	KeyName          string    `json:"key_name"`
+	Languages        []string  `json:"languages"`
+	TranslationCount int       `json:"translation_count"`
+	DeletedAt        time.Time `json:"deleted_at"`
+	Conflict         bool      `json:"conflict"` // 项目中是否已存在同名的键
+}
+
+// TrashedProject 回收站中的项目
+type TrashedProject struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// RestoreKeysParams 恢复翻译键参数
+type RestoreKeysParams struct {
+	KeyNames []string
+	Strategy string
+}
+
+// RestoreKeysResult 恢复翻译键结果
+type RestoreKeysResult struct {
+	Restored     []string          `json:"restored"`
+	Renamed      map[string]string `json:"renamed"` // 原键名 -> 新键名
+	Skipped      []string          `json:"skipped"`
+	Translations int               `json:"translations"` // 恢复的翻译条数
+}
+
+// TranslationRestore 单条翻译的恢复操作
+type TranslationRestore struct {
+	ID      uint64
+	KeyName string // 恢复后的键名
+}
+
+// TrashPurgeResult 回收站清理结果
+type TrashPurgeResult struct {
+	Translations int64 `json:"translations"`
+	Projects     int64 `json:"projects"`
+}
+
+// ========== Key Usage Service Params ==========
+
+// KeyReferenceInput 单个代码引用位置
+type KeyReferenceInput struct {
+	FilePath string
+	Line     int
+}
+
+// RecordScanParams 上报代码扫描结果参数
+// References 为本次扫描中每个键的全部引用位置，未出现的键视为在该仓库分支中未被使用
+type RecordScanParams struct {
+	ProjectID  uint64
+	Repository string
+	Branch     string
+	Commit     string
+	References map[string][]KeyReferenceInput
+}
+
+// KeyUsageDetail 翻译键的引用详情
+type KeyUsageDetail struct {
+	KeyName     string          `json:"key_name"`
+	References  []*KeyReference `json:"references"`
+	LastSeenAt  *time.Time      `json:"last_seen_at"`
+	Screenshots []*Screenshot   `json:"screenshots"` // 标注了该键的截图
+}
+
+// UnusedKey 未被代码引用的翻译键
+type UnusedKey struct {
+	KeyName     string     `json:"key_name"`
+	CreatedAt   time.Time  `json:"created_at"`   // 键的创建时间
+	LastSeenAt  *time.Time `json:"last_seen_at"` // 最近一次被引用的时间，从未被引用时为空
+	UnusedSince time.Time  `json:"unused_since"` // 开始未被引用的时间，从未被引用时取键创建和项目首次扫描中较晚者
+}
+
+// UnusedKeysReport 未使用键报告
+type UnusedKeysReport struct {
+	Scans []*KeyScan   `json:"scans"` // 参与判断的各仓库分支最近一次扫描
+	Keys  []*UnusedKey `json:"keys"`
+}
+
+// ========== Key Extraction Service Params ==========
+
+// 键提取器类型
+const (
+	KeyExtractorGo      = "go"      // Go 语法树，匹配 i18n.T("key") 等函数调用
+	KeyExtractorRegex   = "regex"   // 自定义正则，适用于 PHP 等其他语言
+	KeyExtractorI18next = "i18next" // i18next 的 t() 调用和 Trans 组件
+)
+
+// KeyExtractorConfig 键提取器配置
+type KeyExtractorConfig struct {
+	Type       string   `json:"type"`
+	Extensions []string `json:"extensions"` // 适用的文件扩展名，如 .go、.tsx
+	Functions  []string `json:"functions"`  // go 提取器匹配的函数，如 i18n.T
+	Patterns   []string `json:"patterns"`   // regex 提取器的正则，键名取命名捕获组 key 或第一个捕获组
+}
+
+// ExtractKeysParams 从源码压缩包提取翻译键参数
+type ExtractKeysParams struct {
+	ProjectID  uint64
+	Archive    []byte
+	Extractors []KeyExtractorConfig // 为空时使用默认提取器
+	Push       bool                 // 为 true 时将代码中新出现的键推送到项目
+	UserID     uint64
+}
+
+// KeyOccurrence 翻译键在源码中的位置
+type KeyOccurrence struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// ExtractedKeyInfo 提取到的翻译键及其出现位置
+type ExtractedKeyInfo struct {
+	KeyName     string          `json:"key_name"`
+	Occurrences []KeyOccurrence `json:"occurrences"`
+}
+
+// KeyExtractionResult 键提取结果，与项目现有键对比
+type KeyExtractionResult struct {
+	FilesScanned int                 `json:"files_scanned"`
+	FilesSkipped int                 `json:"files_skipped"` // 过大而跳过的文件数
+	Keys         []*ExtractedKeyInfo `json:"keys"`
+	NewKeys      []string            `json:"new_keys"`     // 代码中存在、项目中不存在
+	MissingKeys  []string            `json:"missing_keys"` // 项目中存在、代码中未出现
+	ParseErrors  []string            `json:"parse_errors"` // 无法解析的文件
+	Pushed       *PushKeysResult     `json:"pushed,omitempty"`
+}
+
+// ========== Translation Memory Service Params ==========
+
+// 翻译记忆来源
+const (
+	TranslationMemoryOriginProject = "project" // 项目中的翻译
+	TranslationMemoryOriginTMX     = "tmx"     // 从 TMX 导入
+)
+
+// TranslationMemoryLookupParams 翻译记忆查询参数
+type TranslationMemoryLookupParams struct {
+	UserID         uint64 // 只返回该用户有权查看的项目中的翻译
+	Source         string
+	SourceLanguage string
+	TargetLanguage string
+	MinScore       float64 // 模糊匹配的最低相似度，0~1
+	Limit          int
+}
+
+// TranslationMemorySuggestParams 编辑翻译时获取翻译记忆建议的参数
+type TranslationMemorySuggestParams struct {
+	UserID         uint64
+	ProjectID      uint64
+	KeyName        string
+	TargetLanguage string
+	MinScore       float64
+	Limit          int
+}
+
+// TranslationMemoryMatch 翻译记忆匹配结果
+type TranslationMemoryMatch struct {
+	SourceText   string    `json:"source_text"`
+	TargetText   string    `json:"target_text"`
+	Score        float64   `json:"score"` // 相似度，1 为完全匹配
+	Exact        bool      `json:"exact"` // 原文完全相同
+	Origin       string    `json:"origin"`
+	ProjectID    uint64    `json:"project_id,omitempty"`
+	ProjectName  string    `json:"project_name,omitempty"`
+	KeyName      string    `json:"key_name,omitempty"`
+	ImportedFrom string    `json:"imported_from,omitempty"` // TMX 导入来源
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TranslationMemorySuggestions 翻译记忆建议
+type TranslationMemorySuggestions struct {
+	Source         string                    `json:"source"` // 键在默认语言下的文本
+	SourceLanguage string                    `json:"source_language"`
+	Matches        []*TranslationMemoryMatch `json:"matches"`
+}
+
+// TranslationMemoryQuery 翻译记忆候选查询条件
+type TranslationMemoryQuery struct {
+	ProjectIDs     []uint64 // 可查询的项目，为 nil 时不限制
+	SourceLanguage string
+	TargetLanguage string
+	Exact          string   // 非空时只查找原文相同的记录
+	ExactHash      string   // Exact 规范化后的哈希，用于查找导入的记忆
+	MinLength      int      // 原文最小字符数
+	MaxLength      int      // 原文最大字符数
+	Terms          []string // 原文至少包含其中一个词
+	Limit          int
+}
+
+// TranslationMemorySegmentRow 项目中某个键在某种语言下的翻译，用于导出翻译记忆
+type TranslationMemorySegmentRow struct {
+	ProjectID   uint64
+	ProjectName string
+	KeyName     string
+	Language    string
+	Value       string
+}
+
+// TranslationMemoryLeverageParams 批量查询原文在翻译记忆中的最高匹配度的参数
+type TranslationMemoryLeverageParams struct {
+	UserID         uint64
+	ProjectID      uint64
+	SourceLanguage string
+	TargetLanguage string
+	Sources        map[string]string // 键名 -> 原文，匹配结果不包含该键自身
+	MinScore       float64
+}
+
+// ImportTMXParams 导入 TMX 参数
+type ImportTMXParams struct {
+	Data   []byte
+	Origin string // 来源，通常为文件名
+	UserID uint64
+}
+
+// ImportTMXResult 导入 TMX 结果
+type ImportTMXResult struct {
+	Units            int      `json:"units"`             // 文件中的翻译单元数
+	Imported         int      `json:"imported"`          // 新导入的单元数
+	Duplicates       int      `json:"duplicates"`        // 已存在而跳过的单元数
+	Skipped          int      `json:"skipped"`           // 少于两种已知语言而跳过的单元数
+	UnknownLanguages []string `json:"unknown_languages"` // 系统中不存在的语言
+}
+
+// ExportTMXParams 导出 TMX 参数
+type ExportTMXParams struct {
+	UserID          uint64
+	ProjectID       uint64 // 为0时导出用户有权查看的所有项目
+	SourceLanguage  string // 为空时使用默认语言
+	IncludeImported bool   // 是否包含从 TMX 导入的记忆
+}
+
+// ========== Glossary Service Params ==========
+
+// 术语译法状态
+const (
+	GlossaryStatusApproved  = "approved"  // 批准的译法
+	GlossaryStatusForbidden = "forbidden" // 禁用的译法
+)
+
+// GlossaryTranslationInput 术语译法输入
+type GlossaryTranslationInput struct {
+	Language  string `json:"language" binding:"required"`
+	Value     string `json:"value" binding:"required,max=255"`
+	Forbidden bool   `json:"forbidden"` // 是否为禁用的译法
+}
+
+// CreateGlossaryEntryParams 创建术语参数
+type CreateGlossaryEntryParams struct {
+	ProjectID      uint64 // 0 为全局术语
+	Term           string
+	Description    string
+	CaseSensitive  bool
+	DoNotTranslate bool
+	Translations   []GlossaryTranslationInput
+	UserID         uint64
+}
+
+// UpdateGlossaryEntryParams 更新术语参数，Translations 会整体替换已有译法
+type UpdateGlossaryEntryParams struct {
+	ProjectID      uint64
+	Term           string
+	Description    string
+	CaseSensitive  bool
+	DoNotTranslate bool
+	Translations   []GlossaryTranslationInput
+	UserID         uint64
+}
+
+// GlossaryCheckParams 术语检查参数
+type GlossaryCheckParams struct {
+	ProjectID uint64
+	Languages []string // 只检查这些语言，为空时检查所有非默认语言
+	KeyNames  []string // 只检查这些键，为空时检查所有键
+}
+
+// GlossaryViolation 译文违反术语表的问题
+type GlossaryViolation struct {
+	KeyName  string   `json:"key_name"`
+	Language string   `json:"language"`
+	Term     string   `json:"term"`
+	Type     string   `json:"type"`            // missing_term, forbidden_term, do_not_translate
+	Found    string   `json:"found,omitempty"` // 译文中出现的禁用译法
+	Expected []string `json:"expected"`        // 期望的译法
+	Source   string   `json:"source"`          // 默认语言下的原文
+	Value    string   `json:"value"`           // 译文
+}
+
+// GlossaryCheckReport 术语检查报告
+type GlossaryCheckReport struct {
+	SourceLanguage string               `json:"source_language"`
+	Checked        int                  `json:"checked"` // 检查的译文数
+	Violations     []*GlossaryViolation `json:"violations"`
+}
+
+// ImportTBXParams 导入 TBX 参数
+type ImportTBXParams struct {
+	ProjectID uint64 // 0 为全局术语
+	Data      []byte
+	UserID    uint64
+}
+
+// ImportTBXResult 导入 TBX 结果
+type ImportTBXResult struct {
+	Entries          int      `json:"entries"` // 文件中的术语条目数
+	Created          int      `json:"created"`
+	Updated          int      `json:"updated"`
+	Skipped          int      `json:"skipped"`           // 缺少默认语言术语而跳过的条目数
+	UnknownLanguages []string `json:"unknown_languages"` // 系统中不存在的语言
+}
+
+// ========== Machine Translation Service Params ==========
+
+// PreTranslateParams 机器预翻译参数
+type PreTranslateParams struct {
+	ProjectID uint64
+	Languages []string // 目标语言代码
+	KeyNames  []string // 只处理这些键，为空时处理所有键
+	UserID    uint64
+}
+
+// PreTranslateFailure 未能机器翻译的单元格
+type PreTranslateFailure struct {
+	KeyName  string `json:"key_name"`
+	Language string `json:"language"`
+	Reason   string `json:"reason"`
+}
+
+// PreTranslateResult 机器预翻译结果
+type PreTranslateResult struct {
+	Provider      string                 `json:"provider"`
+	Translated    int                    `json:"translated"` // 填充的空单元格数
+	Characters    int64                  `json:"characters"` // 本次消耗的字符数
+	Failed        []*PreTranslateFailure `json:"failed"`
+	QuotaExceeded bool                   `json:"quota_exceeded"`           // 因额度用完而提前停止
+	ProviderError string                 `json:"provider_error,omitempty"` // 提供方请求失败而提前停止
+}
+
+// MachineTranslationUsageReport 项目本月的机器翻译用量
+type MachineTranslationUsageReport struct {
+	Provider   string `json:"provider"` // 为空表示未启用机器翻译
+	Period     string `json:"period"`
+	Characters int64  `json:"characters"`
+	Quota      int64  `json:"quota"` // 0 表示不限制
+}
+
+// ========== In-Context Service Params ==========
+
+// EditorToken 页内编辑令牌，只能用于解析绑定项目的标记
+type EditorToken struct {
+	Token     string    `json:"token"`
+	ProjectID uint64    `json:"project_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// InContextKey 从标记中解析出的键及其所有语言的翻译
+type InContextKey struct {
+	KeyName      string                     `json:"key_name"`
+	Translations map[string]TranslationCell `json:"translations"` // 语言代码 -> 单元格，键已删除时为空
+	Screenshots  []*Screenshot              `json:"screenshots"`  // 标注了该键的截图
+}
+
+// InContextResolution 页内编辑标记解析结果
+type InContextResolution struct {
+	ProjectID uint64          `json:"project_id"`
+	CanEdit   bool            `json:"can_edit"` // 令牌所属用户当前是否有编辑权限
+	Keys      []*InContextKey `json:"keys"`
+}
+
+// ========== Screenshot Service Params ==========
+
+// UploadScreenshotParams 上传截图参数
+type UploadScreenshotParams struct {
+	ProjectID uint64
+	Name      string // 为空时使用文件名
+	Data      []byte
+	UserID    uint64
+}
+
+// ScreenshotRegionInput 截图区域输入，坐标以原图像素为单位
+type ScreenshotRegionInput struct {
+	X        int      `json:"x" binding:"min=0"`
+	Y        int      `json:"y" binding:"min=0"`
+	Width    int      `json:"width" binding:"min=1"`
+	Height   int      `json:"height" binding:"min=1"`
+	KeyNames []string `json:"key_names" binding:"required,min=1,dive,required,max=255"`
+}
+
+// ScreenshotImage 截图的图片内容
+type ScreenshotImage struct {
+	Data        []byte
+	ContentType string
+}
+
+// ========== Statistics Service Params ==========
+
+// ProjectStatisticsParams 项目字数统计参数
+type ProjectStatisticsParams struct {
+	ProjectID    uint64
+	Since        *time.Time // 不为 nil 时统计该时间之后新增或修改的原文
+	SinceVersion string     // 不为空时统计相对该版本快照新增或修改的原文，不能与 Since 同时使用
+	Cost         bool       // 是否估算翻译费用，需要查询翻译记忆
+	UserID       uint64     // 估算费用时按用户可查看的项目匹配翻译记忆
+}
+
+// StatisticsCount 键数及其原文的词数和字符数
+type StatisticsCount struct {
+	Keys       int `json:"keys"`
+	Words      int `json:"words"`
+	Characters int `json:"characters"`
+}
+
+// ProjectStatistics 项目字数统计
+// 只统计默认语言下有非空原文的有效键，各语言的词数和字符数均按原文计算
+type ProjectStatistics struct {
+	ProjectID      uint64                `json:"project_id"`
+	SourceLanguage string                `json:"source_language"`
+	Source         StatisticsCount       `json:"source"`
+	Since          *time.Time            `json:"since,omitempty"`
+	SinceVersion   string                `json:"since_version,omitempty"`
+	NewSource      *StatisticsCount      `json:"new_source,omitempty"` // since 或 since_version 之后新增或修改的原文
+	Languages      []*LanguageStatistics `json:"languages"`
+	Cost           *CostSummary          `json:"cost,omitempty"`
+}
+
+// LanguageStatistics 单个目标语言的统计
+// 原文在译文最后一次修改之后被修改的为过期翻译
+type LanguageStatistics struct {
+	Language     string           `json:"language"`
+	Total        StatisticsCount  `json:"total"`
+	Translated   StatisticsCount  `json:"translated"`
+	Untranslated StatisticsCount  `json:"untranslated"`
+	Outdated     StatisticsCount  `json:"outdated"`
+	New          *StatisticsCount `json:"new,omitempty"` // since 之后新增或修改、仍需翻译的原文
+	Cost         *CostEstimate    `json:"cost,omitempty"`
+}
+
+// CostEstimate 单个目标语言的翻译费用估算，未翻译和过期的原文按翻译记忆匹配度分档计费
+type CostEstimate struct {
+	RatePerWord   float64     `json:"rate_per_word"`
+	Words         int         `json:"words"`          // 需翻译的原文词数
+	WeightedWords float64     `json:"weighted_words"` // 按匹配度折算后的计费词数
+	Amount        float64     `json:"amount"`
+	Bands         []*CostBand `json:"bands"`
+}
+
+// CostBand 翻译记忆匹配度分档
+type CostBand struct {
+	MinMatch int     `json:"min_match"` // 匹配度下限（百分比），0 为无匹配
+	Factor   float64 `json:"factor"`    // 按单价计费的比例
+	Keys     int     `json:"keys"`
+	Words    int     `json:"words"`
+	Amount   float64 `json:"amount"`
+}
+
+// CostSummary 所有目标语言的费用合计
+type CostSummary struct {
+	Currency      string  `json:"currency"`
+	Words         int     `json:"words"`
+	WeightedWords float64 `json:"weighted_words"`
+	Amount        float64 `json:"amount"`
+	Partial       bool    `json:"partial"` // 需翻译的原文过多，部分原文未做翻译记忆分析而按全价计算
+}
+
+// ========== Snapshot Service Params ==========
+
+// CreateSnapshotParams 创建版本快照参数
+type CreateSnapshotParams struct {
+	ProjectID   uint64
+	Name        string
+	Description string
+	UserID      uint64
+}
+
+// SnapshotDiff 两个版本之间的译文差异，To 为 0 时与当前数据比较
+type SnapshotDiff struct {
+	From        *Snapshot                `json:"from"`
+	To          *Snapshot                `json:"to"` // 与当前数据比较时为 null
+	AddedKeys   []string                 `json:"added_keys"`
+	RemovedKeys []string                 `json:"removed_keys"`
+	Languages   map[string]*LanguageDiff `json:"languages"` // 语言代码 -> 差异，只包含有变化的语言
+}
+
+// LanguageDiff 一种语言下的译文差异
+type LanguageDiff struct {
+	Added   []*ValueChange `json:"added"`
+	Removed []*ValueChange `json:"removed"`
+	Changed []*ValueChange `json:"changed"`
+}
+
+// ValueChange 一个键的译文变化
+type ValueChange struct {
+	KeyName string `json:"key_name"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// ========== Branch Service Params ==========
+
+// CreateBranchParams 创建翻译分支参数
+type CreateBranchParams struct {
+	ProjectID   uint64
+	Name        string
+	Description string
+	UserID      uint64
+}
+
+// BranchDetail 翻译分支及其修改
+type BranchDetail struct {
+	*TranslationBranch
+	Changes []*BranchChange `json:"changes"`
+}
+
+// BranchCellInput 修改分支上的一个单元格，Deleted 为 true 时删除该单元格；删除时 LanguageCode 为空表示删除整个键
+type BranchCellInput struct {
+	KeyName      string
+	LanguageCode string
+	Value        string
+	Context      string
+	Deleted      bool
+}
+
+// SetBranchValuesParams 修改分支译文参数
+type SetBranchValuesParams struct {
+	ProjectID uint64
+	BranchID  uint64
+	Cells     []BranchCellInput
+	UserID    uint64
+}
+
+// SetBranchValuesResult 修改分支译文结果
+type SetBranchValuesResult struct {
+	Changed  int `json:"changed"`  // 记录为分支修改的单元格数
+	Reverted int `json:"reverted"` // 改回主干原值、不再算作修改的单元格数
+}
+
+// 合并冲突的解决方式
+const (
+	BranchResolutionBranch = "branch" // 使用分支的修改
+	BranchResolutionMain   = "main"   // 保留主干当前的译文
+	BranchResolutionValue  = "value"  // 使用指定的译文
+)
+
+// BranchResolution 一个冲突单元格的解决方式
+type BranchResolution struct {
+	KeyName      string
+	LanguageCode string
+	Take         string // branch, main, value
+	Value        string // Take 为 value 时使用
+}
+
+// MergeBranchParams 合并分支参数，存在未解决的冲突时不做任何修改
+type MergeBranchParams struct {
+	ProjectID   uint64
+	BranchID    uint64
+	Resolutions []BranchResolution
+	DryRun      bool // 只检查冲突，不合并
+	UserID      uint64
+}
+
+// BranchConflict 分支和主干在分支修改之后都修改了的单元格，nil 表示该单元格不存在
+type BranchConflict struct {
+	KeyName      string  `json:"key_name"`
+	LanguageCode string  `json:"language_code"`
+	Base         *string `json:"base"`   // 分支修改前主干的译文
+	Main         *string `json:"main"`   // 主干当前的译文
+	Branch       *string `json:"branch"` // 分支的译文
+}
+
+// BranchMergeResult 合并分支结果
+type BranchMergeResult struct {
+	Merged    bool              `json:"merged"`
+	Updated   int               `json:"updated"` // 写入主干的单元格数
+	Deleted   int               `json:"deleted"` // 从主干删除的单元格数
+	Conflicts []*BranchConflict `json:"conflicts"`
+}
+
+// ========== Sync Service Params ==========
+
+// SyncParams CLI 三方合并同步参数
+type SyncParams struct {
+	ProjectID    uint64
+	BaseRevision uint64                       // 客户端上次拉取时的修订号，0 表示客户端没有基准
+	Values       map[string]map[string]string // 客户端本地的译文：键名 -> 语言代码 -> 译文
+	Languages    []string                     // 参与合并的语言，为空时为 Values 中出现的语言
+	DryRun       bool                         // 只计算合并结果，不写入
+}
+
+// SyncConflict 客户端和服务端在基准之后都修改了且结果不同的单元格，nil 表示该单元格不存在
+type SyncConflict struct {
+	KeyName      string  `json:"key_name"`
+	LanguageCode string  `json:"language_code"`
+	Base         *string `json:"base"`   // 基准修订号时的译文
+	Server       *string `json:"server"` // 服务端当前的译文
+	Local        *string `json:"local"`  // 客户端本地的译文
+}
+
+// SyncResult 三方合并同步结果
+type SyncResult struct {
+	Revision  uint64                       `json:"revision"`  // 合并前服务端的修订号，客户端应用 Changed 和 Deleted 后以此作为新的基准
+	Pushed    int                          `json:"pushed"`    // 写入服务端的单元格数
+	Removed   int                          `json:"removed"`   // 从服务端删除的单元格数
+	Changed   map[string]map[string]string `json:"changed"`   // 客户端应写入本地的单元格
+	Deleted   []TranslationCellRef         `json:"deleted"`   // 客户端应从本地删除的单元格
+	Conflicts []*SyncConflict              `json:"conflicts"` // 两边都没有写入的冲突单元格
+}
+
+// ========== Git Sync Service Params ==========
+
+// git 仓库同步结果状态
+const (
+	GitSyncStatusSuccess  = "success"
+	GitSyncStatusConflict = "conflict"
+	GitSyncStatusFailed   = "failed"
+)
+
+// SaveGitSyncParams 保存项目 git 仓库同步设置参数
+type SaveGitSyncParams struct {
+	ProjectID       uint64
+	RepositoryURL   string
+	Branch          string // 为空时为 main
+	PathTemplate    string
+	Format          string // 为空时为 json
+	Languages       []string
+	LanguageMapping map[string]string // 文件路径中的语言代码 -> 语言代码
+	IntervalMinutes int
+	AutoExport      bool
+	UserID          uint64
+}
+
+// GitSyncResult 一次 git 仓库同步的结果
+type GitSyncResult struct {
+	Status    string          `json:"status"`    // success 或 conflict
+	Commit    string          `json:"commit"`    // 同步后分支的提交，空分支为空
+	Committed bool            `json:"committed"` // 是否提交并推送了导出的文件
+	Imported  int             `json:"imported"`  // 从仓库写入项目的单元格数
+	Removed   int             `json:"removed"`   // 按仓库从项目删除的单元格数
+	Files     []string        `json:"files"`     // 导出时修改的文件
+	Conflicts []*SyncConflict `json:"conflicts"` // 仓库和项目都修改了的单元格，两边都保留原值
+}
+
+// ========== Release Gate Service Params ==========
+
+// 发布门禁未满足的条件
+const (
+	ReleaseFailureLanguage   = "language_unavailable" // 必需语言已删除或停用
+	ReleaseFailureCompletion = "completion"           // 完成度低于要求
+	ReleaseFailureOutdated   = "outdated"             // 存在过期的译文
+	ReleaseFailureUnapproved = "unapproved"           // 存在未经人工确认的机器翻译
+	ReleaseFailureQAErrors   = "qa_errors"            // 存在阻断发布的质量问题
+)
+
+// 发布门禁的质量问题类型，术语问题沿用术语检查的类型
+const (
+	ReleaseIssuePlaceholderMismatch = "placeholder_mismatch" // 译文与原文的占位符参数不同
+	ReleaseIssueInvalidValue        = "invalid_value"        // 译文不符合项目的值类型
+)
+
+// UpdateReleaseCriteriaParams 更新发布门禁条件参数
+type UpdateReleaseCriteriaParams struct {
+	ProjectID         uint64
+	RequiredLanguages []string           // 为空时要求所有启用的非默认语言
+	MinCompletion     float64            // 百分比
+	LanguageMinimums  map[string]float64 // 语言代码 -> 该语言的最低完成度，覆盖 MinCompletion
+	AllowOutdated     bool
+	AllowUnapproved   bool
+	AllowQAErrors     bool
+	UserID            uint64
+}
+
+// ReleaseCell 发布检查时一个有效单元格的状态
+type ReleaseCell struct {
+	Value             string
+	MachineTranslated bool
+	UpdatedAt         time.Time
+}
+
+// ReleaseLanguageInput 检查一种必需语言的输入
+type ReleaseLanguageInput struct {
+	Language          string
+	Sources           map[string]ReleaseCell // 键名 -> 默认语言下的非空原文
+	Targets           map[string]ReleaseCell // 键名 -> 该语言下的译文
+	PlaceholderFormat string
+	ValueType         string
+	Glossary          []*GlossaryViolation // 该语言的术语问题
+}
+
+// ReleaseReport 发布门禁检查报告
+type ReleaseReport struct {
+	ProjectID      uint64                   `json:"project_id"`
+	Passed         bool                     `json:"passed"`
+	Revision       uint64                   `json:"revision"` // 检查时项目的修订号
+	SourceLanguage string                   `json:"source_language"`
+	Criteria       *ReleaseCriteria         `json:"criteria"`
+	Languages      []*ReleaseLanguageReport `json:"languages"`
+}
+
+// ReleaseLanguageReport 一种必需语言的检查结果，键列表按键名排序
+// 问题键总是全部列出，条件允许的问题不计入 Failures
+type ReleaseLanguageReport struct {
+	Language      string            `json:"language"`
+	Passed        bool              `json:"passed"`
+	Failures      []string          `json:"failures"` // 未满足的条件
+	Total         int               `json:"total"`    // 默认语言下有原文的键数
+	Translated    int               `json:"translated"`
+	Completion    float64           `json:"completion"`     // 百分比
+	MinCompletion float64           `json:"min_completion"` // 百分比
+	Untranslated  []string          `json:"untranslated"`
+	Outdated      []string          `json:"outdated"`
+	Unapproved    []string          `json:"unapproved"`
+	QAErrors      []*ReleaseQAIssue `json:"qa_errors"`
+}
+
+// ReleaseQAIssue 阻断发布的质量问题
+type ReleaseQAIssue struct {
+	KeyName string `json:"key_name"`
+	Type    string `json:"type"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// ========== Lock Service Params ==========
+
+// CreateLockParams 创建锁定参数，KeyName 和 LanguageCode 至少设置一个
+type CreateLockParams struct {
+	ProjectID    uint64
+	KeyName      string // 为空时锁定整个语言
+	LanguageCode string // 为空时锁定键的所有语言
+	Reason       string
+	UserID       uint64
+}
+
+// ProjectLocks 项目的字符串冻结状态和锁定
+type ProjectLocks struct {
+	StringFreeze bool               `json:"string_freeze"`
+	Locks        []*TranslationLock `json:"locks"`
+}
+
+// WriteRestrictions 项目对翻译写入的限制
+type WriteRestrictions struct {
+	StringFreeze     bool
+	SourceLanguageID uint64 // 默认语言
+	Locks            []*TranslationLock
+}
+
+// CellWrite 对翻译单元格的一次写入或删除
+type CellWrite struct {
+	KeyName    string
+	LanguageID uint64
+	Value      string
+	Delete     bool
+}
+
+// ========== Inheritance Service Params ==========
+
+// SetBaseProjectsParams 设置基础项目参数，BaseProjectIDs 按优先级排列，为空时取消继承
+type SetBaseProjectsParams struct {
+	ProjectID      uint64
+	BaseProjectIDs []uint64
+	UserID         uint64
+}
+
+// ProjectInheritance 项目的继承关系
+type ProjectInheritance struct {
+	Bases    []*Project `json:"bases"`    // 直接继承的基础项目，按优先级排列
+	Inherits []*Project `json:"inherits"` // 所有被继承的项目（包括基础项目的基础项目），按查找顺序排列
+	Children []*Project `json:"children"` // 直接继承当前项目的项目
+}
+
+// ========== Distribution Service Params ==========
+
+// CreateDistributionTokenParams 创建分发令牌参数
+type CreateDistributionTokenParams struct {
+	ProjectID   uint64
+	Environment string
+	Name        string
+	UserID      uint64
+}
+
+// PublishDistributionParams 发布译文参数，SnapshotID 为 0 时发布当前的译文
+type PublishDistributionParams struct {
+	ProjectID   uint64
+	Environment string
+	SnapshotID  uint64
+	UserID      uint64
+}
+
+// DistributionManifest 发布清单，序列化后用 Ed25519 签名，客户端用公钥验证后按哈希校验译文包
+type DistributionManifest struct {
+	ProjectID   uint64                               `json:"project_id"`
+	Environment string                               `json:"environment"`
+	Version     int                                  `json:"version"`
+	Snapshot    string                               `json:"snapshot,omitempty"` // 发布的版本快照名
+	PublishedAt time.Time                            `json:"published_at"`
+	KeyID       string                               `json:"key_id"`
+	Bundles     map[string]*DistributionManifestItem `json:"bundles"` // 语言代码 -> 译文包
+}
+
+// DistributionManifestItem 清单中的一个译文包，URL 相对于清单地址
+type DistributionManifestItem struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	Keys   int    `json:"keys"`
+	URL    string `json:"url"`
+}
+
+// DistributionFile 分发给客户端的文件，Encoding 为内容编码（identity、gzip、br）
+type DistributionFile struct {
+	Data      []byte
+	Hash      string // 未压缩内容的 SHA-256
+	Encoding  string
+	Signature string // 只有清单有签名
+	KeyID     string
+}
+
+// DistributionPublicKey 验证清单签名的公钥
+type DistributionPublicKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"` // base64
+}
+
+// ========== Consistency Service Params ==========
+
+// ConsistencyReport 翻译一致性分析结果
+type ConsistencyReport struct {
+	SourceLanguage     string            `json:"source_language"`
+	Groups             []*DuplicateGroup `json:"groups"`
+	DuplicateKeys      int               `json:"duplicate_keys"`      // 所有组中的键数
+	InconsistentGroups int               `json:"inconsistent_groups"` // 存在不一致译文的组数
+}
+
+// DuplicateGroup 默认语言原文相同（忽略首尾和连续空白）的一组有效键，可以合并
+type DuplicateGroup struct {
+	Source                string                           `json:"source"`
+	Keys                  []string                         `json:"keys"`
+	InconsistentLanguages []string                         `json:"inconsistent_languages"` // 存在多种非空译文的语言
+	Variants              map[string][]*TranslationVariant `json:"variants"`               // 语言代码 -> 各种译文，只包含不一致的语言
+}
+
+// TranslationVariant 同一原文在某种语言下的一种译文及使用它的键
+type TranslationVariant struct {
+	Value string   `json:"value"`
+	Keys  []string `json:"keys"`
+}
+
+// MergeKeysParams 合并翻译键参数，SourceKeys 合并到 TargetKey 后删除
+type MergeKeysParams struct {
+	ProjectID  uint64
+	TargetKey  string
+	SourceKeys []string
+	UserID     uint64
+}
+
+// MergeKeysResult 合并翻译键结果
+type MergeKeysResult struct {
+	TargetKey       string            `json:"target_key"`
+	MergedKeys      []string          `json:"merged_keys"`
+	FilledLanguages []string          `json:"filled_languages"` // 目标键缺少、从被合并的键补充译文的语言
+	Discarded       []*DiscardedValue `json:"discarded"`        // 与目标键译文不同而被丢弃的译文，被合并的键可从回收站恢复
+}
+
+// DiscardedValue 合并时丢弃的译文
+type DiscardedValue struct {
+	KeyName  string `json:"key_name"`
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+// HarmonizeParams 统一译文参数，Value 为空时使用 FromKey 在该语言下的译文
+type HarmonizeParams struct {
+	ProjectID uint64
+	KeyNames  []string
+	Language  string
+	Value     string
+	FromKey   string
+}
+
+// HarmonizeResult 统一译文结果
+type HarmonizeResult struct {
+	Language string   `json:"language"`
+	Value    string   `json:"value"`
+	Updated  []string `json:"updated"` // 译文被修改的键
+}
+
 // ========== Dashboard Service Params ==========
 
 // DashboardStats 仪表板统计结果
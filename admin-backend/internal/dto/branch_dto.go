@@ -0,0 +1,40 @@
+package dto
+
+// CreateBranchRequest 创建翻译分支请求
+type CreateBranchRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// BranchCellRequest 分支上一个单元格的修改，deleted 为 true 且 language 为空时删除整个键
+type BranchCellRequest struct {
+	KeyName  string `json:"key_name" binding:"required,max=255"`
+	Language string `json:"language" binding:"max=10"`
+	Value    string `json:"value"`
+	Context  string `json:"context" binding:"max=500"`
+	Deleted  bool   `json:"deleted"`
+}
+
+// SetBranchValuesRequest 修改分支译文请求
+type SetBranchValuesRequest struct {
+	Cells []BranchCellRequest `json:"cells" binding:"required,min=1,max=1000,dive"`
+}
+
+// RevertBranchRequest 丢弃分支上指定键的修改请求
+type RevertBranchRequest struct {
+	KeyNames []string `json:"key_names" binding:"required,min=1,max=1000,dive,required,max=255"`
+}
+
+// BranchResolutionRequest 合并冲突的解决方式：branch 使用分支的修改，main 保留主干的译文，value 使用指定的译文
+type BranchResolutionRequest struct {
+	KeyName  string `json:"key_name" binding:"required,max=255"`
+	Language string `json:"language" binding:"required,max=10"`
+	Take     string `json:"take" binding:"required,oneof=branch main value"`
+	Value    string `json:"value"`
+}
+
+// MergeBranchRequest 合并分支请求，dry_run 为 true 时只检查冲突
+type MergeBranchRequest struct {
+	Resolutions []BranchResolutionRequest `json:"resolutions" binding:"max=1000,dive"`
+	DryRun      bool                      `json:"dry_run"`
+}
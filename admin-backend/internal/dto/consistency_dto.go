@@ -0,0 +1,15 @@
+package dto
+
+// MergeKeysRequest 合并翻译键请求，source_keys 合并到 target_key 后移入回收站
+type MergeKeysRequest struct {
+	TargetKey  string   `json:"target_key" binding:"required,max=255"`
+	SourceKeys []string `json:"source_keys" binding:"required,min=1,max=99,dive,required,max=255"`
+}
+
+// HarmonizeRequest 统一译文请求，value 为空时使用 from_key 在该语言下的译文
+type HarmonizeRequest struct {
+	KeyNames []string `json:"key_names" binding:"required,min=1,max=100,dive,required,max=255"`
+	Language string   `json:"language" binding:"required"`
+	Value    string   `json:"value"`
+	FromKey  string   `json:"from_key" binding:"max=255"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// CreateDistributionTokenRequest 创建分发令牌请求
+type CreateDistributionTokenRequest struct {
+	Environment string `json:"environment" binding:"required,max=32"`
+	Name        string `json:"name" binding:"required,max=100"`
+}
+
+// PublishDistributionRequest 发布译文请求，snapshot_id 为空时发布当前的译文
+type PublishDistributionRequest struct {
+	Environment string `json:"environment" binding:"required,max=32"`
+	SnapshotID  uint64 `json:"snapshot_id"`
+}
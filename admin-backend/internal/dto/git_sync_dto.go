@@ -0,0 +1,13 @@
+package dto
+
+// SaveGitSyncRequest 保存 git 仓库同步设置请求
+type SaveGitSyncRequest struct {
+	RepositoryURL   string            `json:"repository_url" binding:"required,max=500"`
+	Branch          string            `json:"branch" binding:"max=255"`
+	PathTemplate    string            `json:"path_template" binding:"required,max=500"`
+	Format          string            `json:"format" binding:"omitempty,oneof=json json_flat"`
+	Languages       []string          `json:"languages" binding:"max=100,dive,required,max=10"`
+	LanguageMapping map[string]string `json:"language_mapping" binding:"max=100"`
+	IntervalMinutes int               `json:"interval_minutes" binding:"min=0,max=10080"`
+	AutoExport      bool              `json:"auto_export"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "i18n-flow/internal/domain"
+
+// GlossaryEntryRequest 创建或更新术语请求
+type GlossaryEntryRequest struct {
+	Term           string                            `json:"term" binding:"required,max=255"`
+	Description    string                            `json:"description" binding:"max=500"`
+	CaseSensitive  bool                              `json:"case_sensitive"`
+	DoNotTranslate bool                              `json:"do_not_translate"`
+	Translations   []domain.GlossaryTranslationInput `json:"translations" binding:"dive"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+// ResolveInContextRequest 页内编辑标记解析请求
+type ResolveInContextRequest struct {
+	Text string `json:"text" binding:"required,max=10000"` // 页面上选中的带零宽标记的文本
+}
@@ -0,0 +1,6 @@
+package dto
+
+// SetBaseProjectsRequest 设置基础项目请求，base_project_ids 按优先级排列，为空时取消继承
+type SetBaseProjectsRequest struct {
+	BaseProjectIDs []uint64 `json:"base_project_ids" binding:"max=10"`
+}
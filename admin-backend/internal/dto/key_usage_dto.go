@@ -0,0 +1,20 @@
+package dto
+
+// KeyReferenceLocation 翻译键在代码中的引用位置
+type KeyReferenceLocation struct {
+	File string `json:"file" binding:"required"`
+	Line int    `json:"line"`
+}
+
+// KeyUsageReport CLI上报的代码扫描结果
+type KeyUsageReport struct {
+	Repository string                            `json:"repository" binding:"required,max=255"`
+	Branch     string                            `json:"branch" binding:"max=255"`
+	Commit     string                            `json:"commit" binding:"max=64"`
+	References map[string][]KeyReferenceLocation `json:"references" binding:"required"` // 键名 -> 引用位置
+}
+
+// DeprecateUnusedKeysRequest 废弃未使用翻译键请求
+type DeprecateUnusedKeysRequest struct {
+	GraceDays int `json:"grace_days" binding:"omitempty,min=1,max=3650"` // 宽限天数，默认使用服务端配置
+}
@@ -0,0 +1,13 @@
+package dto
+
+// CreateLockRequest 创建锁定请求，key_name 为空时锁定整个语言，language_code 为空时锁定键的所有语言
+type CreateLockRequest struct {
+	KeyName      string `json:"key_name" binding:"max=255"`
+	LanguageCode string `json:"language_code" binding:"max=10"`
+	Reason       string `json:"reason" binding:"max=500"`
+}
+
+// StringFreezeRequest 开启或解除字符串冻结请求
+type StringFreezeRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// PreTranslateRequest 机器预翻译请求
+type PreTranslateRequest struct {
+	Languages []string `json:"languages" binding:"required,min=1"` // 目标语言代码
+	KeyNames  []string `json:"key_names"`                          // 只处理这些键，为空时处理所有键
+}
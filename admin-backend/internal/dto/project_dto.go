@@ -2,13 +2,27 @@ package dto
 
 // CreateProjectRequest 创建项目请求
 type CreateProjectRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
+	Name              string `json:"name" binding:"required"`
+	Description       string `json:"description"`
+	PlaceholderFormat string `json:"placeholder_format" binding:"omitempty,oneof=brace double_brace android ios gettext"` // 占位符规范语法，默认 brace
+	ValueType         string `json:"value_type" binding:"omitempty,oneof=plain html_subset markdown"`                     // 翻译值类型，默认 plain
+	TemplateID        uint64 `json:"template_id"`                                                                         // 项目模板ID，未设置的占位符语法和值类型取模板的设置
+}
+
+// CloneProjectRequest 克隆项目请求
+type CloneProjectRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Description     string `json:"description"`                                // 为空时使用源项目的描述
+	Keys            string `json:"keys" binding:"omitempty,oneof=keys values"` // keys 只复制键，values 复制键和译文，为空时不复制
+	IncludeMembers  bool   `json:"include_members"`                            // 复制成员及其角色
+	IncludeSettings bool   `json:"include_settings"`                           // 复制占位符语法、值类型、基础项目和发布门禁条件
 }
 
 // UpdateProjectRequest 更新项目请求
 type UpdateProjectRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	Status            string `json:"status"`
+	PlaceholderFormat string `json:"placeholder_format" binding:"omitempty,oneof=brace double_brace android ios gettext"`
+	ValueType         string `json:"value_type" binding:"omitempty,oneof=plain html_subset markdown"`
 }
@@ -0,0 +1,26 @@
+package dto
+
+// ProjectTemplateRequest 创建或更新项目模板请求，languages 为空时初始键在所有启用的语言下创建
+type ProjectTemplateRequest struct {
+	Name              string                  `json:"name" binding:"required,max=100"`
+	Description       string                  `json:"description" binding:"max=500"`
+	PlaceholderFormat string                  `json:"placeholder_format" binding:"omitempty,oneof=brace double_brace android ios gettext"`
+	ValueType         string                  `json:"value_type" binding:"omitempty,oneof=plain html_subset markdown"`
+	Languages         []string                `json:"languages" binding:"max=100,dive,required,max=10"`
+	BaseProjectIDs    []uint64                `json:"base_project_ids" binding:"max=10"`
+	StarterKeys       []TemplateKeyRequest    `json:"starter_keys" binding:"max=1000,dive"`
+	DefaultMembers    []TemplateMemberRequest `json:"default_members" binding:"max=100,dive"`
+}
+
+// TemplateKeyRequest 模板初始键，values 为语言代码到初始译文的映射
+type TemplateKeyRequest struct {
+	KeyName string            `json:"key_name" binding:"required,max=255"`
+	Context string            `json:"context" binding:"max=500"`
+	Values  map[string]string `json:"values"`
+}
+
+// TemplateMemberRequest 模板默认成员
+type TemplateMemberRequest struct {
+	UserID uint64 `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+// UpdateReleaseCriteriaRequest 更新发布门禁条件请求，required_languages 为空时要求所有启用的目标语言
+type UpdateReleaseCriteriaRequest struct {
+	RequiredLanguages []string           `json:"required_languages" binding:"max=100,dive,required,max=10"`
+	MinCompletion     *float64           `json:"min_completion" binding:"required,min=0,max=100"`
+	LanguageMinimums  map[string]float64 `json:"language_minimums" binding:"max=100"`
+	AllowOutdated     bool               `json:"allow_outdated"`
+	AllowUnapproved   bool               `json:"allow_unapproved"`
+	AllowQAErrors     bool               `json:"allow_qa_errors"`
+}
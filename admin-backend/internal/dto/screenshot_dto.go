@@ -0,0 +1,8 @@
+package dto
+
+import "i18n-flow/internal/domain"
+
+// UpdateScreenshotRegionsRequest 更新截图区域请求，regions 会整体替换已有区域
+type UpdateScreenshotRegionsRequest struct {
+	Regions []domain.ScreenshotRegionInput `json:"regions" binding:"dive"`
+}
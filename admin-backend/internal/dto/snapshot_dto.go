@@ -0,0 +1,7 @@
+package dto
+
+// CreateSnapshotRequest 创建版本快照请求
+type CreateSnapshotRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
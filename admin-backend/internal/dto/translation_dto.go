@@ -16,3 +16,9 @@ type BatchTranslationRequest struct {
 	Context      string            `json:"context"`
 	Translations map[string]string `json:"translations" binding:"required"`
 }
+
+// TagSubtreeRequest 为键树文件夹添加标签请求
+type TagSubtreeRequest struct {
+	Path string   `json:"path" binding:"required"`
+	Tags []string `json:"tags" binding:"required,min=1"`
+}
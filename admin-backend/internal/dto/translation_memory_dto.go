@@ -0,0 +1,10 @@
+package dto
+
+// TranslationMemoryLookupRequest 翻译记忆查询请求
+type TranslationMemoryLookupRequest struct {
+	Source         string  `json:"source" binding:"required,max=10000"`
+	SourceLanguage string  `json:"source_language" binding:"required"`
+	TargetLanguage string  `json:"target_language" binding:"required"`
+	MinScore       float64 `json:"min_score" binding:"omitempty,gt=0,lte=1"` // 最低相似度，默认 0.7
+	Limit          int     `json:"limit" binding:"omitempty,min=1,max=50"`   // 返回的匹配数，默认 10
+}
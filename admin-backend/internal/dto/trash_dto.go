@@ -0,0 +1,13 @@
+package dto
+
+// RestoreKeysRequest 恢复回收站翻译键请求
+type RestoreKeysRequest struct {
+	KeyNames []string `json:"key_names" binding:"required,min=1"`
+	Strategy string   `json:"strategy" binding:"omitempty,oneof=skip overwrite rename"` // 冲突处理策略，默认 skip
+}
+
+// PurgeKeysRequest 彻底删除回收站翻译键请求
+type PurgeKeysRequest struct {
+	KeyNames []string `json:"key_names"`
+	All      bool     `json:"all"` // 为 true 时清空整个项目回收站
+}
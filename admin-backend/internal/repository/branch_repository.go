@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// branchChangeBatchSize 写入或删除分支修改时每批的行数
+const branchChangeBatchSize = 500
+
+// BranchRepository 翻译分支仓储实现
+type BranchRepository struct {
+	db *gorm.DB
+}
+
+// NewBranchRepository 创建翻译分支仓储实例
+func NewBranchRepository(db *gorm.DB) *BranchRepository {
+	return &BranchRepository{db: db}
+}
+
+// List 获取项目的翻译分支及各分支的修改数，未合并的在前
+func (r *BranchRepository) List(ctx context.Context, projectID uint64) ([]*domain.TranslationBranch, error) {
+	var branches []*domain.TranslationBranch
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).
+		Order("status = 'merged'").Order("id DESC").Find(&branches).Error; err != nil {
+		return nil, err
+	}
+	if len(branches) == 0 {
+		return branches, nil
+	}
+
+	ids := make([]uint64, len(branches))
+	for i, branch := range branches {
+		ids[i] = branch.ID
+	}
+	var counts []struct {
+		BranchID uint64
+		Count    int
+	}
+	if err := r.db.WithContext(ctx).Model(&domain.BranchChange{}).
+		Select("branch_id, COUNT(*) AS count").
+		Where("branch_id IN ?", ids).
+		Group("branch_id").Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	countByBranch := make(map[uint64]int, len(counts))
+	for _, count := range counts {
+		countByBranch[count.BranchID] = count.Count
+	}
+	for _, branch := range branches {
+		branch.ChangeCount = countByBranch[branch.ID]
+	}
+	return branches, nil
+}
+
+// GetByID 根据ID获取翻译分支
+func (r *BranchRepository) GetByID(ctx context.Context, id uint64) (*domain.TranslationBranch, error) {
+	var branch domain.TranslationBranch
+	if err := r.db.WithContext(ctx).First(&branch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// GetByName 根据分支名获取项目的翻译分支
+func (r *BranchRepository) GetByName(ctx context.Context, projectID uint64, name string) (*domain.TranslationBranch, error) {
+	var branch domain.TranslationBranch
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND name = ?", projectID, name).First(&branch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// Create 创建翻译分支
+func (r *BranchRepository) Create(ctx context.Context, branch *domain.TranslationBranch) error {
+	return r.db.WithContext(ctx).Create(branch).Error
+}
+
+// MarkMerged 标记分支已合并
+func (r *BranchRepository) MarkMerged(ctx context.Context, id, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&domain.TranslationBranch{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":    domain.BranchStatusMerged,
+		"merged_by": userID,
+		"merged_at": time.Now(),
+	}).Error
+}
+
+// Delete 删除翻译分支及其修改
+func (r *BranchRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("branch_id = ?", id).Delete(&domain.BranchChange{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.TranslationBranch{}, id).Error
+	})
+}
+
+// GetChanges 获取分支的所有修改，按键名和语言排序
+func (r *BranchRepository) GetChanges(ctx context.Context, branchID uint64) ([]*domain.BranchChange, error) {
+	var changes []*domain.BranchChange
+	if err := r.db.WithContext(ctx).Where("branch_id = ?", branchID).
+		Order("key_name").Order("language_code").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// SaveChanges 写入分支修改，单元格已有修改时只更新译文，保留第一次修改时记录的主干状态
+func (r *BranchRepository) SaveChanges(ctx context.Context, changes []*domain.BranchChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "branch_id"}, {Name: "key_name"}, {Name: "language_code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "context", "deleted", "updated_by", "updated_at"}),
+		}).
+		CreateInBatches(changes, branchChangeBatchSize).Error
+}
+
+// DeleteChanges 删除分支上指定单元格的修改
+func (r *BranchRepository) DeleteChanges(ctx context.Context, branchID uint64, cells []*domain.BranchChange) error {
+	if len(cells) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(cells); start += branchChangeBatchSize {
+			end := min(start+branchChangeBatchSize, len(cells))
+			pairs := make([][]interface{}, 0, end-start)
+			for _, cell := range cells[start:end] {
+				pairs = append(pairs, []interface{}{cell.KeyName, cell.LanguageCode})
+			}
+			if err := tx.Where("branch_id = ?", branchID).
+				Where("(key_name, language_code) IN ?", pairs).
+				Delete(&domain.BranchChange{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
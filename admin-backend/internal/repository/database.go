@@ -66,6 +66,33 @@ func InitDB(cfg *config.Config, zapLogger *zap.Logger, monitor *internal_utils.D
 		&domain.Project{},
 		&domain.Language{},
 		&domain.Translation{},
+		&domain.TranslationTombstone{},
+		&domain.TranslationVersion{},
+		&domain.KeyTag{},
+		&domain.KeyScan{},
+		&domain.KeyReference{},
+		&domain.KeyUsage{},
+		&domain.TranslationMemoryUnit{},
+		&domain.TranslationMemorySegment{},
+		&domain.GlossaryEntry{},
+		&domain.GlossaryTranslation{},
+		&domain.Screenshot{},
+		&domain.ScreenshotRegion{},
+		&domain.ScreenshotKey{},
+		&domain.Snapshot{},
+		&domain.SnapshotEntry{},
+		&domain.SnapshotValue{},
+		&domain.TranslationBranch{},
+		&domain.BranchChange{},
+		&domain.DistributionToken{},
+		&domain.DistributionRelease{},
+		&domain.DistributionBundle{},
+		&domain.ReleaseCriteria{},
+		&domain.TranslationLock{},
+		&domain.ProjectBase{},
+		&domain.ProjectTemplate{},
+		&domain.GitSync{},
+		&domain.MachineTranslationUsage{},
 		&domain.ProjectMember{},
 		&domain.Invitation{},
 	)
@@ -73,6 +100,17 @@ func InitDB(cfg *config.Config, zapLogger *zap.Logger, monitor *internal_utils.D
 		return nil, fmt.Errorf("自动迁移表结构失败: %w", err)
 	}
 
+	// 删除旧版唯一索引：它包含已软删除的翻译，会导致删除后无法重新创建相同的键
+	// 新的唯一约束 idx_translation_active_unique 只约束未删除的翻译
+	if err := dropIndexIfExists(db, "translations", "idx_translation_unique", zapLogger); err != nil {
+		zapLogger.Warn("Warning during legacy index removal", zap.Error(err))
+	}
+
+	// 为已有的翻译补写版本记录，三方合并依赖版本记录还原基准译文
+	if err := backfillTranslationVersions(db, zapLogger); err != nil {
+		return nil, fmt.Errorf("补写翻译版本记录失败: %w", err)
+	}
+
 	// 创建额外的性能优化索引
 	if err := createOptimizationIndexes(db, zapLogger); err != nil {
 		zapLogger.Warn("Warning during index creation", zap.Error(err))
@@ -258,13 +296,6 @@ func createOptimizationIndexes(db *gorm.DB, zapLogger *zap.Logger) error {
 			Columns:   []string{"code", "status"},
 			Unique:    false,
 		},
-		// 添加翻译唯一约束索引（如果GORM没有自动创建）
-		{
-			Name:      "idx_translation_unique",
-			TableName: "translations",
-			Columns:   []string{"project_id", "key_name", "language_id"},
-			Unique:    true,
-		},
 		// 项目成员相关索引
 		{
 			Name:      "idx_project_members_project",
@@ -347,3 +378,45 @@ func indexExists(db *gorm.DB, tableName, indexName string) (bool, error) {
 
 	return count > 0, nil
 }
+
+// dropIndexIfExists 如果索引存在则删除
+func dropIndexIfExists(db *gorm.DB, tableName, indexName string, zapLogger *zap.Logger) error {
+	exists, err := indexExists(db, tableName, indexName)
+	if err != nil {
+		return fmt.Errorf("检查索引是否存在时出错: %w", err)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	if err := db.Migrator().DropIndex(tableName, indexName); err != nil {
+		return fmt.Errorf("删除索引失败: %w", err)
+	}
+
+	zapLogger.Info("Index dropped successfully", zap.String("index", indexName))
+	return nil
+}
+
+// backfillTranslationVersions 版本表为空时按翻译的当前状态补写版本记录
+// 只在引入版本记录后的第一次启动时有实际写入，之后每次写入翻译都会同时记录版本
+func backfillTranslationVersions(db *gorm.DB, zapLogger *zap.Logger) error {
+	var count int64
+	if err := db.Model(&domain.TranslationVersion{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	result := db.Exec(`INSERT INTO translation_versions (project_id, key_name, language_id, revision, value, deleted, created_at)
+		SELECT project_id, key_name, language_id, revision, value, status <> 'active', NOW()
+		FROM translations WHERE deleted_at IS NULL`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		zapLogger.Info("Translation versions backfilled", zap.Int64("rows", result.RowsAffected))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DistributionRepository 译文分发仓储实现
+type DistributionRepository struct {
+	db *gorm.DB
+}
+
+// NewDistributionRepository 创建译文分发仓储实例
+func NewDistributionRepository(db *gorm.DB) *DistributionRepository {
+	return &DistributionRepository{db: db}
+}
+
+// ListTokens 获取项目的分发令牌
+func (r *DistributionRepository) ListTokens(ctx context.Context, projectID uint64) ([]*domain.DistributionToken, error) {
+	var tokens []*domain.DistributionToken
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Order("id DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetTokenByID 根据ID获取分发令牌
+func (r *DistributionRepository) GetTokenByID(ctx context.Context, id uint64) (*domain.DistributionToken, error) {
+	var token domain.DistributionToken
+	if err := r.db.WithContext(ctx).First(&token, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDistributionTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetTokenByHash 根据令牌哈希获取分发令牌，所属项目已删除时视为无效令牌
+func (r *DistributionRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.DistributionToken, error) {
+	var token domain.DistributionToken
+	if err := r.db.WithContext(ctx).
+		Joins("INNER JOIN projects p ON p.id = distribution_tokens.project_id AND p.deleted_at IS NULL").
+		Where("distribution_tokens.token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidDistributionToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// CreateToken 创建分发令牌
+func (r *DistributionRepository) CreateToken(ctx context.Context, token *domain.DistributionToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// DeleteToken 删除分发令牌
+func (r *DistributionRepository) DeleteToken(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Delete(&domain.DistributionToken{}, id).Error
+}
+
+// ListReleases 分页获取项目的发布记录，environment 不为空时只获取该环境，按发布时间倒序
+func (r *DistributionRepository) ListReleases(ctx context.Context, projectID uint64, environment string, limit, offset int) ([]*domain.DistributionRelease, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.DistributionRelease{}).Where("project_id = ?", projectID)
+	if environment != "" {
+		query = query.Where("environment = ?", environment)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.DistributionRelease{}, 0, nil
+	}
+
+	var releases []*domain.DistributionRelease
+	if err := query.Preload("Bundles").Order("id DESC").Limit(limit).Offset(offset).Find(&releases).Error; err != nil {
+		return nil, 0, err
+	}
+	return releases, total, nil
+}
+
+// GetLatestRelease 获取环境的最新发布及其译文包
+func (r *DistributionRepository) GetLatestRelease(ctx context.Context, projectID uint64, environment string) (*domain.DistributionRelease, error) {
+	var release domain.DistributionRelease
+	err := r.db.WithContext(ctx).
+		Preload("Bundles").
+		Where("project_id = ? AND environment = ?", projectID, environment).
+		Order("version DESC").
+		First(&release).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDistributionNotPublished
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+// CreateRelease 创建发布及其译文包
+// 锁定项目行后在同一事务中分配环境内的下一个版本号，并发发布依次取号；清单包含版本号，由 sign 在写入前签名
+func (r *DistributionRepository) CreateRelease(ctx context.Context, release *domain.DistributionRelease, sign func(release *domain.DistributionRelease) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var project domain.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&project, release.ProjectID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrProjectNotFound
+			}
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&domain.DistributionRelease{}).
+			Where("project_id = ? AND environment = ?", release.ProjectID, release.Environment).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		release.Version = latest + 1
+
+		if err := sign(release); err != nil {
+			return err
+		}
+		return tx.Create(release).Error
+	})
+}
+
+// FindBundle 在环境的所有发布中查找指定哈希的译文包
+func (r *DistributionRepository) FindBundle(ctx context.Context, projectID uint64, environment, hash string) (*domain.DistributionBundle, error) {
+	var bundle domain.DistributionBundle
+	err := r.db.WithContext(ctx).
+		Joins("INNER JOIN distribution_releases dr ON dr.id = distribution_bundles.release_id").
+		Where("dr.project_id = ? AND dr.environment = ? AND distribution_bundles.hash = ?", projectID, environment, hash).
+		First(&bundle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDistributionBundleNotFound
+		}
+		return nil, err
+	}
+	return &bundle, nil
+}
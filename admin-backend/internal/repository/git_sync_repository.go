@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// GitSyncRepository git 仓库同步设置仓储实现
+type GitSyncRepository struct {
+	db *gorm.DB
+}
+
+// NewGitSyncRepository 创建 git 仓库同步设置仓储实例
+func NewGitSyncRepository(db *gorm.DB) *GitSyncRepository {
+	return &GitSyncRepository{db: db}
+}
+
+// GetByProject 获取项目的 git 仓库同步设置
+func (r *GitSyncRepository) GetByProject(ctx context.Context, projectID uint64) (*domain.GitSync, error) {
+	var gitSync domain.GitSync
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).First(&gitSync).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGitSyncNotConfigured
+		}
+		return nil, err
+	}
+	return &gitSync, nil
+}
+
+// ListScheduled 获取开启了定时同步的设置，不包含已删除的项目
+func (r *GitSyncRepository) ListScheduled(ctx context.Context) ([]*domain.GitSync, error) {
+	var gitSyncs []*domain.GitSync
+	if err := r.db.WithContext(ctx).
+		Joins("INNER JOIN projects p ON p.id = git_syncs.project_id AND p.deleted_at IS NULL").
+		Where("git_syncs.interval_minutes > 0").
+		Order("git_syncs.project_id").
+		Find(&gitSyncs).Error; err != nil {
+		return nil, err
+	}
+	return gitSyncs, nil
+}
+
+// Save 创建或更新 git 仓库同步设置
+func (r *GitSyncRepository) Save(ctx context.Context, gitSync *domain.GitSync) error {
+	return r.db.WithContext(ctx).Save(gitSync).Error
+}
+
+// Delete 删除项目的 git 仓库同步设置
+func (r *GitSyncRepository) Delete(ctx context.Context, projectID uint64) error {
+	return r.db.WithContext(ctx).Where("project_id = ?", projectID).Delete(&domain.GitSync{}).Error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// GlossaryRepository 术语表仓储实现
+type GlossaryRepository struct {
+	db *gorm.DB
+}
+
+// NewGlossaryRepository 创建术语表仓储实例
+func NewGlossaryRepository(db *gorm.DB) *GlossaryRepository {
+	return &GlossaryRepository{db: db}
+}
+
+// List 分页获取项目术语表（projectID 为0时为全局术语），按术语排序
+func (r *GlossaryRepository) List(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*domain.GlossaryEntry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.GlossaryEntry{}).Where("project_id = ?", projectID)
+	if keyword != "" {
+		pattern := "%" + internal_utils.EscapeLike(keyword) + "%"
+		query = query.Where("(term LIKE ? OR description LIKE ? OR id IN (?))", pattern, pattern,
+			r.db.Model(&domain.GlossaryTranslation{}).Select("entry_id").Where("value LIKE ?", pattern))
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.GlossaryEntry{}, 0, nil
+	}
+
+	var entries []*domain.GlossaryEntry
+	if err := query.Preload("Translations").Order("term").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// ListForProject 获取对项目生效的所有术语，包括全局术语
+func (r *GlossaryRepository) ListForProject(ctx context.Context, projectID uint64) ([]*domain.GlossaryEntry, error) {
+	var entries []*domain.GlossaryEntry
+	err := r.db.WithContext(ctx).
+		Preload("Translations").
+		Where("project_id IN ?", []uint64{0, projectID}).
+		Order("project_id, term").
+		Find(&entries).Error
+	return entries, err
+}
+
+// GetByID 根据ID获取术语
+func (r *GlossaryRepository) GetByID(ctx context.Context, id uint64) (*domain.GlossaryEntry, error) {
+	var entry domain.GlossaryEntry
+	if err := r.db.WithContext(ctx).Preload("Translations").First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGlossaryEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByTerm 根据术语获取条目
+func (r *GlossaryRepository) GetByTerm(ctx context.Context, projectID uint64, term string) (*domain.GlossaryEntry, error) {
+	var entry domain.GlossaryEntry
+	err := r.db.WithContext(ctx).
+		Preload("Translations").
+		Where("project_id = ? AND term = ?", projectID, term).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGlossaryEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Create 创建术语及其译法
+func (r *GlossaryRepository) Create(ctx context.Context, entry *domain.GlossaryEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// Update 更新术语，并用 entry.Translations 替换已有译法
+func (r *GlossaryRepository) Update(ctx context.Context, entry *domain.GlossaryEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Translations").Save(entry).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("entry_id = ?", entry.ID).Delete(&domain.GlossaryTranslation{}).Error; err != nil {
+			return err
+		}
+		if len(entry.Translations) == 0 {
+			return nil
+		}
+		for _, translation := range entry.Translations {
+			translation.ID = 0
+			translation.EntryID = entry.ID
+		}
+		return tx.Create(entry.Translations).Error
+	})
+}
+
+// Delete 删除术语及其译法
+func (r *GlossaryRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entry_id = ?", id).Delete(&domain.GlossaryTranslation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.GlossaryEntry{}, id).Error
+	})
+}
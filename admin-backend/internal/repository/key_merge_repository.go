@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyMergeRepository 合并翻译键的仓储实现
+type KeyMergeRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyMergeRepository 创建键合并仓储实例
+func NewKeyMergeRepository(db *gorm.DB) *KeyMergeRepository {
+	return &KeyMergeRepository{db: db}
+}
+
+// MergeKeys 在一个事务中合并翻译键：写入目标键补充的译文，把被合并的键的引用改写到目标键，再把 deleteIDs 的翻译移入回收站
+// 任一步失败时整个合并回滚
+func (r *KeyMergeRepository) MergeKeys(ctx context.Context, merge domain.KeyMerge) error {
+	if len(merge.SourceKeys) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(merge.Fills) > 0 {
+			if err := upsertTranslations(tx, merge.Fills); err != nil {
+				return err
+			}
+		}
+		if err := rewriteKeyReferences(tx, merge.ProjectID, merge.TargetKey, merge.SourceKeys); err != nil {
+			return err
+		}
+		if len(merge.DeleteIDs) > 0 {
+			return deleteTranslations(tx, merge.DeleteIDs)
+		}
+		return nil
+	})
+}
+
+// rewriteKeyReferences 把 sourceKeys 的标签、代码引用、最近引用时间和截图标注改写到 targetKey
+func rewriteKeyReferences(tx *gorm.DB, projectID uint64, targetKey string, sourceKeys []string) error {
+	if err := mergeKeyTags(tx, projectID, targetKey, sourceKeys); err != nil {
+		return err
+	}
+	if err := tx.Model(&domain.KeyReference{}).
+		Where("project_id = ? AND key_name IN ?", projectID, sourceKeys).
+		Update("key_name", targetKey).Error; err != nil {
+		return err
+	}
+	if err := mergeKeyUsages(tx, projectID, targetKey, sourceKeys); err != nil {
+		return err
+	}
+	return mergeScreenshotKeys(tx, projectID, targetKey, sourceKeys)
+}
+
+// mergeKeyTags 目标键补上被合并的键的标签，再删除被合并的键的标签
+func mergeKeyTags(tx *gorm.DB, projectID uint64, targetKey string, sourceKeys []string) error {
+	var tags []*domain.KeyTag
+	if err := tx.Where("project_id = ? AND key_name IN ?", projectID, sourceKeys).Find(&tags).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	merged := make([]*domain.KeyTag, 0, len(tags))
+	for _, tag := range tags {
+		if seen[tag.Tag] {
+			continue
+		}
+		seen[tag.Tag] = true
+		merged = append(merged, &domain.KeyTag{
+			ProjectID: projectID,
+			KeyName:   targetKey,
+			Tag:       tag.Tag,
+			CreatedBy: tag.CreatedBy,
+		})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&merged).Error; err != nil {
+		return err
+	}
+	return tx.Where("project_id = ? AND key_name IN ?", projectID, sourceKeys).Delete(&domain.KeyTag{}).Error
+}
+
+// mergeKeyUsages 目标键的最近引用时间取所有键中最晚的一个
+func mergeKeyUsages(tx *gorm.DB, projectID uint64, targetKey string, sourceKeys []string) error {
+	var usages []*domain.KeyUsage
+	if err := tx.Where("project_id = ? AND key_name IN ?", projectID, append([]string{targetKey}, sourceKeys...)).
+		Find(&usages).Error; err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		return nil
+	}
+
+	latest := usages[0].LastSeenAt
+	for _, usage := range usages[1:] {
+		if usage.LastSeenAt.After(latest) {
+			latest = usage.LastSeenAt
+		}
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}, {Name: "key_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+	}).Create(&domain.KeyUsage{ProjectID: projectID, KeyName: targetKey, LastSeenAt: latest}).Error; err != nil {
+		return err
+	}
+	return tx.Where("project_id = ? AND key_name IN ?", projectID, sourceKeys).Delete(&domain.KeyUsage{}).Error
+}
+
+// mergeScreenshotKeys 截图区域中的标注改为目标键，同一区域只保留一个目标键标注
+func mergeScreenshotKeys(tx *gorm.DB, projectID uint64, targetKey string, sourceKeys []string) error {
+	var keys []*domain.ScreenshotKey
+	if err := tx.Where("project_id = ? AND key_name IN ?", projectID, append([]string{targetKey}, sourceKeys...)).
+		Order("id").Find(&keys).Error; err != nil {
+		return err
+	}
+
+	// 每个区域优先保留已有的目标键标注，否则保留第一个被合并的键的标注并改名
+	kept := make(map[uint64]*domain.ScreenshotKey)
+	for _, key := range keys {
+		if current, ok := kept[key.RegionID]; !ok || (key.KeyName == targetKey && current.KeyName != targetKey) {
+			kept[key.RegionID] = key
+		}
+	}
+	var renamed, removed []uint64
+	for _, key := range keys {
+		switch {
+		case kept[key.RegionID] != key:
+			removed = append(removed, key.ID)
+		case key.KeyName != targetKey:
+			renamed = append(renamed, key.ID)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := tx.Delete(&domain.ScreenshotKey{}, removed).Error; err != nil {
+			return err
+		}
+	}
+	if len(renamed) > 0 {
+		return tx.Model(&domain.ScreenshotKey{}).Where("id IN ?", renamed).Update("key_name", targetKey).Error
+	}
+	return nil
+}
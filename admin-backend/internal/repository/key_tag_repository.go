@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyTagRepository 翻译键标签仓储实现
+type KeyTagRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyTagRepository 创建翻译键标签仓储实例
+func NewKeyTagRepository(db *gorm.DB) *KeyTagRepository {
+	return &KeyTagRepository{db: db}
+}
+
+// GetByProjectAndKeys 获取指定键的所有标签
+func (r *KeyTagRepository) GetByProjectAndKeys(ctx context.Context, projectID uint64, keyNames []string) ([]*domain.KeyTag, error) {
+	if len(keyNames) == 0 {
+		return []*domain.KeyTag{}, nil
+	}
+
+	var tags []*domain.KeyTag
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND key_name IN ?", projectID, keyNames).
+		Order("tag").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CreateBatch 批量创建标签，已存在的标签会被忽略
+func (r *KeyTagRepository) CreateBatch(ctx context.Context, tags []*domain.KeyTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(tags, 100).Error
+}
+
+// DeleteByKeyPrefix 删除指定前缀下所有键的标签
+func (r *KeyTagRepository) DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) error {
+	if prefix == "" {
+		return domain.ErrInvalidKeyPath
+	}
+	return r.db.WithContext(ctx).
+		Where("project_id = ? AND key_name LIKE ?", projectID, internal_utils.EscapeLike(prefix)+"%").
+		Delete(&domain.KeyTag{}).Error
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// keyNameChunkSize 按键名批量更新时每批的键数
+const keyNameChunkSize = 500
+
+// KeyUsageRepository 翻译键代码引用仓储实现
+type KeyUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyUsageRepository 创建翻译键代码引用仓储实例
+func NewKeyUsageRepository(db *gorm.DB) *KeyUsageRepository {
+	return &KeyUsageRepository{db: db}
+}
+
+// SaveScan 保存一次扫描结果
+// 替换该仓库分支之前的引用，更新键的最近引用时间，并重新启用再次被引用的已废弃键
+func (r *KeyUsageRepository) SaveScan(ctx context.Context, scan *domain.KeyScan, references []*domain.KeyReference) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(scan).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("project_id = ? AND repository = ? AND branch = ?", scan.ProjectID, scan.Repository, scan.Branch).
+			Delete(&domain.KeyReference{}).Error; err != nil {
+			return err
+		}
+
+		if len(references) == 0 {
+			return nil
+		}
+
+		keySet := make(map[string]bool)
+		for _, reference := range references {
+			reference.ScanID = scan.ID
+			keySet[reference.KeyName] = true
+		}
+		if err := tx.CreateInBatches(references, 100).Error; err != nil {
+			return err
+		}
+
+		usages := make([]*domain.KeyUsage, 0, len(keySet))
+		keyNames := make([]string, 0, len(keySet))
+		for keyName := range keySet {
+			usages = append(usages, &domain.KeyUsage{
+				ProjectID:  scan.ProjectID,
+				KeyName:    keyName,
+				LastSeenAt: scan.CreatedAt,
+			})
+			keyNames = append(keyNames, keyName)
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}, {Name: "key_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+		}).CreateInBatches(usages, 100).Error; err != nil {
+			return err
+		}
+
+		// 重新被引用的废弃翻译恢复为有效，并写入新的修订号供增量同步下发
+		var revision uint64
+		for start := 0; start < len(keyNames); start += keyNameChunkSize {
+			end := min(start+keyNameChunkSize, len(keyNames))
+			var translations []*domain.Translation
+			if err := tx.Select("id, key_name, language_id, value").
+				Where("project_id = ? AND key_name IN ? AND status = ?", scan.ProjectID, keyNames[start:end], "deprecated").
+				Find(&translations).Error; err != nil {
+				return err
+			}
+			if len(translations) == 0 {
+				continue
+			}
+			if revision == 0 {
+				var err error
+				if revision, err = nextRevision(tx, scan.ProjectID); err != nil {
+					return err
+				}
+			}
+			ids := make([]uint64, len(translations))
+			versions := make([]*domain.TranslationVersion, len(translations))
+			for i, translation := range translations {
+				ids[i] = translation.ID
+				versions[i] = &domain.TranslationVersion{
+					ProjectID:  scan.ProjectID,
+					KeyName:    translation.KeyName,
+					LanguageID: translation.LanguageID,
+					Revision:   revision,
+					Value:      translation.Value,
+				}
+			}
+			if err := recordVersions(tx, versions); err != nil {
+				return err
+			}
+			if err := tx.Model(&domain.Translation{}).
+				Where("id IN ?", ids).
+				Updates(map[string]interface{}{"status": "active", "revision": revision}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetReferences 获取翻译键在各仓库分支中的引用位置
+func (r *KeyUsageRepository) GetReferences(ctx context.Context, projectID uint64, keyName string) ([]*domain.KeyReference, error) {
+	var references []*domain.KeyReference
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Order("repository, branch, file_path, line").
+		Find(&references).Error; err != nil {
+		return nil, err
+	}
+	return references, nil
+}
+
+// GetUsage 获取翻译键的最近引用时间，从未被引用时返回 nil
+func (r *KeyUsageRepository) GetUsage(ctx context.Context, projectID uint64, keyName string) (*domain.KeyUsage, error) {
+	var usage domain.KeyUsage
+	err := r.db.WithContext(ctx).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		First(&usage).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// GetLatestScans 获取项目每个仓库分支最近一次扫描
+func (r *KeyUsageRepository) GetLatestScans(ctx context.Context, projectID uint64) ([]*domain.KeyScan, error) {
+	latestIDs := r.db.Model(&domain.KeyScan{}).
+		Select("MAX(id)").
+		Where("project_id = ?", projectID).
+		Group("repository, branch")
+
+	var scans []*domain.KeyScan
+	if err := r.db.WithContext(ctx).
+		Where("id IN (?)", latestIDs).
+		Order("created_at DESC").
+		Find(&scans).Error; err != nil {
+		return nil, err
+	}
+	return scans, nil
+}
+
+// GetFirstScanAt 获取项目首次扫描时间，未扫描过时返回 nil
+func (r *KeyUsageRepository) GetFirstScanAt(ctx context.Context, projectID uint64) (*time.Time, error) {
+	var scan domain.KeyScan
+	err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		First(&scan).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &scan.CreatedAt, nil
+}
+
+// GetUnreferencedKeys 获取项目中没有任何代码引用的有效翻译键
+func (r *KeyUsageRepository) GetUnreferencedKeys(ctx context.Context, projectID uint64) ([]*domain.UnusedKey, error) {
+	var rows []struct {
+		KeyName    string     `gorm:"column:key_name"`
+		CreatedAt  time.Time  `gorm:"column:created_at"`
+		LastSeenAt *time.Time `gorm:"column:last_seen_at"`
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("translations t").
+		Select("t.key_name, MIN(t.created_at) AS created_at, MAX(u.last_seen_at) AS last_seen_at").
+		Joins("LEFT JOIN key_usages u ON u.project_id = t.project_id AND u.key_name = t.key_name").
+		Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", projectID, "active").
+		Where("NOT EXISTS (SELECT 1 FROM key_references r WHERE r.project_id = t.project_id AND r.key_name = t.key_name)").
+		Group("t.key_name").
+		Order("t.key_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domain.UnusedKey, len(rows))
+	for i, row := range rows {
+		keys[i] = &domain.UnusedKey{
+			KeyName:    row.KeyName,
+			CreatedAt:  row.CreatedAt,
+			LastSeenAt: row.LastSeenAt,
+		}
+	}
+	return keys, nil
+}
+
+// MarkKeysDeprecated 将翻译键的所有有效翻译标记为废弃，返回更新的行数
+func (r *KeyUsageRepository) MarkKeysDeprecated(ctx context.Context, projectID uint64, keyNames []string) (int64, error) {
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(keyNames); start += keyNameChunkSize {
+			chunk := keyNames[start:min(start+keyNameChunkSize, len(keyNames))]
+			scope := func(db *gorm.DB) *gorm.DB {
+				return db.Where("project_id = ? AND key_name IN ? AND status = ?", projectID, chunk, "active")
+			}
+			// 废弃的翻译不再导出，对增量同步而言等同于删除
+			if err := recordTombstones(tx, scope); err != nil {
+				return err
+			}
+			result := tx.Model(&domain.Translation{}).Scopes(scope).Update("status", "deprecated")
+			if result.Error != nil {
+				return result.Error
+			}
+			affected += result.RowsAffected
+		}
+		return nil
+	})
+	return affected, err
+}
+
+// GetScannedProjectIDs 获取上报过扫描结果的项目ID
+func (r *KeyUsageRepository) GetScannedProjectIDs(ctx context.Context) ([]uint64, error) {
+	var ids []uint64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KeyScan{}).
+		Distinct().
+		Pluck("project_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
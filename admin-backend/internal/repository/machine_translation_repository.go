@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MachineTranslationUsageRepository 机器翻译用量仓储实现
+type MachineTranslationUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewMachineTranslationUsageRepository 创建机器翻译用量仓储实例
+func NewMachineTranslationUsageRepository(db *gorm.DB) *MachineTranslationUsageRepository {
+	return &MachineTranslationUsageRepository{db: db}
+}
+
+// GetCharacters 获取项目在指定月份已使用的字符数
+func (r *MachineTranslationUsageRepository) GetCharacters(ctx context.Context, projectID uint64, period string) (int64, error) {
+	var characters int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.MachineTranslationUsage{}).
+		Where("project_id = ? AND period = ?", projectID, period).
+		Select("COALESCE(SUM(characters), 0)").
+		Scan(&characters).Error
+	return characters, err
+}
+
+// AddCharacters 累加项目在指定月份使用的字符数
+func (r *MachineTranslationUsageRepository) AddCharacters(ctx context.Context, projectID uint64, period string, characters int64) error {
+	usage := &domain.MachineTranslationUsage{
+		ProjectID:  projectID,
+		Period:     period,
+		Characters: characters,
+		UpdatedAt:  time.Now(),
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "project_id"}, {Name: "period"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"characters": gorm.Expr("characters + ?", characters),
+				"updated_at": usage.UpdatedAt,
+			}),
+		}).
+		Create(usage).Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ProjectBaseRepository 项目继承关系仓储实现
+type ProjectBaseRepository struct {
+	db *gorm.DB
+}
+
+// NewProjectBaseRepository 创建项目继承关系仓储实例
+func NewProjectBaseRepository(db *gorm.DB) *ProjectBaseRepository {
+	return &ProjectBaseRepository{db: db}
+}
+
+// ListByProject 获取项目的基础项目，按优先级排序，不包含已删除的基础项目
+func (r *ProjectBaseRepository) ListByProject(ctx context.Context, projectID uint64) ([]*domain.ProjectBase, error) {
+	var bases []*domain.ProjectBase
+	if err := r.db.WithContext(ctx).
+		Joins("INNER JOIN projects p ON p.id = project_bases.base_project_id AND p.deleted_at IS NULL").
+		Where("project_bases.project_id = ?", projectID).
+		Order("project_bases.position, project_bases.id").
+		Find(&bases).Error; err != nil {
+		return nil, err
+	}
+	return bases, nil
+}
+
+// ListByBase 获取直接继承指定项目的项目，不包含已删除的项目
+func (r *ProjectBaseRepository) ListByBase(ctx context.Context, baseProjectID uint64) ([]*domain.ProjectBase, error) {
+	var bases []*domain.ProjectBase
+	if err := r.db.WithContext(ctx).
+		Joins("INNER JOIN projects p ON p.id = project_bases.project_id AND p.deleted_at IS NULL").
+		Where("project_bases.base_project_id = ?", baseProjectID).
+		Order("project_bases.project_id").
+		Find(&bases).Error; err != nil {
+		return nil, err
+	}
+	return bases, nil
+}
+
+// Replace 整体替换项目的基础项目
+func (r *ProjectBaseRepository) Replace(ctx context.Context, projectID uint64, bases []*domain.ProjectBase) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&domain.ProjectBase{}).Error; err != nil {
+			return err
+		}
+		if len(bases) == 0 {
+			return nil
+		}
+		return tx.Create(&bases).Error
+	})
+}
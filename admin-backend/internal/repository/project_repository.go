@@ -92,14 +92,29 @@ func (r *ProjectRepository) GetAll(ctx context.Context, limit, offset int, keywo
 	return projects, total, nil
 }
 
+// FindDeletedByNameOrSlug 查找回收站中名称或标识相同的项目，未找到时返回 nil
+func (r *ProjectRepository) FindDeletedByNameOrSlug(ctx context.Context, name, slug string) (*domain.Project, error) {
+	var project domain.Project
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND (name = ? OR slug = ?)", name, slug).
+		First(&project).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
 // Create 创建项目
 func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
 	return r.db.WithContext(ctx).Create(project).Error
 }
 
-// Update 更新项目
+// Update 更新项目，修订号只由翻译写入递增，不随项目信息覆盖
 func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
-	return r.db.WithContext(ctx).Save(project).Error
+	return r.db.WithContext(ctx).Omit("revision").Save(project).Error
 }
 
 // Delete 删除项目
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ProjectTemplateRepository 项目模板仓储实现
+type ProjectTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewProjectTemplateRepository 创建项目模板仓储实例
+func NewProjectTemplateRepository(db *gorm.DB) *ProjectTemplateRepository {
+	return &ProjectTemplateRepository{db: db}
+}
+
+// List 获取所有项目模板，按名称排序
+func (r *ProjectTemplateRepository) List(ctx context.Context) ([]*domain.ProjectTemplate, error) {
+	var templates []*domain.ProjectTemplate
+	if err := r.db.WithContext(ctx).Order("name").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetByID 根据ID获取项目模板
+func (r *ProjectTemplateRepository) GetByID(ctx context.Context, id uint64) (*domain.ProjectTemplate, error) {
+	var template domain.ProjectTemplate
+	if err := r.db.WithContext(ctx).First(&template, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Create 创建项目模板
+func (r *ProjectTemplateRepository) Create(ctx context.Context, template *domain.ProjectTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// Update 更新项目模板
+func (r *ProjectTemplateRepository) Update(ctx context.Context, template *domain.ProjectTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+// Delete 删除项目模板
+func (r *ProjectTemplateRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Delete(&domain.ProjectTemplate{}, id).Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ReleaseCriteriaRepository 发布门禁条件仓储实现
+type ReleaseCriteriaRepository struct {
+	db *gorm.DB
+}
+
+// NewReleaseCriteriaRepository 创建发布门禁条件仓储实例
+func NewReleaseCriteriaRepository(db *gorm.DB) *ReleaseCriteriaRepository {
+	return &ReleaseCriteriaRepository{db: db}
+}
+
+// Get 获取项目的发布门禁条件，项目没有保存条件时返回 nil
+func (r *ReleaseCriteriaRepository) Get(ctx context.Context, projectID uint64) (*domain.ReleaseCriteria, error) {
+	var criteria domain.ReleaseCriteria
+	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).First(&criteria).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &criteria, nil
+}
+
+// Save 保存项目的发布门禁条件
+func (r *ReleaseCriteriaRepository) Save(ctx context.Context, criteria *domain.ReleaseCriteria) error {
+	return r.db.WithContext(ctx).Save(criteria).Error
+}
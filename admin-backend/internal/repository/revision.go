@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"i18n-flow/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tombstoneBatchSize 写入删除标记或版本记录时每批的行数
+const tombstoneBatchSize = 500
+
+// nextRevision 递增项目的修订号并返回新值，必须在事务中调用
+// 递增会锁住项目行直到事务结束，同一项目的写入因此按修订号的顺序提交
+func nextRevision(tx *gorm.DB, projectID uint64) (uint64, error) {
+	result := tx.Unscoped().Model(&domain.Project{}).
+		Where("id = ?", projectID).
+		UpdateColumn("revision", gorm.Expr("revision + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, domain.ErrProjectNotFound
+	}
+
+	var revision uint64
+	if err := tx.Unscoped().Model(&domain.Project{}).
+		Where("id = ?", projectID).
+		Select("revision").
+		Scan(&revision).Error; err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
+
+// stampRevisions 为待写入的翻译按项目分配新的修订号，并记录写入后的版本
+func stampRevisions(tx *gorm.DB, translations []*domain.Translation) error {
+	revisions := make(map[uint64]uint64)
+	versions := make([]*domain.TranslationVersion, len(translations))
+	for i, translation := range translations {
+		revision, ok := revisions[translation.ProjectID]
+		if !ok {
+			var err error
+			if revision, err = nextRevision(tx, translation.ProjectID); err != nil {
+				return err
+			}
+			revisions[translation.ProjectID] = revision
+		}
+		translation.Revision = revision
+		versions[i] = &domain.TranslationVersion{
+			ProjectID:  translation.ProjectID,
+			KeyName:    translation.KeyName,
+			LanguageID: translation.LanguageID,
+			Revision:   revision,
+			Value:      translation.Value,
+			Deleted:    translation.Status != "" && translation.Status != "active",
+		}
+	}
+	return recordVersions(tx, versions)
+}
+
+// recordVersions 写入翻译版本记录
+func recordVersions(tx *gorm.DB, versions []*domain.TranslationVersion) error {
+	if len(versions) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(versions, tombstoneBatchSize).Error
+}
+
+// recordTombstones 为 scope 选中的有效翻译写入删除标记和删除后的版本，须在删除或废弃这些翻译之前、在同一事务中调用
+func recordTombstones(tx *gorm.DB, scope func(*gorm.DB) *gorm.DB) error {
+	var cells []struct {
+		ProjectID  uint64
+		KeyName    string
+		LanguageID uint64
+	}
+	if err := tx.Model(&domain.Translation{}).
+		Scopes(scope).
+		Select("project_id, key_name, language_id").
+		Find(&cells).Error; err != nil {
+		return err
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	revisions := make(map[uint64]uint64)
+	tombstones := make([]*domain.TranslationTombstone, len(cells))
+	versions := make([]*domain.TranslationVersion, len(cells))
+	for i, cell := range cells {
+		revision, ok := revisions[cell.ProjectID]
+		if !ok {
+			var err error
+			if revision, err = nextRevision(tx, cell.ProjectID); err != nil {
+				return err
+			}
+			revisions[cell.ProjectID] = revision
+		}
+		tombstones[i] = &domain.TranslationTombstone{
+			ProjectID:  cell.ProjectID,
+			KeyName:    cell.KeyName,
+			LanguageID: cell.LanguageID,
+			Revision:   revision,
+			DeletedAt:  now,
+		}
+		versions[i] = &domain.TranslationVersion{
+			ProjectID:  cell.ProjectID,
+			KeyName:    cell.KeyName,
+			LanguageID: cell.LanguageID,
+			Revision:   revision,
+			Deleted:    true,
+		}
+	}
+	if err := recordVersions(tx, versions); err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}, {Name: "key_name"}, {Name: "language_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revision", "deleted_at"}),
+	}).CreateInBatches(tombstones, tombstoneBatchSize).Error
+}
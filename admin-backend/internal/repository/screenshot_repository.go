@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScreenshotRepository 截图仓储实现
+type ScreenshotRepository struct {
+	db *gorm.DB
+}
+
+// NewScreenshotRepository 创建截图仓储实例
+func NewScreenshotRepository(db *gorm.DB) *ScreenshotRepository {
+	return &ScreenshotRepository{db: db}
+}
+
+// List 分页获取项目截图，keyName 不为空时只返回标注了该键的截图，按上传时间倒序
+func (r *ScreenshotRepository) List(ctx context.Context, projectID uint64, keyName string, limit, offset int) ([]*domain.Screenshot, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Screenshot{}).Where("project_id = ?", projectID)
+	if keyName != "" {
+		query = query.Where("id IN (?)", r.db.Model(&domain.ScreenshotKey{}).
+			Select("screenshot_id").Where("project_id = ? AND key_name = ?", projectID, keyName))
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.Screenshot{}, 0, nil
+	}
+
+	var screenshots []*domain.Screenshot
+	if err := query.Preload("Regions.Keys").Order("id DESC").Limit(limit).Offset(offset).Find(&screenshots).Error; err != nil {
+		return nil, 0, err
+	}
+	return screenshots, total, nil
+}
+
+// ListByKeys 获取标注了任一指定键的截图
+func (r *ScreenshotRepository) ListByKeys(ctx context.Context, projectID uint64, keyNames []string) ([]*domain.Screenshot, error) {
+	if len(keyNames) == 0 {
+		return []*domain.Screenshot{}, nil
+	}
+	var screenshots []*domain.Screenshot
+	err := r.db.WithContext(ctx).
+		Preload("Regions.Keys").
+		Where("project_id = ? AND id IN (?)", projectID, r.db.Model(&domain.ScreenshotKey{}).
+			Select("screenshot_id").Where("project_id = ? AND key_name IN ?", projectID, keyNames)).
+		Order("id DESC").
+		Find(&screenshots).Error
+	return screenshots, err
+}
+
+// GetByID 根据ID获取截图及其区域
+func (r *ScreenshotRepository) GetByID(ctx context.Context, id uint64) (*domain.Screenshot, error) {
+	var screenshot domain.Screenshot
+	if err := r.db.WithContext(ctx).Preload("Regions.Keys").First(&screenshot, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrScreenshotNotFound
+		}
+		return nil, err
+	}
+	return &screenshot, nil
+}
+
+// Create 创建截图
+func (r *ScreenshotRepository) Create(ctx context.Context, screenshot *domain.Screenshot) error {
+	return r.db.WithContext(ctx).Omit("Regions").Create(screenshot).Error
+}
+
+// ReplaceRegions 用 regions 替换截图的所有区域及其标注的键
+func (r *ScreenshotRepository) ReplaceRegions(ctx context.Context, screenshot *domain.Screenshot, regions []*domain.ScreenshotRegion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("screenshot_id = ?", screenshot.ID).Delete(&domain.ScreenshotKey{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("screenshot_id = ?", screenshot.ID).Delete(&domain.ScreenshotRegion{}).Error; err != nil {
+			return err
+		}
+		for _, region := range regions {
+			region.ID = 0
+			region.ScreenshotID = screenshot.ID
+			if err := tx.Omit("Keys").Create(region).Error; err != nil {
+				return err
+			}
+			for _, key := range region.Keys {
+				key.ID = 0
+				key.RegionID = region.ID
+				key.ScreenshotID = screenshot.ID
+				key.ProjectID = screenshot.ProjectID
+			}
+			if len(region.Keys) > 0 {
+				if err := tx.Create(region.Keys).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Model(screenshot).Update("updated_at", time.Now()).Error
+	})
+}
+
+// Delete 删除截图及其区域
+func (r *ScreenshotRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("screenshot_id = ?", id).Delete(&domain.ScreenshotKey{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("screenshot_id = ?", id).Delete(&domain.ScreenshotRegion{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Screenshot{}, id).Error
+	})
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// snapshotBatchSize 写入快照译文时每批的行数
+const snapshotBatchSize = 500
+
+// SnapshotRepository 版本快照仓储实现
+type SnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewSnapshotRepository 创建版本快照仓储实例
+func NewSnapshotRepository(db *gorm.DB) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+// List 分页获取项目的版本快照，按创建时间倒序
+func (r *SnapshotRepository) List(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Snapshot, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Snapshot{}).Where("project_id = ?", projectID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.Snapshot{}, 0, nil
+	}
+
+	var snapshots []*domain.Snapshot
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&snapshots).Error; err != nil {
+		return nil, 0, err
+	}
+	return snapshots, total, nil
+}
+
+// GetByID 根据ID获取版本快照
+func (r *SnapshotRepository) GetByID(ctx context.Context, id uint64) (*domain.Snapshot, error) {
+	var snapshot domain.Snapshot
+	if err := r.db.WithContext(ctx).First(&snapshot, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// GetByName 根据版本名获取项目的版本快照
+func (r *SnapshotRepository) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Snapshot, error) {
+	var snapshot domain.Snapshot
+	if err := r.db.WithContext(ctx).Where("project_id = ? AND name = ?", projectID, name).First(&snapshot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Create 创建版本快照，values 为 键名 -> 语言代码 -> 译文
+// 译文内容按哈希去重，已存在的内容直接引用
+func (r *SnapshotRepository) Create(ctx context.Context, snapshot *domain.Snapshot, values map[string]map[string]string) error {
+	contents := make(map[string]string)
+	var entries []*domain.SnapshotEntry
+	var entryHashes []string
+	for keyName, languages := range values {
+		for code, value := range languages {
+			hash := hashSnapshotValue(value)
+			contents[hash] = value
+			entries = append(entries, &domain.SnapshotEntry{KeyName: keyName, LanguageCode: code})
+			entryHashes = append(entryHashes, hash)
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(snapshot).Error; err != nil {
+			return err
+		}
+
+		hashes := make([]string, 0, len(contents))
+		rows := make([]*domain.SnapshotValue, 0, len(contents))
+		for hash, value := range contents {
+			hashes = append(hashes, hash)
+			rows = append(rows, &domain.SnapshotValue{Hash: hash, Value: value})
+		}
+		if len(rows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, snapshotBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		ids := make(map[string]uint64, len(hashes))
+		for start := 0; start < len(hashes); start += snapshotBatchSize {
+			end := min(start+snapshotBatchSize, len(hashes))
+			var existing []*domain.SnapshotValue
+			if err := tx.Select("id", "hash").Where("hash IN ?", hashes[start:end]).Find(&existing).Error; err != nil {
+				return err
+			}
+			for _, value := range existing {
+				ids[value.Hash] = value.ID
+			}
+		}
+
+		for i, entry := range entries {
+			entry.SnapshotID = snapshot.ID
+			entry.ValueID = ids[entryHashes[i]]
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(entries, snapshotBatchSize).Error
+	})
+}
+
+// GetValues 获取快照的译文（键名 -> 语言代码 -> 译文），languageCode 不为空时只获取该语言
+func (r *SnapshotRepository) GetValues(ctx context.Context, snapshotID uint64, languageCode string) (map[string]map[string]string, error) {
+	var rows []struct {
+		KeyName      string
+		LanguageCode string
+		Value        string
+	}
+	query := r.db.WithContext(ctx).
+		Table("snapshot_entries e").
+		Select("e.key_name, e.language_code, v.value").
+		Joins("INNER JOIN snapshot_values v ON v.id = e.value_id").
+		Where("e.snapshot_id = ?", snapshotID)
+	if languageCode != "" {
+		query = query.Where("e.language_code = ?", languageCode)
+	}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]map[string]string)
+	for _, row := range rows {
+		if values[row.KeyName] == nil {
+			values[row.KeyName] = make(map[string]string)
+		}
+		values[row.KeyName][row.LanguageCode] = row.Value
+	}
+	return values, nil
+}
+
+// Delete 删除版本快照，并清理不再被任何快照引用的译文内容
+func (r *SnapshotRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteSnapshots(tx, []uint64{id})
+	})
+}
+
+// deleteSnapshots 在事务中删除快照及其条目，并清理不再被任何快照引用的译文内容
+func deleteSnapshots(tx *gorm.DB, snapshotIDs []uint64) error {
+	if len(snapshotIDs) == 0 {
+		return nil
+	}
+	var valueIDs []uint64
+	if err := tx.Model(&domain.SnapshotEntry{}).Where("snapshot_id IN ?", snapshotIDs).
+		Distinct().Pluck("value_id", &valueIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("snapshot_id IN ?", snapshotIDs).Delete(&domain.SnapshotEntry{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id IN ?", snapshotIDs).Delete(&domain.Snapshot{}).Error; err != nil {
+		return err
+	}
+
+	for start := 0; start < len(valueIDs); start += snapshotBatchSize {
+		end := min(start+snapshotBatchSize, len(valueIDs))
+		if err := tx.Where("id IN ?", valueIDs[start:end]).
+			Where("NOT EXISTS (SELECT 1 FROM snapshot_entries e WHERE e.value_id = snapshot_values.id)").
+			Delete(&domain.SnapshotValue{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashSnapshotValue 计算译文内容的哈希
+func hashSnapshotValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
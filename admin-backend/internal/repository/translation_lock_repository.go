@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// TranslationLockRepository 翻译锁定仓储实现
+type TranslationLockRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationLockRepository 创建翻译锁定仓储实例
+func NewTranslationLockRepository(db *gorm.DB) *TranslationLockRepository {
+	return &TranslationLockRepository{db: db}
+}
+
+// ListByProject 获取项目的所有锁定，按键名和语言排序
+func (r *TranslationLockRepository) ListByProject(ctx context.Context, projectID uint64) ([]*domain.TranslationLock, error) {
+	var locks []*domain.TranslationLock
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("key_name, language_id").
+		Find(&locks).Error; err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+// GetByID 根据ID获取锁定
+func (r *TranslationLockRepository) GetByID(ctx context.Context, id uint64) (*domain.TranslationLock, error) {
+	var lock domain.TranslationLock
+	if err := r.db.WithContext(ctx).First(&lock, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrLockNotFound
+		}
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// Create 创建锁定
+func (r *TranslationLockRepository) Create(ctx context.Context, lock *domain.TranslationLock) error {
+	return r.db.WithContext(ctx).Create(lock).Error
+}
+
+// Delete 删除锁定
+func (r *TranslationLockRepository) Delete(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Delete(&domain.TranslationLock{}, id).Error
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TranslationMemoryRepository 翻译记忆仓储实现
+type TranslationMemoryRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationMemoryRepository 创建翻译记忆仓储实例
+func NewTranslationMemoryRepository(db *gorm.DB) *TranslationMemoryRepository {
+	return &TranslationMemoryRepository{db: db}
+}
+
+// FindProjectCandidates 查找项目翻译中原文可能匹配的记录
+// 原文和译文均为未删除、未废弃、非机器翻译草稿的非空翻译，结果按译文更新时间倒序
+func (r *TranslationMemoryRepository) FindProjectCandidates(ctx context.Context, query domain.TranslationMemoryQuery) ([]*domain.TranslationMemoryMatch, error) {
+	matches := make([]*domain.TranslationMemoryMatch, 0)
+	if query.ProjectIDs != nil && len(query.ProjectIDs) == 0 {
+		return matches, nil
+	}
+
+	db := r.db.WithContext(ctx).
+		Table("translations s").
+		Select("s.value AS source_text, t.value AS target_text, s.project_id, p.name AS project_name, s.key_name, t.updated_at").
+		Joins("INNER JOIN languages ls ON ls.id = s.language_id AND ls.code = ?", query.SourceLanguage).
+		Joins("INNER JOIN languages lt ON lt.code = ?", query.TargetLanguage).
+		Joins("INNER JOIN translations t ON t.project_id = s.project_id AND t.key_name = s.key_name AND t.language_id = lt.id"+
+			" AND t.status = ? AND t.deleted_at IS NULL AND t.value <> '' AND t.machine_translated = ?", "active", false).
+		Joins("INNER JOIN projects p ON p.id = s.project_id AND p.deleted_at IS NULL").
+		Where("s.status = ? AND s.deleted_at IS NULL AND s.value <> '' AND s.machine_translated = ?", "active", false)
+	if query.ProjectIDs != nil {
+		db = db.Where("s.project_id IN ?", query.ProjectIDs)
+	}
+	if query.Exact != "" {
+		db = db.Where("s.value = ?", query.Exact)
+	} else {
+		db = applyFuzzyConditions(db, "s.value", query)
+	}
+
+	if err := db.Order("t.updated_at DESC").Limit(query.Limit).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// FindImportedCandidates 查找导入的翻译记忆中原文可能匹配的记录
+func (r *TranslationMemoryRepository) FindImportedCandidates(ctx context.Context, query domain.TranslationMemoryQuery) ([]*domain.TranslationMemoryMatch, error) {
+	db := r.db.WithContext(ctx).
+		Table("translation_memory_segments s").
+		Select("s.text AS source_text, t.text AS target_text, u.origin AS imported_from, u.created_at AS updated_at").
+		Joins("INNER JOIN translation_memory_segments t ON t.unit_id = s.unit_id AND t.language = ?", query.TargetLanguage).
+		Joins("INNER JOIN translation_memory_units u ON u.id = s.unit_id").
+		Where("s.language = ?", query.SourceLanguage)
+	if query.ExactHash != "" {
+		db = db.Where("s.text_hash = ?", query.ExactHash)
+	} else {
+		db = applyFuzzyConditions(db, "s.text", query)
+	}
+
+	matches := make([]*domain.TranslationMemoryMatch, 0)
+	if err := db.Order("u.id DESC").Limit(query.Limit).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// applyFuzzyConditions 按原文长度范围和检索词预筛选模糊匹配的候选
+func applyFuzzyConditions(db *gorm.DB, column string, query domain.TranslationMemoryQuery) *gorm.DB {
+	if query.MaxLength > 0 {
+		db = db.Where("CHAR_LENGTH("+column+") BETWEEN ? AND ?", query.MinLength, query.MaxLength)
+	}
+	if len(query.Terms) > 0 {
+		conditions := make([]string, len(query.Terms))
+		args := make([]interface{}, len(query.Terms))
+		for i, term := range query.Terms {
+			conditions[i] = column + " LIKE ?"
+			args[i] = "%" + internal_utils.EscapeLike(term) + "%"
+		}
+		db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	return db
+}
+
+// ListProjectSegments 获取项目中所有未删除、未废弃、非机器翻译草稿的非空翻译，按项目和键名排序
+func (r *TranslationMemoryRepository) ListProjectSegments(ctx context.Context, projectIDs []uint64) ([]*domain.TranslationMemorySegmentRow, error) {
+	rows := make([]*domain.TranslationMemorySegmentRow, 0)
+	if projectIDs != nil && len(projectIDs) == 0 {
+		return rows, nil
+	}
+
+	db := r.db.WithContext(ctx).
+		Table("translations t").
+		Select("t.project_id, p.name AS project_name, t.key_name, l.code AS language, t.value").
+		Joins("INNER JOIN languages l ON l.id = t.language_id").
+		Joins("INNER JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL").
+		Where("t.status = ? AND t.deleted_at IS NULL AND t.value <> '' AND t.machine_translated = ?", "active", false)
+	if projectIDs != nil {
+		db = db.Where("t.project_id IN ?", projectIDs)
+	}
+
+	if err := db.Order("t.project_id, t.key_name").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ListImportedUnits 分页获取导入的翻译记忆单元及其各语言文本
+func (r *TranslationMemoryRepository) ListImportedUnits(ctx context.Context, limit, offset int) ([]*domain.TranslationMemoryUnit, error) {
+	var units []*domain.TranslationMemoryUnit
+	err := r.db.WithContext(ctx).
+		Preload("Segments").
+		Order("id").
+		Limit(limit).
+		Offset(offset).
+		Find(&units).Error
+	return units, err
+}
+
+// GetExistingUnitHashes 返回已存在的翻译记忆单元哈希
+func (r *TranslationMemoryRepository) GetExistingUnitHashes(ctx context.Context, hashes []string) ([]string, error) {
+	existing := make([]string, 0)
+	for start := 0; start < len(hashes); start += keyNameChunkSize {
+		end := min(start+keyNameChunkSize, len(hashes))
+		var chunk []string
+		if err := r.db.WithContext(ctx).
+			Model(&domain.TranslationMemoryUnit{}).
+			Where("content_hash IN ?", hashes[start:end]).
+			Pluck("content_hash", &chunk).Error; err != nil {
+			return nil, err
+		}
+		existing = append(existing, chunk...)
+	}
+	return existing, nil
+}
+
+// CreateUnits 批量创建翻译记忆单元及其各语言文本
+func (r *TranslationMemoryRepository) CreateUnits(ctx context.Context, units []*domain.TranslationMemoryUnit) error {
+	if len(units) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(units, 100).Error
+	})
+}
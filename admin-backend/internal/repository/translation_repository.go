@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -32,6 +34,18 @@ func (r *TranslationRepository) GetByID(ctx context.Context, id uint64) (*domain
 	return &translation, nil
 }
 
+// GetByIDs 根据ID批量获取翻译，不存在的ID被忽略
+func (r *TranslationRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	if len(ids) == 0 {
+		return translations, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
 // GetByProjectID 根据项目ID获取翻译（分页）
 func (r *TranslationRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
 	var translations []*domain.Translation
@@ -180,43 +194,138 @@ func (r *TranslationRepository) GetMatrix(ctx context.Context, projectID uint64,
 		return make(map[string]map[string]domain.TranslationCell), totalCount, nil
 	}
 
+	matrix, err := r.GetCellsByKeys(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return matrix, totalCount, nil
+}
+
+// GetKeyNames 获取多个项目中有效翻译的键名（去重并排序），keyword 匹配键名或译文
+func (r *TranslationRepository) GetKeyNames(ctx context.Context, projectIDs []uint64, keyword string) ([]string, error) {
+	var keyNames []string
+	if len(projectIDs) == 0 {
+		return keyNames, nil
+	}
+
+	query := r.db.WithContext(ctx).Model(&domain.Translation{}).
+		Select("DISTINCT key_name").
+		Where("project_id IN ? AND status = ?", projectIDs, "active")
+	if keyword != "" {
+		query = query.Where("key_name LIKE ? OR value LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+	}
+	if err := query.Order("key_name").Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// GetCellsByKeys 获取指定键名的翻译单元格（key-language映射）
+func (r *TranslationRepository) GetCellsByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]domain.TranslationCell, error) {
+	matrix := make(map[string]map[string]domain.TranslationCell)
+	if len(keyNames) == 0 {
+		return matrix, nil
+	}
+
 	// 优化：使用JOIN查询避免N+1问题，只查询必要字段
 	var results []struct {
-		ID           uint64 `gorm:"column:id"`
-		KeyName      string `gorm:"column:key_name"`
-		LanguageCode string `gorm:"column:language_code"`
-		Value        string `gorm:"column:value"`
+		ID                uint64 `gorm:"column:id"`
+		KeyName           string `gorm:"column:key_name"`
+		LanguageCode      string `gorm:"column:language_code"`
+		Value             string `gorm:"column:value"`
+		MachineTranslated bool   `gorm:"column:machine_translated"`
 	}
 
 	err := r.db.WithContext(ctx).
 		Table("translations t").
-		Select("t.id, t.key_name, l.code as language_code, t.value").
+		Select("t.id, t.key_name, l.code as language_code, t.value, t.machine_translated").
 		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
-		Where("t.project_id = ? AND t.key_name IN ? AND t.status = ?", projectID, keyNames, "active").
+		Where("t.project_id = ? AND t.key_name IN ? AND t.status = ? AND t.deleted_at IS NULL", projectID, keyNames, "active").
 		Find(&results).Error
 
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	// 构建矩阵
-	matrix := make(map[string]map[string]domain.TranslationCell)
 	for _, result := range results {
 		if matrix[result.KeyName] == nil {
 			matrix[result.KeyName] = make(map[string]domain.TranslationCell)
 		}
 		matrix[result.KeyName][result.LanguageCode] = domain.TranslationCell{
-			ID:    result.ID,
-			Value: result.Value,
+			ID:                result.ID,
+			Value:             result.Value,
+			MachineTranslated: result.MachineTranslated,
 		}
 	}
 
-	return matrix, totalCount, nil
+	return matrix, nil
+}
+
+// GetKeyLanguageStats 获取指定前缀下每个键在每种语言中的翻译状态
+// prefix 为空时返回整个项目的统计
+func (r *TranslationRepository) GetKeyLanguageStats(ctx context.Context, projectID uint64, prefix string) ([]domain.KeyLanguageStat, error) {
+	var results []struct {
+		KeyName      string    `gorm:"column:key_name"`
+		LanguageCode string    `gorm:"column:language_code"`
+		Translated   bool      `gorm:"column:translated"`
+		UpdatedAt    time.Time `gorm:"column:updated_at"`
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("translations t").
+		Select("t.key_name, l.code as language_code, (t.value <> '') as translated, t.updated_at").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+		Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", projectID, "active")
+	if prefix != "" {
+		query = query.Where("t.key_name LIKE ?", internal_utils.EscapeLike(prefix)+"%")
+	}
+
+	if err := query.Order("t.key_name").Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.KeyLanguageStat, len(results))
+	for i, result := range results {
+		stats[i] = domain.KeyLanguageStat{
+			KeyName:      result.KeyName,
+			LanguageCode: result.LanguageCode,
+			Translated:   result.Translated,
+			UpdatedAt:    result.UpdatedAt,
+		}
+	}
+	return stats, nil
+}
+
+// DeleteByKeyPrefix 删除指定前缀下的所有翻译，返回删除的行数
+func (r *TranslationRepository) DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error) {
+	if prefix == "" {
+		return 0, domain.ErrInvalidKeyPath
+	}
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where("project_id = ? AND key_name LIKE ?", projectID, internal_utils.EscapeLike(prefix)+"%")
+	}
+	var affected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := recordTombstones(tx, scope); err != nil {
+			return err
+		}
+		result := tx.Scopes(scope).Delete(&domain.Translation{})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
 }
 
 // Create 创建翻译
 func (r *TranslationRepository) Create(ctx context.Context, translation *domain.Translation) error {
-	return r.db.WithContext(ctx).Create(translation).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := stampRevisions(tx, []*domain.Translation{translation}); err != nil {
+			return err
+		}
+		return tx.Create(translation).Error
+	})
 }
 
 // CreateBatch 批量创建翻译
@@ -224,29 +333,51 @@ func (r *TranslationRepository) CreateBatch(ctx context.Context, translations []
 	if len(translations) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).CreateInBatches(translations, 100).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := stampRevisions(tx, translations); err != nil {
+			return err
+		}
+		return tx.CreateInBatches(translations, 100).Error
+	})
 }
 
 // Update 更新翻译
 func (r *TranslationRepository) Update(ctx context.Context, translation *domain.Translation) error {
-	return r.db.WithContext(ctx).Save(translation).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := stampRevisions(tx, []*domain.Translation{translation}); err != nil {
+			return err
+		}
+		return tx.Save(translation).Error
+	})
 }
 
 // Delete 删除翻译
 func (r *TranslationRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.Translation{}, id).Error
+	return r.DeleteBatch(ctx, []uint64{id})
 }
 
-// DeleteBatch 批量删除翻译
+// DeleteBatch 批量删除翻译，同时记录删除标记
 func (r *TranslationRepository) DeleteBatch(ctx context.Context, ids []uint64) error {
 	if len(ids) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Delete(&domain.Translation{}, ids).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteTranslations(tx, ids)
+	})
+}
+
+// deleteTranslations 写入删除标记后软删除翻译，必须在事务中调用
+func deleteTranslations(tx *gorm.DB, ids []uint64) error {
+	if err := recordTombstones(tx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN ?", ids)
+	}); err != nil {
+		return err
+	}
+	return tx.Delete(&domain.Translation{}, ids).Error
 }
 
 // UpsertBatch 批量创建或更新翻译
-// 如果翻译已存在（基于唯一索引：project_id + key_name + language_id），则更新
+// 如果翻译已存在（基于唯一索引：project_id + key_name + language_id，仅约束未删除的翻译），则更新
 // 如果不存在，则创建
 // 使用数据库原生的 UPSERT 能力（MySQL: ON DUPLICATE KEY UPDATE, PostgreSQL: ON CONFLICT DO UPDATE）
 func (r *TranslationRepository) UpsertBatch(ctx context.Context, translations []*domain.Translation) error {
@@ -259,16 +390,126 @@ func (r *TranslationRepository) UpsertBatch(ctx context.Context, translations []
 	// - MySQL: INSERT ... ON DUPLICATE KEY UPDATE
 	// - PostgreSQL: INSERT ... ON CONFLICT ... DO UPDATE
 	// - SQLite: INSERT ... ON CONFLICT ... DO UPDATE
-	return r.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			// 基于唯一索引 idx_translation_unique (project_id, key_name, language_id)
-			Columns: []clause.Column{
-				{Name: "project_id"},
-				{Name: "key_name"},
-				{Name: "language_id"},
-			},
-			// 冲突时更新这些字段
-			DoUpdates: clause.AssignmentColumns([]string{"value", "context", "updated_at"}),
-		}).
-		Create(&translations).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return upsertTranslations(tx, translations)
+	})
+}
+
+// upsertTranslations 分配修订号后创建或更新翻译，必须在事务中调用
+func upsertTranslations(tx *gorm.DB, translations []*domain.Translation) error {
+	if err := stampRevisions(tx, translations); err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		// 基于唯一索引 idx_translation_active_unique (project_id, key_name, language_id, live_flag)
+		Columns: []clause.Column{
+			{Name: "project_id"},
+			{Name: "key_name"},
+			{Name: "language_id"},
+		},
+		// 冲突时更新这些字段，新值来自人工提交，同时清除机器翻译草稿标记
+		DoUpdates: clause.AssignmentColumns([]string{"value", "context", "machine_translated", "revision", "updated_at"}),
+	}).Create(&translations).Error
+}
+
+// GetChangesSince 获取项目在 since 之后写入的有效翻译和删除标记，since 为 0 时返回全部有效翻译且没有删除标记
+// 先读取项目的当前修订号，只返回不超过该修订号的变更，读取期间提交的写入留给下一次同步
+func (r *TranslationRepository) GetChangesSince(ctx context.Context, projectID, since uint64) (*domain.TranslationChanges, error) {
+	var project domain.Project
+	if err := r.db.WithContext(ctx).Select("id, revision").First(&project, projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	changes := &domain.TranslationChanges{
+		Revision: project.Revision,
+		Changed:  make(map[string]map[string]domain.TranslationCell),
+		Deleted:  []domain.TranslationCellRef{},
+	}
+	if since > 0 && since >= project.Revision {
+		return changes, nil
+	}
+
+	var results []struct {
+		ID                uint64 `gorm:"column:id"`
+		KeyName           string `gorm:"column:key_name"`
+		LanguageCode      string `gorm:"column:language_code"`
+		Value             string `gorm:"column:value"`
+		MachineTranslated bool   `gorm:"column:machine_translated"`
+	}
+	// 修订号上线之前写入的翻译修订号为 0，只在 since 为 0 的全量同步中返回
+	query := r.db.WithContext(ctx).
+		Table("translations t").
+		Select("t.id, t.key_name, l.code as language_code, t.value, t.machine_translated").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+		Where("t.project_id = ? AND t.revision <= ?", projectID, project.Revision).
+		Where("t.status = ? AND t.deleted_at IS NULL", "active")
+	if since > 0 {
+		query = query.Where("t.revision > ?", since)
+	}
+	if err := query.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if changes.Changed[result.KeyName] == nil {
+			changes.Changed[result.KeyName] = make(map[string]domain.TranslationCell)
+		}
+		changes.Changed[result.KeyName][result.LanguageCode] = domain.TranslationCell{
+			ID:                result.ID,
+			Value:             result.Value,
+			MachineTranslated: result.MachineTranslated,
+		}
+	}
+
+	if since == 0 {
+		return changes, nil
+	}
+
+	// 删除后又重新写入的单元格以有效翻译为准，不再下发删除
+	if err := r.db.WithContext(ctx).
+		Table("translation_tombstones ts").
+		Select("ts.key_name, l.code as language_code").
+		Joins("INNER JOIN languages l ON ts.language_id = l.id").
+		Where("ts.project_id = ? AND ts.revision > ? AND ts.revision <= ?", projectID, since, project.Revision).
+		Where("NOT EXISTS (?)", r.db.Table("translations t").Select("1").
+			Where("t.project_id = ts.project_id AND t.key_name = ts.key_name AND t.language_id = ts.language_id").
+			Where("t.status = ? AND t.deleted_at IS NULL", "active")).
+		Order("ts.key_name, l.code").
+		Find(&changes.Deleted).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetValuesAt 由版本记录还原项目在指定修订号时的译文（键名 -> 语言代码 -> 译文），只包含启用的语言
+func (r *TranslationRepository) GetValuesAt(ctx context.Context, projectID, revision uint64) (map[string]map[string]string, error) {
+	latest := r.db.Model(&domain.TranslationVersion{}).
+		Select("MAX(id) AS id").
+		Where("project_id = ? AND revision <= ?", projectID, revision).
+		Group("key_name, language_id")
+
+	var results []struct {
+		KeyName      string `gorm:"column:key_name"`
+		LanguageCode string `gorm:"column:language_code"`
+		Value        string `gorm:"column:value"`
+	}
+	if err := r.db.WithContext(ctx).
+		Table("translation_versions v").
+		Select("v.key_name, l.code as language_code, v.value").
+		Joins("INNER JOIN (?) latest ON latest.id = v.id", latest).
+		Joins("INNER JOIN languages l ON v.language_id = l.id AND l.status = ?", "active").
+		Where("v.deleted = ?", false).
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]map[string]string)
+	for _, result := range results {
+		if values[result.KeyName] == nil {
+			values[result.KeyName] = make(map[string]string)
+		}
+		values[result.KeyName][result.LanguageCode] = result.Value
+	}
+	return values, nil
 }
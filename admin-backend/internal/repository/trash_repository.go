@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrashRepository 回收站仓储实现
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository 创建回收站仓储实例
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// ListDeletedKeys 按键名分组列出项目回收站中的翻译（分页）
+func (r *TrashRepository) ListDeletedKeys(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*domain.TrashedKey, int64, error) {
+	query := r.db.WithContext(ctx).Unscoped().
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID)
+	if keyword != "" {
+		query = query.Where("key_name LIKE ?", "%"+internal_utils.EscapeLike(keyword)+"%")
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Distinct("key_name").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.TrashedKey{}, 0, nil
+	}
+
+	var groups []struct {
+		KeyName   string    `gorm:"column:key_name"`
+		Count     int       `gorm:"column:cnt"`
+		DeletedAt time.Time `gorm:"column:last_deleted_at"`
+	}
+	if err := query.Session(&gorm.Session{}).
+		Select("key_name, COUNT(*) AS cnt, MAX(deleted_at) AS last_deleted_at").
+		Group("key_name").
+		Order("last_deleted_at DESC, key_name").
+		Limit(limit).Offset(offset).
+		Scan(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+
+	keyNames := make([]string, len(groups))
+	for i, group := range groups {
+		keyNames[i] = group.KeyName
+	}
+
+	// 查询每个键涉及的语言
+	var languageRows []struct {
+		KeyName string `gorm:"column:key_name"`
+		Code    string `gorm:"column:code"`
+	}
+	if len(keyNames) > 0 {
+		if err := r.db.WithContext(ctx).
+			Table("translations t").
+			Select("DISTINCT t.key_name, l.code").
+			Joins("INNER JOIN languages l ON t.language_id = l.id").
+			Where("t.project_id = ? AND t.key_name IN ? AND t.deleted_at IS NOT NULL", projectID, keyNames).
+			Scan(&languageRows).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	languagesByKey := make(map[string][]string)
+	for _, row := range languageRows {
+		languagesByKey[row.KeyName] = append(languagesByKey[row.KeyName], row.Code)
+	}
+
+	keys := make([]*domain.TrashedKey, len(groups))
+	for i, group := range groups {
+		languages := languagesByKey[group.KeyName]
+		if languages == nil {
+			languages = []string{}
+		}
+		sort.Strings(languages)
+		keys[i] = &domain.TrashedKey{
+			KeyName:          group.KeyName,
+			Languages:        languages,
+			TranslationCount: group.Count,
+			DeletedAt:        group.DeletedAt,
+		}
+	}
+
+	return keys, total, nil
+}
+
+// GetDeletedTranslations 获取项目回收站中指定键的翻译，按删除时间倒序
+func (r *TrashRepository) GetDeletedTranslations(ctx context.Context, projectID uint64, keyNames []string) ([]*domain.Translation, error) {
+	if len(keyNames) == 0 {
+		return []*domain.Translation{}, nil
+	}
+
+	var translations []*domain.Translation
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("project_id = ? AND key_name IN ? AND deleted_at IS NOT NULL", projectID, keyNames).
+		Order("deleted_at DESC, id DESC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetExistingKeyNames 返回项目中仍存在（未删除）的键名
+func (r *TrashRepository) GetExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	if len(keyNames) == 0 {
+		return []string{}, nil
+	}
+
+	var existing []string
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name IN ?", projectID, keyNames).
+		Distinct().
+		Pluck("key_name", &existing).Error; err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// RestoreTranslations 在同一事务中先将 displaceIDs 对应的现有翻译移入回收站，再恢复回收站中的翻译
+func (r *TrashRepository) RestoreTranslations(ctx context.Context, restores []domain.TranslationRestore, displaceIDs []uint64) error {
+	if len(restores) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(displaceIDs) > 0 {
+			if err := recordTombstones(tx, func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN ?", displaceIDs)
+			}); err != nil {
+				return err
+			}
+			if err := tx.Delete(&domain.Translation{}, displaceIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		// 恢复的翻译写入新的修订号，增量同步时作为变更下发
+		ids := make([]uint64, len(restores))
+		for i, restore := range restores {
+			ids[i] = restore.ID
+		}
+		var rows []struct {
+			ID         uint64
+			ProjectID  uint64
+			LanguageID uint64
+			Value      string
+		}
+		if err := tx.Unscoped().Model(&domain.Translation{}).
+			Where("id IN ?", ids).
+			Select("id, project_id, language_id, value").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		keyNames := make(map[uint64]string, len(restores))
+		for _, restore := range restores {
+			keyNames[restore.ID] = restore.KeyName
+		}
+		projectRevisions := make(map[uint64]uint64)
+		revisions := make(map[uint64]uint64, len(rows))
+		versions := make([]*domain.TranslationVersion, len(rows))
+		for i, row := range rows {
+			revision, ok := projectRevisions[row.ProjectID]
+			if !ok {
+				var err error
+				if revision, err = nextRevision(tx, row.ProjectID); err != nil {
+					return err
+				}
+				projectRevisions[row.ProjectID] = revision
+			}
+			revisions[row.ID] = revision
+			versions[i] = &domain.TranslationVersion{
+				ProjectID:  row.ProjectID,
+				KeyName:    keyNames[row.ID],
+				LanguageID: row.LanguageID,
+				Revision:   revision,
+				Value:      row.Value,
+			}
+		}
+		if err := recordVersions(tx, versions); err != nil {
+			return err
+		}
+
+		for _, restore := range restores {
+			result := tx.Unscoped().
+				Model(&domain.Translation{}).
+				Where("id = ? AND deleted_at IS NOT NULL", restore.ID).
+				Updates(map[string]interface{}{
+					"key_name":   restore.KeyName,
+					"deleted_at": nil,
+					"revision":   revisions[restore.ID],
+					"updated_at": time.Now(),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrTrashItemNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// PurgeDeletedKeys 彻底删除项目回收站中的翻译，keyNames 为空时清空整个项目回收站
+func (r *TrashRepository) PurgeDeletedKeys(ctx context.Context, projectID uint64, keyNames []string) (int64, error) {
+	query := r.db.WithContext(ctx).Unscoped().
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID)
+	if len(keyNames) > 0 {
+		query = query.Where("key_name IN ?", keyNames)
+	}
+
+	result := query.Delete(&domain.Translation{})
+	return result.RowsAffected, result.Error
+}
+
+// ListDeletedProjects 列出回收站中的项目（分页）
+func (r *TrashRepository) ListDeletedProjects(ctx context.Context, keyword string, limit, offset int) ([]*domain.TrashedProject, int64, error) {
+	query := r.db.WithContext(ctx).Unscoped().
+		Model(&domain.Project{}).
+		Where("deleted_at IS NOT NULL")
+	if keyword != "" {
+		pattern := "%" + internal_utils.EscapeLike(keyword) + "%"
+		query = query.Where("(name LIKE ? OR slug LIKE ?)", pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.TrashedProject{}, 0, nil
+	}
+
+	var projects []*domain.Project
+	if err := query.Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+
+	trashed := make([]*domain.TrashedProject, len(projects))
+	for i, project := range projects {
+		trashed[i] = &domain.TrashedProject{
+			ID:          project.ID,
+			Name:        project.Name,
+			Slug:        project.Slug,
+			Description: project.Description,
+			DeletedAt:   project.DeletedAt.Time,
+		}
+	}
+	return trashed, total, nil
+}
+
+// RestoreProject 恢复回收站中的项目
+func (r *TrashRepository) RestoreProject(ctx context.Context, id uint64) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&domain.Project{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTrashItemNotFound
+	}
+	return nil
+}
+
+// PurgeProject 彻底删除回收站中的项目及其全部翻译、标签、术语、截图、快照、分支、分发发布和成员，返回需要从文件存储中删除的文件
+func (r *TrashRepository) PurgeProject(ctx context.Context, id uint64) (*domain.PurgedProjectFiles, error) {
+	files := &domain.PurgedProjectFiles{StorageKeys: []string{}, BundleHashes: []string{}}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Unscoped().Model(&domain.Project{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrTrashItemNotFound
+		}
+
+		// 截图文件在事务提交后由调用方从文件存储中删除
+		var screenshots []*domain.Screenshot
+		if err := tx.Select("storage_key", "thumbnail_key").Where("project_id = ?", id).Find(&screenshots).Error; err != nil {
+			return err
+		}
+		for _, screenshot := range screenshots {
+			files.StorageKeys = append(files.StorageKeys, screenshot.StorageKey, screenshot.ThumbnailKey)
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.ScreenshotKey{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("screenshot_id IN (?)", tx.Model(&domain.Screenshot{}).Select("id").Where("project_id = ?", id)).
+			Delete(&domain.ScreenshotRegion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.Screenshot{}).Error; err != nil {
+			return err
+		}
+
+		// 分发令牌、发布和译文包，译文包文件在事务提交后由调用方删除
+		if err := tx.Model(&domain.DistributionBundle{}).
+			Where("release_id IN (?)", tx.Model(&domain.DistributionRelease{}).Select("id").Where("project_id = ?", id)).
+			Distinct().Pluck("hash", &files.BundleHashes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("release_id IN (?)", tx.Model(&domain.DistributionRelease{}).Select("id").Where("project_id = ?", id)).
+			Delete(&domain.DistributionBundle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.DistributionRelease{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.DistributionToken{}).Error; err != nil {
+			return err
+		}
+
+		var snapshotIDs []uint64
+		if err := tx.Model(&domain.Snapshot{}).Where("project_id = ?", id).Pluck("id", &snapshotIDs).Error; err != nil {
+			return err
+		}
+		if err := deleteSnapshots(tx, snapshotIDs); err != nil {
+			return err
+		}
+
+		if err := tx.Where("branch_id IN (?)", tx.Model(&domain.TranslationBranch{}).Select("id").Where("project_id = ?", id)).
+			Delete(&domain.BranchChange{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.TranslationBranch{}).Error; err != nil {
+			return err
+		}
+
+		// 删除没有外键约束的关联数据
+		for _, model := range []interface{}{&domain.KeyTag{}, &domain.KeyReference{}, &domain.KeyUsage{}, &domain.KeyScan{}, &domain.TranslationTombstone{}, &domain.TranslationVersion{}, &domain.ReleaseCriteria{}, &domain.TranslationLock{}, &domain.GitSync{}, &domain.MachineTranslationUsage{}} {
+			if err := tx.Where("project_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("entry_id IN (?)", tx.Model(&domain.GlossaryEntry{}).Select("id").Where("project_id = ?", id)).
+			Delete(&domain.GlossaryTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&domain.GlossaryEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("project_id = ?", id).Delete(&domain.Translation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("project_id = ?", id).Delete(&domain.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ? OR base_project_id = ?", id, id).Delete(&domain.ProjectBase{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&domain.Project{}, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PurgeTranslationsDeletedBefore 彻底删除在指定时间之前移入回收站的翻译
+func (r *TrashRepository) PurgeTranslationsDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&domain.Translation{})
+	return result.RowsAffected, result.Error
+}
+
+// GetProjectIDsDeletedBefore 获取在指定时间之前移入回收站的项目ID
+func (r *TrashRepository) GetProjectIDsDeletedBefore(ctx context.Context, before time.Time) ([]uint64, error) {
+	var ids []uint64
+	if err := r.db.WithContext(ctx).Unscoped().
+		Model(&domain.Project{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxBranchKeyNameLength 键名最大长度
+	maxBranchKeyNameLength = 255
+	// branchContextBatchSize 合并时每批查询上下文说明的单元格数
+	branchContextBatchSize = 500
+)
+
+var branchNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,99}$`)
+
+// BranchService 翻译分支服务实现
+// 分支只保存修改过的单元格，读取时叠加在主干当前的译文上；合并通过带缓存的翻译服务写入主干
+type BranchService struct {
+	branchRepo         domain.BranchRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+}
+
+// NewBranchService 创建翻译分支服务实例
+func NewBranchService(
+	branchRepo domain.BranchRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+) *BranchService {
+	return &BranchService{
+		branchRepo:         branchRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+	}
+}
+
+// List 获取项目的翻译分支
+func (s *BranchService) List(ctx context.Context, projectID uint64) ([]*domain.TranslationBranch, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.branchRepo.List(ctx, projectID)
+}
+
+// Create 创建翻译分支，分支名在项目内唯一
+func (s *BranchService) Create(ctx context.Context, params domain.CreateBranchParams) (*domain.TranslationBranch, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	name := strings.TrimSpace(params.Name)
+	if !branchNamePattern.MatchString(name) {
+		return nil, domain.ErrInvalidBranchName
+	}
+	if _, err := s.branchRepo.GetByName(ctx, params.ProjectID, name); err == nil {
+		return nil, domain.ErrBranchExists
+	} else if err != domain.ErrBranchNotFound {
+		return nil, err
+	}
+
+	branch := &domain.TranslationBranch{
+		ProjectID:   params.ProjectID,
+		Name:        name,
+		Description: strings.TrimSpace(params.Description),
+		Status:      domain.BranchStatusOpen,
+		CreatedBy:   params.UserID,
+	}
+	if err := s.branchRepo.Create(ctx, branch); err != nil {
+		return nil, err
+	}
+	return branch, nil
+}
+
+// Get 获取翻译分支及其修改
+func (s *BranchService) Get(ctx context.Context, projectID, id uint64) (*domain.BranchDetail, error) {
+	branch, err := s.getBranch(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
+	if err != nil {
+		return nil, err
+	}
+	branch.ChangeCount = len(changes)
+	return &domain.BranchDetail{TranslationBranch: branch, Changes: changes}, nil
+}
+
+// Delete 删除翻译分支，未合并的修改一并丢弃
+func (s *BranchService) Delete(ctx context.Context, projectID, id uint64) error {
+	if _, err := s.getBranch(ctx, projectID, id); err != nil {
+		return err
+	}
+	return s.branchRepo.Delete(ctx, id)
+}
+
+// GetMatrix 获取分支上的译文（键名 -> 语言代码 -> 译文）：主干当前的译文叠加分支的修改
+func (s *BranchService) GetMatrix(ctx context.Context, projectID, id uint64) (map[string]map[string]string, error) {
+	branch, err := s.getBranch(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.branchMatrix(ctx, branch)
+}
+
+// SetValues 修改分支上的单元格，改回主干原值的单元格不再算作修改
+func (s *BranchService) SetValues(ctx context.Context, params domain.SetBranchValuesParams) (*domain.SetBranchValuesResult, error) {
+	branch, err := s.openBranch(ctx, params.ProjectID, params.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Cells) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	return s.setCells(ctx, branch, params.Cells, params.UserID)
+}
+
+// Revert 丢弃分支上指定键的所有修改，返回丢弃的单元格数
+func (s *BranchService) Revert(ctx context.Context, projectID, id uint64, keyNames []string) (int, error) {
+	branch, err := s.openBranch(ctx, projectID, id)
+	if err != nil {
+		return 0, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make(map[string]bool, len(keyNames))
+	for _, keyName := range keyNames {
+		keys[strings.TrimSpace(keyName)] = true
+	}
+	var reverted []*domain.BranchChange
+	for _, change := range changes {
+		if keys[change.KeyName] {
+			reverted = append(reverted, change)
+		}
+	}
+	if err := s.branchRepo.DeleteChanges(ctx, branch.ID, reverted); err != nil {
+		return 0, err
+	}
+	return len(reverted), nil
+}
+
+// Merge 把分支的修改合并到主干
+// 主干在分支修改之后也修改了的单元格为冲突，须在 Resolutions 中逐一解决，存在未解决的冲突时不做任何修改
+func (s *BranchService) Merge(ctx context.Context, params domain.MergeBranchParams) (*domain.BranchMergeResult, error) {
+	branch, err := s.openBranch(ctx, params.ProjectID, params.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyNames := make([]string, 0, len(changes))
+	seen := make(map[string]bool)
+	for _, change := range changes {
+		if !seen[change.KeyName] {
+			seen[change.KeyName] = true
+			keyNames = append(keyNames, change.KeyName)
+		}
+	}
+	mainCells, err := s.translationRepo.GetCellsByKeys(ctx, params.ProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+
+	apply, conflicts := PlanBranchMerge(toSimpleMatrix(mainCells), changes)
+
+	resolutions := make(map[string]domain.BranchResolution, len(params.Resolutions))
+	for _, resolution := range params.Resolutions {
+		switch resolution.Take {
+		case domain.BranchResolutionBranch, domain.BranchResolutionMain, domain.BranchResolutionValue:
+		default:
+			return nil, domain.ErrInvalidInput
+		}
+		resolutions[resolution.KeyName+"\x00"+resolution.LanguageCode] = resolution
+	}
+	result := &domain.BranchMergeResult{Conflicts: []*domain.BranchConflict{}}
+	for _, conflict := range conflicts {
+		resolution, ok := resolutions[conflict.KeyName+"\x00"+conflict.LanguageCode]
+		switch {
+		case !ok:
+			result.Conflicts = append(result.Conflicts, conflict)
+		case resolution.Take == domain.BranchResolutionBranch:
+			change := &domain.BranchChange{KeyName: conflict.KeyName, LanguageCode: conflict.LanguageCode, Deleted: conflict.Branch == nil}
+			if conflict.Branch != nil {
+				change.Value = *conflict.Branch
+			}
+			apply = append(apply, change)
+		case resolution.Take == domain.BranchResolutionValue:
+			apply = append(apply, &domain.BranchChange{KeyName: conflict.KeyName, LanguageCode: conflict.LanguageCode, Value: resolution.Value})
+		}
+	}
+	for _, change := range apply {
+		if change.Deleted {
+			if _, exists := mainCells[change.KeyName][change.LanguageCode]; exists {
+				result.Deleted++
+			}
+		} else {
+			result.Updated++
+		}
+	}
+	if len(result.Conflicts) > 0 || params.DryRun {
+		return result, nil
+	}
+
+	if err := s.applyToMain(ctx, params.ProjectID, apply, changes, mainCells); err != nil {
+		return nil, err
+	}
+	if err := s.branchRepo.MarkMerged(ctx, branch.ID, params.UserID); err != nil {
+		return nil, err
+	}
+	result.Merged = true
+	return result, nil
+}
+
+// PushKeys 把 CLI 推送的新键添加到分支，主干或分支上已有的键视为已存在
+func (s *BranchService) PushKeys(ctx context.Context, params domain.PushKeysParams) (*domain.PushKeysResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	branch, err := s.branchRepo.GetByName(ctx, params.ProjectID, params.Branch)
+	if err != nil {
+		return nil, err
+	}
+	if branch.Status != domain.BranchStatusOpen {
+		return nil, domain.ErrBranchMerged
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(languages) == 0 {
+		return nil, domain.ErrNoLanguages
+	}
+	defaultLanguage := languages[0]
+	for _, language := range languages {
+		if language.IsDefault {
+			defaultLanguage = language
+			break
+		}
+	}
+
+	matrix, err := s.branchMatrix(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PushKeysResult{
+		Added:   []string{},
+		Existed: []string{},
+		Failed:  []string{},
+	}
+	var cells []domain.BranchCellInput
+	for _, key := range params.Keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, exists := matrix[key]; exists {
+			result.Existed = append(result.Existed, key)
+			continue
+		}
+		if len(key) > maxBranchKeyNameLength {
+			result.Failed = append(result.Failed, key)
+			continue
+		}
+
+		for _, language := range languages {
+			var value string
+			if params.Translations != nil {
+				value = params.Translations[language.Code][key]
+			} else if language.ID == defaultLanguage.ID {
+				value = params.Defaults[key]
+			}
+			cells = append(cells, domain.BranchCellInput{KeyName: key, LanguageCode: language.Code, Value: value})
+		}
+		result.Added = append(result.Added, key)
+		// 同一次推送中重复的键视为已存在
+		matrix[key] = nil
+	}
+
+	if len(cells) > 0 {
+		if _, err := s.setCells(ctx, branch, cells, params.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getBranch 获取翻译分支，分支不属于项目时视为不存在
+func (s *BranchService) getBranch(ctx context.Context, projectID, id uint64) (*domain.TranslationBranch, error) {
+	branch, err := s.branchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if branch.ProjectID != projectID {
+		return nil, domain.ErrBranchNotFound
+	}
+	return branch, nil
+}
+
+// openBranch 获取未合并的翻译分支
+func (s *BranchService) openBranch(ctx context.Context, projectID, id uint64) (*domain.TranslationBranch, error) {
+	branch, err := s.getBranch(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if branch.Status != domain.BranchStatusOpen {
+		return nil, domain.ErrBranchMerged
+	}
+	return branch, nil
+}
+
+// branchMatrix 主干当前的译文叠加分支的修改
+func (s *BranchService) branchMatrix(ctx context.Context, branch *domain.TranslationBranch) (map[string]map[string]string, error) {
+	matrix, _, err := s.translationRepo.GetMatrix(ctx, branch.ProjectID, -1, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyBranchChanges(toSimpleMatrix(matrix), changes), nil
+}
+
+// setCells 记录分支上单元格的修改
+// 单元格第一次修改时记录主干当时的状态，修改后与该状态相同的单元格删除修改记录
+func (s *BranchService) setCells(ctx context.Context, branch *domain.TranslationBranch, inputs []domain.BranchCellInput, userID uint64) (*domain.SetBranchValuesResult, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodes := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		languageCodes[language.Code] = true
+	}
+
+	keyNames := make([]string, 0, len(inputs))
+	seen := make(map[string]bool)
+	for i := range inputs {
+		input := &inputs[i]
+		input.KeyName = strings.TrimSpace(input.KeyName)
+		if input.KeyName == "" || len(input.KeyName) > maxBranchKeyNameLength {
+			return nil, domain.ErrInvalidInput
+		}
+		if input.LanguageCode == "" {
+			if !input.Deleted {
+				return nil, domain.ErrInvalidInput
+			}
+		} else if !languageCodes[input.LanguageCode] {
+			return nil, domain.ErrLanguageNotFound
+		}
+		if !seen[input.KeyName] {
+			seen[input.KeyName] = true
+			keyNames = append(keyNames, input.KeyName)
+		}
+	}
+
+	mainCells, err := s.translationRepo.GetCellsByKeys(ctx, branch.ProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]map[string]*domain.BranchChange)
+	for _, change := range changes {
+		if existing[change.KeyName] == nil {
+			existing[change.KeyName] = make(map[string]*domain.BranchChange)
+		}
+		existing[change.KeyName][change.LanguageCode] = change
+	}
+
+	// 删除整个键时展开为该键在主干和分支上的所有单元格，同一单元格以最后一次修改为准
+	type cellKey struct{ keyName, languageCode string }
+	var order []cellKey
+	cells := make(map[cellKey]domain.BranchCellInput)
+	addCell := func(input domain.BranchCellInput) {
+		key := cellKey{input.KeyName, input.LanguageCode}
+		if _, ok := cells[key]; !ok {
+			order = append(order, key)
+		}
+		cells[key] = input
+	}
+	for _, input := range inputs {
+		if input.LanguageCode != "" {
+			input.Value = strings.TrimSpace(input.Value)
+			addCell(input)
+			continue
+		}
+		codes := make(map[string]bool)
+		for code := range mainCells[input.KeyName] {
+			codes[code] = true
+		}
+		for code := range existing[input.KeyName] {
+			codes[code] = true
+		}
+		for code := range codes {
+			addCell(domain.BranchCellInput{KeyName: input.KeyName, LanguageCode: code, Deleted: true})
+		}
+	}
+
+	result := &domain.SetBranchValuesResult{}
+	var save, revert []*domain.BranchChange
+	for _, key := range order {
+		input := cells[key]
+		change := existing[key.keyName][key.languageCode]
+		if change == nil {
+			cell, exists := mainCells[key.keyName][key.languageCode]
+			change = &domain.BranchChange{
+				BranchID:     branch.ID,
+				KeyName:      key.keyName,
+				LanguageCode: key.languageCode,
+				BaseValue:    cell.Value,
+				BaseExists:   exists,
+			}
+		} else if change.Context != "" && input.Context == "" {
+			input.Context = change.Context
+		}
+
+		if sameCellState(change.BaseValue, change.BaseExists, input.Value, !input.Deleted) {
+			if existing[key.keyName][key.languageCode] != nil {
+				revert = append(revert, change)
+				result.Reverted++
+			}
+			continue
+		}
+		change.Value = input.Value
+		change.Deleted = input.Deleted
+		change.Context = strings.TrimSpace(input.Context)
+		change.UpdatedBy = userID
+		if change.Deleted {
+			change.Value = ""
+		}
+		save = append(save, change)
+		result.Changed++
+	}
+
+	if err := s.branchRepo.SaveChanges(ctx, save); err != nil {
+		return nil, err
+	}
+	if err := s.branchRepo.DeleteChanges(ctx, branch.ID, revert); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyToMain 通过翻译服务把合并结果写入主干，上下文说明优先使用分支上记录的
+func (s *BranchService) applyToMain(ctx context.Context, projectID uint64, apply, changes []*domain.BranchChange, mainCells map[string]map[string]domain.TranslationCell) error {
+	branchContexts := make(map[string]string)
+	for _, change := range changes {
+		if change.Context != "" {
+			branchContexts[change.KeyName] = change.Context
+		}
+	}
+	writer := cellWriter{
+		languageRepo:       s.languageRepo,
+		translationRepo:    s.translationRepo,
+		translationService: s.translationService,
+	}
+	return writer.write(ctx, projectID, apply, branchContexts, mainCells)
+}
+
+// cellWriter 通过带缓存的翻译服务把单元格修改写入主干
+type cellWriter struct {
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+}
+
+// write 写入单元格修改，Deleted 的单元格从主干删除，不存在的语言被跳过
+// 上下文说明优先使用 keyContexts 中的，其次是主干该单元格的，最后是该键默认语言翻译的
+func (w cellWriter) write(ctx context.Context, projectID uint64, apply []*domain.BranchChange, keyContexts map[string]string, mainCells map[string]map[string]domain.TranslationCell) error {
+	languages, err := w.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	languageIDs := make(map[string]uint64, len(languages))
+	var defaultLanguageID uint64
+	for _, language := range languages {
+		languageIDs[language.Code] = language.ID
+		if language.IsDefault {
+			defaultLanguageID = language.ID
+		}
+	}
+
+	var upserts []*domain.BranchChange
+	var deleteIDs []uint64
+	var lookups []domain.TranslationKey
+	writes := make([]domain.CellWrite, 0, len(apply))
+	for _, change := range apply {
+		languageID, ok := languageIDs[change.LanguageCode]
+		if !ok {
+			// 已被删除的语言
+			continue
+		}
+		if change.Deleted {
+			if cell, exists := mainCells[change.KeyName][change.LanguageCode]; exists {
+				deleteIDs = append(deleteIDs, cell.ID)
+				writes = append(writes, domain.CellWrite{KeyName: change.KeyName, LanguageID: languageID, Delete: true})
+			}
+			continue
+		}
+		upserts = append(upserts, change)
+		writes = append(writes, domain.CellWrite{KeyName: change.KeyName, LanguageID: languageID, Value: strings.TrimSpace(change.Value)})
+		if keyContexts[change.KeyName] == "" {
+			lookups = append(lookups, domain.TranslationKey{ProjectID: projectID, KeyName: change.KeyName, LanguageID: languageID})
+			if defaultLanguageID != 0 && defaultLanguageID != languageID {
+				lookups = append(lookups, domain.TranslationKey{ProjectID: projectID, KeyName: change.KeyName, LanguageID: defaultLanguageID})
+			}
+		}
+	}
+
+	contexts := make(map[string]map[uint64]string)
+	for start := 0; start < len(lookups); start += branchContextBatchSize {
+		end := min(start+branchContextBatchSize, len(lookups))
+		translations, err := w.translationRepo.GetByProjectKeyLanguages(ctx, lookups[start:end])
+		if err != nil {
+			return err
+		}
+		for _, translation := range translations {
+			if contexts[translation.KeyName] == nil {
+				contexts[translation.KeyName] = make(map[uint64]string)
+			}
+			contexts[translation.KeyName][translation.LanguageID] = translation.Context
+		}
+	}
+
+	inputs := make([]domain.TranslationInput, 0, len(upserts))
+	for _, change := range upserts {
+		languageID := languageIDs[change.LanguageCode]
+		keyContext := keyContexts[change.KeyName]
+		if keyContext == "" {
+			var ok bool
+			if keyContext, ok = contexts[change.KeyName][languageID]; !ok {
+				keyContext = contexts[change.KeyName][defaultLanguageID]
+			}
+		}
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  projectID,
+			LanguageID: languageID,
+			KeyName:    change.KeyName,
+			Context:    keyContext,
+			Value:      change.Value,
+		})
+	}
+
+	// 写入和删除分两次提交，先检查全部修改是否违反字符串冻结或锁定，避免删除被拒绝时写入已经提交
+	if err := w.translationService.CheckWrites(ctx, projectID, writes); err != nil {
+		return err
+	}
+	if err := w.translationService.UpsertBatch(ctx, inputs); err != nil {
+		return err
+	}
+	if len(deleteIDs) > 0 {
+		return w.translationService.DeleteBatch(ctx, deleteIDs)
+	}
+	return nil
+}
+
+// ApplyBranchChanges 把分支的修改叠加到译文矩阵（键名 -> 语言代码 -> 译文）上，直接修改并返回 matrix
+// 删除的单元格从矩阵中移除，所有单元格都被删除的键一并移除
+func ApplyBranchChanges(matrix map[string]map[string]string, changes []*domain.BranchChange) map[string]map[string]string {
+	for _, change := range changes {
+		if change.Deleted {
+			if cells, ok := matrix[change.KeyName]; ok {
+				delete(cells, change.LanguageCode)
+				if len(cells) == 0 {
+					delete(matrix, change.KeyName)
+				}
+			}
+			continue
+		}
+		if matrix[change.KeyName] == nil {
+			matrix[change.KeyName] = make(map[string]string)
+		}
+		matrix[change.KeyName][change.LanguageCode] = change.Value
+	}
+	return matrix
+}
+
+// PlanBranchMerge 按单元格三方比较分支修改前的主干、主干当前和分支的状态
+// 主干未变的单元格采用分支的修改；主干与分支改成相同状态的单元格无需处理；其余为冲突，按键名和语言排序
+func PlanBranchMerge(main map[string]map[string]string, changes []*domain.BranchChange) ([]*domain.BranchChange, []*domain.BranchConflict) {
+	var apply []*domain.BranchChange
+	var conflicts []*domain.BranchConflict
+	for _, change := range changes {
+		mainValue, mainExists := main[change.KeyName][change.LanguageCode]
+		switch {
+		case sameCellState(mainValue, mainExists, change.Value, !change.Deleted):
+			continue
+		case sameCellState(mainValue, mainExists, change.BaseValue, change.BaseExists):
+			apply = append(apply, change)
+		default:
+			conflict := &domain.BranchConflict{KeyName: change.KeyName, LanguageCode: change.LanguageCode}
+			if change.BaseExists {
+				conflict.Base = &change.BaseValue
+			}
+			if mainExists {
+				conflict.Main = &mainValue
+			}
+			if !change.Deleted {
+				conflict.Branch = &change.Value
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].KeyName != conflicts[j].KeyName {
+			return conflicts[i].KeyName < conflicts[j].KeyName
+		}
+		return conflicts[i].LanguageCode < conflicts[j].LanguageCode
+	})
+	return apply, conflicts
+}
+
+// sameCellState 判断两个单元格状态（是否存在及译文）是否相同
+func sameCellState(value string, exists bool, otherValue string, otherExists bool) bool {
+	if !exists || !otherExists {
+		return exists == otherExists
+	}
+	return value == otherValue
+}
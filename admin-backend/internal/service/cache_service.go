@@ -187,6 +187,14 @@ func (s *CacheService) GetProjectsKey() string {
 	return domain.ProjectsKey
 }
 
+// invalidateTranslationCaches 清除项目的翻译列表、翻译矩阵和仪表板缓存
+// 供不经过 CachedTranslationService 修改翻译数据的服务使用
+func invalidateTranslationCaches(ctx context.Context, cacheService domain.CacheService, projectID uint64) {
+	cacheService.DeleteByPattern(ctx, cacheService.GetTranslationKey(projectID)+"*")
+	cacheService.DeleteByPattern(ctx, cacheService.GetTranslationMatrixKey(projectID, "")+"*")
+	cacheService.Delete(ctx, cacheService.GetDashboardStatsKey())
+}
+
 // isEmptyValue 检查值是否为空
 func isEmptyValue(value interface{}) bool {
 	switch v := value.(type) {
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// maxMergeKeys 一次最多合并或统一的键数
+const maxMergeKeys = 100
+
+// ConsistencyService 翻译一致性检查服务实现
+// 默认语言原文相同（忽略首尾和连续空白）的有效键视为重复键，重复键在其他语言下的译文不同时视为不一致
+type ConsistencyService struct {
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	keyMergeRepo       domain.KeyMergeRepository
+	translationService domain.TranslationService
+}
+
+// NewConsistencyService 创建翻译一致性检查服务实例
+func NewConsistencyService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	keyMergeRepo domain.KeyMergeRepository,
+	translationService domain.TranslationService,
+) *ConsistencyService {
+	return &ConsistencyService{
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		keyMergeRepo:       keyMergeRepo,
+		translationService: translationService,
+	}
+}
+
+// Analyze 找出项目中的重复键及其不一致的译文，不一致的组排在前面
+// inconsistentOnly 为 true 时只返回存在不一致译文的组，统计数仍包含所有组
+func (s *ConsistencyService) Analyze(ctx context.Context, projectID uint64, inconsistentOnly bool) (*domain.ConsistencyReport, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	translations, _, err := s.translationRepo.GetByProjectID(ctx, projectID, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string][]string)
+	sourceValues := make(map[string]string)
+	values := make(map[string]map[string]string)
+	for _, translation := range translations {
+		if translation.Language.ID == 0 || translation.Language.Status != "active" {
+			continue
+		}
+		if translation.LanguageID == defaultLanguage.ID {
+			normalized := internal_utils.NormalizeSegment(translation.Value)
+			if translation.Status == "active" && normalized != "" {
+				sources[normalized] = append(sources[normalized], translation.KeyName)
+				if _, ok := sourceValues[normalized]; !ok {
+					sourceValues[normalized] = translation.Value
+				}
+			}
+			continue
+		}
+		if translation.Value == "" {
+			continue
+		}
+		if values[translation.KeyName] == nil {
+			values[translation.KeyName] = make(map[string]string)
+		}
+		values[translation.KeyName][translation.Language.Code] = translation.Value
+	}
+
+	report := &domain.ConsistencyReport{
+		SourceLanguage: defaultLanguage.Code,
+		Groups:         []*domain.DuplicateGroup{},
+	}
+	for normalized, keyNames := range sources {
+		if len(keyNames) < 2 {
+			continue
+		}
+		sort.Strings(keyNames)
+		group := buildDuplicateGroup(sourceValues[normalized], keyNames, values)
+		report.DuplicateKeys += len(keyNames)
+		if len(group.InconsistentLanguages) > 0 {
+			report.InconsistentGroups++
+		} else if inconsistentOnly {
+			continue
+		}
+		report.Groups = append(report.Groups, group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if (len(a.InconsistentLanguages) > 0) != (len(b.InconsistentLanguages) > 0) {
+			return len(a.InconsistentLanguages) > 0
+		}
+		if len(a.Keys) != len(b.Keys) {
+			return len(a.Keys) > len(b.Keys)
+		}
+		return a.Source < b.Source
+	})
+	return report, nil
+}
+
+// buildDuplicateGroup 汇总一组重复键在各语言下的译文，只保留存在多种非空译文的语言
+func buildDuplicateGroup(source string, keyNames []string, values map[string]map[string]string) *domain.DuplicateGroup {
+	variants := make(map[string]map[string][]string)
+	for _, keyName := range keyNames {
+		for code, value := range values[keyName] {
+			if variants[code] == nil {
+				variants[code] = make(map[string][]string)
+			}
+			variants[code][value] = append(variants[code][value], keyName)
+		}
+	}
+
+	group := &domain.DuplicateGroup{
+		Source:                source,
+		Keys:                  keyNames,
+		InconsistentLanguages: []string{},
+		Variants:              make(map[string][]*domain.TranslationVariant),
+	}
+	for code, byValue := range variants {
+		if len(byValue) < 2 {
+			continue
+		}
+		list := make([]*domain.TranslationVariant, 0, len(byValue))
+		for value, keys := range byValue {
+			list = append(list, &domain.TranslationVariant{Value: value, Keys: keys})
+		}
+		// 使用最多的译文排在前面，便于选择统一后的译文
+		sort.Slice(list, func(i, j int) bool {
+			if len(list[i].Keys) != len(list[j].Keys) {
+				return len(list[i].Keys) > len(list[j].Keys)
+			}
+			return list[i].Value < list[j].Value
+		})
+		group.InconsistentLanguages = append(group.InconsistentLanguages, code)
+		group.Variants[code] = list
+	}
+	sort.Strings(group.InconsistentLanguages)
+	return group
+}
+
+// MergeKeys 把原文相同的 SourceKeys 合并到 TargetKey
+// 目标键缺少的译文从被合并的键中补充（按 SourceKeys 顺序取第一个非空译文），
+// 标签、代码引用和截图标注改为目标键，被合并的键的翻译移入回收站
+func (s *ConsistencyService) MergeKeys(ctx context.Context, params domain.MergeKeysParams) (*domain.MergeKeysResult, error) {
+	targetKey := strings.TrimSpace(params.TargetKey)
+	sourceKeys := uniqueKeyNames(params.SourceKeys, targetKey)
+	if targetKey == "" || len(sourceKeys) == 0 || len(sourceKeys)+1 > maxMergeKeys {
+		return nil, domain.ErrInvalidInput
+	}
+
+	keySet, err := s.loadDuplicateKeys(ctx, params.ProjectID, append([]string{targetKey}, sourceKeys...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.MergeKeysResult{
+		TargetKey:       targetKey,
+		MergedKeys:      sourceKeys,
+		FilledLanguages: []string{},
+		Discarded:       []*domain.DiscardedValue{},
+	}
+	var fills []*domain.Translation
+	for _, language := range keySet.languages {
+		if language.ID == keySet.defaultLanguage.ID {
+			continue
+		}
+		value := keySet.value(targetKey, language.ID)
+		if value == "" {
+			for _, keyName := range sourceKeys {
+				if candidate := keySet.value(keyName, language.ID); candidate != "" {
+					value = candidate
+					fills = append(fills, &domain.Translation{
+						ProjectID:  params.ProjectID,
+						KeyName:    targetKey,
+						Context:    strings.TrimSpace(keySet.context(targetKey, language.ID)),
+						LanguageID: language.ID,
+						Value:      value,
+						Status:     "active",
+					})
+					result.FilledLanguages = append(result.FilledLanguages, language.Code)
+					break
+				}
+			}
+		}
+		for _, keyName := range sourceKeys {
+			if discarded := keySet.value(keyName, language.ID); discarded != "" && discarded != value {
+				result.Discarded = append(result.Discarded, &domain.DiscardedValue{
+					KeyName:  keyName,
+					Language: language.Code,
+					Value:    discarded,
+				})
+			}
+		}
+	}
+
+	var ids []uint64
+	writes := make([]domain.CellWrite, 0, len(fills))
+	for _, fill := range fills {
+		writes = append(writes, domain.CellWrite{KeyName: fill.KeyName, LanguageID: fill.LanguageID, Value: fill.Value})
+	}
+	for _, keyName := range sourceKeys {
+		for _, translation := range keySet.translations[keyName] {
+			ids = append(ids, translation.ID)
+			writes = append(writes, domain.CellWrite{KeyName: keyName, LanguageID: translation.LanguageID, Delete: true})
+		}
+	}
+	// 补充译文、改写引用和删除被合并的键在同一个事务中写入，写入前检查全部修改是否违反字符串冻结或锁定
+	if err := s.translationService.CheckWrites(ctx, params.ProjectID, writes); err != nil {
+		return nil, err
+	}
+	if err := s.keyMergeRepo.MergeKeys(ctx, domain.KeyMerge{
+		ProjectID:  params.ProjectID,
+		TargetKey:  targetKey,
+		SourceKeys: sourceKeys,
+		Fills:      fills,
+		DeleteIDs:  ids,
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Harmonize 把原文相同的一组键在指定语言下的译文统一为同一个值
+func (s *ConsistencyService) Harmonize(ctx context.Context, params domain.HarmonizeParams) (*domain.HarmonizeResult, error) {
+	fromKey := strings.TrimSpace(params.FromKey)
+	keyNames := uniqueKeyNames(append(params.KeyNames, fromKey), "")
+	if len(keyNames) == 0 || len(keyNames) > maxMergeKeys {
+		return nil, domain.ErrInvalidInput
+	}
+	language, err := s.languageRepo.GetByCode(ctx, strings.TrimSpace(params.Language))
+	if err != nil || language == nil || language.Status != "active" {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	keySet, err := s.loadDuplicateKeys(ctx, params.ProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	if language.ID == keySet.defaultLanguage.ID {
+		return nil, domain.ErrInvalidInput
+	}
+
+	value := params.Value
+	if value == "" && fromKey != "" {
+		value = keySet.value(fromKey, language.ID)
+	}
+	if value == "" {
+		return nil, domain.ErrHarmonizeNoValue
+	}
+
+	result := &domain.HarmonizeResult{Language: language.Code, Value: value, Updated: []string{}}
+	var inputs []domain.TranslationInput
+	for _, keyName := range keyNames {
+		if keySet.value(keyName, language.ID) == value {
+			continue
+		}
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  params.ProjectID,
+			LanguageID: language.ID,
+			KeyName:    keyName,
+			Context:    keySet.context(keyName, language.ID),
+			Value:      value,
+		})
+		result.Updated = append(result.Updated, keyName)
+	}
+	if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// duplicateKeySet 一组原文相同的键在所有语言下的翻译
+type duplicateKeySet struct {
+	defaultLanguage *domain.Language
+	languages       []*domain.Language
+	translations    map[string]map[uint64]*domain.Translation // 键名 -> 语言ID -> 翻译
+}
+
+// value 获取键在某语言下的译文
+func (k *duplicateKeySet) value(keyName string, languageID uint64) string {
+	if translation, ok := k.translations[keyName][languageID]; ok {
+		return translation.Value
+	}
+	return ""
+}
+
+// context 获取键的上下文说明，该语言下没有翻译时使用默认语言翻译的上下文
+func (k *duplicateKeySet) context(keyName string, languageID uint64) string {
+	if translation, ok := k.translations[keyName][languageID]; ok {
+		return translation.Context
+	}
+	if translation, ok := k.translations[keyName][k.defaultLanguage.ID]; ok {
+		return translation.Context
+	}
+	return ""
+}
+
+// loadDuplicateKeys 加载键的所有翻译，并检查这些键都是有效键且默认语言原文相同
+func (s *ConsistencyService) loadDuplicateKeys(ctx context.Context, projectID uint64, keyNames []string) (*duplicateKeySet, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.TranslationKey, 0, len(keyNames)*len(languages))
+	for _, keyName := range keyNames {
+		for _, language := range languages {
+			keys = append(keys, domain.TranslationKey{ProjectID: projectID, KeyName: keyName, LanguageID: language.ID})
+		}
+	}
+	translations, err := s.translationRepo.GetByProjectKeyLanguages(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &duplicateKeySet{
+		defaultLanguage: defaultLanguage,
+		languages:       languages,
+		translations:    make(map[string]map[uint64]*domain.Translation, len(keyNames)),
+	}
+	for _, translation := range translations {
+		if keySet.translations[translation.KeyName] == nil {
+			keySet.translations[translation.KeyName] = make(map[uint64]*domain.Translation)
+		}
+		keySet.translations[translation.KeyName][translation.LanguageID] = translation
+	}
+
+	var missing []string
+	source := ""
+	for i, keyName := range keyNames {
+		translation, ok := keySet.translations[keyName][defaultLanguage.ID]
+		if !ok || translation.Status != "active" {
+			missing = append(missing, keyName)
+			continue
+		}
+		normalized := internal_utils.NormalizeSegment(translation.Value)
+		if normalized == "" || (i > 0 && source != "" && normalized != source) {
+			return nil, domain.ErrKeysNotDuplicates
+		}
+		source = normalized
+	}
+	if len(missing) > 0 {
+		return nil, domain.NewAppErrorWithDetails(
+			domain.ErrorTypeValidation,
+			"UNKNOWN_MERGE_KEYS",
+			"键不存在或已废弃",
+			"无效的键: "+strings.Join(missing, ", "),
+		)
+	}
+	return keySet, nil
+}
+
+// uniqueKeyNames 去除空白、空键名、重复键名和 exclude
+func uniqueKeyNames(keyNames []string, exclude string) []string {
+	seen := map[string]bool{exclude: true, "": true}
+	result := make([]string, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		keyName = strings.TrimSpace(keyName)
+		if seen[keyName] {
+			continue
+		}
+		seen[keyName] = true
+		result = append(result, keyName)
+	}
+	return result
+}
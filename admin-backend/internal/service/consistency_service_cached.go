@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+)
+
+// CachedConsistencyService 带缓存失效处理的翻译一致性检查服务实现
+// 合并键在一个事务中直接写入翻译，不经过带缓存的翻译服务，合并后需清除翻译缓存
+type CachedConsistencyService struct {
+	consistencyService *ConsistencyService
+	cacheService       domain.CacheService
+}
+
+// NewCachedConsistencyService 创建带缓存失效处理的翻译一致性检查服务实例
+func NewCachedConsistencyService(
+	consistencyService *ConsistencyService,
+	cacheService domain.CacheService,
+) *CachedConsistencyService {
+	return &CachedConsistencyService{
+		consistencyService: consistencyService,
+		cacheService:       cacheService,
+	}
+}
+
+// Analyze 找出项目中的重复键（不缓存）
+func (s *CachedConsistencyService) Analyze(ctx context.Context, projectID uint64, inconsistentOnly bool) (*domain.ConsistencyReport, error) {
+	return s.consistencyService.Analyze(ctx, projectID, inconsistentOnly)
+}
+
+// MergeKeys 合并翻译键（清除翻译缓存）
+func (s *CachedConsistencyService) MergeKeys(ctx context.Context, params domain.MergeKeysParams) (*domain.MergeKeysResult, error) {
+	result, err := s.consistencyService.MergeKeys(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateTranslationCaches(ctx, s.cacheService, params.ProjectID)
+
+	return result, nil
+}
+
+// Harmonize 统一译文（通过带缓存的翻译服务写入，缓存已随之清除）
+func (s *CachedConsistencyService) Harmonize(ctx context.Context, params domain.HarmonizeParams) (*domain.HarmonizeResult, error) {
+	return s.consistencyService.Harmonize(ctx, params)
+}
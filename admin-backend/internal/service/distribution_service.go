@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	// distributionTokenPrefix 分发令牌前缀，便于在代码和日志中识别
+	distributionTokenPrefix = "dist_"
+	// distributionTokenDisplayLength 列表中显示的令牌前缀长度
+	distributionTokenDisplayLength = 12
+	// maxDistributionTokenNameLength 令牌名称最大长度
+	maxDistributionTokenNameLength = 100
+)
+
+var (
+	environmentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+	bundleHashPattern  = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// DistributionService 译文分发服务实现
+// 发布时为每种语言生成译文包并预先压缩，按内容哈希保存在文件存储中；清单列出各语言译文包的哈希并用 Ed25519 签名
+type DistributionService struct {
+	distributionRepo   domain.DistributionRepository
+	projectRepo        domain.ProjectRepository
+	translationService domain.TranslationService
+	snapshotRepo       domain.SnapshotRepository
+	storage            domain.FileStorage
+	signingKey         ed25519.PrivateKey
+}
+
+// NewDistributionService 创建译文分发服务实例，signingKey 为空时不能发布
+func NewDistributionService(
+	distributionRepo domain.DistributionRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+	snapshotRepo domain.SnapshotRepository,
+	storage domain.FileStorage,
+	signingKey ed25519.PrivateKey,
+) *DistributionService {
+	return &DistributionService{
+		distributionRepo:   distributionRepo,
+		projectRepo:        projectRepo,
+		translationService: translationService,
+		snapshotRepo:       snapshotRepo,
+		storage:            storage,
+		signingKey:         signingKey,
+	}
+}
+
+// ListTokens 获取项目的分发令牌
+func (s *DistributionService) ListTokens(ctx context.Context, projectID uint64) ([]*domain.DistributionToken, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.distributionRepo.ListTokens(ctx, projectID)
+}
+
+// CreateToken 为项目的一个环境创建分发令牌，完整令牌只在返回值中出现一次
+func (s *DistributionService) CreateToken(ctx context.Context, params domain.CreateDistributionTokenParams) (*domain.DistributionToken, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if !environmentPattern.MatchString(params.Environment) {
+		return nil, domain.ErrInvalidEnvironment
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len([]rune(name)) > maxDistributionTokenNameLength {
+		return nil, domain.ErrInvalidInput
+	}
+
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	plain := distributionTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	token := &domain.DistributionToken{
+		ProjectID:   params.ProjectID,
+		Environment: params.Environment,
+		Name:        name,
+		TokenHash:   internal_utils.SHA256Hex([]byte(plain)),
+		TokenPrefix: plain[:distributionTokenDisplayLength],
+		CreatedBy:   params.UserID,
+	}
+	if err := s.distributionRepo.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+	token.Token = plain
+	return token, nil
+}
+
+// DeleteToken 删除分发令牌，使用该令牌的客户端立即无法获取译文
+func (s *DistributionService) DeleteToken(ctx context.Context, projectID, id uint64) error {
+	token, err := s.distributionRepo.GetTokenByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if token.ProjectID != projectID {
+		return domain.ErrDistributionTokenNotFound
+	}
+	return s.distributionRepo.DeleteToken(ctx, id)
+}
+
+// ListReleases 分页获取项目的发布记录
+func (s *DistributionService) ListReleases(ctx context.Context, projectID uint64, environment string, limit, offset int) ([]*domain.DistributionRelease, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	return s.distributionRepo.ListReleases(ctx, projectID, environment, limit, offset)
+}
+
+// Publish 把版本快照或当前的译文发布到环境，客户端下次获取清单时看到新版本
+func (s *DistributionService) Publish(ctx context.Context, params domain.PublishDistributionParams) (*domain.DistributionRelease, error) {
+	if s.signingKey == nil {
+		return nil, domain.ErrDistributionSigningDisabled
+	}
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if !environmentPattern.MatchString(params.Environment) {
+		return nil, domain.ErrInvalidEnvironment
+	}
+
+	release := &domain.DistributionRelease{
+		ProjectID:   params.ProjectID,
+		Environment: params.Environment,
+		CreatedBy:   params.UserID,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	manifest := &domain.DistributionManifest{
+		ProjectID:   params.ProjectID,
+		Environment: params.Environment,
+		PublishedAt: release.CreatedAt,
+		KeyID:       internal_utils.Ed25519KeyID(s.signingKey.Public().(ed25519.PublicKey)),
+		Bundles:     make(map[string]*domain.DistributionManifestItem),
+	}
+
+	var values map[string]map[string]string
+	if params.SnapshotID != 0 {
+		snapshot, err := s.snapshotRepo.GetByID(ctx, params.SnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.ProjectID != params.ProjectID {
+			return nil, domain.ErrSnapshotNotFound
+		}
+		if values, err = s.snapshotRepo.GetValues(ctx, snapshot.ID, ""); err != nil {
+			return nil, err
+		}
+		release.SnapshotID = &snapshot.ID
+		manifest.Snapshot = snapshot.Name
+	} else {
+		matrix, _, err := s.translationService.GetMatrix(ctx, params.ProjectID, -1, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		values = nonEmptyMatrix(matrix)
+	}
+
+	locales := make(map[string]map[string]string)
+	for keyName, languages := range values {
+		for code, value := range languages {
+			if locales[code] == nil {
+				locales[code] = make(map[string]string)
+			}
+			locales[code][keyName] = value
+		}
+	}
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		bundle, err := s.storeBundle(ctx, params.ProjectID, code, locales[code])
+		if err != nil {
+			return nil, err
+		}
+		release.Bundles = append(release.Bundles, bundle)
+		manifest.Bundles[code] = &domain.DistributionManifestItem{
+			SHA256: bundle.Hash,
+			Size:   bundle.Size,
+			Keys:   bundle.Keys,
+			URL:    "bundles/" + bundle.Hash,
+		}
+	}
+
+	// 版本号在仓储的事务中分配，清单带上版本号后签名
+	sign := func(release *domain.DistributionRelease) error {
+		manifest.Version = release.Version
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		release.Manifest = string(data)
+		release.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.signingKey, data))
+		release.KeyID = manifest.KeyID
+		return nil
+	}
+	if err := s.distributionRepo.CreateRelease(ctx, release, sign); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// storeBundle 序列化一种语言的译文包，连同 gzip 和 brotli 压缩版本写入文件存储
+// 文件名为内容哈希，内容未变的译文包在多次发布之间共用
+func (s *DistributionService) storeBundle(ctx context.Context, projectID uint64, locale string, values map[string]string) (*domain.DistributionBundle, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	gzipped, err := internal_utils.GzipBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	brotli, err := internal_utils.BrotliBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &domain.DistributionBundle{
+		Locale:     locale,
+		Hash:       internal_utils.SHA256Hex(data),
+		Keys:       len(values),
+		Size:       int64(len(data)),
+		GzipSize:   int64(len(gzipped)),
+		BrotliSize: int64(len(brotli)),
+	}
+	files := map[string][]byte{
+		internal_utils.EncodingIdentity: data,
+		internal_utils.EncodingGzip:     gzipped,
+		internal_utils.EncodingBrotli:   brotli,
+	}
+	for encoding, content := range files {
+		if err := s.storage.Put(ctx, bundleStorageKey(projectID, bundle.Hash, encoding), content, "application/json"); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+// GetPublicKey 获取验证清单签名的公钥
+func (s *DistributionService) GetPublicKey() (*domain.DistributionPublicKey, error) {
+	if s.signingKey == nil {
+		return nil, domain.ErrDistributionSigningDisabled
+	}
+	publicKey := s.signingKey.Public().(ed25519.PublicKey)
+	return &domain.DistributionPublicKey{
+		Algorithm: "ed25519",
+		KeyID:     internal_utils.Ed25519KeyID(publicKey),
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}, nil
+}
+
+// GetManifest 获取令牌所属环境最新发布的签名清单
+func (s *DistributionService) GetManifest(ctx context.Context, token string) (*domain.DistributionFile, error) {
+	release, err := s.latestRelease(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	data := []byte(release.Manifest)
+	return &domain.DistributionFile{
+		Data:      data,
+		Hash:      internal_utils.SHA256Hex(data),
+		Encoding:  internal_utils.EncodingIdentity,
+		Signature: release.Signature,
+		KeyID:     release.KeyID,
+	}, nil
+}
+
+// GetLocaleBundle 获取令牌所属环境最新发布中一种语言的译文包
+func (s *DistributionService) GetLocaleBundle(ctx context.Context, token, locale, encoding string) (*domain.DistributionFile, error) {
+	release, err := s.latestRelease(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range release.Bundles {
+		if bundle.Locale == locale {
+			return s.readBundle(ctx, release.ProjectID, bundle.Hash, encoding)
+		}
+	}
+	return nil, domain.ErrDistributionBundleNotFound
+}
+
+// GetBundle 按内容哈希获取令牌所属环境发布过的译文包
+func (s *DistributionService) GetBundle(ctx context.Context, token, hash, encoding string) (*domain.DistributionFile, error) {
+	distributionToken, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !bundleHashPattern.MatchString(hash) {
+		return nil, domain.ErrDistributionBundleNotFound
+	}
+	if _, err := s.distributionRepo.FindBundle(ctx, distributionToken.ProjectID, distributionToken.Environment, hash); err != nil {
+		return nil, err
+	}
+	return s.readBundle(ctx, distributionToken.ProjectID, hash, encoding)
+}
+
+// resolveToken 校验分发令牌
+func (s *DistributionService) resolveToken(ctx context.Context, token string) (*domain.DistributionToken, error) {
+	if !strings.HasPrefix(token, distributionTokenPrefix) {
+		return nil, domain.ErrInvalidDistributionToken
+	}
+	return s.distributionRepo.GetTokenByHash(ctx, internal_utils.SHA256Hex([]byte(token)))
+}
+
+// latestRelease 获取令牌所属环境的最新发布
+func (s *DistributionService) latestRelease(ctx context.Context, token string) (*domain.DistributionRelease, error) {
+	distributionToken, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.distributionRepo.GetLatestRelease(ctx, distributionToken.ProjectID, distributionToken.Environment)
+}
+
+// readBundle 从文件存储读取指定编码的译文包
+func (s *DistributionService) readBundle(ctx context.Context, projectID uint64, hash, encoding string) (*domain.DistributionFile, error) {
+	data, err := s.storage.Get(ctx, bundleStorageKey(projectID, hash, encoding))
+	if err != nil {
+		if err == domain.ErrStoredFileNotFound {
+			return nil, domain.ErrDistributionBundleNotFound
+		}
+		return nil, err
+	}
+	return &domain.DistributionFile{Data: data, Hash: hash, Encoding: encoding}, nil
+}
+
+// bundleStorageKey 译文包在文件存储中的路径
+func bundleStorageKey(projectID uint64, hash, encoding string) string {
+	key := fmt.Sprintf("distribution/%d/%s.json", projectID, hash)
+	switch encoding {
+	case internal_utils.EncodingGzip:
+		return key + ".gz"
+	case internal_utils.EncodingBrotli:
+		return key + ".br"
+	}
+	return key
+}
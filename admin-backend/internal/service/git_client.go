@@ -0,0 +1,384 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultGitTimeout 未配置时单次 git 命令的超时时间
+const defaultGitTimeout = 2 * time.Minute
+
+var (
+	// scp 风格的 SSH 地址，如 git@github.com:org/app.git
+	scpRepositoryPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9][A-Za-z0-9.-]*:[^\s]+$`)
+	// git 分支名中不允许的字符和序列
+	invalidBranchPattern = regexp.MustCompile(`[\x00-\x20\x7f~^:?*\[\\]|\.\.|@\{|//|/\.|\.lock(/|$)`)
+)
+
+// GitClient 调用 git 命令管理仓库的工作副本
+// 只允许 SSH 和 HTTPS 协议（开启 AllowLocal 时允许本地路径），不会强制推送
+type GitClient struct {
+	root          string
+	sshKeyFile    string
+	httpsHost     string
+	httpsUsername string
+	httpsToken    string
+	authorName    string
+	authorEmail   string
+	allowLocal    bool
+	timeout       time.Duration
+}
+
+// NewGitClient 创建 git 客户端实例，工作副本保存在 cfg.WorkDir 下
+func NewGitClient(cfg config.GitSyncConfig) *GitClient {
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultGitTimeout
+	}
+	return &GitClient{
+		root:          cfg.WorkDir,
+		sshKeyFile:    cfg.SSHKeyFile,
+		httpsHost:     cfg.HTTPSHost,
+		httpsUsername: cfg.HTTPSUsername,
+		httpsToken:    cfg.HTTPSToken,
+		authorName:    cfg.AuthorName,
+		authorEmail:   cfg.AuthorEmail,
+		allowLocal:    cfg.AllowLocal,
+		timeout:       timeout,
+	}
+}
+
+// ValidateRepositoryURL 校验仓库地址：SSH（ssh:// 或 scp 风格）或 HTTPS 地址，不能包含密码；
+// 开启 AllowLocal 时也接受服务器上的绝对路径和 file:// 地址
+func (c *GitClient) ValidateRepositoryURL(repositoryURL string) error {
+	if repositoryURL == "" || strings.HasPrefix(repositoryURL, "-") || strings.ContainsAny(repositoryURL, "\x00\n\r") {
+		return domain.ErrInvalidRepositoryURL
+	}
+	if scpRepositoryPattern.MatchString(repositoryURL) && !strings.Contains(repositoryURL, "://") {
+		return nil
+	}
+	if filepath.IsAbs(repositoryURL) {
+		if c.allowLocal {
+			return nil
+		}
+		return domain.ErrInvalidRepositoryURL
+	}
+
+	parsed, err := url.Parse(repositoryURL)
+	if err != nil {
+		return domain.ErrInvalidRepositoryURL
+	}
+	switch parsed.Scheme {
+	case "https", "ssh":
+		if parsed.Host == "" || strings.HasPrefix(parsed.Host, "-") {
+			return domain.ErrInvalidRepositoryURL
+		}
+		if _, hasPassword := parsed.User.Password(); hasPassword || (parsed.Scheme == "https" && parsed.User != nil) {
+			return domain.ErrInvalidRepositoryURL
+		}
+		return nil
+	case "file":
+		if c.allowLocal && parsed.Host == "" && parsed.Path != "" {
+			return nil
+		}
+	}
+	return domain.ErrInvalidRepositoryURL
+}
+
+// IsValidBranchName 判断是否为合法的 git 分支名
+func IsValidBranchName(branch string) bool {
+	if branch == "" || len(branch) > 255 || branch == "@" {
+		return false
+	}
+	if strings.HasPrefix(branch, "-") || strings.HasPrefix(branch, "/") || strings.HasPrefix(branch, ".") ||
+		strings.HasSuffix(branch, "/") || strings.HasSuffix(branch, ".") {
+		return false
+	}
+	return !invalidBranchPattern.MatchString(branch)
+}
+
+// GitWorkTree 检出到某个分支的工作副本
+type GitWorkTree struct {
+	client *GitClient
+	dir    string
+	branch string
+	head   string
+}
+
+// Checkout 把名为 name 的工作副本更新到远程分支的最新提交，本地修改和未跟踪的文件被丢弃
+// 工作副本不存在或仓库地址改变时重新克隆；远程分支不存在时从仓库的默认分支创建，空仓库时创建空分支
+func (c *GitClient) Checkout(ctx context.Context, name, repositoryURL, branch string) (*GitWorkTree, error) {
+	if err := c.ValidateRepositoryURL(repositoryURL); err != nil {
+		return nil, err
+	}
+	if !IsValidBranchName(branch) {
+		return nil, domain.ErrInvalidGitBranch
+	}
+
+	dir, err := filepath.Abs(filepath.Join(c.root, name))
+	if err != nil {
+		return nil, err
+	}
+	if !c.isClone(ctx, dir, repositoryURL) {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+			return nil, err
+		}
+		if _, err := c.run(ctx, filepath.Dir(dir), "clone", "--no-checkout", "--", repositoryURL, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := c.run(ctx, dir, "fetch", "--prune", "origin"); err != nil {
+		return nil, err
+	}
+	// 克隆空仓库时没有默认分支，之后仓库有了提交需要重新获取；仓库仍为空时失败，忽略
+	_, _ = c.run(ctx, dir, "remote", "set-head", "origin", "--auto")
+	tree := &GitWorkTree{client: c, dir: dir, branch: branch}
+	remoteBranch := "refs/remotes/origin/" + branch
+	if tree.head, err = c.revParse(ctx, dir, remoteBranch); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tree.head != "":
+		_, err = c.run(ctx, dir, "checkout", "--force", "-B", branch, remoteBranch)
+	default:
+		defaultHead, revErr := c.revParse(ctx, dir, "refs/remotes/origin/HEAD")
+		if revErr != nil {
+			return nil, revErr
+		}
+		if defaultHead != "" {
+			_, err = c.run(ctx, dir, "checkout", "--force", "-B", branch, defaultHead)
+		} else {
+			// 空仓库：创建没有提交的分支
+			_, err = c.run(ctx, dir, "checkout", "--force", "--orphan", branch)
+			if err == nil {
+				_, err = c.run(ctx, dir, "rm", "-r", "-q", "--cached", "--ignore-unmatch", ".")
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.run(ctx, dir, "clean", "-f", "-d", "-q"); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// isClone 判断 dir 是否为仓库地址 repositoryURL 的工作副本
+func (c *GitClient) isClone(ctx context.Context, dir, repositoryURL string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return false
+	}
+	current, err := c.run(ctx, dir, "config", "--get", "remote.origin.url")
+	return err == nil && current == repositoryURL
+}
+
+// Remove 删除名为 name 的工作副本
+func (c *GitClient) Remove(name string) error {
+	return os.RemoveAll(filepath.Join(c.root, name))
+}
+
+// Head 检出时远程分支的提交，分支不存在时为空
+func (w *GitWorkTree) Head() string {
+	return w.head
+}
+
+// ReadFile 读取工作副本中的文件，文件不存在时返回 os.ErrNotExist
+func (w *GitWorkTree) ReadFile(name string) ([]byte, error) {
+	filename, err := w.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filename)
+}
+
+// WriteFile 写入工作副本中的文件，自动创建目录
+func (w *GitWorkTree) WriteFile(name string, data []byte) error {
+	filename, err := w.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// Commit 提交工作副本的所有修改，没有修改时不提交并返回 false
+func (w *GitWorkTree) Commit(ctx context.Context, message string) (bool, error) {
+	if _, err := w.client.run(ctx, w.dir, "add", "--all"); err != nil {
+		return false, err
+	}
+	status, err := w.client.run(ctx, w.dir, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if status == "" {
+		return false, nil
+	}
+	if _, err := w.client.run(ctx, w.dir, "commit", "--quiet", "--no-verify", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Push 把提交推送到远程分支，只允许快进；远程分支在检出后有新的提交时返回 domain.ErrGitPushRejected
+// 推送成功后 Head 更新为推送的提交
+func (w *GitWorkTree) Push(ctx context.Context) error {
+	head, err := w.client.revParse(ctx, w.dir, "HEAD")
+	if err != nil {
+		return err
+	}
+	// 以检出时的远程提交为条件推送，远程分支被其他人更新或创建时推送失败，不会覆盖
+	lease := fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", w.branch, w.head)
+	if _, err := w.client.run(ctx, w.dir, "push", "--porcelain", lease, "origin", "HEAD:refs/heads/"+w.branch); err != nil {
+		var gitErr *gitCommandError
+		if errors.As(err, &gitErr) && (strings.Contains(gitErr.output, "[rejected]") || strings.Contains(gitErr.output, "stale info") ||
+			strings.Contains(gitErr.output, "non-fast-forward") || strings.Contains(gitErr.output, "fetch first")) {
+			return domain.ErrGitPushRejected
+		}
+		return err
+	}
+	w.head = head
+	return nil
+}
+
+// path 把仓库内的相对路径转换为工作副本中的路径，不允许指向工作副本之外、.git 目录或经过符号链接
+func (w *GitWorkTree) path(name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == "." || strings.HasPrefix(cleaned, "..") {
+		return "", domain.ErrInvalidPathTemplate
+	}
+	first := strings.SplitN(filepath.ToSlash(cleaned), "/", 2)[0]
+	if strings.EqualFold(first, ".git") {
+		return "", domain.ErrInvalidPathTemplate
+	}
+	// 远程仓库中可能提交了符号链接，逐级检查已存在的路径，避免读写跟随链接到工作副本之外
+	current := w.dir
+	for _, part := range strings.Split(cleaned, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return "", domain.ErrGitSymlinkPath
+		}
+	}
+	return filepath.Join(w.dir, cleaned), nil
+}
+
+// revParse 获取引用指向的提交，引用不存在时返回空
+func (c *GitClient) revParse(ctx context.Context, dir, ref string) (string, error) {
+	commit, err := c.run(ctx, dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		var gitErr *gitCommandError
+		if errors.As(err, &gitErr) && gitErr.exitCode == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return commit, nil
+}
+
+// gitCommandError git 命令执行失败
+type gitCommandError struct {
+	command  string
+	exitCode int
+	output   string
+}
+
+func (e *gitCommandError) Error() string {
+	if e.output == "" {
+		return fmt.Sprintf("git %s failed with exit code %d", e.command, e.exitCode)
+	}
+	return fmt.Sprintf("git %s: %s", e.command, e.output)
+}
+
+// run 在 dir 中执行 git 命令，返回去掉首尾空白的标准输出
+// 凭据和提交作者通过环境变量传给 git，不出现在命令行参数中
+func (c *GitClient) run(ctx context.Context, dir string, args ...string) (string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), c.env()...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		gitErr := &gitCommandError{command: args[0], exitCode: -1, output: strings.TrimSpace(stderr.String() + "\n" + stdout.String())}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			gitErr.exitCode = exitErr.ExitCode()
+		}
+		if ctx.Err() != nil {
+			gitErr.output = "timed out"
+		}
+		return "", gitErr
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// env git 命令的环境变量：禁止交互式输入，限制协议，设置 SSH 密钥、HTTPS 令牌和提交作者
+func (c *GitClient) env() []string {
+	protocols := "ssh:https"
+	if c.allowLocal {
+		protocols += ":file"
+	}
+	sshCommand := "ssh -o BatchMode=yes -o StrictHostKeyChecking=accept-new"
+	if c.sshKeyFile != "" {
+		sshCommand += " -o IdentitiesOnly=yes -i '" + strings.ReplaceAll(c.sshKeyFile, "'", `'\''`) + "'"
+	}
+
+	settings := [][2]string{
+		{"core.hooksPath", os.DevNull},
+		{"commit.gpgsign", "false"},
+		{"protocol.ext.allow", "never"},
+	}
+	if c.httpsToken != "" && c.httpsHost != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(c.httpsUsername + ":" + c.httpsToken))
+		settings = append(settings, [2]string{"http." + c.httpsHost + ".extraHeader", "Authorization: Basic " + credentials})
+	}
+
+	env := []string{
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL=" + protocols,
+		"GIT_SSH_COMMAND=" + sshCommand,
+		"GIT_AUTHOR_NAME=" + c.authorName,
+		"GIT_AUTHOR_EMAIL=" + c.authorEmail,
+		"GIT_COMMITTER_NAME=" + c.authorName,
+		"GIT_COMMITTER_EMAIL=" + c.authorEmail,
+		"LC_ALL=C",
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(settings)),
+	}
+	for i, setting := range settings {
+		env = append(env, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, setting[0]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, setting[1]))
+	}
+	return env
+}
@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"io/fs"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	defaultGitBranch      = "main"
+	minGitSyncInterval    = 5     // 定时同步的最小间隔（分钟）
+	maxGitSyncInterval    = 10080 // 定时同步的最大间隔（分钟），一周
+	maxGitSyncErrorLength = 1000
+	gitPushAttempts       = 2 // 推送被拒绝时重新检出并合并一次
+)
+
+// 文件路径中的语言代码只能是单个路径段
+var fileLanguagePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@+-]*$`)
+
+// GitSyncService git 仓库同步服务实现
+// 仓库中的翻译文件相当于一个 CLI 客户端：以 GitSync.LastRevision 为基准，通过 SyncService 三方合并，
+// 仓库的修改写入项目，导出时把合并后的译文提交回分支；两边都修改了的单元格作为冲突报告，两边都不覆盖
+type GitSyncService struct {
+	gitSyncRepo        domain.GitSyncRepository
+	projectRepo        domain.ProjectRepository
+	projectBaseRepo    domain.ProjectBaseRepository
+	languageRepo       domain.LanguageRepository
+	translationService domain.TranslationService
+	userRepo           domain.UserRepository
+	syncService        domain.SyncService
+	git                *GitClient
+	running            sync.Map // 正在同步的项目ID，同一个项目同一时刻只允许一次同步
+}
+
+// NewGitSyncService 创建 git 仓库同步服务实例
+func NewGitSyncService(
+	gitSyncRepo domain.GitSyncRepository,
+	projectRepo domain.ProjectRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	languageRepo domain.LanguageRepository,
+	translationService domain.TranslationService,
+	userRepo domain.UserRepository,
+	syncService domain.SyncService,
+	git *GitClient,
+) *GitSyncService {
+	return &GitSyncService{
+		gitSyncRepo:        gitSyncRepo,
+		projectRepo:        projectRepo,
+		projectBaseRepo:    projectBaseRepo,
+		languageRepo:       languageRepo,
+		translationService: translationService,
+		userRepo:           userRepo,
+		syncService:        syncService,
+		git:                git,
+	}
+}
+
+// Get 获取项目的 git 仓库同步设置和最近一次同步的结果
+func (s *GitSyncService) Get(ctx context.Context, projectID uint64) (*domain.GitSync, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.gitSyncRepo.GetByProject(ctx, projectID)
+}
+
+// Save 创建或更新项目的 git 仓库同步设置
+// 仓库、分支、路径模板、格式或语言代码映射改变后，仓库中的文件不再对应原来的基准，基准重置
+// 三方合并按项目自身的修订号进行，继承了基础项目的项目不能配置同步
+func (s *GitSyncService) Save(ctx context.Context, params domain.SaveGitSyncParams) (*domain.GitSync, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	bases, err := s.projectBaseRepo.ListByProject(ctx, params.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bases) > 0 {
+		return nil, domain.ErrInheritanceGitSync
+	}
+	params.RepositoryURL = strings.TrimSpace(params.RepositoryURL)
+	if params.Branch == "" {
+		params.Branch = defaultGitBranch
+	}
+	if params.Format == "" {
+		params.Format = internal_utils.LocaleFormatJSON
+	}
+	if err := s.validate(ctx, params); err != nil {
+		return nil, err
+	}
+
+	gitSync, err := s.gitSyncRepo.GetByProject(ctx, params.ProjectID)
+	if errors.Is(err, domain.ErrGitSyncNotConfigured) {
+		gitSync = &domain.GitSync{ProjectID: params.ProjectID, CreatedBy: params.UserID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if gitSync.RepositoryURL != params.RepositoryURL || gitSync.Branch != params.Branch ||
+		gitSync.PathTemplate != params.PathTemplate || gitSync.Format != params.Format ||
+		!maps.Equal(gitSync.LanguageMapping, params.LanguageMapping) {
+		gitSync.LastRevision = 0
+		gitSync.LastCommit = ""
+		gitSync.LastConflicts = nil
+	}
+	gitSync.RepositoryURL = params.RepositoryURL
+	gitSync.Branch = params.Branch
+	gitSync.PathTemplate = params.PathTemplate
+	gitSync.Format = params.Format
+	gitSync.Languages = params.Languages
+	gitSync.LanguageMapping = params.LanguageMapping
+	gitSync.IntervalMinutes = params.IntervalMinutes
+	gitSync.AutoExport = params.AutoExport
+	gitSync.UpdatedBy = params.UserID
+	if err := s.gitSyncRepo.Save(ctx, gitSync); err != nil {
+		return nil, err
+	}
+	return gitSync, nil
+}
+
+// validate 校验同步设置：仓库地址、分支、路径模板和格式必须合法，语言必须存在，
+// 映射的文件语言代码必须是单个路径段，且不能有两个文件语言代码映射到同一种语言
+func (s *GitSyncService) validate(ctx context.Context, params domain.SaveGitSyncParams) error {
+	if err := s.git.ValidateRepositoryURL(params.RepositoryURL); err != nil {
+		return err
+	}
+	if !IsValidBranchName(params.Branch) {
+		return domain.ErrInvalidGitBranch
+	}
+	if !internal_utils.IsPathTemplate(params.PathTemplate) {
+		return domain.ErrInvalidPathTemplate
+	}
+	if !internal_utils.IsLocaleFormat(params.Format) {
+		return domain.ErrInvalidLocaleFormat
+	}
+	if params.IntervalMinutes != 0 && (params.IntervalMinutes < minGitSyncInterval || params.IntervalMinutes > maxGitSyncInterval) {
+		return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, "INVALID_SYNC_INTERVAL", "无效的同步间隔",
+			fmt.Sprintf("同步间隔为 0（只手动同步）或 %d 到 %d 分钟", minGitSyncInterval, maxGitSyncInterval))
+	}
+
+	for _, code := range params.Languages {
+		if _, err := s.languageRepo.GetByCode(ctx, code); err != nil {
+			return domain.ErrLanguageNotFound
+		}
+	}
+	mapped := make(map[string]string, len(params.LanguageMapping))
+	for fileCode, code := range params.LanguageMapping {
+		if !fileLanguagePattern.MatchString(fileCode) || fileCode == "." || fileCode == ".." {
+			return invalidLanguageMapping(fmt.Sprintf("文件语言代码无效: %q", fileCode))
+		}
+		if _, err := s.languageRepo.GetByCode(ctx, code); err != nil {
+			return domain.ErrLanguageNotFound
+		}
+		if other, ok := mapped[code]; ok {
+			return invalidLanguageMapping(fmt.Sprintf("%s 和 %s 映射到同一种语言 %s", other, fileCode, code))
+		}
+		mapped[code] = fileCode
+	}
+	return nil
+}
+
+// Delete 删除项目的 git 仓库同步设置和工作副本
+func (s *GitSyncService) Delete(ctx context.Context, projectID uint64) error {
+	if _, err := s.Get(ctx, projectID); err != nil {
+		return err
+	}
+	if _, loaded := s.running.LoadOrStore(projectID, true); loaded {
+		return domain.ErrGitSyncRunning
+	}
+	defer s.running.Delete(projectID)
+
+	if err := s.gitSyncRepo.Delete(ctx, projectID); err != nil {
+		return err
+	}
+	return s.git.Remove(gitWorkTreeName(projectID))
+}
+
+// Import 把仓库中的修改合并到项目，不修改仓库
+func (s *GitSyncService) Import(ctx context.Context, projectID, userID uint64) (*domain.GitSyncResult, error) {
+	return s.run(ctx, projectID, userID, false)
+}
+
+// Export 先把仓库中的修改合并到项目，再把合并后的译文提交并推送到分支
+func (s *GitSyncService) Export(ctx context.Context, projectID, userID uint64) (*domain.GitSyncResult, error) {
+	return s.run(ctx, projectID, userID, true)
+}
+
+// RunScheduled 执行到期的定时同步，开启了 AutoExport 的项目同时导出，返回同步成功和失败的项目数
+// 每个项目的结果记录在它的同步设置中；正在同步的项目跳过
+func (s *GitSyncService) RunScheduled(ctx context.Context) (synced, failed int, err error) {
+	gitSyncs, err := s.gitSyncRepo.ListScheduled(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	now := time.Now()
+	for _, gitSync := range gitSyncs {
+		if gitSync.LastSyncAt != nil && now.Sub(*gitSync.LastSyncAt) < time.Duration(gitSync.IntervalMinutes)*time.Minute {
+			continue
+		}
+		_, err := s.run(ctx, gitSync.ProjectID, 0, gitSync.AutoExport)
+		switch {
+		case errors.Is(err, domain.ErrGitSyncRunning):
+		case err != nil:
+			failed++
+		default:
+			synced++
+		}
+	}
+	return synced, failed, nil
+}
+
+// run 执行一次同步，推送被拒绝时重新检出并合并，结果记录在同步设置中
+func (s *GitSyncService) run(ctx context.Context, projectID, userID uint64, export bool) (*domain.GitSyncResult, error) {
+	if _, loaded := s.running.LoadOrStore(projectID, true); loaded {
+		return nil, domain.ErrGitSyncRunning
+	}
+	defer s.running.Delete(projectID)
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	gitSync, err := s.gitSyncRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result   *domain.GitSyncResult
+		revision uint64
+	)
+	for attempt := 0; attempt < gitPushAttempts; attempt++ {
+		result, revision, err = s.syncOnce(ctx, project, gitSync, userID, export)
+		if !errors.Is(err, domain.ErrGitPushRejected) {
+			break
+		}
+	}
+
+	now := time.Now()
+	gitSync.LastSyncAt = &now
+	if err != nil {
+		gitSync.LastStatus = domain.GitSyncStatusFailed
+		gitSync.LastError = gitSyncErrorMessage(err)
+	} else {
+		gitSync.LastStatus = result.Status
+		gitSync.LastError = ""
+		gitSync.LastCommit = result.Commit
+		gitSync.LastConflicts = result.Conflicts
+		if revision > 0 {
+			gitSync.LastRevision = revision
+		}
+	}
+	if saveErr := s.gitSyncRepo.Save(ctx, gitSync); saveErr != nil && err == nil {
+		return nil, saveErr
+	}
+	return result, err
+}
+
+// syncOnce 检出分支，把仓库中的文件与项目三方合并，导出时提交并推送合并后的文件
+// 返回同步结果和新的基准修订号，基准不前移时为 0
+func (s *GitSyncService) syncOnce(ctx context.Context, project *domain.Project, gitSync *domain.GitSync, userID uint64, export bool) (*domain.GitSyncResult, uint64, error) {
+	tree, err := s.git.Checkout(ctx, gitWorkTreeName(project.ID), gitSync.RepositoryURL, gitSync.Branch)
+	if err != nil {
+		return nil, 0, gitSyncFailed(err)
+	}
+	languages, err := s.syncLanguages(ctx, gitSync)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 读取仓库中的文件，没有文件的语言不参与合并，否则会被视为在仓库中删除了所有译文
+	files := make(map[string]map[string]string)
+	local := make(map[string]map[string]string)
+	present := make([]string, 0, len(languages))
+	for _, code := range languages {
+		name := gitFileName(gitSync, code)
+		data, err := tree.ReadFile(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, gitSyncFailed(err)
+		}
+		values, err := internal_utils.ParseLocaleFile(gitSync.Format, data)
+		if err != nil {
+			return nil, 0, invalidLocaleFile(name, err.Error())
+		}
+		for keyName, value := range values {
+			if strings.TrimSpace(keyName) == "" || len([]rune(keyName)) > maxBranchKeyNameLength {
+				return nil, 0, invalidLocaleFile(name, fmt.Sprintf("无效的键名: %q", keyName))
+			}
+			if local[keyName] == nil {
+				local[keyName] = make(map[string]string)
+			}
+			local[keyName][code] = value
+		}
+		files[code] = values
+		present = append(present, code)
+	}
+
+	result := &domain.GitSyncResult{Status: domain.GitSyncStatusSuccess, Commit: tree.Head(), Files: []string{}, Conflicts: []*domain.SyncConflict{}}
+	merge := &domain.SyncResult{}
+	if len(present) > 0 {
+		merge, err = s.syncService.Sync(ctx, domain.SyncParams{
+			ProjectID:    project.ID,
+			BaseRevision: gitSync.LastRevision,
+			Values:       local,
+			Languages:    present,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		result.Imported = merge.Pushed
+		result.Removed = merge.Removed
+		result.Conflicts = merge.Conflicts
+	}
+	if len(result.Conflicts) > 0 {
+		result.Status = domain.GitSyncStatusConflict
+	}
+
+	if !export {
+		// 仓库的文件与项目一致时基准前移；项目有仓库没有的修改时保持原基准，下次导入时仍按原基准比较
+		if len(present) > 0 && len(merge.Changed) == 0 && len(merge.Deleted) == 0 && len(merge.Conflicts) == 0 {
+			return result, merge.Revision, nil
+		}
+		return result, 0, nil
+	}
+
+	// 先读取修订号再读取译文，读取期间的写入在下一次同步时会被再次比较
+	current, err := s.projectRepo.GetByID(ctx, project.ID)
+	if err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	cells, _, err := s.translationService.GetMatrix(ctx, project.ID, -1, 0, "")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	planned := PlanGitExport(toSimpleMatrix(cells), files, languages, result.Conflicts)
+	changes := make(map[string]int, len(planned))
+	for _, code := range languages {
+		values, ok := planned[code]
+		if !ok {
+			continue
+		}
+		data, err := internal_utils.MarshalLocaleFile(gitSync.Format, values)
+		if err != nil {
+			return nil, 0, err
+		}
+		name := gitFileName(gitSync, code)
+		if err := tree.WriteFile(name, data); err != nil {
+			return nil, 0, gitSyncFailed(err)
+		}
+		result.Files = append(result.Files, name)
+		changes[code] = countChangedValues(files[code], values)
+	}
+
+	if len(result.Files) > 0 {
+		message := gitCommitMessage(project.Name, current.Revision, changes, s.operatorName(ctx, userID))
+		committed, err := tree.Commit(ctx, message)
+		if err != nil {
+			return nil, 0, gitSyncFailed(err)
+		}
+		if committed {
+			if err := tree.Push(ctx); err != nil {
+				return nil, 0, gitSyncFailed(err)
+			}
+			result.Committed = true
+			result.Commit = tree.Head()
+		}
+	}
+
+	// 有冲突时仓库保留了自己的值，基准不前移，冲突在下一次同步时继续报告
+	if len(result.Conflicts) > 0 {
+		return result, 0, nil
+	}
+	return result, current.Revision, nil
+}
+
+// syncLanguages 同步的语言：设置中的语言或所有启用的语言，已停用的语言跳过
+func (s *GitSyncService) syncLanguages(ctx context.Context, gitSync *domain.GitSync) ([]string, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configured := make(map[string]bool, len(gitSync.Languages))
+	for _, code := range gitSync.Languages {
+		configured[code] = true
+	}
+	codes := make([]string, 0, len(languages))
+	for _, language := range languages {
+		if language.Status != "active" || (len(configured) > 0 && !configured[language.Code]) {
+			continue
+		}
+		codes = append(codes, language.Code)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
+
+// operatorName 提交信息中的操作人，定时同步为 schedule
+func (s *GitSyncService) operatorName(ctx context.Context, userID uint64) string {
+	if userID == 0 {
+		return "schedule"
+	}
+	if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		return user.Username
+	}
+	return fmt.Sprintf("user #%d", userID)
+}
+
+// PlanGitExport 计算导出后每种语言的文件内容（语言代码 -> 键名 -> 译文），只返回内容改变的语言
+// server 为项目当前的译文（键名 -> 语言代码 -> 译文），files 为仓库中已有的文件；
+// 冲突的单元格保留仓库中的值，项目和仓库都没有译文的语言不创建文件
+func PlanGitExport(server map[string]map[string]string, files map[string]map[string]string, languages []string, conflicts []*domain.SyncConflict) map[string]map[string]string {
+	planned := make(map[string]map[string]string)
+	for _, code := range languages {
+		values := make(map[string]string)
+		for keyName, cells := range server {
+			if value := cells[code]; value != "" {
+				values[keyName] = value
+			}
+		}
+		for _, conflict := range conflicts {
+			if conflict.LanguageCode != code {
+				continue
+			}
+			if conflict.Local != nil {
+				values[conflict.KeyName] = *conflict.Local
+			} else {
+				delete(values, conflict.KeyName)
+			}
+		}
+
+		existing, exists := files[code]
+		if (!exists && len(values) == 0) || (exists && maps.Equal(existing, values)) {
+			continue
+		}
+		planned[code] = values
+	}
+	return planned
+}
+
+// countChangedValues 统计文件中新增、修改和删除的译文数
+func countChangedValues(before, after map[string]string) int {
+	changed := 0
+	for keyName, value := range after {
+		if old, ok := before[keyName]; !ok || old != value {
+			changed++
+		}
+	}
+	for keyName := range before {
+		if _, ok := after[keyName]; !ok {
+			changed++
+		}
+	}
+	return changed
+}
+
+// gitCommitMessage 生成导出提交的提交信息
+func gitCommitMessage(projectName string, revision uint64, changes map[string]int, operator string) string {
+	codes := make([]string, 0, len(changes))
+	for code := range changes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "Update %s translations (%s)\n\n", projectName, strings.Join(codes, ", "))
+	for _, code := range codes {
+		fmt.Fprintf(&message, "- %s: %d changed\n", code, changes[code])
+	}
+	fmt.Fprintf(&message, "\nExported from i18n-flow at project revision %d by %s.\n", revision, operator)
+	return message.String()
+}
+
+// gitFileName 语言在仓库中的文件路径，映射了文件语言代码时使用映射的代码
+func gitFileName(gitSync *domain.GitSync, code string) string {
+	fileCode := code
+	for mappedCode, languageCode := range gitSync.LanguageMapping {
+		if languageCode == code {
+			fileCode = mappedCode
+			break
+		}
+	}
+	return internal_utils.RenderPathTemplate(gitSync.PathTemplate, fileCode)
+}
+
+// gitWorkTreeName 项目工作副本的目录名
+func gitWorkTreeName(projectID uint64) string {
+	return fmt.Sprintf("project-%d", projectID)
+}
+
+// gitSyncFailed 把 git 命令和文件读写的错误包装为应用错误，应用错误原样返回
+func gitSyncFailed(err error) error {
+	if _, ok := domain.IsAppError(err); ok {
+		return err
+	}
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeBadRequest, "GIT_SYNC_FAILED", "git 仓库同步失败", err.Error())
+}
+
+// invalidLocaleFile 仓库中的翻译文件无法解析
+func invalidLocaleFile(name, details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, "INVALID_LOCALE_FILE", "仓库中的翻译文件无效", name+": "+details)
+}
+
+// invalidLanguageMapping 语言代码映射错误
+func invalidLanguageMapping(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, "INVALID_LANGUAGE_MAPPING", "无效的语言代码映射", details)
+}
+
+// gitSyncErrorMessage 记录在同步设置中的失败原因
+func gitSyncErrorMessage(err error) string {
+	message := err.Error()
+	if appErr, ok := domain.IsAppError(err); ok {
+		message = appErr.Message
+		if appErr.Details != "" {
+			message += ": " + appErr.Details
+		}
+	}
+	if runes := []rune(message); len(runes) > maxGitSyncErrorLength {
+		message = string(runes[:maxGitSyncErrorLength])
+	}
+	return message
+}
@@ -0,0 +1,505 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strconv"
+	"strings"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	glossaryTBXSource        = "i18n-flow glossary"
+	tbxDescripDefinition     = "definition"
+	tbxDescripDoNotTranslate = "x-doNotTranslate"
+	tbxDescripCaseSensitive  = "x-caseSensitive"
+)
+
+// GlossaryService 术语表服务实现
+// 术语以默认语言书写；项目术语与全局术语的术语相同时，项目术语优先
+type GlossaryService struct {
+	glossaryRepo    domain.GlossaryRepository
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+}
+
+// NewGlossaryService 创建术语表服务实例
+func NewGlossaryService(
+	glossaryRepo domain.GlossaryRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+) *GlossaryService {
+	return &GlossaryService{
+		glossaryRepo:    glossaryRepo,
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+	}
+}
+
+// List 分页获取项目术语（projectID 为0时为全局术语）
+func (s *GlossaryService) List(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*domain.GlossaryEntry, int64, error) {
+	if err := s.checkScope(ctx, projectID); err != nil {
+		return nil, 0, err
+	}
+	return s.glossaryRepo.List(ctx, projectID, strings.TrimSpace(keyword), limit, offset)
+}
+
+// Get 获取术语，术语不属于指定范围时视为不存在
+func (s *GlossaryService) Get(ctx context.Context, projectID, id uint64) (*domain.GlossaryEntry, error) {
+	entry, err := s.glossaryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.ProjectID != projectID {
+		return nil, domain.ErrGlossaryEntryNotFound
+	}
+	return entry, nil
+}
+
+// Create 创建术语
+func (s *GlossaryService) Create(ctx context.Context, params domain.CreateGlossaryEntryParams) (*domain.GlossaryEntry, error) {
+	if err := s.checkScope(ctx, params.ProjectID); err != nil {
+		return nil, err
+	}
+
+	term := strings.TrimSpace(params.Term)
+	if term == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.glossaryRepo.GetByTerm(ctx, params.ProjectID, term); err == nil {
+		return nil, domain.ErrGlossaryTermExists
+	} else if !errors.Is(err, domain.ErrGlossaryEntryNotFound) {
+		return nil, err
+	}
+
+	translations, err := s.buildTranslations(ctx, params.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.GlossaryEntry{
+		ProjectID:      params.ProjectID,
+		Term:           term,
+		Description:    strings.TrimSpace(params.Description),
+		CaseSensitive:  params.CaseSensitive,
+		DoNotTranslate: params.DoNotTranslate,
+		Translations:   translations,
+		CreatedBy:      params.UserID,
+		UpdatedBy:      params.UserID,
+	}
+	if err := s.glossaryRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Update 更新术语，译法整体替换
+func (s *GlossaryService) Update(ctx context.Context, id uint64, params domain.UpdateGlossaryEntryParams) (*domain.GlossaryEntry, error) {
+	entry, err := s.Get(ctx, params.ProjectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	term := strings.TrimSpace(params.Term)
+	if term == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if term != entry.Term {
+		if existing, err := s.glossaryRepo.GetByTerm(ctx, params.ProjectID, term); err == nil && existing.ID != entry.ID {
+			return nil, domain.ErrGlossaryTermExists
+		} else if err != nil && !errors.Is(err, domain.ErrGlossaryEntryNotFound) {
+			return nil, err
+		}
+	}
+
+	translations, err := s.buildTranslations(ctx, params.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Term = term
+	entry.Description = strings.TrimSpace(params.Description)
+	entry.CaseSensitive = params.CaseSensitive
+	entry.DoNotTranslate = params.DoNotTranslate
+	entry.Translations = translations
+	entry.UpdatedBy = params.UserID
+	if err := s.glossaryRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Delete 删除术语
+func (s *GlossaryService) Delete(ctx context.Context, projectID, id uint64) error {
+	if _, err := s.Get(ctx, projectID, id); err != nil {
+		return err
+	}
+	return s.glossaryRepo.Delete(ctx, id)
+}
+
+// checkScope 校验术语表所属项目存在，projectID 为0时为全局术语表
+func (s *GlossaryService) checkScope(ctx context.Context, projectID uint64) error {
+	if projectID == 0 {
+		return nil
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return domain.ErrProjectNotFound
+	}
+	return nil
+}
+
+// buildTranslations 校验译法的语言并去除重复项
+func (s *GlossaryService) buildTranslations(ctx context.Context, inputs []domain.GlossaryTranslationInput) ([]*domain.GlossaryTranslation, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		codes[language.Code] = true
+	}
+
+	seen := make(map[string]bool)
+	translations := make([]*domain.GlossaryTranslation, 0, len(inputs))
+	for _, input := range inputs {
+		if !codes[input.Language] {
+			return nil, domain.ErrLanguageNotFound
+		}
+		value := strings.TrimSpace(input.Value)
+		if value == "" {
+			return nil, domain.ErrInvalidInput
+		}
+		status := domain.GlossaryStatusApproved
+		if input.Forbidden {
+			status = domain.GlossaryStatusForbidden
+		}
+		key := input.Language + "\x00" + value
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		translations = append(translations, &domain.GlossaryTranslation{Language: input.Language, Value: value, Status: status})
+	}
+	return translations, nil
+}
+
+// Check 按项目术语和全局术语检查项目翻译
+// 以键在默认语言下的翻译为原文，原文包含术语时译文须使用批准的译法，且不能出现禁用的译法
+func (s *GlossaryService) Check(ctx context.Context, params domain.GlossaryCheckParams) (*domain.GlossaryCheckReport, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]*domain.Language, 0, len(languages))
+	requested := toStringSet(params.Languages)
+	checkAll := len(requested) == 0
+	for _, language := range languages {
+		if language.ID == defaultLanguage.ID {
+			continue
+		}
+		if checkAll || requested[language.Code] {
+			targets = append(targets, language)
+			delete(requested, language.Code)
+		}
+	}
+	if len(requested) > 0 {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	entries, err := s.glossaryRepo.ListForProject(ctx, params.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	entries = effectiveGlossaryEntries(entries)
+
+	report := &domain.GlossaryCheckReport{
+		SourceLanguage: defaultLanguage.Code,
+		Violations:     make([]*domain.GlossaryViolation, 0),
+	}
+	if len(entries) == 0 {
+		return report, nil
+	}
+
+	sources, err := activeTranslationValues(ctx, s.translationRepo, params.ProjectID, defaultLanguage.ID, params.KeyNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range targets {
+		rules := glossaryRules(entries, language.Code)
+		if len(rules) == 0 {
+			continue
+		}
+		values, err := activeTranslationValues(ctx, s.translationRepo, params.ProjectID, language.ID, params.KeyNames)
+		if err != nil {
+			return nil, err
+		}
+		for keyName, value := range values {
+			source, ok := sources[keyName]
+			if !ok {
+				continue
+			}
+			report.Checked++
+			for _, issue := range internal_utils.CheckGlossary(source, value, rules) {
+				report.Violations = append(report.Violations, &domain.GlossaryViolation{
+					KeyName:  keyName,
+					Language: language.Code,
+					Term:     issue.Term,
+					Type:     issue.Type,
+					Found:    issue.Found,
+					Expected: issue.Expected,
+					Source:   source,
+					Value:    value,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.KeyName != b.KeyName {
+			return a.KeyName < b.KeyName
+		}
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		return a.Term < b.Term
+	})
+	return report, nil
+}
+
+// activeTranslationValues 获取项目在某种语言下未废弃的非空翻译，keyNames 不为空时只返回这些键
+func activeTranslationValues(
+	ctx context.Context,
+	translationRepo domain.TranslationRepository,
+	projectID, languageID uint64,
+	keyNames []string,
+) (map[string]string, error) {
+	translations, err := translationRepo.GetByProjectAndLanguage(ctx, projectID, languageID)
+	if err != nil {
+		return nil, err
+	}
+	filter := toStringSet(keyNames)
+	values := make(map[string]string, len(translations))
+	for _, translation := range translations {
+		if translation.Status != "active" || strings.TrimSpace(translation.Value) == "" {
+			continue
+		}
+		if len(filter) > 0 && !filter[translation.KeyName] {
+			continue
+		}
+		values[translation.KeyName] = translation.Value
+	}
+	return values, nil
+}
+
+// effectiveGlossaryEntries 合并项目术语和全局术语，术语相同（忽略大小写）时项目术语优先
+func effectiveGlossaryEntries(entries []*domain.GlossaryEntry) []*domain.GlossaryEntry {
+	projectTerms := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.ProjectID != 0 {
+			projectTerms[strings.ToLower(entry.Term)] = true
+		}
+	}
+	effective := make([]*domain.GlossaryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ProjectID == 0 && projectTerms[strings.ToLower(entry.Term)] {
+			continue
+		}
+		effective = append(effective, entry)
+	}
+	return effective
+}
+
+// glossaryRules 构建术语在目标语言下的检查规则，没有可检查内容的术语被忽略
+func glossaryRules(entries []*domain.GlossaryEntry, language string) []internal_utils.GlossaryRule {
+	rules := make([]internal_utils.GlossaryRule, 0, len(entries))
+	for _, entry := range entries {
+		rule := internal_utils.GlossaryRule{
+			Term:           entry.Term,
+			CaseSensitive:  entry.CaseSensitive,
+			DoNotTranslate: entry.DoNotTranslate,
+		}
+		for _, translation := range entry.Translations {
+			if translation.Language != language {
+				continue
+			}
+			if translation.Status == domain.GlossaryStatusForbidden {
+				rule.Forbidden = append(rule.Forbidden, translation.Value)
+			} else {
+				rule.Approved = append(rule.Approved, translation.Value)
+			}
+		}
+		if rule.DoNotTranslate || len(rule.Approved) > 0 || len(rule.Forbidden) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ImportTBX 导入 TBX 文件，按术语更新已有条目
+// 默认语言下首个未废弃的术语作为条目的术语，其他语言中 preferred/admitted 为批准的译法，deprecated 为禁用的译法
+func (s *GlossaryService) ImportTBX(ctx context.Context, params domain.ImportTBXParams) (*domain.ImportTBXResult, error) {
+	if err := s.checkScope(ctx, params.ProjectID); err != nil {
+		return nil, err
+	}
+
+	file, err := internal_utils.ParseTBX(params.Data)
+	if err != nil {
+		return nil, domain.ErrInvalidTBX
+	}
+
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolve := newLanguageResolver(languages)
+
+	result := &domain.ImportTBXResult{Entries: len(file.Entries), UnknownLanguages: make([]string, 0)}
+	unknown := make(map[string]bool)
+	for _, tbxEntry := range file.Entries {
+		var term string
+		translations := make([]domain.GlossaryTranslationInput, 0)
+		for tbxLanguage, terms := range tbxEntry.Languages {
+			code, ok := resolve(tbxLanguage)
+			if !ok {
+				unknown[tbxLanguage] = true
+				continue
+			}
+			for _, tbxTerm := range terms {
+				if code == defaultLanguage.Code {
+					if term == "" && tbxTerm.Status != internal_utils.TBXStatusDeprecated {
+						term = tbxTerm.Text
+					}
+					continue
+				}
+				translations = append(translations, domain.GlossaryTranslationInput{
+					Language:  code,
+					Value:     tbxTerm.Text,
+					Forbidden: tbxTerm.Status == internal_utils.TBXStatusDeprecated,
+				})
+			}
+		}
+		if term == "" {
+			result.Skipped++
+			continue
+		}
+		sort.SliceStable(translations, func(i, j int) bool {
+			return translations[i].Language < translations[j].Language
+		})
+
+		doNotTranslate, _ := strconv.ParseBool(tbxEntry.Descriptions[tbxDescripDoNotTranslate])
+		caseSensitive, _ := strconv.ParseBool(tbxEntry.Descriptions[tbxDescripCaseSensitive])
+		description := tbxEntry.Descriptions[tbxDescripDefinition]
+
+		existing, err := s.glossaryRepo.GetByTerm(ctx, params.ProjectID, term)
+		if err != nil && !errors.Is(err, domain.ErrGlossaryEntryNotFound) {
+			return nil, err
+		}
+		if existing == nil {
+			if _, err := s.Create(ctx, domain.CreateGlossaryEntryParams{
+				ProjectID:      params.ProjectID,
+				Term:           term,
+				Description:    description,
+				CaseSensitive:  caseSensitive,
+				DoNotTranslate: doNotTranslate,
+				Translations:   translations,
+				UserID:         params.UserID,
+			}); err != nil {
+				return nil, err
+			}
+			result.Created++
+			continue
+		}
+
+		if description == "" {
+			description = existing.Description
+		}
+		if _, err := s.Update(ctx, existing.ID, domain.UpdateGlossaryEntryParams{
+			ProjectID:      params.ProjectID,
+			Term:           term,
+			Description:    description,
+			CaseSensitive:  caseSensitive,
+			DoNotTranslate: doNotTranslate,
+			Translations:   translations,
+			UserID:         params.UserID,
+		}); err != nil {
+			return nil, err
+		}
+		result.Updated++
+	}
+
+	for language := range unknown {
+		result.UnknownLanguages = append(result.UnknownLanguages, language)
+	}
+	sort.Strings(result.UnknownLanguages)
+	return result, nil
+}
+
+// ExportTBX 导出术语表为 TBX 文件（projectID 为0时为全局术语表）
+func (s *GlossaryService) ExportTBX(ctx context.Context, projectID uint64) ([]byte, error) {
+	if err := s.checkScope(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	// 术语表规模有限，一次读取全部条目
+	entries, _, err := s.glossaryRepo.List(ctx, projectID, "", -1, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &internal_utils.TBXFile{Language: defaultLanguage.Code}
+	for _, entry := range entries {
+		tbxEntry := internal_utils.TBXEntry{
+			ID:           fmt.Sprintf("g%d", entry.ID),
+			Descriptions: make(map[string]string),
+			Languages: map[string][]internal_utils.TBXTerm{
+				defaultLanguage.Code: {{Text: entry.Term, Status: internal_utils.TBXStatusPreferred}},
+			},
+		}
+		if entry.Description != "" {
+			tbxEntry.Descriptions[tbxDescripDefinition] = entry.Description
+		}
+		if entry.DoNotTranslate {
+			tbxEntry.Descriptions[tbxDescripDoNotTranslate] = "true"
+		}
+		if entry.CaseSensitive {
+			tbxEntry.Descriptions[tbxDescripCaseSensitive] = "true"
+		}
+		for _, translation := range entry.Translations {
+			status := internal_utils.TBXStatusPreferred
+			if translation.Status == domain.GlossaryStatusForbidden {
+				status = internal_utils.TBXStatusDeprecated
+			}
+			tbxEntry.Languages[translation.Language] = append(tbxEntry.Languages[translation.Language],
+				internal_utils.TBXTerm{Text: translation.Value, Status: status})
+		}
+		file.Entries = append(file.Entries, tbxEntry)
+	}
+
+	return internal_utils.MarshalTBX(file, glossaryTBXSource)
+}
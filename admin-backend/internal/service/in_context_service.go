@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	editorTokenIssuer   = "i18n-flow-in-context"
+	inContextMaxMarkers = 100 // 单次解析最多处理的标记数
+)
+
+// editorTokenClaims 页内编辑令牌的 claim
+type editorTokenClaims struct {
+	UserID    uint64 `json:"user_id"`
+	ProjectID uint64 `json:"project_id"`
+	jwt.RegisteredClaims
+}
+
+// InContextService 页内编辑服务实现
+// 编辑令牌使用由 JWT 密钥派生的独立密钥签名，不能当作登录令牌使用，登录令牌也不能当作编辑令牌
+type InContextService struct {
+	signingKey           []byte
+	tokenTTL             time.Duration
+	projectRepo          domain.ProjectRepository
+	translationRepo      domain.TranslationRepository
+	projectMemberService domain.ProjectMemberService
+	screenshotService    domain.ScreenshotService
+}
+
+// NewInContextService 创建页内编辑服务实例
+func NewInContextService(
+	jwtConfig config.JWTConfig,
+	inContextConfig config.InContextConfig,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	projectMemberService domain.ProjectMemberService,
+	screenshotService domain.ScreenshotService,
+) *InContextService {
+	mac := hmac.New(sha256.New, []byte(jwtConfig.Secret))
+	mac.Write([]byte(editorTokenIssuer))
+
+	return &InContextService{
+		signingKey:           mac.Sum(nil),
+		tokenTTL:             time.Duration(inContextConfig.TokenTTLMinutes) * time.Minute,
+		projectRepo:          projectRepo,
+		translationRepo:      translationRepo,
+		projectMemberService: projectMemberService,
+		screenshotService:    screenshotService,
+	}
+}
+
+// IssueEditorToken 为用户签发绑定项目的短期编辑令牌
+func (s *InContextService) IssueEditorToken(ctx context.Context, projectID, userID uint64) (*domain.EditorToken, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.tokenTTL)
+	claims := &editorTokenClaims{
+		UserID:    userID,
+		ProjectID: projectID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    editorTokenIssuer,
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EditorToken{Token: token, ProjectID: projectID, ExpiresAt: expiresAt}, nil
+}
+
+// Resolve 解析文本中的页内编辑标记，返回键、所有语言的翻译和令牌所属用户的编辑权限
+// 用户的项目权限在每次解析时重新检查，令牌签发后被移出项目的用户无法继续使用
+func (s *InContextService) Resolve(ctx context.Context, token string, text string) (*domain.InContextResolution, error) {
+	claims, err := s.parseEditorToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	markers := internal_utils.ParseInContextMarkers(text)
+	if len(markers) == 0 {
+		return nil, domain.ErrNoInContextMarkers
+	}
+	keyNames := make([]string, 0, len(markers))
+	for _, marker := range markers {
+		if marker.ProjectID == claims.ProjectID && len(keyNames) < inContextMaxMarkers {
+			keyNames = append(keyNames, marker.KeyName)
+		}
+	}
+	if len(keyNames) == 0 {
+		return nil, domain.ErrInContextWrongScope
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, claims.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	canView, err := s.projectMemberService.CheckPermission(ctx, claims.UserID, claims.ProjectID, "viewer")
+	if err != nil || !canView {
+		return nil, domain.ErrInsufficientPerm
+	}
+	canEdit, err := s.projectMemberService.CheckPermission(ctx, claims.UserID, claims.ProjectID, "editor")
+	if err != nil {
+		return nil, err
+	}
+
+	cells, err := s.translationRepo.GetCellsByKeys(ctx, claims.ProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+
+	screenshots, err := s.screenshotService.ListByKeys(ctx, claims.ProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := &domain.InContextResolution{
+		ProjectID: claims.ProjectID,
+		CanEdit:   canEdit,
+		Keys:      make([]*domain.InContextKey, 0, len(keyNames)),
+	}
+	for _, keyName := range keyNames {
+		translations := cells[keyName]
+		if translations == nil {
+			translations = make(map[string]domain.TranslationCell)
+		}
+		keyScreenshots := screenshots[keyName]
+		if keyScreenshots == nil {
+			keyScreenshots = []*domain.Screenshot{}
+		}
+		resolution.Keys = append(resolution.Keys, &domain.InContextKey{
+			KeyName:      keyName,
+			Translations: translations,
+			Screenshots:  keyScreenshots,
+		})
+	}
+	return resolution, nil
+}
+
+// parseEditorToken 验证编辑令牌的签名、签发者和有效期
+func (s *InContextService) parseEditorToken(token string) (*editorTokenClaims, error) {
+	if token == "" {
+		return nil, domain.ErrInvalidEditorToken
+	}
+
+	claims := &editorTokenClaims{}
+	parsed, err := jwt.ParseWithClaims(
+		token,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			return s.signingKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(editorTokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !parsed.Valid || claims.ProjectID == 0 {
+		return nil, domain.ErrInvalidEditorToken
+	}
+	return claims, nil
+}
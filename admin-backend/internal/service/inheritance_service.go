@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+)
+
+// maxBaseProjects 一个项目最多直接继承的基础项目数
+const maxBaseProjects = 10
+
+// InheritanceService 项目继承服务实现
+// 继承的翻译由翻译服务在读取时合并，这里只负责管理继承关系
+// 修订号只记录项目自身的写入，三方合并无法覆盖继承的翻译，因此配置了 git 仓库同步的项目不能继承基础项目
+type InheritanceService struct {
+	projectRepo          domain.ProjectRepository
+	projectBaseRepo      domain.ProjectBaseRepository
+	gitSyncRepo          domain.GitSyncRepository
+	projectMemberService domain.ProjectMemberService
+}
+
+// NewInheritanceService 创建项目继承服务实例
+func NewInheritanceService(
+	projectRepo domain.ProjectRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	gitSyncRepo domain.GitSyncRepository,
+	projectMemberService domain.ProjectMemberService,
+) *InheritanceService {
+	return &InheritanceService{
+		projectRepo:          projectRepo,
+		projectBaseRepo:      projectBaseRepo,
+		gitSyncRepo:          gitSyncRepo,
+		projectMemberService: projectMemberService,
+	}
+}
+
+// Get 获取项目的基础项目、所有被继承的项目和直接继承它的项目
+func (s *InheritanceService) Get(ctx context.Context, projectID uint64) (*domain.ProjectInheritance, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	graph, err := loadBaseGraph(ctx, s.projectBaseRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	bases, err := s.orderedProjects(ctx, graph[projectID])
+	if err != nil {
+		return nil, err
+	}
+	inherits, err := s.orderedProjects(ctx, ResolveInheritance(projectID, graph)[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.projectBaseRepo.ListByBase(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	childIDs := make([]uint64, 0, len(links))
+	for _, link := range links {
+		childIDs = append(childIDs, link.ProjectID)
+	}
+	children, err := s.orderedProjects(ctx, childIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProjectInheritance{Bases: bases, Inherits: inherits, Children: children}, nil
+}
+
+// SetBases 整体替换项目的基础项目
+// 设置者需要能查看每个基础项目，基础项目不能直接或间接继承当前项目；配置了 git 仓库同步的项目不能设置基础项目
+func (s *InheritanceService) SetBases(ctx context.Context, params domain.SetBaseProjectsParams) (*domain.ProjectInheritance, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if len(params.BaseProjectIDs) > 0 {
+		if _, err := s.gitSyncRepo.GetByProject(ctx, params.ProjectID); err == nil {
+			return nil, domain.ErrInheritanceGitSync
+		} else if !errors.Is(err, domain.ErrGitSyncNotConfigured) {
+			return nil, err
+		}
+	}
+
+	baseIDs := make([]uint64, 0, len(params.BaseProjectIDs))
+	seen := make(map[uint64]bool, len(params.BaseProjectIDs))
+	for _, baseID := range params.BaseProjectIDs {
+		if !seen[baseID] {
+			seen[baseID] = true
+			baseIDs = append(baseIDs, baseID)
+		}
+	}
+	if len(baseIDs) > maxBaseProjects {
+		return nil, domain.ErrInvalidInput
+	}
+
+	bases := make([]*domain.ProjectBase, 0, len(baseIDs))
+	for i, baseID := range baseIDs {
+		if baseID == params.ProjectID {
+			return nil, domain.ErrInheritanceCycle
+		}
+		if _, err := s.projectRepo.GetByID(ctx, baseID); err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		canView, err := s.projectMemberService.CheckPermission(ctx, params.UserID, baseID, "viewer")
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, domain.ErrBaseProjectForbidden
+		}
+
+		graph, err := loadBaseGraph(ctx, s.projectBaseRepo, baseID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestorID := range ResolveInheritance(baseID, graph) {
+			if ancestorID == params.ProjectID {
+				return nil, domain.ErrInheritanceCycle
+			}
+		}
+
+		bases = append(bases, &domain.ProjectBase{
+			ProjectID:     params.ProjectID,
+			BaseProjectID: baseID,
+			Position:      i,
+			CreatedBy:     params.UserID,
+		})
+	}
+
+	if err := s.projectBaseRepo.Replace(ctx, params.ProjectID, bases); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, params.ProjectID)
+}
+
+// orderedProjects 按给定顺序获取项目
+func (s *InheritanceService) orderedProjects(ctx context.Context, ids []uint64) ([]*domain.Project, error) {
+	if len(ids) == 0 {
+		return []*domain.Project{}, nil
+	}
+	projects, err := s.projectRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*domain.Project, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+	ordered := make([]*domain.Project, 0, len(ids))
+	for _, id := range ids {
+		if project, ok := byID[id]; ok {
+			ordered = append(ordered, project)
+		}
+	}
+	return ordered, nil
+}
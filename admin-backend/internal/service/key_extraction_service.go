@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/domain"
+	internal_utils "i18n-flow/internal/utils"
+	"path"
+	"sort"
+	"strings"
+)
+
+// 源码压缩包的读取限制
+const (
+	maxArchiveFiles       = 20000
+	maxArchiveFileSize    = 2 << 20
+	maxArchiveTotalSize   = 256 << 20
+	maxReportedParseError = 100
+)
+
+// ignoredSourceDirs 提取时跳过的依赖和版本控制目录
+var ignoredSourceDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+}
+
+// defaultKeyExtractors 未指定提取器时使用的默认配置
+var defaultKeyExtractors = []domain.KeyExtractorConfig{
+	{Type: domain.KeyExtractorGo, Extensions: []string{".go"}, Functions: []string{"i18n.T"}},
+	{Type: domain.KeyExtractorI18next, Extensions: []string{".js", ".jsx", ".ts", ".tsx", ".vue", ".svelte"}},
+	{Type: domain.KeyExtractorRegex, Extensions: []string{".php"}, Patterns: []string{`(?:\b__|\btrans|\b_t)\(\s*['"](?P<key>[^'"]+)['"]`}},
+}
+
+// KeyExtractionService 源码翻译键提取服务实现
+type KeyExtractionService struct {
+	translationRepo    domain.TranslationRepository
+	projectRepo        domain.ProjectRepository
+	translationService domain.TranslationService
+}
+
+// NewKeyExtractionService 创建源码翻译键提取服务实例
+// 推送新键复用 TranslationService.PushKeys，与 CLI 推送保持一致
+func NewKeyExtractionService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+) *KeyExtractionService {
+	return &KeyExtractionService{
+		translationRepo:    translationRepo,
+		projectRepo:        projectRepo,
+		translationService: translationService,
+	}
+}
+
+// ExtractFromArchive 从源码压缩包中提取翻译键，并与项目现有键对比
+func (s *KeyExtractionService) ExtractFromArchive(ctx context.Context, params domain.ExtractKeysParams) (*domain.KeyExtractionResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	configs := params.Extractors
+	if len(configs) == 0 {
+		configs = defaultKeyExtractors
+	}
+	extractors, err := buildKeyExtractors(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.KeyExtractionResult{ParseErrors: []string{}}
+	occurrences := make(map[string][]domain.KeyOccurrence)
+
+	accept := func(filePath string) bool {
+		for _, dir := range strings.Split(path.Dir(filePath), "/") {
+			if ignoredSourceDirs[dir] {
+				return false
+			}
+		}
+		return len(extractors[strings.ToLower(path.Ext(filePath))]) > 0
+	}
+	stats, err := internal_utils.WalkSourceArchive(params.Archive, internal_utils.SourceArchiveLimits{
+		MaxFiles:     maxArchiveFiles,
+		MaxFileSize:  maxArchiveFileSize,
+		MaxTotalSize: maxArchiveTotalSize,
+	}, accept, func(filePath string, content []byte) error {
+		for _, extractor := range extractors[strings.ToLower(path.Ext(filePath))] {
+			keys, err := extractor.Extract(filePath, content)
+			if err != nil {
+				if len(result.ParseErrors) < maxReportedParseError {
+					result.ParseErrors = append(result.ParseErrors, fmt.Sprintf("%s: %v", filePath, err))
+				}
+				continue
+			}
+			for _, key := range keys {
+				keyName := strings.TrimSpace(key.Key)
+				occurrences[keyName] = append(occurrences[keyName], domain.KeyOccurrence{File: filePath, Line: key.Line})
+			}
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, internal_utils.ErrArchiveTooLarge):
+			return nil, domain.ErrArchiveTooLarge
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return nil, err
+		default:
+			return nil, domain.ErrInvalidArchive
+		}
+	}
+	result.FilesScanned = stats.Files
+	result.FilesSkipped = stats.Skipped
+
+	// 与项目现有键对比
+	matrix, _, err := s.translationRepo.GetMatrix(ctx, params.ProjectID, -1, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result.Keys = make([]*domain.ExtractedKeyInfo, 0, len(occurrences))
+	result.NewKeys = []string{}
+	for keyName, found := range occurrences {
+		result.Keys = append(result.Keys, &domain.ExtractedKeyInfo{KeyName: keyName, Occurrences: found})
+		if _, exists := matrix[keyName]; !exists {
+			result.NewKeys = append(result.NewKeys, keyName)
+		}
+	}
+	result.MissingKeys = []string{}
+	for keyName := range matrix {
+		if _, found := occurrences[keyName]; !found {
+			result.MissingKeys = append(result.MissingKeys, keyName)
+		}
+	}
+	sort.Slice(result.Keys, func(i, j int) bool { return result.Keys[i].KeyName < result.Keys[j].KeyName })
+	sort.Strings(result.NewKeys)
+	sort.Strings(result.MissingKeys)
+
+	if params.Push && len(result.NewKeys) > 0 {
+		result.Pushed, err = s.translationService.PushKeys(ctx, domain.PushKeysParams{
+			ProjectID: params.ProjectID,
+			Keys:      result.NewKeys,
+			UserID:    params.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// buildKeyExtractors 按文件扩展名构建提取器
+func buildKeyExtractors(configs []domain.KeyExtractorConfig) (map[string][]internal_utils.KeyExtractor, error) {
+	byExtension := make(map[string][]internal_utils.KeyExtractor)
+	for i, config := range configs {
+		var extractor internal_utils.KeyExtractor
+		var err error
+		switch config.Type {
+		case domain.KeyExtractorGo:
+			extractor, err = internal_utils.NewGoKeyExtractor(config.Functions)
+		case domain.KeyExtractorRegex:
+			extractor, err = internal_utils.NewRegexKeyExtractor(config.Patterns...)
+		case domain.KeyExtractorI18next:
+			extractor = internal_utils.NewI18nextKeyExtractor()
+		default:
+			err = fmt.Errorf("unknown type %q", config.Type)
+		}
+		if err == nil && len(config.Extensions) == 0 {
+			err = errors.New("extensions is required")
+		}
+		if err != nil {
+			return nil, domain.NewAppErrorWithDetails(
+				domain.ErrorTypeValidation,
+				domain.ErrInvalidKeyExtractor.Code,
+				domain.ErrInvalidKeyExtractor.Message,
+				fmt.Sprintf("提取器 #%d: %v", i+1, err),
+			)
+		}
+
+		for _, extension := range config.Extensions {
+			extension = strings.ToLower(strings.TrimSpace(extension))
+			if extension == "" {
+				continue
+			}
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+			byExtension[extension] = append(byExtension[extension], extractor)
+		}
+	}
+	return byExtension, nil
+}
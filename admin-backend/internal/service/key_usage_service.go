@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	"strings"
+	"time"
+)
+
+// maxReferencesPerScan 单次扫描最多接收的引用数
+const maxReferencesPerScan = 100000
+
+// KeyUsageService 翻译键代码引用服务实现
+type KeyUsageService struct {
+	keyUsageRepo      domain.KeyUsageRepository
+	projectRepo       domain.ProjectRepository
+	screenshotService domain.ScreenshotService
+	graceDays         int
+}
+
+// NewKeyUsageService 创建翻译键代码引用服务实例
+// graceDays 为未使用的键自动废弃前的宽限天数，小于等于0表示不自动废弃
+func NewKeyUsageService(
+	keyUsageRepo domain.KeyUsageRepository,
+	projectRepo domain.ProjectRepository,
+	screenshotService domain.ScreenshotService,
+	graceDays int,
+) *KeyUsageService {
+	return &KeyUsageService{
+		keyUsageRepo:      keyUsageRepo,
+		projectRepo:       projectRepo,
+		screenshotService: screenshotService,
+		graceDays:         graceDays,
+	}
+}
+
+// RecordScan 记录一次代码扫描结果，替换该仓库分支之前的引用
+func (s *KeyUsageService) RecordScan(ctx context.Context, params domain.RecordScanParams) (*domain.KeyScan, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	scan := &domain.KeyScan{
+		ProjectID:  params.ProjectID,
+		Repository: strings.TrimSpace(params.Repository),
+		Branch:     strings.TrimSpace(params.Branch),
+		Commit:     strings.TrimSpace(params.Commit),
+		CreatedAt:  time.Now(),
+	}
+
+	var references []*domain.KeyReference
+	for keyName, locations := range params.References {
+		keyName = strings.TrimSpace(keyName)
+		if keyName == "" {
+			continue
+		}
+		scan.KeyCount++
+		for _, location := range locations {
+			filePath := strings.TrimSpace(location.FilePath)
+			if filePath == "" {
+				continue
+			}
+			references = append(references, &domain.KeyReference{
+				ProjectID:  params.ProjectID,
+				KeyName:    keyName,
+				Repository: scan.Repository,
+				Branch:     scan.Branch,
+				Commit:     scan.Commit,
+				FilePath:   filePath,
+				Line:       location.Line,
+			})
+		}
+	}
+	if len(references) > maxReferencesPerScan {
+		return nil, domain.NewAppError(domain.ErrorTypeValidation, "TOO_MANY_REFERENCES", "单次上报的引用数量过多")
+	}
+	scan.ReferenceCount = len(references)
+
+	if err := s.keyUsageRepo.SaveScan(ctx, scan, references); err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+// GetKeyUsage 获取翻译键的代码引用详情
+func (s *KeyUsageService) GetKeyUsage(ctx context.Context, projectID uint64, keyName string) (*domain.KeyUsageDetail, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	keyName = strings.TrimSpace(keyName)
+	if keyName == "" {
+		return nil, domain.ErrInvalidKey
+	}
+
+	references, err := s.keyUsageRepo.GetReferences(ctx, projectID, keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.keyUsageRepo.GetUsage(ctx, projectID, keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	screenshots, err := s.screenshotService.ListByKeys(ctx, projectID, []string{keyName})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.KeyUsageDetail{
+		KeyName:     keyName,
+		References:  references,
+		Screenshots: screenshots[keyName],
+	}
+	if detail.Screenshots == nil {
+		detail.Screenshots = []*domain.Screenshot{}
+	}
+	if usage != nil {
+		detail.LastSeenAt = &usage.LastSeenAt
+	}
+	return detail, nil
+}
+
+// ListUnusedKeys 列出在所有仓库分支最近一次扫描中都未被引用的键
+func (s *KeyUsageService) ListUnusedKeys(ctx context.Context, projectID uint64) (*domain.UnusedKeysReport, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	scans, err := s.keyUsageRepo.GetLatestScans(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(scans) == 0 {
+		return nil, domain.ErrNoKeyScans
+	}
+
+	firstScanAt, err := s.keyUsageRepo.GetFirstScanAt(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keyUsageRepo.GetUnreferencedKeys(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		key.UnusedSince = unusedSince(key, firstScanAt)
+	}
+
+	return &domain.UnusedKeysReport{Scans: scans, Keys: keys}, nil
+}
+
+// DeprecateUnusedKeys 将未被引用超过宽限期的键标记为废弃，返回被废弃的键名
+// graceDays 小于等于0时使用配置的默认宽限期
+func (s *KeyUsageService) DeprecateUnusedKeys(ctx context.Context, projectID uint64, graceDays int) ([]string, error) {
+	if graceDays <= 0 {
+		graceDays = s.graceDays
+	}
+	if graceDays <= 0 {
+		return nil, domain.NewAppError(domain.ErrorTypeValidation, "INVALID_GRACE_PERIOD", "宽限期必须大于0天")
+	}
+
+	report, err := s.ListUnusedKeys(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().AddDate(0, 0, -graceDays)
+	expired := make([]string, 0)
+	for _, key := range report.Keys {
+		if key.UnusedSince.Before(deadline) {
+			expired = append(expired, key.KeyName)
+		}
+	}
+	if len(expired) == 0 {
+		return expired, nil
+	}
+
+	if _, err := s.keyUsageRepo.MarkKeysDeprecated(ctx, projectID, expired); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// DeprecateAllUnusedKeys 按配置的宽限期处理所有上报过扫描结果的项目，返回被废弃的键总数
+func (s *KeyUsageService) DeprecateAllUnusedKeys(ctx context.Context) (int, error) {
+	return s.deprecateAllUnusedKeys(ctx, nil)
+}
+
+// deprecateAllUnusedKeys 处理所有上报过扫描结果的项目，项目中有键被废弃时调用 onDeprecated
+func (s *KeyUsageService) deprecateAllUnusedKeys(ctx context.Context, onDeprecated func(ctx context.Context, projectID uint64)) (int, error) {
+	if s.graceDays <= 0 {
+		return 0, nil
+	}
+
+	projectIDs, err := s.keyUsageRepo.GetScannedProjectIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, projectID := range projectIDs {
+		deprecated, err := s.DeprecateUnusedKeys(ctx, projectID, s.graceDays)
+		if err != nil {
+			// 已删除的项目和扫描记录已被清除的项目跳过
+			if errors.Is(err, domain.ErrProjectNotFound) || errors.Is(err, domain.ErrNoKeyScans) {
+				continue
+			}
+			return total, err
+		}
+		if len(deprecated) > 0 && onDeprecated != nil {
+			onDeprecated(ctx, projectID)
+		}
+		total += len(deprecated)
+	}
+	return total, nil
+}
+
+// unusedSince 计算键开始未被引用的时间
+func unusedSince(key *domain.UnusedKey, firstScanAt *time.Time) time.Time {
+	if key.LastSeenAt != nil {
+		return *key.LastSeenAt
+	}
+	if firstScanAt != nil && firstScanAt.After(key.CreatedAt) {
+		return *firstScanAt
+	}
+	return key.CreatedAt
+}
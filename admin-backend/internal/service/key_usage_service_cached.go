@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+)
+
+// CachedKeyUsageService 带缓存失效处理的翻译键代码引用服务实现
+// 上报扫描结果可能重新启用已废弃的键，废弃键会改变导出内容，均需清除翻译缓存
+type CachedKeyUsageService struct {
+	keyUsageService *KeyUsageService
+	cacheService    domain.CacheService
+}
+
+// NewCachedKeyUsageService 创建带缓存失效处理的翻译键代码引用服务实例
+func NewCachedKeyUsageService(
+	keyUsageService *KeyUsageService,
+	cacheService domain.CacheService,
+) *CachedKeyUsageService {
+	return &CachedKeyUsageService{
+		keyUsageService: keyUsageService,
+		cacheService:    cacheService,
+	}
+}
+
+// RecordScan 记录代码扫描结果（清除翻译缓存）
+func (s *CachedKeyUsageService) RecordScan(ctx context.Context, params domain.RecordScanParams) (*domain.KeyScan, error) {
+	scan, err := s.keyUsageService.RecordScan(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateTranslationCaches(ctx, s.cacheService, params.ProjectID)
+
+	return scan, nil
+}
+
+// GetKeyUsage 获取翻译键的代码引用详情（不缓存）
+func (s *CachedKeyUsageService) GetKeyUsage(ctx context.Context, projectID uint64, keyName string) (*domain.KeyUsageDetail, error) {
+	return s.keyUsageService.GetKeyUsage(ctx, projectID, keyName)
+}
+
+// ListUnusedKeys 列出未被引用的键（不缓存）
+func (s *CachedKeyUsageService) ListUnusedKeys(ctx context.Context, projectID uint64) (*domain.UnusedKeysReport, error) {
+	return s.keyUsageService.ListUnusedKeys(ctx, projectID)
+}
+
+// DeprecateUnusedKeys 废弃超过宽限期的未使用键（清除翻译缓存）
+func (s *CachedKeyUsageService) DeprecateUnusedKeys(ctx context.Context, projectID uint64, graceDays int) ([]string, error) {
+	deprecated, err := s.keyUsageService.DeprecateUnusedKeys(ctx, projectID, graceDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deprecated) > 0 {
+		invalidateTranslationCaches(ctx, s.cacheService, projectID)
+	}
+
+	return deprecated, nil
+}
+
+// DeprecateAllUnusedKeys 处理所有项目的未使用键（按项目清除翻译缓存）
+func (s *CachedKeyUsageService) DeprecateAllUnusedKeys(ctx context.Context) (int, error) {
+	return s.keyUsageService.deprecateAllUnusedKeys(ctx, func(ctx context.Context, projectID uint64) {
+		invalidateTranslationCaches(ctx, s.cacheService, projectID)
+	})
+}
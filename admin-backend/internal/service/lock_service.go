@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"strings"
+)
+
+// LockService 字符串冻结和翻译锁定服务实现
+// 冻结和锁定由翻译服务在每条写入路径上检查，这里只负责管理
+type LockService struct {
+	projectRepo    domain.ProjectRepository
+	languageRepo   domain.LanguageRepository
+	lockRepo       domain.TranslationLockRepository
+	projectService domain.ProjectService
+}
+
+// NewLockService 创建字符串冻结和翻译锁定服务实例
+func NewLockService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	lockRepo domain.TranslationLockRepository,
+	projectService domain.ProjectService,
+) *LockService {
+	return &LockService{
+		projectRepo:    projectRepo,
+		languageRepo:   languageRepo,
+		lockRepo:       lockRepo,
+		projectService: projectService,
+	}
+}
+
+// List 获取项目的字符串冻结状态和所有锁定
+func (s *LockService) List(ctx context.Context, projectID uint64) (*domain.ProjectLocks, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	locks, err := s.lockRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillLanguageCodes(ctx, locks); err != nil {
+		return nil, err
+	}
+	return &domain.ProjectLocks{StringFreeze: project.StringFreeze, Locks: locks}, nil
+}
+
+// Create 锁定键、语言或单元格
+func (s *LockService) Create(ctx context.Context, params domain.CreateLockParams) (*domain.TranslationLock, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	lock := &domain.TranslationLock{
+		ProjectID: params.ProjectID,
+		KeyName:   strings.TrimSpace(params.KeyName),
+		Reason:    strings.TrimSpace(params.Reason),
+		CreatedBy: params.UserID,
+	}
+	languageCode := strings.TrimSpace(params.LanguageCode)
+	if lock.KeyName == "" && languageCode == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if languageCode != "" {
+		language, err := s.languageRepo.GetByCode(ctx, languageCode)
+		if err != nil {
+			return nil, domain.ErrLanguageNotFound
+		}
+		lock.LanguageID = language.ID
+		lock.LanguageCode = language.Code
+	}
+
+	if err := s.lockRepo.Create(ctx, lock); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrLockExists
+		}
+		return nil, err
+	}
+	return lock, nil
+}
+
+// Delete 解除项目的一个锁定
+func (s *LockService) Delete(ctx context.Context, projectID, id uint64) error {
+	lock, err := s.lockRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if lock.ProjectID != projectID {
+		return domain.ErrLockNotFound
+	}
+	return s.lockRepo.Delete(ctx, id)
+}
+
+// SetStringFreeze 开启或解除项目的字符串冻结，通过项目服务更新以清除项目缓存
+func (s *LockService) SetStringFreeze(ctx context.Context, projectID uint64, enabled bool, userID uint64) (*domain.Project, error) {
+	return s.projectService.Update(ctx, projectID, domain.UpdateProjectParams{StringFreeze: &enabled}, userID)
+}
+
+// fillLanguageCodes 为锁定填充语言代码
+func (s *LockService) fillLanguageCodes(ctx context.Context, locks []*domain.TranslationLock) error {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	codes := make(map[uint64]string, len(languages))
+	for _, language := range languages {
+		codes[language.ID] = language.Code
+	}
+	for _, lock := range locks {
+		lock.LanguageCode = codes[lock.LanguageID]
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	mtBatchSize       = 25        // 每次请求最多发送的文本数
+	mtBatchCharacters = 5000      // 每次请求最多发送的字符数
+	mtPeriodLayout    = "2006-01" // 用量统计周期（月）
+
+	mtReasonMarkupAltered = "placeholders or HTML tags were altered by machine translation"
+	mtReasonEmpty         = "machine translation returned an empty value"
+)
+
+// MachineTranslationService 机器翻译服务实现
+// 预翻译只填充空单元格，结果标记为机器翻译草稿；发送前保护占位符和 HTML 标签，返回后还原
+type MachineTranslationService struct {
+	translator      domain.MachineTranslator // 未启用机器翻译时为 nil
+	usageRepo       domain.MachineTranslationUsageRepository
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	lockRepo        domain.TranslationLockRepository
+	monthlyQuota    int64 // 每个项目每月的字符额度，0表示不限制
+}
+
+// NewMachineTranslationService 创建机器翻译服务实例
+func NewMachineTranslationService(
+	translator domain.MachineTranslator,
+	usageRepo domain.MachineTranslationUsageRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	lockRepo domain.TranslationLockRepository,
+	monthlyQuota int,
+) *MachineTranslationService {
+	return &MachineTranslationService{
+		translator:      translator,
+		usageRepo:       usageRepo,
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		lockRepo:        lockRepo,
+		monthlyQuota:    int64(monthlyQuota),
+	}
+}
+
+// mtCell 待机器翻译的空单元格
+type mtCell struct {
+	keyName  string
+	source   string
+	existing *domain.Translation // 值为空的已有翻译，为 nil 时新建
+}
+
+// preTranslateRun 一次预翻译的上下文
+type preTranslateRun struct {
+	project        *domain.Project
+	sourceLanguage string
+	userID         uint64
+	period         string
+	usedBefore     int64 // 本月此前已使用的字符数
+	result         *domain.PreTranslateResult
+}
+
+// PreTranslate 用机器翻译填充指定语言中的空单元格
+// 以键在默认语言下的翻译为原文，原文相同的单元格只翻译一次；额度用完或提供方出错时停止，已完成的部分会保留；锁定的单元格跳过
+func (s *MachineTranslationService) PreTranslate(ctx context.Context, params domain.PreTranslateParams) (*domain.PreTranslateResult, error) {
+	if s.translator == nil {
+		return nil, domain.ErrMTNotConfigured
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, params.ProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	targets, err := s.targetLanguages(ctx, params.Languages, defaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &preTranslateRun{
+		project:        project,
+		sourceLanguage: defaultLanguage.Code,
+		userID:         params.UserID,
+		period:         time.Now().Format(mtPeriodLayout),
+		result: &domain.PreTranslateResult{
+			Provider: s.translator.Name(),
+			Failed:   make([]*domain.PreTranslateFailure, 0),
+		},
+	}
+	run.usedBefore, err = s.usageRepo.GetCharacters(ctx, project.ID, run.period)
+	if err != nil {
+		return nil, err
+	}
+	if s.monthlyQuota > 0 && run.usedBefore >= s.monthlyQuota {
+		return nil, domain.ErrMTQuotaExceeded
+	}
+
+	sources, err := activeTranslationValues(ctx, s.translationRepo, project.ID, defaultLanguage.ID, params.KeyNames)
+	if err != nil {
+		return nil, err
+	}
+	locks, err := s.lockRepo.ListByProject(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range targets {
+		cells, err := s.emptyCells(ctx, project.ID, language.ID, sources, locks)
+		if err != nil {
+			return nil, err
+		}
+		stopped, err := s.preTranslateLanguage(ctx, run, language, cells)
+		if err != nil {
+			return nil, err
+		}
+		if stopped {
+			break
+		}
+	}
+	return run.result, nil
+}
+
+// targetLanguages 校验并去重目标语言，不能包含默认语言
+func (s *MachineTranslationService) targetLanguages(ctx context.Context, codes []string, defaultLanguage *domain.Language) ([]*domain.Language, error) {
+	if len(codes) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*domain.Language, len(languages))
+	for _, language := range languages {
+		byCode[language.Code] = language
+	}
+
+	seen := make(map[string]bool)
+	targets := make([]*domain.Language, 0, len(codes))
+	for _, code := range codes {
+		language, ok := byCode[code]
+		if !ok {
+			return nil, domain.ErrLanguageNotFound
+		}
+		if language.ID == defaultLanguage.ID {
+			return nil, domain.ErrMTDefaultLanguage
+		}
+		if !seen[code] {
+			seen[code] = true
+			targets = append(targets, language)
+		}
+	}
+	return targets, nil
+}
+
+// emptyCells 获取目标语言中原文非空、译文缺失或为空的单元格，已废弃和锁定的单元格不处理
+func (s *MachineTranslationService) emptyCells(ctx context.Context, projectID, languageID uint64, sources map[string]string, locks []*domain.TranslationLock) ([]*mtCell, error) {
+	translations, err := s.translationRepo.GetByProjectAndLanguage(ctx, projectID, languageID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*domain.Translation, len(translations))
+	for _, translation := range translations {
+		existing[translation.KeyName] = translation
+	}
+
+	keyNames := make([]string, 0, len(sources))
+	for keyName := range sources {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	cells := make([]*mtCell, 0)
+	for _, keyName := range keyNames {
+		if isLocked(locks, keyName, languageID) {
+			continue
+		}
+		translation := existing[keyName]
+		switch {
+		case translation == nil:
+			cells = append(cells, &mtCell{keyName: keyName, source: sources[keyName]})
+		case translation.Status == "active" && strings.TrimSpace(translation.Value) == "":
+			cells = append(cells, &mtCell{keyName: keyName, source: sources[keyName], existing: translation})
+		}
+	}
+	return cells, nil
+}
+
+// preTranslateLanguage 分批翻译一种语言的空单元格，返回是否因额度或提供方错误而停止
+func (s *MachineTranslationService) preTranslateLanguage(ctx context.Context, run *preTranslateRun, language *domain.Language, cells []*mtCell) (bool, error) {
+	translated := make(map[string]string) // 原文 -> 还原后的译文
+	failed := make(map[string]string)     // 原文 -> 失败原因
+	var pending []*mtCell
+	var batch []string
+	var batchCharacters int64
+	inBatch := make(map[string]bool)
+
+	// flush 翻译当前批次并写入所有待写入的单元格
+	flush := func() (bool, error) {
+		stopped := false
+		if len(batch) > 0 {
+			if err := s.translateBatch(ctx, run, language.Code, batch, batchCharacters, translated, failed); err != nil {
+				if !errors.Is(err, errMTProvider) {
+					return false, err
+				}
+				stopped = true
+			}
+		}
+		if err := s.writeCells(ctx, run, language, pending, translated, failed); err != nil {
+			return false, err
+		}
+		pending, batch, batchCharacters = nil, nil, 0
+		inBatch = make(map[string]bool)
+		return stopped, nil
+	}
+
+	for _, cell := range cells {
+		_, done := translated[cell.source]
+		if _, fail := failed[cell.source]; done || fail || inBatch[cell.source] {
+			pending = append(pending, cell)
+			continue
+		}
+
+		characters := int64(utf8.RuneCountInString(cell.source))
+		if s.monthlyQuota > 0 && run.usedBefore+run.result.Characters+batchCharacters+characters > s.monthlyQuota {
+			run.result.QuotaExceeded = true
+			_, err := flush()
+			return true, err
+		}
+		if len(batch) > 0 && (len(batch) == mtBatchSize || batchCharacters+characters > mtBatchCharacters) {
+			if stopped, err := flush(); stopped || err != nil {
+				return stopped, err
+			}
+		}
+
+		batch = append(batch, cell.source)
+		inBatch[cell.source] = true
+		batchCharacters += characters
+		pending = append(pending, cell)
+	}
+	return flush()
+}
+
+// errMTProvider 提供方请求失败，错误信息已记录在结果中
+var errMTProvider = errors.New("machine translation provider failed")
+
+// translateBatch 保护标记后调用提供方翻译一批原文，记录用量并还原译文
+func (s *MachineTranslationService) translateBatch(
+	ctx context.Context,
+	run *preTranslateRun,
+	targetLanguage string,
+	sources []string,
+	characters int64,
+	translated, failed map[string]string,
+) error {
+	syntax := projectPlaceholderFormat(run.project)
+	texts := make([]string, len(sources))
+	tokens := make([][]string, len(sources))
+	for i, source := range sources {
+		texts[i], tokens[i] = internal_utils.ProtectMarkup(source, syntax)
+	}
+
+	results, err := s.translator.Translate(ctx, texts, run.sourceLanguage, targetLanguage)
+	if err != nil {
+		run.result.ProviderError = err.Error()
+		return errMTProvider
+	}
+
+	// 提供方已处理这些字符，无论译文能否还原都计入用量
+	if err := s.usageRepo.AddCharacters(ctx, run.project.ID, run.period, characters); err != nil {
+		return err
+	}
+	run.result.Characters += characters
+
+	for i, source := range sources {
+		value, err := internal_utils.RestoreMarkup(results[i], tokens[i])
+		switch {
+		case err != nil:
+			failed[source] = mtReasonMarkupAltered
+		case strings.TrimSpace(value) == "":
+			failed[source] = mtReasonEmpty
+		default:
+			translated[source] = strings.TrimSpace(value)
+		}
+	}
+	return nil
+}
+
+// writeCells 将已翻译的单元格保存为机器翻译草稿，未翻译的记录失败原因，尚未翻译的跳过
+func (s *MachineTranslationService) writeCells(
+	ctx context.Context,
+	run *preTranslateRun,
+	language *domain.Language,
+	cells []*mtCell,
+	translated, failed map[string]string,
+) error {
+	created := make([]*domain.Translation, 0, len(cells))
+	for _, cell := range cells {
+		if reason, ok := failed[cell.source]; ok {
+			run.result.Failed = append(run.result.Failed, &domain.PreTranslateFailure{
+				KeyName:  cell.keyName,
+				Language: language.Code,
+				Reason:   reason,
+			})
+			continue
+		}
+		value, ok := translated[cell.source]
+		if !ok {
+			continue
+		}
+
+		if cell.existing != nil {
+			cell.existing.Value = value
+			cell.existing.MachineTranslated = true
+			cell.existing.UpdatedBy = run.userID
+			if err := s.translationRepo.Update(ctx, cell.existing); err != nil {
+				return err
+			}
+		} else {
+			created = append(created, &domain.Translation{
+				ProjectID:         run.project.ID,
+				KeyName:           cell.keyName,
+				LanguageID:        language.ID,
+				Value:             value,
+				Status:            "active",
+				MachineTranslated: true,
+				CreatedBy:         run.userID,
+				UpdatedBy:         run.userID,
+			})
+		}
+		run.result.Translated++
+	}
+
+	if len(created) == 0 {
+		return nil
+	}
+	return s.translationRepo.CreateBatch(ctx, created)
+}
+
+// GetUsage 获取项目本月的机器翻译用量
+func (s *MachineTranslationService) GetUsage(ctx context.Context, projectID uint64) (*domain.MachineTranslationUsageReport, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	period := time.Now().Format(mtPeriodLayout)
+	characters, err := s.usageRepo.GetCharacters(ctx, projectID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.MachineTranslationUsageReport{
+		Period:     period,
+		Characters: characters,
+		Quota:      s.monthlyQuota,
+	}
+	if s.translator != nil {
+		report.Provider = s.translator.Name()
+	}
+	return report, nil
+}
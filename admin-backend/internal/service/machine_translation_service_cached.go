@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+)
+
+// CachedMachineTranslationService 带缓存失效处理的机器翻译服务实现
+// 用量数据不缓存，预翻译写入翻译后清除项目的翻译缓存
+type CachedMachineTranslationService struct {
+	mtService    *MachineTranslationService
+	cacheService domain.CacheService
+}
+
+// NewCachedMachineTranslationService 创建带缓存失效处理的机器翻译服务实例
+func NewCachedMachineTranslationService(
+	mtService *MachineTranslationService,
+	cacheService domain.CacheService,
+) *CachedMachineTranslationService {
+	return &CachedMachineTranslationService{
+		mtService:    mtService,
+		cacheService: cacheService,
+	}
+}
+
+// PreTranslate 机器预翻译（有翻译写入时清除翻译缓存）
+func (s *CachedMachineTranslationService) PreTranslate(ctx context.Context, params domain.PreTranslateParams) (*domain.PreTranslateResult, error) {
+	result, err := s.mtService.PreTranslate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Translated > 0 {
+		invalidateTranslationCaches(ctx, s.cacheService, params.ProjectID)
+	}
+
+	return result, nil
+}
+
+// GetUsage 获取机器翻译用量（不缓存）
+func (s *CachedMachineTranslationService) GetUsage(ctx context.Context, projectID uint64) (*domain.MachineTranslationUsageReport, error) {
+	return s.mtService.GetUsage(ctx, projectID)
+}
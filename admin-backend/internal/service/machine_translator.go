@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// mtErrorBodyLimit 提供方返回错误时记录的响应内容长度上限
+const mtErrorBodyLimit = 512
+
+// NewMachineTranslator 根据配置创建机器翻译提供方，未启用时返回 nil
+func NewMachineTranslator(cfg config.MachineTranslationConfig) domain.MachineTranslator {
+	client := &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second}
+	switch cfg.Provider {
+	case "deepl":
+		return NewDeepLTranslator(cfg.APIKey, cfg.Endpoint, client)
+	case "google":
+		return NewGoogleTranslator(cfg.APIKey, cfg.Endpoint, client)
+	case "openai":
+		return NewOpenAITranslator(cfg.APIKey, cfg.Endpoint, cfg.Model, client)
+	case "fake":
+		return NewFakeTranslator()
+	default:
+		return nil
+	}
+}
+
+// FakeTranslator 本地确定性机器翻译，不调用外部服务，用于开发和测试
+// 译文为 "[目标语言] 原文"，原文中的保护标记原样保留
+type FakeTranslator struct{}
+
+// NewFakeTranslator 创建本地机器翻译实例
+func NewFakeTranslator() *FakeTranslator {
+	return &FakeTranslator{}
+}
+
+// Name 提供方名称
+func (t *FakeTranslator) Name() string {
+	return "fake"
+}
+
+// Translate 翻译文本
+func (t *FakeTranslator) Translate(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = "[" + targetLanguage + "] " + text
+	}
+	return translations, nil
+}
+
+// postJSON 以 JSON 格式调用提供方接口并解析响应，非 2xx 响应返回包含响应内容的错误
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, mtErrorBodyLimit))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// splitLanguageCode 拆分语言代码为小写主语言和大写地区/书写系统，如 zh_hant_TW -> zh, HANT-TW
+func splitLanguageCode(code string) (string, string) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(code), "_", "-")
+	primary, region, _ := strings.Cut(normalized, "-")
+	return strings.ToLower(primary), strings.ToUpper(region)
+}
+
+// isTraditionalChinese 判断中文地区/书写系统是否使用繁体
+func isTraditionalChinese(region string) bool {
+	return strings.Contains(region, "HANT") || strings.Contains(region, "TW") ||
+		strings.Contains(region, "HK") || strings.Contains(region, "MO")
+}
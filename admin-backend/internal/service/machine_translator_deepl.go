@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	deepLFreeEndpoint = "https://api-free.deepl.com/v2/translate"
+	deepLProEndpoint  = "https://api.deepl.com/v2/translate"
+)
+
+// DeepLTranslator DeepL 机器翻译
+// 以 XML 模式发送，<x> 标记被 DeepL 视为不可翻译的标签
+type DeepLTranslator struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+// NewDeepLTranslator 创建 DeepL 机器翻译实例，endpoint 为空时按密钥类型选择免费版或专业版接口
+func NewDeepLTranslator(apiKey, endpoint string, client *http.Client) *DeepLTranslator {
+	if endpoint == "" {
+		endpoint = deepLProEndpoint
+		if strings.HasSuffix(apiKey, ":fx") {
+			endpoint = deepLFreeEndpoint
+		}
+	}
+	return &DeepLTranslator{apiKey: apiKey, endpoint: endpoint, client: client}
+}
+
+// Name 提供方名称
+func (t *DeepLTranslator) Name() string {
+	return "deepl"
+}
+
+// Translate 翻译文本
+func (t *DeepLTranslator) Translate(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	source, _ := splitLanguageCode(sourceLanguage)
+	request := map[string]interface{}{
+		"text":         texts,
+		"source_lang":  strings.ToUpper(source),
+		"target_lang":  deepLTargetLanguage(targetLanguage),
+		"tag_handling": "xml",
+		"ignore_tags":  []string{"x"},
+	}
+
+	var response struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	headers := map[string]string{"Authorization": "DeepL-Auth-Key " + t.apiKey}
+	if err := postJSON(ctx, t.client, t.endpoint, headers, request, &response); err != nil {
+		return nil, fmt.Errorf("deepl: %w", err)
+	}
+	if len(response.Translations) != len(texts) {
+		return nil, fmt.Errorf("deepl: expected %d translations, got %d", len(texts), len(response.Translations))
+	}
+
+	translations := make([]string, len(texts))
+	for i, translation := range response.Translations {
+		translations[i] = translation.Text
+	}
+	return translations, nil
+}
+
+// deepLTargetLanguage 转换为 DeepL 目标语言代码，英语、葡萄牙语和中文需要指定变体
+func deepLTargetLanguage(code string) string {
+	primary, region := splitLanguageCode(code)
+	switch primary {
+	case "en":
+		if region == "GB" {
+			return "EN-GB"
+		}
+		return "EN-US"
+	case "pt":
+		if region == "BR" {
+			return "PT-BR"
+		}
+		return "PT-PT"
+	case "zh":
+		if isTraditionalChinese(region) {
+			return "ZH-HANT"
+		}
+		return "ZH-HANS"
+	default:
+		return strings.ToUpper(primary)
+	}
+}
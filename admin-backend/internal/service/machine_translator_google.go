@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const googleTranslateEndpoint = "https://translation.googleapis.com/language/translate/v2"
+
+// GoogleTranslator Google Cloud Translation（v2）机器翻译
+// 以 HTML 模式发送，<x> 标记作为未知标签原样保留
+type GoogleTranslator struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+// NewGoogleTranslator 创建 Google 机器翻译实例
+func NewGoogleTranslator(apiKey, endpoint string, client *http.Client) *GoogleTranslator {
+	if endpoint == "" {
+		endpoint = googleTranslateEndpoint
+	}
+	return &GoogleTranslator{apiKey: apiKey, endpoint: endpoint, client: client}
+}
+
+// Name 提供方名称
+func (t *GoogleTranslator) Name() string {
+	return "google"
+}
+
+// Translate 翻译文本
+func (t *GoogleTranslator) Translate(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	request := map[string]interface{}{
+		"q":      texts,
+		"source": googleLanguage(sourceLanguage),
+		"target": googleLanguage(targetLanguage),
+		"format": "html",
+	}
+
+	var response struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	headers := map[string]string{"X-Goog-Api-Key": t.apiKey}
+	if err := postJSON(ctx, t.client, t.endpoint, headers, request, &response); err != nil {
+		return nil, fmt.Errorf("google: %w", err)
+	}
+	if len(response.Data.Translations) != len(texts) {
+		return nil, fmt.Errorf("google: expected %d translations, got %d", len(texts), len(response.Data.Translations))
+	}
+
+	translations := make([]string, len(texts))
+	for i, translation := range response.Data.Translations {
+		translations[i] = translation.TranslatedText
+	}
+	return translations, nil
+}
+
+// googleLanguage 转换为 Google 语言代码，中文区分简繁体，其余使用主语言
+func googleLanguage(code string) string {
+	primary, region := splitLanguageCode(code)
+	if primary == "zh" {
+		if isTraditionalChinese(region) {
+			return "zh-TW"
+		}
+		return "zh-CN"
+	}
+	return primary
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const openAIEndpoint = "https://api.openai.com/v1"
+
+// openAISystemPrompt 要求模型逐条翻译并保留保护标记
+const openAISystemPrompt = `You are a professional software localization translator. ` +
+	`Translate each string in the JSON array the user sends from %s to %s. ` +
+	`Keep every <x id="n"/> tag exactly as written, keep XML entities such as &amp; unchanged, and do not add explanations. ` +
+	`Reply with only a JSON array of the translated strings, in the same order and with the same length.`
+
+// OpenAITranslator 兼容 OpenAI Chat Completions 接口的大模型机器翻译
+type OpenAITranslator struct {
+	apiKey   string
+	endpoint string
+	model    string
+	client   *http.Client
+}
+
+// NewOpenAITranslator 创建 OpenAI 兼容接口的机器翻译实例，endpoint 为接口根地址，如 https://api.openai.com/v1
+func NewOpenAITranslator(apiKey, endpoint, model string, client *http.Client) *OpenAITranslator {
+	if endpoint == "" {
+		endpoint = openAIEndpoint
+	}
+	return &OpenAITranslator{
+		apiKey:   apiKey,
+		endpoint: strings.TrimRight(endpoint, "/") + "/chat/completions",
+		model:    model,
+		client:   client,
+	}
+}
+
+// Name 提供方名称
+func (t *OpenAITranslator) Name() string {
+	return "openai"
+}
+
+// Translate 翻译文本
+func (t *OpenAITranslator) Translate(ctx context.Context, texts []string, sourceLanguage, targetLanguage string) ([]string, error) {
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]interface{}{
+		"model":       t.model,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "system", "content": fmt.Sprintf(openAISystemPrompt, sourceLanguage, targetLanguage)},
+			{"role": "user", "content": string(input)},
+		},
+	}
+
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + t.apiKey}
+	if err := postJSON(ctx, t.client, t.endpoint, headers, request, &response); err != nil {
+		return nil, fmt.Errorf("openai: %w", err)
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("openai: empty response")
+	}
+
+	var translations []string
+	if err := json.Unmarshal([]byte(stripCodeFence(response.Choices[0].Message.Content)), &translations); err != nil {
+		return nil, fmt.Errorf("openai: response is not a JSON array of strings: %w", err)
+	}
+	if len(translations) != len(texts) {
+		return nil, fmt.Errorf("openai: expected %d translations, got %d", len(texts), len(translations))
+	}
+	return translations, nil
+}
+
+// stripCodeFence 去除模型回复外层的 Markdown 代码块
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimPrefix(content, "```")
+	if newline := strings.IndexByte(content, '\n'); newline >= 0 {
+		content = content[newline+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(content), "```"))
+}
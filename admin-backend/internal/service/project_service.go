@@ -5,6 +5,8 @@ import (
 	"i18n-flow/internal/domain"
 	"strings"
 
+	internal_utils "i18n-flow/internal/utils"
+
 	"github.com/gosimple/slug"
 )
 
@@ -13,6 +15,11 @@ type ProjectService struct {
 	projectRepo       domain.ProjectRepository
 	userRepo          domain.UserRepository
 	projectMemberRepo domain.ProjectMemberRepository
+	languageRepo      domain.LanguageRepository
+	translationRepo   domain.TranslationRepository
+	templateRepo      domain.ProjectTemplateRepository
+	projectBaseRepo   domain.ProjectBaseRepository
+	criteriaRepo      domain.ReleaseCriteriaRepository
 }
 
 // NewProjectService 创建项目服务实例
@@ -20,16 +27,55 @@ func NewProjectService(
 	projectRepo domain.ProjectRepository,
 	userRepo domain.UserRepository,
 	projectMemberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	templateRepo domain.ProjectTemplateRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
+	criteriaRepo domain.ReleaseCriteriaRepository,
 ) *ProjectService {
 	return &ProjectService{
 		projectRepo:       projectRepo,
 		userRepo:          userRepo,
 		projectMemberRepo: projectMemberRepo,
+		languageRepo:      languageRepo,
+		translationRepo:   translationRepo,
+		templateRepo:      templateRepo,
+		projectBaseRepo:   projectBaseRepo,
+		criteriaRepo:      criteriaRepo,
 	}
 }
 
-// Create 创建项目
+// Create 创建项目，指定模板时未设置的占位符语法和值类型取模板的设置，创建后按模板设置基础项目、初始键和默认成员
 func (s *ProjectService) Create(ctx context.Context, params domain.CreateProjectParams, userID uint64) (*domain.Project, error) {
+	var template *domain.ProjectTemplate
+	if params.TemplateID != 0 {
+		var err error
+		if template, err = s.templateRepo.GetByID(ctx, params.TemplateID); err != nil {
+			return nil, err
+		}
+		if params.PlaceholderFormat == "" {
+			params.PlaceholderFormat = template.PlaceholderFormat
+		}
+		if params.ValueType == "" {
+			params.ValueType = template.ValueType
+		}
+	}
+
+	placeholderFormat := params.PlaceholderFormat
+	if placeholderFormat == "" {
+		placeholderFormat = domain.PlaceholderFormatBrace
+	}
+	if !internal_utils.IsPlaceholderSyntax(placeholderFormat) {
+		return nil, domain.ErrInvalidPlaceholderFormat
+	}
+	valueType := params.ValueType
+	if valueType == "" {
+		valueType = domain.ValueTypePlain
+	}
+	if !internal_utils.IsValueType(valueType) {
+		return nil, domain.ErrInvalidValueType
+	}
+
 	// 生成slug
 	projectSlug := slug.Make(params.Name)
 	if projectSlug == "" {
@@ -42,20 +88,37 @@ func (s *ProjectService) Create(ctx context.Context, params domain.CreateProject
 		return nil, domain.ErrProjectExists
 	}
 
+	// 回收站中的项目仍占用名称和标识
+	trashedProject, err := s.projectRepo.FindDeletedByNameOrSlug(ctx, strings.TrimSpace(params.Name), projectSlug)
+	if err != nil {
+		return nil, err
+	}
+	if trashedProject != nil {
+		return nil, domain.ErrProjectInTrash
+	}
+
 	// 创建项目
 	project := &domain.Project{
-		Name:        strings.TrimSpace(params.Name),
-		Description: strings.TrimSpace(params.Description),
-		Slug:        projectSlug,
-		Status:      "active",
-		CreatedBy:   userID,
-		UpdatedBy:   userID,
+		Name:              strings.TrimSpace(params.Name),
+		Description:       strings.TrimSpace(params.Description),
+		Slug:              projectSlug,
+		Status:            "active",
+		PlaceholderFormat: placeholderFormat,
+		ValueType:         valueType,
+		CreatedBy:         userID,
+		UpdatedBy:         userID,
 	}
 
 	if err := s.projectRepo.Create(ctx, project); err != nil {
 		return nil, err
 	}
 
+	if template != nil {
+		if err := s.applyTemplate(ctx, project, template, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	return project, nil
 }
 
@@ -113,6 +176,34 @@ func (s *ProjectService) Update(ctx context.Context, id uint64, params domain.Up
 		project.Status = params.Status
 	}
 
+	if params.PlaceholderFormat != "" {
+		if !internal_utils.IsPlaceholderSyntax(params.PlaceholderFormat) {
+			return nil, domain.ErrInvalidPlaceholderFormat
+		}
+		project.PlaceholderFormat = params.PlaceholderFormat
+	}
+
+	if params.ValueType != "" {
+		if !internal_utils.IsValueType(params.ValueType) {
+			return nil, domain.ErrInvalidValueType
+		}
+		// 切换值类型前校验现有翻译值，避免已保存的值在新类型下无效
+		if params.ValueType != projectValueType(project) {
+			matrix, _, err := s.translationRepo.GetMatrix(ctx, project.ID, -1, 0, "")
+			if err != nil {
+				return nil, err
+			}
+			if err := validateMatrixValueType(params.ValueType, matrix); err != nil {
+				return nil, err
+			}
+		}
+		project.ValueType = params.ValueType
+	}
+
+	if params.StringFreeze != nil {
+		project.StringFreeze = *params.StringFreeze
+	}
+
 	// 更新UpdatedBy字段
 	project.UpdatedBy = userID
 
@@ -42,6 +42,21 @@ func (s *CachedProjectService) Create(ctx context.Context, params domain.CreateP
 	return project, nil
 }
 
+// Clone 克隆项目（清除相关缓存）
+func (s *CachedProjectService) Clone(ctx context.Context, params domain.CloneProjectParams, userID uint64) (*domain.CloneProjectResult, error) {
+	result, err := s.projectService.Clone(ctx, params, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除项目列表缓存和仪表板缓存
+	baseKey := s.cacheService.GetProjectsKey()
+	s.cacheService.DeleteByPattern(ctx, baseKey+"*")
+	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+
+	return result, nil
+}
+
 // GetByID 根据ID获取项目（使用缓存）
 func (s *CachedProjectService) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
 	cacheKey := s.cacheService.GetProjectKey(id)
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"strings"
+)
+
+// applyTemplate 按模板为新项目设置基础项目、创建初始键和默认成员
+// 模板保存后被删除的基础项目和用户会被跳过
+func (s *ProjectService) applyTemplate(ctx context.Context, project *domain.Project, template *domain.ProjectTemplate, userID uint64) error {
+	bases := make([]*domain.ProjectBase, 0, len(template.BaseProjectIDs))
+	for _, baseID := range template.BaseProjectIDs {
+		if _, err := s.projectRepo.GetByID(ctx, baseID); err != nil {
+			continue
+		}
+		bases = append(bases, &domain.ProjectBase{
+			ProjectID:     project.ID,
+			BaseProjectID: baseID,
+			Position:      len(bases),
+			CreatedBy:     userID,
+		})
+	}
+	if len(bases) > 0 {
+		if err := s.projectBaseRepo.Replace(ctx, project.ID, bases); err != nil {
+			return err
+		}
+	}
+
+	languages, err := s.templateLanguages(ctx, template.Languages)
+	if err != nil {
+		return err
+	}
+	translations := make([]*domain.Translation, 0, len(template.StarterKeys)*len(languages))
+	for _, key := range template.StarterKeys {
+		for _, language := range languages {
+			translations = append(translations, &domain.Translation{
+				ProjectID:  project.ID,
+				KeyName:    key.KeyName,
+				Context:    key.Context,
+				LanguageID: language.ID,
+				Value:      key.Values[language.Code],
+				Status:     "active",
+				CreatedBy:  userID,
+				UpdatedBy:  userID,
+			})
+		}
+	}
+	if len(translations) > 0 {
+		if err := s.translationRepo.CreateBatch(ctx, translations); err != nil {
+			return err
+		}
+	}
+
+	for _, member := range template.DefaultMembers {
+		if _, err := s.userRepo.GetByID(ctx, member.UserID); err != nil {
+			continue
+		}
+		if err := s.projectMemberRepo.Create(ctx, &domain.ProjectMember{
+			ProjectID: project.ID,
+			UserID:    member.UserID,
+			Role:      member.Role,
+			CreatedBy: userID,
+			UpdatedBy: userID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateLanguages 获取模板的语言，模板未指定语言时使用所有启用的语言
+func (s *ProjectService) templateLanguages(ctx context.Context, codes []string) ([]*domain.Language, error) {
+	if len(codes) == 0 {
+		all, err := s.languageRepo.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		languages := make([]*domain.Language, 0, len(all))
+		for _, language := range all {
+			if language.Status != "inactive" {
+				languages = append(languages, language)
+			}
+		}
+		return languages, nil
+	}
+
+	languages := make([]*domain.Language, 0, len(codes))
+	for _, code := range codes {
+		language, err := s.languageRepo.GetByCode(ctx, code)
+		if err != nil {
+			continue
+		}
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+// Clone 以现有项目为蓝本创建新项目，可选复制键（只复制键或连同译文）、成员和设置
+// 只复制源项目自身的有效翻译，继承的翻译通过复制基础项目设置获得
+func (s *ProjectService) Clone(ctx context.Context, params domain.CloneProjectParams, userID uint64) (*domain.CloneProjectResult, error) {
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	switch params.Keys {
+	case domain.CloneKeysNone, domain.CloneKeysOnly, domain.CloneKeysValues:
+	default:
+		return nil, domain.ErrInvalidInput
+	}
+
+	source, err := s.projectRepo.GetByID(ctx, params.SourceID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	createParams := domain.CreateProjectParams{
+		Name:        params.Name,
+		Description: params.Description,
+	}
+	if strings.TrimSpace(createParams.Description) == "" {
+		createParams.Description = source.Description
+	}
+	// 译文按源项目的占位符语法和值类型编写，复制译文时必须沿用
+	if params.IncludeSettings || params.Keys == domain.CloneKeysValues {
+		createParams.PlaceholderFormat = source.PlaceholderFormat
+		createParams.ValueType = source.ValueType
+	}
+	project, err := s.Create(ctx, createParams, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.CloneProjectResult{Project: project}
+
+	if params.IncludeSettings {
+		if err := s.cloneSettings(ctx, source.ID, project.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Keys != domain.CloneKeysNone {
+		result.Keys, result.Translations, err = s.cloneTranslations(ctx, source.ID, project.ID, params.Keys == domain.CloneKeysValues, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if params.IncludeMembers {
+		members, err := s.projectMemberRepo.GetByProjectID(ctx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if err := s.projectMemberRepo.Create(ctx, &domain.ProjectMember{
+				ProjectID: project.ID,
+				UserID:    member.UserID,
+				Role:      member.Role,
+				CreatedBy: userID,
+				UpdatedBy: userID,
+			}); err != nil {
+				return nil, err
+			}
+			result.Members++
+		}
+	}
+
+	return result, nil
+}
+
+// cloneSettings 复制基础项目和发布门禁条件
+func (s *ProjectService) cloneSettings(ctx context.Context, sourceID, projectID, userID uint64) error {
+	links, err := s.projectBaseRepo.ListByProject(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if len(links) > 0 {
+		bases := make([]*domain.ProjectBase, 0, len(links))
+		for _, link := range links {
+			bases = append(bases, &domain.ProjectBase{
+				ProjectID:     projectID,
+				BaseProjectID: link.BaseProjectID,
+				Position:      link.Position,
+				CreatedBy:     userID,
+			})
+		}
+		if err := s.projectBaseRepo.Replace(ctx, projectID, bases); err != nil {
+			return err
+		}
+	}
+
+	criteria, err := s.criteriaRepo.Get(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if criteria == nil {
+		return nil
+	}
+	cloned := *criteria
+	cloned.ProjectID = projectID
+	cloned.UpdatedBy = userID
+	return s.criteriaRepo.Save(ctx, &cloned)
+}
+
+// cloneTranslations 复制源项目的有效翻译，返回复制的键数和翻译数
+func (s *ProjectService) cloneTranslations(ctx context.Context, sourceID, projectID uint64, withValues bool, userID uint64) (int, int, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	keys := make(map[string]bool)
+	var translations []*domain.Translation
+	for _, language := range languages {
+		sourceTranslations, err := s.translationRepo.GetByProjectAndLanguage(ctx, sourceID, language.ID)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, translation := range sourceTranslations {
+			if translation.Status != "active" {
+				continue
+			}
+			keys[translation.KeyName] = true
+			cloned := &domain.Translation{
+				ProjectID:  projectID,
+				KeyName:    translation.KeyName,
+				Context:    translation.Context,
+				LanguageID: translation.LanguageID,
+				Status:     "active",
+				CreatedBy:  userID,
+				UpdatedBy:  userID,
+			}
+			if withValues {
+				cloned.Value = translation.Value
+				cloned.MachineTranslated = translation.MachineTranslated
+			}
+			translations = append(translations, cloned)
+		}
+	}
+
+	if len(translations) > 0 {
+		if err := s.translationRepo.CreateBatch(ctx, translations); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(keys), len(translations), nil
+}
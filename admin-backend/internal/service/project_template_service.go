@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"strings"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// ProjectTemplateService 项目模板服务实现
+type ProjectTemplateService struct {
+	templateRepo domain.ProjectTemplateRepository
+	projectRepo  domain.ProjectRepository
+	languageRepo domain.LanguageRepository
+	userRepo     domain.UserRepository
+}
+
+// NewProjectTemplateService 创建项目模板服务实例
+func NewProjectTemplateService(
+	templateRepo domain.ProjectTemplateRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) *ProjectTemplateService {
+	return &ProjectTemplateService{
+		templateRepo: templateRepo,
+		projectRepo:  projectRepo,
+		languageRepo: languageRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// List 获取所有项目模板
+func (s *ProjectTemplateService) List(ctx context.Context) ([]*domain.ProjectTemplate, error) {
+	return s.templateRepo.List(ctx)
+}
+
+// GetByID 根据ID获取项目模板
+func (s *ProjectTemplateService) GetByID(ctx context.Context, id uint64) (*domain.ProjectTemplate, error) {
+	return s.templateRepo.GetByID(ctx, id)
+}
+
+// Create 创建项目模板
+func (s *ProjectTemplateService) Create(ctx context.Context, params domain.ProjectTemplateParams) (*domain.ProjectTemplate, error) {
+	template := &domain.ProjectTemplate{CreatedBy: params.UserID}
+	if err := s.fill(ctx, template, params); err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrTemplateExists
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Update 整体替换项目模板的内容，已用模板创建的项目不受影响
+func (s *ProjectTemplateService) Update(ctx context.Context, id uint64, params domain.ProjectTemplateParams) (*domain.ProjectTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fill(ctx, template, params); err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrTemplateExists
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Delete 删除项目模板
+func (s *ProjectTemplateService) Delete(ctx context.Context, id uint64) error {
+	if _, err := s.templateRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, id)
+}
+
+// fill 校验参数并写入模板，语言、基础项目和成员必须存在
+func (s *ProjectTemplateService) fill(ctx context.Context, template *domain.ProjectTemplate, params domain.ProjectTemplateParams) error {
+	params.Name = strings.TrimSpace(params.Name)
+	if params.PlaceholderFormat == "" {
+		params.PlaceholderFormat = domain.PlaceholderFormatBrace
+	}
+	if params.ValueType == "" {
+		params.ValueType = domain.ValueTypePlain
+	}
+	if err := ValidateProjectTemplate(params); err != nil {
+		return err
+	}
+
+	codes := make(map[string]bool, len(params.Languages))
+	for _, code := range params.Languages {
+		codes[code] = true
+	}
+	for _, key := range params.StarterKeys {
+		for code := range key.Values {
+			codes[code] = true
+		}
+	}
+	for code := range codes {
+		if _, err := s.languageRepo.GetByCode(ctx, code); err != nil {
+			return invalidTemplate(fmt.Sprintf("语言不存在: %s", code))
+		}
+	}
+	for _, baseID := range params.BaseProjectIDs {
+		if _, err := s.projectRepo.GetByID(ctx, baseID); err != nil {
+			return domain.ErrProjectNotFound
+		}
+	}
+	for _, member := range params.DefaultMembers {
+		if _, err := s.userRepo.GetByID(ctx, member.UserID); err != nil {
+			return domain.ErrUserNotFound
+		}
+	}
+
+	template.Name = params.Name
+	template.Description = strings.TrimSpace(params.Description)
+	template.PlaceholderFormat = params.PlaceholderFormat
+	template.ValueType = params.ValueType
+	template.Languages = params.Languages
+	template.BaseProjectIDs = params.BaseProjectIDs
+	template.StarterKeys = params.StarterKeys
+	template.DefaultMembers = params.DefaultMembers
+	template.UpdatedBy = params.UserID
+	return nil
+}
+
+// ValidateProjectTemplate 校验项目模板参数本身：名称不能为空，设置必须合法，初始键名不能为空或重复，
+// 初始译文需符合模板的值类型，默认成员不能重复且角色为 owner、editor 或 viewer
+func ValidateProjectTemplate(params domain.ProjectTemplateParams) error {
+	if strings.TrimSpace(params.Name) == "" {
+		return invalidTemplate("模板名称不能为空")
+	}
+	if !internal_utils.IsPlaceholderSyntax(params.PlaceholderFormat) {
+		return domain.ErrInvalidPlaceholderFormat
+	}
+	if !internal_utils.IsValueType(params.ValueType) {
+		return domain.ErrInvalidValueType
+	}
+	if len(params.BaseProjectIDs) > maxBaseProjects {
+		return invalidTemplate(fmt.Sprintf("基础项目不能超过 %d 个", maxBaseProjects))
+	}
+
+	keys := make(map[string]bool, len(params.StarterKeys))
+	settings := &domain.Project{ValueType: params.ValueType}
+	for _, key := range params.StarterKeys {
+		if key.KeyName == "" || key.KeyName != strings.TrimSpace(key.KeyName) {
+			return invalidTemplate(fmt.Sprintf("初始键名无效: %q", key.KeyName))
+		}
+		if keys[key.KeyName] {
+			return invalidTemplate(fmt.Sprintf("初始键重复: %s", key.KeyName))
+		}
+		keys[key.KeyName] = true
+		for _, value := range key.Values {
+			if err := validateValueType(settings, key.KeyName, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	members := make(map[uint64]bool, len(params.DefaultMembers))
+	for _, member := range params.DefaultMembers {
+		switch member.Role {
+		case "owner", "editor", "viewer":
+		default:
+			return invalidTemplate(fmt.Sprintf("成员角色无效: %s", member.Role))
+		}
+		if members[member.UserID] {
+			return invalidTemplate(fmt.Sprintf("默认成员重复: %d", member.UserID))
+		}
+		members[member.UserID] = true
+	}
+	return nil
+}
+
+// invalidTemplate 项目模板参数错误
+func invalidTemplate(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, "INVALID_TEMPLATE", "项目模板参数无效", details)
+}
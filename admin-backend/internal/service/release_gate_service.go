@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// ReleaseGateService 发布门禁服务实现
+// 按项目保存的条件检查必需语言的完成度、过期译文、未确认的机器翻译和阻断发布的质量问题，供 CI 在发布前调用
+type ReleaseGateService struct {
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	translationRepo domain.TranslationRepository
+	criteriaRepo    domain.ReleaseCriteriaRepository
+	glossaryService domain.GlossaryService
+}
+
+// NewReleaseGateService 创建发布门禁服务实例
+func NewReleaseGateService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	criteriaRepo domain.ReleaseCriteriaRepository,
+	glossaryService domain.GlossaryService,
+) *ReleaseGateService {
+	return &ReleaseGateService{
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		translationRepo: translationRepo,
+		criteriaRepo:    criteriaRepo,
+		glossaryService: glossaryService,
+	}
+}
+
+// GetCriteria 获取项目的发布门禁条件，项目没有保存条件时返回默认条件
+func (s *ReleaseGateService) GetCriteria(ctx context.Context, projectID uint64) (*domain.ReleaseCriteria, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	criteria, err := s.criteriaRepo.Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if criteria == nil {
+		criteria = &domain.ReleaseCriteria{ProjectID: projectID, MinCompletion: 100}
+	}
+	if criteria.RequiredLanguages == nil {
+		criteria.RequiredLanguages = []string{}
+	}
+	if criteria.LanguageMinimums == nil {
+		criteria.LanguageMinimums = map[string]float64{}
+	}
+	return criteria, nil
+}
+
+// UpdateCriteria 保存项目的发布门禁条件，涉及的语言必须是启用的非默认语言
+func (s *ReleaseGateService) UpdateCriteria(ctx context.Context, params domain.UpdateReleaseCriteriaParams) (*domain.ReleaseCriteria, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if !validPercent(params.MinCompletion) {
+		return nil, domain.ErrInvalidInput
+	}
+	for _, minimum := range params.LanguageMinimums {
+		if !validPercent(minimum) {
+			return nil, domain.ErrInvalidInput
+		}
+	}
+
+	targets, err := s.targetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	required := make([]string, 0, len(params.RequiredLanguages))
+	seen := make(map[string]bool, len(params.RequiredLanguages))
+	for _, code := range params.RequiredLanguages {
+		if targets[code] == nil {
+			return nil, domain.ErrLanguageNotFound
+		}
+		if !seen[code] {
+			seen[code] = true
+			required = append(required, code)
+		}
+	}
+	minimums := make(map[string]float64, len(params.LanguageMinimums))
+	for code, minimum := range params.LanguageMinimums {
+		if targets[code] == nil {
+			return nil, domain.ErrLanguageNotFound
+		}
+		minimums[code] = minimum
+	}
+
+	criteria := &domain.ReleaseCriteria{
+		ProjectID:         params.ProjectID,
+		RequiredLanguages: required,
+		MinCompletion:     params.MinCompletion,
+		LanguageMinimums:  minimums,
+		AllowOutdated:     params.AllowOutdated,
+		AllowUnapproved:   params.AllowUnapproved,
+		AllowQAErrors:     params.AllowQAErrors,
+		UpdatedBy:         params.UserID,
+	}
+	if err := s.criteriaRepo.Save(ctx, criteria); err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+// Check 按发布门禁条件检查项目，任一必需语言未通过时报告不通过
+func (s *ReleaseGateService) Check(ctx context.Context, projectID uint64) (*domain.ReleaseReport, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	criteria, err := s.GetCriteria(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	targets, err := s.targetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	required := criteria.RequiredLanguages
+	if len(required) == 0 {
+		required = make([]string, 0, len(targets))
+		for code := range targets {
+			required = append(required, code)
+		}
+		sort.Strings(required)
+	}
+	available := make([]string, 0, len(required))
+	for _, code := range required {
+		if targets[code] != nil {
+			available = append(available, code)
+		}
+	}
+
+	sources, err := s.releaseCells(ctx, projectID, defaultLanguage.ID)
+	if err != nil {
+		return nil, err
+	}
+	glossary := make(map[string][]*domain.GlossaryViolation)
+	if len(available) > 0 {
+		glossaryReport, err := s.glossaryService.Check(ctx, domain.GlossaryCheckParams{ProjectID: projectID, Languages: available})
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range glossaryReport.Violations {
+			glossary[violation.Language] = append(glossary[violation.Language], violation)
+		}
+	}
+
+	report := &domain.ReleaseReport{
+		ProjectID:      projectID,
+		Passed:         true,
+		Revision:       project.Revision,
+		SourceLanguage: defaultLanguage.Code,
+		Criteria:       criteria,
+		Languages:      make([]*domain.ReleaseLanguageReport, 0, len(required)),
+	}
+	for _, code := range required {
+		var languageReport *domain.ReleaseLanguageReport
+		if language := targets[code]; language == nil {
+			// 保存条件后语言被删除或停用
+			languageReport = &domain.ReleaseLanguageReport{
+				Language:     code,
+				Failures:     []string{domain.ReleaseFailureLanguage},
+				Untranslated: []string{},
+				Outdated:     []string{},
+				Unapproved:   []string{},
+				QAErrors:     []*domain.ReleaseQAIssue{},
+			}
+		} else {
+			cells, err := s.releaseCells(ctx, projectID, language.ID)
+			if err != nil {
+				return nil, err
+			}
+			languageReport = EvaluateReleaseLanguage(criteria, domain.ReleaseLanguageInput{
+				Language:          code,
+				Sources:           sources,
+				Targets:           cells,
+				PlaceholderFormat: project.PlaceholderFormat,
+				ValueType:         projectValueType(project),
+				Glossary:          glossary[code],
+			})
+		}
+		report.Passed = report.Passed && languageReport.Passed
+		report.Languages = append(report.Languages, languageReport)
+	}
+	return report, nil
+}
+
+// targetLanguages 获取启用的非默认语言，按语言代码索引
+func (s *ReleaseGateService) targetLanguages(ctx context.Context) (map[string]*domain.Language, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]*domain.Language, len(languages))
+	for _, language := range languages {
+		if language.Status == "active" && !language.IsDefault {
+			targets[language.Code] = language
+		}
+	}
+	return targets, nil
+}
+
+// releaseCells 获取项目在某种语言下有效且非空的单元格
+func (s *ReleaseGateService) releaseCells(ctx context.Context, projectID, languageID uint64) (map[string]domain.ReleaseCell, error) {
+	translations, err := s.translationRepo.GetByProjectAndLanguage(ctx, projectID, languageID)
+	if err != nil {
+		return nil, err
+	}
+	cells := make(map[string]domain.ReleaseCell, len(translations))
+	for _, translation := range translations {
+		if translation.Status != "active" || translation.Value == "" {
+			continue
+		}
+		cells[translation.KeyName] = domain.ReleaseCell{
+			Value:             translation.Value,
+			MachineTranslated: translation.MachineTranslated,
+			UpdatedAt:         translation.UpdatedAt,
+		}
+	}
+	return cells, nil
+}
+
+// validPercent 判断百分比是否在 0 到 100 之间
+func validPercent(value float64) bool {
+	return value >= 0 && value <= 100
+}
+
+// EvaluateReleaseLanguage 按发布门禁条件检查一种语言
+// 过期和未确认的译文计入完成度，由对应的条件单独判断；缺少批准译法的术语问题可能只是措辞差异，不阻断发布
+func EvaluateReleaseLanguage(criteria *domain.ReleaseCriteria, input domain.ReleaseLanguageInput) *domain.ReleaseLanguageReport {
+	report := &domain.ReleaseLanguageReport{
+		Language:      input.Language,
+		Failures:      []string{},
+		Total:         len(input.Sources),
+		Completion:    100,
+		MinCompletion: criteria.MinCompletion,
+		Untranslated:  []string{},
+		Outdated:      []string{},
+		Unapproved:    []string{},
+		QAErrors:      []*domain.ReleaseQAIssue{},
+	}
+	if minimum, ok := criteria.LanguageMinimums[input.Language]; ok {
+		report.MinCompletion = minimum
+	}
+
+	keyNames := make([]string, 0, len(input.Sources))
+	for keyName := range input.Sources {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	for _, keyName := range keyNames {
+		source := input.Sources[keyName]
+		target, ok := input.Targets[keyName]
+		if !ok || target.Value == "" {
+			report.Untranslated = append(report.Untranslated, keyName)
+			continue
+		}
+		report.Translated++
+		if target.UpdatedAt.Before(source.UpdatedAt) {
+			report.Outdated = append(report.Outdated, keyName)
+		}
+		if target.MachineTranslated {
+			report.Unapproved = append(report.Unapproved, keyName)
+		}
+		if !internal_utils.SamePlaceholders(source.Value, target.Value, input.PlaceholderFormat) {
+			report.QAErrors = append(report.QAErrors, &domain.ReleaseQAIssue{
+				KeyName: keyName,
+				Type:    domain.ReleaseIssuePlaceholderMismatch,
+			})
+		}
+		if err := internal_utils.ValidateValueType(input.ValueType, target.Value); err != nil {
+			report.QAErrors = append(report.QAErrors, &domain.ReleaseQAIssue{
+				KeyName: keyName,
+				Type:    domain.ReleaseIssueInvalidValue,
+				Detail:  err.Error(),
+			})
+		}
+	}
+	for _, violation := range input.Glossary {
+		if violation.Type == internal_utils.GlossaryIssueMissingTerm {
+			continue
+		}
+		report.QAErrors = append(report.QAErrors, &domain.ReleaseQAIssue{
+			KeyName: violation.KeyName,
+			Type:    violation.Type,
+			Detail:  violation.Term,
+		})
+	}
+	sort.SliceStable(report.QAErrors, func(i, j int) bool {
+		return report.QAErrors[i].KeyName < report.QAErrors[j].KeyName
+	})
+
+	if report.Total > 0 {
+		report.Completion = roundAmount(float64(report.Translated) * 100 / float64(report.Total))
+	}
+	if float64(report.Translated)*100 < report.MinCompletion*float64(report.Total) {
+		report.Failures = append(report.Failures, domain.ReleaseFailureCompletion)
+	}
+	if len(report.Outdated) > 0 && !criteria.AllowOutdated {
+		report.Failures = append(report.Failures, domain.ReleaseFailureOutdated)
+	}
+	if len(report.Unapproved) > 0 && !criteria.AllowUnapproved {
+		report.Failures = append(report.Failures, domain.ReleaseFailureUnapproved)
+	}
+	if len(report.QAErrors) > 0 && !criteria.AllowQAErrors {
+		report.Failures = append(report.Failures, domain.ReleaseFailureQAErrors)
+	}
+	report.Passed = len(report.Failures) == 0
+	return report
+}
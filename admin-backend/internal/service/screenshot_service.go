@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"strings"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	// maxScreenshotRegions 单张截图最多的区域数
+	maxScreenshotRegions = 500
+	// maxScreenshotNameLength 截图名称最大长度
+	maxScreenshotNameLength = 255
+)
+
+// ScreenshotService 截图服务实现
+// 原图和缩略图保存在文件存储中，数据库只保存元数据、区域和标注的键
+type ScreenshotService struct {
+	screenshotRepo  domain.ScreenshotRepository
+	projectRepo     domain.ProjectRepository
+	translationRepo domain.TranslationRepository
+	storage         domain.FileStorage
+	maxSize         int64
+	thumbnailWidth  int
+}
+
+// NewScreenshotService 创建截图服务实例
+func NewScreenshotService(
+	screenshotRepo domain.ScreenshotRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	storage domain.FileStorage,
+	cfg config.ScreenshotConfig,
+) *ScreenshotService {
+	return &ScreenshotService{
+		screenshotRepo:  screenshotRepo,
+		projectRepo:     projectRepo,
+		translationRepo: translationRepo,
+		storage:         storage,
+		maxSize:         int64(cfg.MaxSizeMB) << 20,
+		thumbnailWidth:  cfg.ThumbnailWidth,
+	}
+}
+
+// Upload 上传截图，保存原图并生成缩略图
+func (s *ScreenshotService) Upload(ctx context.Context, params domain.UploadScreenshotParams) (*domain.Screenshot, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if int64(len(params.Data)) > s.maxSize {
+		return nil, domain.ErrScreenshotTooLarge
+	}
+
+	info, err := internal_utils.InspectImage(params.Data)
+	if err != nil {
+		return nil, domain.ErrInvalidScreenshot
+	}
+	thumbnail, thumbnailType, err := internal_utils.Thumbnail(params.Data, s.thumbnailWidth)
+	if err != nil {
+		return nil, domain.ErrInvalidScreenshot
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len([]rune(name)) > maxScreenshotNameLength {
+		return nil, domain.ErrInvalidInput
+	}
+
+	id, err := randomStorageID()
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("screenshots/%d/%s", params.ProjectID, id)
+	screenshot := &domain.Screenshot{
+		ProjectID:    params.ProjectID,
+		Name:         name,
+		StorageKey:   prefix + "." + info.Format,
+		ThumbnailKey: prefix + "_thumb" + thumbnailExtension(thumbnailType),
+		ContentType:  info.ContentType,
+		Width:        info.Width,
+		Height:       info.Height,
+		Size:         int64(len(params.Data)),
+		CreatedBy:    params.UserID,
+		Regions:      []*domain.ScreenshotRegion{},
+	}
+
+	if err := s.storage.Put(ctx, screenshot.StorageKey, params.Data, info.ContentType); err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, screenshot.ThumbnailKey, thumbnail, thumbnailType); err != nil {
+		s.storage.Delete(ctx, screenshot.StorageKey)
+		return nil, err
+	}
+	if err := s.screenshotRepo.Create(ctx, screenshot); err != nil {
+		s.storage.Delete(ctx, screenshot.StorageKey)
+		s.storage.Delete(ctx, screenshot.ThumbnailKey)
+		return nil, err
+	}
+
+	s.fillURLs(screenshot)
+	return screenshot, nil
+}
+
+// List 分页获取项目截图，keyName 不为空时只返回标注了该键的截图
+func (s *ScreenshotService) List(ctx context.Context, projectID uint64, keyName string, limit, offset int) ([]*domain.Screenshot, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	screenshots, total, err := s.screenshotRepo.List(ctx, projectID, strings.TrimSpace(keyName), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, screenshot := range screenshots {
+		s.fillURLs(screenshot)
+	}
+	return screenshots, total, nil
+}
+
+// Get 获取截图，截图不属于项目时视为不存在
+func (s *ScreenshotService) Get(ctx context.Context, projectID, id uint64) (*domain.Screenshot, error) {
+	screenshot, err := s.screenshotRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if screenshot.ProjectID != projectID {
+		return nil, domain.ErrScreenshotNotFound
+	}
+	s.fillURLs(screenshot)
+	return screenshot, nil
+}
+
+// UpdateRegions 替换截图的所有区域，区域必须在图片范围内，标注的键必须存在于项目中
+func (s *ScreenshotService) UpdateRegions(ctx context.Context, projectID, id uint64, inputs []domain.ScreenshotRegionInput) (*domain.Screenshot, error) {
+	screenshot, err := s.Get(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) > maxScreenshotRegions {
+		return nil, domain.NewAppError(domain.ErrorTypeValidation, "TOO_MANY_REGIONS", "截图区域数量过多")
+	}
+
+	var keyNames []string
+	regions := make([]*domain.ScreenshotRegion, 0, len(inputs))
+	for _, input := range inputs {
+		if input.X < 0 || input.Y < 0 || input.Width <= 0 || input.Height <= 0 ||
+			input.X+input.Width > screenshot.Width || input.Y+input.Height > screenshot.Height {
+			return nil, domain.ErrInvalidRegion
+		}
+
+		region := &domain.ScreenshotRegion{X: input.X, Y: input.Y, Width: input.Width, Height: input.Height}
+		seen := make(map[string]bool, len(input.KeyNames))
+		for _, keyName := range input.KeyNames {
+			keyName = strings.TrimSpace(keyName)
+			if keyName == "" || seen[keyName] {
+				continue
+			}
+			seen[keyName] = true
+			keyNames = append(keyNames, keyName)
+			region.Keys = append(region.Keys, &domain.ScreenshotKey{KeyName: keyName})
+		}
+		if len(region.Keys) == 0 {
+			return nil, domain.ErrInvalidKey
+		}
+		regions = append(regions, region)
+	}
+
+	if err := s.checkKeysExist(ctx, projectID, keyNames); err != nil {
+		return nil, err
+	}
+	if err := s.screenshotRepo.ReplaceRegions(ctx, screenshot, regions); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, projectID, id)
+}
+
+// Delete 删除截图及其文件
+func (s *ScreenshotService) Delete(ctx context.Context, projectID, id uint64) error {
+	screenshot, err := s.Get(ctx, projectID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.screenshotRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// 文件删除失败不影响结果，残留文件不再被引用
+	s.storage.Delete(ctx, screenshot.StorageKey)
+	s.storage.Delete(ctx, screenshot.ThumbnailKey)
+	return nil
+}
+
+// GetImage 读取截图原图或缩略图
+func (s *ScreenshotService) GetImage(ctx context.Context, projectID, id uint64, thumbnail bool) (*domain.ScreenshotImage, error) {
+	screenshot, err := s.Get(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key, contentType := screenshot.StorageKey, screenshot.ContentType
+	if thumbnail {
+		key = screenshot.ThumbnailKey
+		contentType = "image/png"
+		if strings.HasSuffix(key, thumbnailExtension("image/jpeg")) {
+			contentType = "image/jpeg"
+		}
+	}
+	data, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ScreenshotImage{Data: data, ContentType: contentType}, nil
+}
+
+// ListByKeys 获取标注了指定键的截图，按键名分组
+func (s *ScreenshotService) ListByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string][]*domain.Screenshot, error) {
+	screenshots, err := s.screenshotRepo.ListByKeys(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(keyNames))
+	for _, keyName := range keyNames {
+		wanted[keyName] = true
+	}
+	result := make(map[string][]*domain.Screenshot, len(keyNames))
+	for _, screenshot := range screenshots {
+		s.fillURLs(screenshot)
+		seen := make(map[string]bool)
+		for _, region := range screenshot.Regions {
+			for _, key := range region.Keys {
+				if wanted[key.KeyName] && !seen[key.KeyName] {
+					seen[key.KeyName] = true
+					result[key.KeyName] = append(result[key.KeyName], screenshot)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// checkKeysExist 检查键是否存在于项目中，不存在的键在错误详情中列出
+func (s *ScreenshotService) checkKeysExist(ctx context.Context, projectID uint64, keyNames []string) error {
+	if len(keyNames) == 0 {
+		return nil
+	}
+	cells, err := s.translationRepo.GetCellsByKeys(ctx, projectID, keyNames)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	seen := make(map[string]bool, len(keyNames))
+	for _, keyName := range keyNames {
+		if _, ok := cells[keyName]; !ok && !seen[keyName] {
+			seen[keyName] = true
+			missing = append(missing, keyName)
+		}
+	}
+	if len(missing) > 0 {
+		return domain.NewAppErrorWithDetails(
+			domain.ErrorTypeValidation,
+			"UNKNOWN_SCREENSHOT_KEYS",
+			"区域标注的键不存在",
+			"不存在的键: "+strings.Join(missing, ", "),
+		)
+	}
+	return nil
+}
+
+// fillURLs 填充原图和缩略图的访问地址
+func (s *ScreenshotService) fillURLs(screenshot *domain.Screenshot) {
+	base := fmt.Sprintf("/api/screenshots/by-project/%d/%d", screenshot.ProjectID, screenshot.ID)
+	screenshot.ImageURL = base + "/image"
+	screenshot.ThumbnailURL = base + "/thumbnail"
+	if screenshot.Regions == nil {
+		screenshot.Regions = []*domain.ScreenshotRegion{}
+	}
+}
+
+// thumbnailExtension 缩略图文件扩展名
+func thumbnailExtension(contentType string) string {
+	if contentType == "image/jpeg" {
+		return ".jpeg"
+	}
+	return ".png"
+}
+
+// randomStorageID 生成文件存储中的随机文件名，加上时间前缀便于按时间排查
+func randomStorageID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(b), nil
+}
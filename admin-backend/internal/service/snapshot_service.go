@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+)
+
+// maxSnapshotNameLength 版本名最大长度
+const maxSnapshotNameLength = 100
+
+// SnapshotService 版本快照服务实现
+// 快照保存创建时所有有效键的非空译文，与导出的内容一致
+type SnapshotService struct {
+	snapshotRepo       domain.SnapshotRepository
+	projectRepo        domain.ProjectRepository
+	translationService domain.TranslationService
+}
+
+// NewSnapshotService 创建版本快照服务实例
+func NewSnapshotService(
+	snapshotRepo domain.SnapshotRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+) *SnapshotService {
+	return &SnapshotService{
+		snapshotRepo:       snapshotRepo,
+		projectRepo:        projectRepo,
+		translationService: translationService,
+	}
+}
+
+// Create 为项目当前的译文创建版本快照，版本名在项目内唯一
+func (s *SnapshotService) Create(ctx context.Context, params domain.CreateSnapshotParams) (*domain.Snapshot, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len([]rune(name)) > maxSnapshotNameLength {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.snapshotRepo.GetByName(ctx, params.ProjectID, name); err == nil {
+		return nil, domain.ErrSnapshotExists
+	} else if err != domain.ErrSnapshotNotFound {
+		return nil, err
+	}
+
+	values, err := s.liveValues(ctx, params.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &domain.Snapshot{
+		ProjectID:   params.ProjectID,
+		Name:        name,
+		Description: strings.TrimSpace(params.Description),
+		KeyCount:    len(values),
+		CreatedBy:   params.UserID,
+	}
+	for _, languages := range values {
+		snapshot.ValueCount += len(languages)
+	}
+
+	if err := s.snapshotRepo.Create(ctx, snapshot, values); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// List 分页获取项目的版本快照
+func (s *SnapshotService) List(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Snapshot, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	return s.snapshotRepo.List(ctx, projectID, limit, offset)
+}
+
+// Get 获取版本快照，快照不属于项目时视为不存在
+func (s *SnapshotService) Get(ctx context.Context, projectID, id uint64) (*domain.Snapshot, error) {
+	snapshot, err := s.snapshotRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.ProjectID != projectID {
+		return nil, domain.ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+// Export 按指定格式导出版本快照的译文
+func (s *SnapshotService) Export(ctx context.Context, projectID, id uint64, format string) ([]byte, error) {
+	snapshot, err := s.Get(ctx, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.snapshotRepo.GetValues(ctx, snapshot.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	return marshalExport(values, format)
+}
+
+// Diff 比较两个版本快照，toID 为 0 时与项目当前的译文比较
+func (s *SnapshotService) Diff(ctx context.Context, projectID, fromID, toID uint64) (*domain.SnapshotDiff, error) {
+	from, err := s.Get(ctx, projectID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	fromValues, err := s.snapshotRepo.GetValues(ctx, from.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var to *domain.Snapshot
+	var toValues map[string]map[string]string
+	if toID == 0 {
+		toValues, err = s.liveValues(ctx, projectID)
+	} else if to, err = s.Get(ctx, projectID, toID); err == nil {
+		toValues, err = s.snapshotRepo.GetValues(ctx, to.ID, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := DiffTranslationMatrices(fromValues, toValues)
+	diff.From = from
+	diff.To = to
+	return diff, nil
+}
+
+// Delete 删除版本快照
+func (s *SnapshotService) Delete(ctx context.Context, projectID, id uint64) error {
+	if _, err := s.Get(ctx, projectID, id); err != nil {
+		return err
+	}
+	return s.snapshotRepo.Delete(ctx, id)
+}
+
+// liveValues 获取项目当前所有有效键的非空译文，包含继承的翻译
+func (s *SnapshotService) liveValues(ctx context.Context, projectID uint64) (map[string]map[string]string, error) {
+	matrix, _, err := s.translationService.GetMatrix(ctx, projectID, -1, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return nonEmptyMatrix(matrix), nil
+}
+
+// nonEmptyMatrix 把翻译矩阵转换为 键名 -> 语言代码 -> 译文，丢弃空译文
+func nonEmptyMatrix(matrix map[string]map[string]domain.TranslationCell) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(matrix))
+	for keyName, cells := range matrix {
+		for code, cell := range cells {
+			if cell.Value == "" {
+				continue
+			}
+			if values[keyName] == nil {
+				values[keyName] = make(map[string]string)
+			}
+			values[keyName][code] = cell.Value
+		}
+	}
+	return values
+}
+
+// DiffTranslationMatrices 比较两个译文矩阵（键名 -> 语言代码 -> 译文），空译文视为不存在
+// 键在 from 中没有任何译文而在 to 中有时为新增的键，反之为删除的键；各语言的差异按键名排序
+func DiffTranslationMatrices(from, to map[string]map[string]string) *domain.SnapshotDiff {
+	diff := &domain.SnapshotDiff{
+		AddedKeys:   []string{},
+		RemovedKeys: []string{},
+		Languages:   make(map[string]*domain.LanguageDiff),
+	}
+	languageDiff := func(code string) *domain.LanguageDiff {
+		if diff.Languages[code] == nil {
+			diff.Languages[code] = &domain.LanguageDiff{
+				Added:   []*domain.ValueChange{},
+				Removed: []*domain.ValueChange{},
+				Changed: []*domain.ValueChange{},
+			}
+		}
+		return diff.Languages[code]
+	}
+
+	keyNames := make(map[string]bool, len(to))
+	for keyName := range from {
+		keyNames[keyName] = true
+	}
+	for keyName := range to {
+		keyNames[keyName] = true
+	}
+	sorted := make([]string, 0, len(keyNames))
+	for keyName := range keyNames {
+		sorted = append(sorted, keyName)
+	}
+	sort.Strings(sorted)
+
+	for _, keyName := range sorted {
+		oldValues, newValues := nonEmptyValues(from[keyName]), nonEmptyValues(to[keyName])
+		switch {
+		case len(oldValues) == 0 && len(newValues) > 0:
+			diff.AddedKeys = append(diff.AddedKeys, keyName)
+		case len(oldValues) > 0 && len(newValues) == 0:
+			diff.RemovedKeys = append(diff.RemovedKeys, keyName)
+		}
+
+		for code, newValue := range newValues {
+			oldValue, ok := oldValues[code]
+			switch {
+			case !ok:
+				entry := languageDiff(code)
+				entry.Added = append(entry.Added, &domain.ValueChange{KeyName: keyName, New: newValue})
+			case oldValue != newValue:
+				entry := languageDiff(code)
+				entry.Changed = append(entry.Changed, &domain.ValueChange{KeyName: keyName, Old: oldValue, New: newValue})
+			}
+		}
+		for code, oldValue := range oldValues {
+			if _, ok := newValues[code]; !ok {
+				entry := languageDiff(code)
+				entry.Removed = append(entry.Removed, &domain.ValueChange{KeyName: keyName, Old: oldValue})
+			}
+		}
+	}
+	return diff
+}
+
+// nonEmptyValues 过滤空译文
+func nonEmptyValues(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for code, value := range values {
+		if value != "" {
+			result[code] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/config"
+	"i18n-flow/internal/domain"
+	"math"
+	"sort"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// maxLeverageKeys 一次估算中最多做翻译记忆分析的键数（所有语言合计），超出部分按全价计算
+const maxLeverageKeys = 5000
+
+// StatisticsService 字数统计和费用估算服务实现
+type StatisticsService struct {
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	translationRepo domain.TranslationRepository
+	snapshotRepo    domain.SnapshotRepository
+	tmService       domain.TranslationMemoryService
+	cost            config.CostConfig
+}
+
+// NewStatisticsService 创建字数统计服务实例
+func NewStatisticsService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	snapshotRepo domain.SnapshotRepository,
+	tmService domain.TranslationMemoryService,
+	cost config.CostConfig,
+) *StatisticsService {
+	return &StatisticsService{
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		translationRepo: translationRepo,
+		snapshotRepo:    snapshotRepo,
+		tmService:       tmService,
+		cost:            cost,
+	}
+}
+
+// sourceSegment 默认语言下的原文及其计数
+type sourceSegment struct {
+	value     string
+	count     internal_utils.TextCount
+	updatedAt time.Time
+}
+
+// GetProjectStatistics 统计项目各语言的翻译进度（按键数、原文词数和字符数），可选估算翻译费用
+func (s *StatisticsService) GetProjectStatistics(ctx context.Context, params domain.ProjectStatisticsParams) (*domain.ProjectStatistics, error) {
+	project, err := s.projectRepo.GetByID(ctx, params.ProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceTranslations, err := s.translationRepo.GetByProjectAndLanguage(ctx, params.ProjectID, defaultLanguage.ID)
+	if err != nil {
+		return nil, err
+	}
+	isNew, err := s.newSourceFilter(ctx, params, defaultLanguage.Code)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.ProjectStatistics{
+		ProjectID:      params.ProjectID,
+		SourceLanguage: defaultLanguage.Code,
+		Since:          params.Since,
+		SinceVersion:   params.SinceVersion,
+		Languages:      make([]*domain.LanguageStatistics, 0, len(languages)),
+	}
+	if isNew != nil {
+		result.NewSource = &domain.StatisticsCount{}
+	}
+
+	sources := make(map[string]*sourceSegment, len(sourceTranslations))
+	for _, translation := range sourceTranslations {
+		if translation.Status != "active" || translation.Value == "" {
+			continue
+		}
+		segment := &sourceSegment{
+			value:     translation.Value,
+			count:     internal_utils.CountText(translation.Value, project.PlaceholderFormat),
+			updatedAt: translation.UpdatedAt,
+		}
+		sources[translation.KeyName] = segment
+		addCount(&result.Source, segment.count)
+		if isNew != nil && isNew(translation.KeyName, segment) {
+			addCount(result.NewSource, segment.count)
+		}
+	}
+
+	stats, err := s.translationRepo.GetKeyLanguageStats(ctx, params.ProjectID, "")
+	if err != nil {
+		return nil, err
+	}
+	cells := make(map[string]map[string]domain.KeyLanguageStat)
+	for _, stat := range stats {
+		if cells[stat.LanguageCode] == nil {
+			cells[stat.LanguageCode] = make(map[string]domain.KeyLanguageStat)
+		}
+		cells[stat.LanguageCode][stat.KeyName] = stat
+	}
+
+	keyNames := make([]string, 0, len(sources))
+	for keyName := range sources {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	if params.Cost {
+		result.Cost = &domain.CostSummary{Currency: s.cost.Currency}
+	}
+	leverageBudget := maxLeverageKeys
+	for _, language := range languages {
+		if language.ID == defaultLanguage.ID || language.Status != "active" {
+			continue
+		}
+
+		languageStats := &domain.LanguageStatistics{Language: language.Code}
+		if isNew != nil {
+			languageStats.New = &domain.StatisticsCount{}
+		}
+		pending := make(map[string]string)
+		for _, keyName := range keyNames {
+			source := sources[keyName]
+			addCount(&languageStats.Total, source.count)
+
+			cell, ok := cells[language.Code][keyName]
+			switch {
+			case !ok || !cell.Translated:
+				addCount(&languageStats.Untranslated, source.count)
+			case cell.UpdatedAt.Before(source.updatedAt):
+				addCount(&languageStats.Outdated, source.count)
+			default:
+				addCount(&languageStats.Translated, source.count)
+				continue
+			}
+			pending[keyName] = source.value
+			if isNew != nil && isNew(keyName, source) {
+				addCount(languageStats.New, source.count)
+			}
+		}
+
+		if params.Cost {
+			estimate, partial, err := s.estimateCost(ctx, params, defaultLanguage.Code, language.Code, pending, sources, &leverageBudget)
+			if err != nil {
+				return nil, err
+			}
+			languageStats.Cost = estimate
+			result.Cost.Words += estimate.Words
+			result.Cost.WeightedWords += estimate.WeightedWords
+			result.Cost.Amount += estimate.Amount
+			result.Cost.Partial = result.Cost.Partial || partial
+		}
+		result.Languages = append(result.Languages, languageStats)
+	}
+
+	if result.Cost != nil {
+		result.Cost.WeightedWords = roundAmount(result.Cost.WeightedWords)
+		result.Cost.Amount = roundAmount(result.Cost.Amount)
+	}
+	return result, nil
+}
+
+// newSourceFilter 返回判断原文是否为新增或修改的函数，未指定 Since 和 SinceVersion 时返回 nil
+// 按时间判断时看原文的修改时间，按版本判断时与该版本快照中的原文比较
+func (s *StatisticsService) newSourceFilter(ctx context.Context, params domain.ProjectStatisticsParams, sourceLanguage string) (func(keyName string, segment *sourceSegment) bool, error) {
+	switch {
+	case params.Since != nil && params.SinceVersion != "":
+		return nil, domain.ErrInvalidInput
+	case params.Since != nil:
+		since := *params.Since
+		return func(_ string, segment *sourceSegment) bool {
+			return !segment.updatedAt.Before(since)
+		}, nil
+	case params.SinceVersion != "":
+		snapshot, err := s.snapshotRepo.GetByName(ctx, params.ProjectID, params.SinceVersion)
+		if err != nil {
+			return nil, err
+		}
+		previous, err := s.snapshotRepo.GetValues(ctx, snapshot.ID, sourceLanguage)
+		if err != nil {
+			return nil, err
+		}
+		return func(keyName string, segment *sourceSegment) bool {
+			return previous[keyName][sourceLanguage] != segment.value
+		}, nil
+	}
+	return nil, nil
+}
+
+// estimateCost 估算一个目标语言的费用：需翻译的原文按翻译记忆最高匹配度落入折扣档，无匹配的按全价计算
+// budget 为剩余可做翻译记忆分析的键数，用尽后其余键按全价计算并返回 partial
+func (s *StatisticsService) estimateCost(
+	ctx context.Context,
+	params domain.ProjectStatisticsParams,
+	sourceLanguage, targetLanguage string,
+	pending map[string]string,
+	sources map[string]*sourceSegment,
+	budget *int,
+) (*domain.CostEstimate, bool, error) {
+	rate := s.cost.RatePerWord
+	if languageRate, ok := s.cost.LanguageRates[targetLanguage]; ok {
+		rate = languageRate
+	}
+
+	bands := make([]*domain.CostBand, 0, len(s.cost.TMDiscounts)+1)
+	for percent, factor := range s.cost.TMDiscounts {
+		bands = append(bands, &domain.CostBand{MinMatch: percent, Factor: factor})
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].MinMatch > bands[j].MinMatch })
+	bands = append(bands, &domain.CostBand{MinMatch: 0, Factor: 1})
+
+	scores := make(map[string]float64)
+	partial := false
+	if len(bands) > 1 && len(pending) > 0 {
+		analyzed := pending
+		if len(pending) > *budget {
+			partial = true
+			keyNames := make([]string, 0, len(pending))
+			for keyName := range pending {
+				keyNames = append(keyNames, keyName)
+			}
+			sort.Strings(keyNames)
+			analyzed = make(map[string]string, *budget)
+			for _, keyName := range keyNames[:*budget] {
+				analyzed[keyName] = pending[keyName]
+			}
+		}
+		*budget -= len(analyzed)
+
+		if len(analyzed) > 0 {
+			var err error
+			scores, err = s.tmService.Leverage(ctx, domain.TranslationMemoryLeverageParams{
+				UserID:         params.UserID,
+				ProjectID:      params.ProjectID,
+				SourceLanguage: sourceLanguage,
+				TargetLanguage: targetLanguage,
+				Sources:        analyzed,
+				MinScore:       float64(bands[len(bands)-2].MinMatch) / 100,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	estimate := &domain.CostEstimate{RatePerWord: rate, Bands: bands}
+	for keyName := range pending {
+		words := sources[keyName].count.Words
+		band := bands[len(bands)-1]
+		if score, ok := scores[keyName]; ok {
+			percent := int(math.Floor(score*100 + 1e-9))
+			for _, candidate := range bands {
+				if percent >= candidate.MinMatch {
+					band = candidate
+					break
+				}
+			}
+		}
+		band.Keys++
+		band.Words += words
+		estimate.Words += words
+		estimate.WeightedWords += float64(words) * band.Factor
+	}
+	for _, band := range bands {
+		band.Amount = roundAmount(float64(band.Words) * band.Factor * rate)
+		estimate.Amount += band.Amount
+	}
+	estimate.WeightedWords = roundAmount(estimate.WeightedWords)
+	estimate.Amount = roundAmount(estimate.Amount)
+	return estimate, partial, nil
+}
+
+// addCount 累加一个键的计数
+func addCount(total *domain.StatisticsCount, count internal_utils.TextCount) {
+	total.Keys++
+	total.Words += count.Words
+	total.Characters += count.Characters
+}
+
+// roundAmount 保留两位小数
+func roundAmount(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+)
+
+// SyncService CLI 三方合并同步服务实现
+// 以客户端上次拉取时的修订号为基准，由版本记录还原基准译文，按单元格比较基准、服务端和客户端的状态
+type SyncService struct {
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+	projectBaseRepo    domain.ProjectBaseRepository
+}
+
+// NewSyncService 创建三方合并同步服务实例
+func NewSyncService(
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	projectBaseRepo domain.ProjectBaseRepository,
+) *SyncService {
+	return &SyncService{
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+		projectBaseRepo:    projectBaseRepo,
+	}
+}
+
+// Sync 合并客户端本地的译文，把客户端的修改写入服务端，并返回客户端需要拉取的修改和冲突
+// 只合并参与同步的语言；客户端没有的键视为在本地删除；空译文视为单元格不存在
+// 修订号只记录项目自身的写入，继承了基础项目的项目不支持三方合并
+func (s *SyncService) Sync(ctx context.Context, params domain.SyncParams) (*domain.SyncResult, error) {
+	// 先读取修订号再读取译文，读取期间的写入在下一次同步时会被再次比较，不会丢失
+	project, err := s.projectRepo.GetByID(ctx, params.ProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	bases, err := s.projectBaseRepo.ListByProject(ctx, params.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bases) > 0 {
+		return nil, domain.ErrInheritedRevision
+	}
+	if params.BaseRevision > project.Revision {
+		return nil, domain.ErrRevisionAhead
+	}
+	for keyName := range params.Values {
+		if strings.TrimSpace(keyName) == "" || len([]rune(keyName)) > maxBranchKeyNameLength {
+			return nil, domain.ErrInvalidInput
+		}
+	}
+
+	scope, err := s.syncLanguages(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	base := map[string]map[string]string{}
+	if params.BaseRevision > 0 {
+		if base, err = s.translationRepo.GetValuesAt(ctx, params.ProjectID, params.BaseRevision); err != nil {
+			return nil, err
+		}
+	}
+	mainCells, _, err := s.translationRepo.GetMatrix(ctx, params.ProjectID, -1, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	push, result := PlanSync(
+		filterLanguages(base, scope),
+		filterLanguages(toSimpleMatrix(mainCells), scope),
+		filterLanguages(params.Values, scope),
+	)
+	result.Revision = project.Revision
+	for _, change := range push {
+		if change.Deleted {
+			result.Removed++
+		} else {
+			result.Pushed++
+		}
+	}
+	if params.DryRun || len(push) == 0 {
+		return result, nil
+	}
+
+	writer := cellWriter{
+		languageRepo:       s.languageRepo,
+		translationRepo:    s.translationRepo,
+		translationService: s.translationService,
+	}
+	if err := writer.write(ctx, params.ProjectID, push, nil, mainCells); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// syncLanguages 确定参与合并的语言，语言必须存在且已启用
+func (s *SyncService) syncLanguages(ctx context.Context, params domain.SyncParams) (map[string]bool, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		if language.Status == "active" {
+			active[language.Code] = true
+		}
+	}
+
+	scope := make(map[string]bool)
+	for _, code := range params.Languages {
+		scope[code] = true
+	}
+	if len(scope) == 0 {
+		for _, cells := range params.Values {
+			for code := range cells {
+				scope[code] = true
+			}
+		}
+	}
+	for code := range scope {
+		if !active[code] {
+			return nil, domain.ErrLanguageNotFound
+		}
+	}
+	return scope, nil
+}
+
+// filterLanguages 只保留指定语言的单元格
+func filterLanguages(matrix map[string]map[string]string, languages map[string]bool) map[string]map[string]string {
+	filtered := make(map[string]map[string]string, len(matrix))
+	for keyName, cells := range matrix {
+		for code, value := range cells {
+			if !languages[code] {
+				continue
+			}
+			if filtered[keyName] == nil {
+				filtered[keyName] = make(map[string]string)
+			}
+			filtered[keyName][code] = value
+		}
+	}
+	return filtered
+}
+
+// PlanSync 按单元格三方比较基准、服务端和客户端的译文（键名 -> 语言代码 -> 译文），空译文视为单元格不存在
+// 只有客户端修改的单元格写入服务端，返回为待写入的修改；只有服务端修改的单元格返回给客户端拉取；
+// 两边改成相同状态的单元格无需处理；其余为冲突。写入的修改、删除的单元格和冲突都按键名和语言排序
+func PlanSync(base, server, local map[string]map[string]string) ([]*domain.BranchChange, *domain.SyncResult) {
+	result := &domain.SyncResult{
+		Changed:   make(map[string]map[string]string),
+		Deleted:   []domain.TranslationCellRef{},
+		Conflicts: []*domain.SyncConflict{},
+	}
+	var push []*domain.BranchChange
+
+	cells := make(map[domain.TranslationCellRef]bool)
+	for _, matrix := range []map[string]map[string]string{base, server, local} {
+		for keyName, values := range matrix {
+			for code := range nonEmptyValues(values) {
+				cells[domain.TranslationCellRef{KeyName: keyName, LanguageCode: code}] = true
+			}
+		}
+	}
+	sorted := make([]domain.TranslationCellRef, 0, len(cells))
+	for cell := range cells {
+		sorted = append(sorted, cell)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].KeyName != sorted[j].KeyName {
+			return sorted[i].KeyName < sorted[j].KeyName
+		}
+		return sorted[i].LanguageCode < sorted[j].LanguageCode
+	})
+
+	lookup := func(matrix map[string]map[string]string, cell domain.TranslationCellRef) (string, bool) {
+		value, ok := matrix[cell.KeyName][cell.LanguageCode]
+		return value, ok && value != ""
+	}
+	for _, cell := range sorted {
+		baseValue, baseExists := lookup(base, cell)
+		serverValue, serverExists := lookup(server, cell)
+		localValue, localExists := lookup(local, cell)
+
+		switch {
+		case sameCellState(serverValue, serverExists, localValue, localExists):
+			continue
+		case sameCellState(baseValue, baseExists, localValue, localExists):
+			// 只有服务端修改
+			if serverExists {
+				if result.Changed[cell.KeyName] == nil {
+					result.Changed[cell.KeyName] = make(map[string]string)
+				}
+				result.Changed[cell.KeyName][cell.LanguageCode] = serverValue
+			} else {
+				result.Deleted = append(result.Deleted, cell)
+			}
+		case sameCellState(baseValue, baseExists, serverValue, serverExists):
+			// 只有客户端修改
+			push = append(push, &domain.BranchChange{
+				KeyName:      cell.KeyName,
+				LanguageCode: cell.LanguageCode,
+				Value:        localValue,
+				Deleted:      !localExists,
+			})
+		default:
+			conflict := &domain.SyncConflict{KeyName: cell.KeyName, LanguageCode: cell.LanguageCode}
+			if baseExists {
+				conflict.Base = &baseValue
+			}
+			if serverExists {
+				conflict.Server = &serverValue
+			}
+			if localExists {
+				conflict.Local = &localValue
+			}
+			result.Conflicts = append(result.Conflicts, conflict)
+		}
+	}
+	return push, result
+}
@@ -0,0 +1,566 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+const (
+	defaultTMMinScore = 0.7  // 默认最低相似度
+	defaultTMLimit    = 10   // 默认返回的匹配数
+	maxTMLimit        = 50   // 最多返回的匹配数
+	tmCandidateLimit  = 500  // 每次查询最多比较的候选数
+	tmSearchTermCount = 6    // 预筛选模糊匹配候选时使用的检索词数
+	tmImportChunkSize = 500  // 导入 TMX 时每批写入的单元数
+	tmExportChunkSize = 1000 // 导出 TMX 时每批读取的导入单元数
+	tmOriginMaxLength = 255  // 导入来源的最大长度
+	tmCreationTool    = "i18n-flow"
+)
+
+// TranslationMemoryService 翻译记忆服务实现
+// 项目中未删除、未废弃的翻译即为已确认的翻译记忆，按用户可查看的项目过滤；从 TMX 导入的记忆对所有用户可见
+type TranslationMemoryService struct {
+	tmRepo            domain.TranslationMemoryRepository
+	translationRepo   domain.TranslationRepository
+	projectRepo       domain.ProjectRepository
+	languageRepo      domain.LanguageRepository
+	userRepo          domain.UserRepository
+	projectMemberRepo domain.ProjectMemberRepository
+}
+
+// NewTranslationMemoryService 创建翻译记忆服务实例
+func NewTranslationMemoryService(
+	tmRepo domain.TranslationMemoryRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+) *TranslationMemoryService {
+	return &TranslationMemoryService{
+		tmRepo:            tmRepo,
+		translationRepo:   translationRepo,
+		projectRepo:       projectRepo,
+		languageRepo:      languageRepo,
+		userRepo:          userRepo,
+		projectMemberRepo: projectMemberRepo,
+	}
+}
+
+// Lookup 查找原文的完全匹配和模糊匹配
+func (s *TranslationMemoryService) Lookup(ctx context.Context, params domain.TranslationMemoryLookupParams) ([]*domain.TranslationMemoryMatch, error) {
+	source := internal_utils.NormalizeSegment(params.Source)
+	if source == "" {
+		return nil, domain.ErrEmptyTMSource
+	}
+	if err := s.validateLanguagePair(ctx, params.SourceLanguage, params.TargetLanguage); err != nil {
+		return nil, err
+	}
+
+	projectIDs, err := accessibleProjectIDs(ctx, s.userRepo, s.projectMemberRepo, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.lookup(ctx, tmLookup{
+		source:         source,
+		sourceLanguage: params.SourceLanguage,
+		targetLanguage: params.TargetLanguage,
+		projectIDs:     projectIDs,
+		minScore:       params.MinScore,
+		limit:          params.Limit,
+	})
+}
+
+// Suggest 以键在默认语言下的翻译为原文，查找目标语言的翻译记忆，结果不包含该键自身
+func (s *TranslationMemoryService) Suggest(ctx context.Context, params domain.TranslationMemorySuggestParams) (*domain.TranslationMemorySuggestions, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+	if err != nil || defaultLanguage == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+	if err := s.validateLanguagePair(ctx, defaultLanguage.Code, params.TargetLanguage); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.translationRepo.GetByProjectKeyLanguage(ctx, params.ProjectID, params.KeyName, defaultLanguage.ID)
+	if err != nil {
+		return nil, err
+	}
+	if translation == nil {
+		return nil, domain.ErrTranslationNotFound
+	}
+
+	suggestions := &domain.TranslationMemorySuggestions{
+		Source:         translation.Value,
+		SourceLanguage: defaultLanguage.Code,
+		Matches:        make([]*domain.TranslationMemoryMatch, 0),
+	}
+	source := internal_utils.NormalizeSegment(translation.Value)
+	if source == "" {
+		return suggestions, nil
+	}
+
+	projectIDs, err := accessibleProjectIDs(ctx, s.userRepo, s.projectMemberRepo, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions.Matches, err = s.lookup(ctx, tmLookup{
+		source:         source,
+		sourceLanguage: defaultLanguage.Code,
+		targetLanguage: params.TargetLanguage,
+		projectIDs:     projectIDs,
+		minScore:       params.MinScore,
+		limit:          params.Limit,
+		exclude: func(match *domain.TranslationMemoryMatch) bool {
+			return match.ProjectID == params.ProjectID && match.KeyName == params.KeyName
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// Leverage 批量查询原文在翻译记忆中的最高匹配度，用于估算工作量；低于 MinScore 或无匹配的键不出现在结果中
+// 相同的原文只查询一次，匹配结果不包含同一项目中的同名键自身
+func (s *TranslationMemoryService) Leverage(ctx context.Context, params domain.TranslationMemoryLeverageParams) (map[string]float64, error) {
+	if err := s.validateLanguagePair(ctx, params.SourceLanguage, params.TargetLanguage); err != nil {
+		return nil, err
+	}
+	projectIDs, err := accessibleProjectIDs(ctx, s.userRepo, s.projectMemberRepo, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	keysBySource := make(map[string][]string)
+	for keyName, source := range params.Sources {
+		source = internal_utils.NormalizeSegment(source)
+		if source != "" {
+			keysBySource[source] = append(keysBySource[source], keyName)
+		}
+	}
+
+	scores := make(map[string]float64, len(params.Sources))
+	for source, keyNames := range keysBySource {
+		excluded := make(map[string]bool, len(keyNames))
+		for _, keyName := range keyNames {
+			excluded[keyName] = true
+		}
+		matches, err := s.lookup(ctx, tmLookup{
+			source:         source,
+			sourceLanguage: params.SourceLanguage,
+			targetLanguage: params.TargetLanguage,
+			projectIDs:     projectIDs,
+			minScore:       params.MinScore,
+			limit:          1,
+			exclude: func(match *domain.TranslationMemoryMatch) bool {
+				return match.ProjectID == params.ProjectID && excluded[match.KeyName]
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		for _, keyName := range keyNames {
+			scores[keyName] = matches[0].Score
+		}
+	}
+	return scores, nil
+}
+
+// tmLookup 翻译记忆查询条件，source 已规范化
+type tmLookup struct {
+	source         string
+	sourceLanguage string
+	targetLanguage string
+	projectIDs     []uint64
+	minScore       float64
+	limit          int
+	exclude        func(match *domain.TranslationMemoryMatch) bool
+}
+
+// lookup 查询项目翻译和导入的记忆，按相似度排序并去除原文和译文都相同的重复项
+func (s *TranslationMemoryService) lookup(ctx context.Context, lookup tmLookup) ([]*domain.TranslationMemoryMatch, error) {
+	minScore := lookup.minScore
+	if minScore <= 0 || minScore > 1 {
+		minScore = defaultTMMinScore
+	}
+	limit := lookup.limit
+	if limit <= 0 {
+		limit = defaultTMLimit
+	}
+	limit = min(limit, maxTMLimit)
+
+	queries := []domain.TranslationMemoryQuery{{
+		ProjectIDs:     lookup.projectIDs,
+		SourceLanguage: lookup.sourceLanguage,
+		TargetLanguage: lookup.targetLanguage,
+		Exact:          lookup.source,
+		ExactHash:      segmentHash(lookup.source),
+		Limit:          tmCandidateLimit,
+	}}
+	if minScore < 1 {
+		minLength, maxLength := internal_utils.LengthWindow(lookup.source, minScore)
+		queries = append(queries, domain.TranslationMemoryQuery{
+			ProjectIDs:     lookup.projectIDs,
+			SourceLanguage: lookup.sourceLanguage,
+			TargetLanguage: lookup.targetLanguage,
+			MinLength:      minLength,
+			MaxLength:      maxLength,
+			Terms:          internal_utils.SearchTerms(lookup.source, tmSearchTermCount),
+			Limit:          tmCandidateLimit,
+		})
+	}
+
+	var candidates []*domain.TranslationMemoryMatch
+	for _, query := range queries {
+		projectMatches, err := s.tmRepo.FindProjectCandidates(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range projectMatches {
+			match.Origin = domain.TranslationMemoryOriginProject
+		}
+
+		importedMatches, err := s.tmRepo.FindImportedCandidates(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range importedMatches {
+			match.Origin = domain.TranslationMemoryOriginTMX
+		}
+
+		candidates = append(candidates, projectMatches...)
+		candidates = append(candidates, importedMatches...)
+	}
+
+	matches := make([]*domain.TranslationMemoryMatch, 0)
+	for _, candidate := range candidates {
+		if lookup.exclude != nil && lookup.exclude(candidate) {
+			continue
+		}
+		candidate.Exact = internal_utils.NormalizeSegment(candidate.SourceText) == lookup.source
+		candidate.Score = internal_utils.Similarity(lookup.source, candidate.SourceText)
+		if candidate.Score >= minScore {
+			matches = append(matches, candidate)
+		}
+	}
+
+	// 相似度高者在前；相同时项目翻译优先于导入的记忆，较新的优先
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Origin != matches[j].Origin {
+			return matches[i].Origin == domain.TranslationMemoryOriginProject
+		}
+		return matches[i].UpdatedAt.After(matches[j].UpdatedAt)
+	})
+
+	seen := make(map[string]bool)
+	result := make([]*domain.TranslationMemoryMatch, 0, limit)
+	for _, match := range matches {
+		key := internal_utils.NormalizeSegment(match.SourceText) + "\x00" + match.TargetText
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, match)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// validateLanguagePair 校验源语言和目标语言存在且不同
+func (s *TranslationMemoryService) validateLanguagePair(ctx context.Context, sourceLanguage, targetLanguage string) error {
+	if sourceLanguage == "" || targetLanguage == "" {
+		return domain.ErrInvalidLanguage
+	}
+	if sourceLanguage == targetLanguage {
+		return domain.ErrSameLanguages
+	}
+	for _, code := range []string{sourceLanguage, targetLanguage} {
+		language, err := s.languageRepo.GetByCode(ctx, code)
+		if err != nil || language == nil {
+			return domain.ErrLanguageNotFound
+		}
+	}
+	return nil
+}
+
+// ImportTMX 导入 TMX 文件
+// 语言代码按系统中的语言匹配（忽略大小写，en-US 可匹配 en），少于两种已知语言的单元和已导入过的单元会被跳过
+func (s *TranslationMemoryService) ImportTMX(ctx context.Context, params domain.ImportTMXParams) (*domain.ImportTMXResult, error) {
+	file, err := internal_utils.ParseTMX(params.Data)
+	if err != nil {
+		return nil, domain.ErrInvalidTMX
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolve := newLanguageResolver(languages)
+
+	origin := strings.TrimSpace(params.Origin)
+	if len(origin) > tmOriginMaxLength {
+		origin = strings.ToValidUTF8(origin[:tmOriginMaxLength], "")
+	}
+
+	result := &domain.ImportTMXResult{Units: len(file.Units), UnknownLanguages: make([]string, 0)}
+	unknown := make(map[string]bool)
+	units := make([]*domain.TranslationMemoryUnit, 0, len(file.Units))
+	hashes := make([]string, 0, len(file.Units))
+	inFile := make(map[string]bool)
+	for _, tmxUnit := range file.Units {
+		segments := make(map[string]string)
+		for tmxLanguage, text := range tmxUnit.Segments {
+			code, ok := resolve(tmxLanguage)
+			if !ok {
+				unknown[tmxLanguage] = true
+				continue
+			}
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			// 多个地区变体对应同一语言时，优先使用代码完全一致的文本
+			if _, exists := segments[code]; !exists || strings.EqualFold(tmxLanguage, code) {
+				segments[code] = text
+			}
+		}
+		if len(segments) < 2 {
+			result.Skipped++
+			continue
+		}
+
+		unit := newTranslationMemoryUnit(segments, origin, params.UserID)
+		if inFile[unit.ContentHash] {
+			result.Duplicates++
+			continue
+		}
+		inFile[unit.ContentHash] = true
+		units = append(units, unit)
+		hashes = append(hashes, unit.ContentHash)
+	}
+
+	existing, err := s.tmRepo.GetExistingUnitHashes(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	existingSet := toStringSet(existing)
+
+	newUnits := make([]*domain.TranslationMemoryUnit, 0, len(units))
+	for _, unit := range units {
+		if existingSet[unit.ContentHash] {
+			result.Duplicates++
+			continue
+		}
+		newUnits = append(newUnits, unit)
+	}
+	for start := 0; start < len(newUnits); start += tmImportChunkSize {
+		end := min(start+tmImportChunkSize, len(newUnits))
+		if err := s.tmRepo.CreateUnits(ctx, newUnits[start:end]); err != nil {
+			return nil, err
+		}
+		result.Imported += end - start
+	}
+
+	for language := range unknown {
+		result.UnknownLanguages = append(result.UnknownLanguages, language)
+	}
+	sort.Strings(result.UnknownLanguages)
+	return result, nil
+}
+
+// newTranslationMemoryUnit 构建翻译记忆单元，内容哈希与语言顺序无关
+func newTranslationMemoryUnit(segments map[string]string, origin string, userID uint64) *domain.TranslationMemoryUnit {
+	languages := make([]string, 0, len(segments))
+	for language := range segments {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	hash := sha256.New()
+	unit := &domain.TranslationMemoryUnit{Origin: origin, CreatedBy: userID}
+	for _, language := range languages {
+		text := segments[language]
+		hash.Write([]byte(language + "\x00" + text + "\x00"))
+		unit.Segments = append(unit.Segments, &domain.TranslationMemorySegment{
+			Language: language,
+			Text:     text,
+			TextHash: segmentHash(internal_utils.NormalizeSegment(text)),
+		})
+	}
+	unit.ContentHash = hex.EncodeToString(hash.Sum(nil))
+	return unit
+}
+
+// segmentHash 计算规范化文本的哈希
+func segmentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// newLanguageResolver 返回将外部语言代码映射为系统语言代码的函数
+// 先忽略大小写完全匹配（_ 视为 -），再按主语言匹配，如 en-US 匹配 en
+func newLanguageResolver(languages []*domain.Language) func(string) (string, bool) {
+	codes := make(map[string]string, len(languages))
+	for _, language := range languages {
+		codes[strings.ToLower(language.Code)] = language.Code
+	}
+	return func(code string) (string, bool) {
+		normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"))
+		if resolved, ok := codes[normalized]; ok {
+			return resolved, true
+		}
+		if primary, _, found := strings.Cut(normalized, "-"); found {
+			if resolved, ok := codes[primary]; ok {
+				return resolved, true
+			}
+		}
+		return "", false
+	}
+}
+
+// ExportTMX 导出翻译记忆为 TMX 文件
+// 每个项目中的键为一个翻译单元，只导出包含源语言翻译且至少有两种语言的单元
+func (s *TranslationMemoryService) ExportTMX(ctx context.Context, params domain.ExportTMXParams) ([]byte, error) {
+	sourceLanguage := params.SourceLanguage
+	if sourceLanguage == "" {
+		defaultLanguage, err := s.languageRepo.GetDefault(ctx)
+		if err != nil || defaultLanguage == nil {
+			return nil, domain.ErrLanguageNotFound
+		}
+		sourceLanguage = defaultLanguage.Code
+	} else if language, err := s.languageRepo.GetByCode(ctx, sourceLanguage); err != nil || language == nil {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	projectIDs, err := accessibleProjectIDs(ctx, s.userRepo, s.projectMemberRepo, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if params.ProjectID != 0 {
+		if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		if projectIDs != nil && !containsProjectID(projectIDs, params.ProjectID) {
+			return nil, domain.ErrInsufficientPerm
+		}
+		projectIDs = []uint64{params.ProjectID}
+	}
+
+	rows, err := s.tmRepo.ListProjectSegments(ctx, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &internal_utils.TMXFile{SourceLanguage: sourceLanguage}
+	var current *internal_utils.TMXUnit
+	var currentProjectID uint64
+	flush := func() {
+		if current != nil && len(current.Segments) >= 2 && current.Segments[sourceLanguage] != "" {
+			file.Units = append(file.Units, *current)
+		}
+	}
+	for _, row := range rows {
+		if current == nil || currentProjectID != row.ProjectID || current.Properties["x-key"] != row.KeyName {
+			flush()
+			currentProjectID = row.ProjectID
+			current = &internal_utils.TMXUnit{
+				ID:         fmt.Sprintf("%d:%s", row.ProjectID, row.KeyName),
+				Segments:   make(map[string]string),
+				Properties: map[string]string{"x-project": row.ProjectName, "x-key": row.KeyName},
+			}
+		}
+		current.Segments[row.Language] = row.Value
+	}
+	flush()
+
+	if params.IncludeImported {
+		for offset := 0; ; offset += tmExportChunkSize {
+			units, err := s.tmRepo.ListImportedUnits(ctx, tmExportChunkSize, offset)
+			if err != nil {
+				return nil, err
+			}
+			for _, unit := range units {
+				tmxUnit := internal_utils.TMXUnit{
+					ID:         fmt.Sprintf("tm:%d", unit.ID),
+					Segments:   make(map[string]string, len(unit.Segments)),
+					Properties: map[string]string{"x-origin": unit.Origin},
+				}
+				for _, segment := range unit.Segments {
+					tmxUnit.Segments[segment.Language] = segment.Text
+				}
+				if tmxUnit.Segments[sourceLanguage] != "" {
+					file.Units = append(file.Units, tmxUnit)
+				}
+			}
+			if len(units) < tmExportChunkSize {
+				break
+			}
+		}
+	}
+
+	return internal_utils.MarshalTMX(file, tmCreationTool, time.Now())
+}
+
+// accessibleProjectIDs 获取用户可查看的项目ID，管理员返回 nil 表示不限制
+func accessibleProjectIDs(
+	ctx context.Context,
+	userRepo domain.UserRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+	userID uint64,
+) ([]uint64, error) {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUnauthorized
+	}
+	if user.Role == "admin" {
+		return nil, nil
+	}
+
+	members, err := projectMemberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]uint64, 0, len(members))
+	for _, member := range members {
+		projectIDs = append(projectIDs, member.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// containsProjectID 判断项目ID是否在列表中
+func containsProjectID(projectIDs []uint64, projectID uint64) bool {
+	for _, id := range projectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
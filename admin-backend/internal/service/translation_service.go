@@ -13,6 +13,10 @@ type TranslationService struct {
 	translationRepo domain.TranslationRepository
 	projectRepo     domain.ProjectRepository
 	languageRepo    domain.LanguageRepository
+	keyTagRepo      domain.KeyTagRepository
+	branchRepo      domain.BranchRepository
+	lockRepo        domain.TranslationLockRepository
+	projectBaseRepo domain.ProjectBaseRepository
 }
 
 // NewTranslationService 创建翻译服务实例
@@ -20,18 +24,26 @@ func NewTranslationService(
 	translationRepo domain.TranslationRepository,
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
+	keyTagRepo domain.KeyTagRepository,
+	branchRepo domain.BranchRepository,
+	lockRepo domain.TranslationLockRepository,
+	projectBaseRepo domain.ProjectBaseRepository,
 ) *TranslationService {
 	return &TranslationService{
 		translationRepo: translationRepo,
 		projectRepo:     projectRepo,
 		languageRepo:    languageRepo,
+		keyTagRepo:      keyTagRepo,
+		branchRepo:      branchRepo,
+		lockRepo:        lockRepo,
+		projectBaseRepo: projectBaseRepo,
 	}
 }
 
 // Create 创建翻译
 func (s *TranslationService) Create(ctx context.Context, input domain.TranslationInput, userID uint64) (*domain.Translation, error) {
 	// 验证项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, input.ProjectID)
+	project, err := s.projectRepo.GetByID(ctx, input.ProjectID)
 	if err != nil {
 		return nil, domain.ErrProjectNotFound
 	}
@@ -44,6 +56,14 @@ func (s *TranslationService) Create(ctx context.Context, input domain.Translatio
 
 	// 检查翻译是否已存在
 	keyName := strings.TrimSpace(input.KeyName)
+	if err := validateValueType(project, keyName, strings.TrimSpace(input.Value)); err != nil {
+		return nil, err
+	}
+	if err := s.checkWrites(ctx, project.ID, []domain.CellWrite{
+		{KeyName: keyName, LanguageID: input.LanguageID, Value: strings.TrimSpace(input.Value)},
+	}); err != nil {
+		return nil, err
+	}
 	existing, err := s.translationRepo.GetByProjectKeyLanguage(ctx, input.ProjectID, keyName, input.LanguageID)
 	if err == nil && existing != nil {
 		return nil, domain.NewAppErrorWithDetails(
@@ -116,6 +136,11 @@ func (s *TranslationService) CreateBatch(ctx context.Context, inputs []domain.Tr
 		return domain.ErrProjectNotFound
 	}
 
+	// 按项目的值类型校验翻译值
+	if err := validateInputsValueType(projects, inputs); err != nil {
+		return err
+	}
+
 	// 批量验证语言 (修复 N+1 查询)
 	languages, err := s.languageRepo.GetByIDs(ctx, languageIDs)
 	if err != nil {
@@ -125,6 +150,11 @@ func (s *TranslationService) CreateBatch(ctx context.Context, inputs []domain.Tr
 		return domain.ErrLanguageNotFound
 	}
 
+	// 检查字符串冻结和锁定
+	if err := s.checkInputs(ctx, inputs); err != nil {
+		return err
+	}
+
 	// 构建所有要查询的键（修复 N+1 查询问题）
 	keys := make([]domain.TranslationKey, 0, len(inputs))
 	for _, input := range inputs {
@@ -226,6 +256,11 @@ func (s *TranslationService) UpsertBatch(ctx context.Context, inputs []domain.Tr
 		return domain.ErrProjectNotFound
 	}
 
+	// 按项目的值类型校验翻译值
+	if err := validateInputsValueType(projects, inputs); err != nil {
+		return err
+	}
+
 	// 批量验证语言 (修复 N+1 查询)
 	languages, err := s.languageRepo.GetByIDs(ctx, languageIDs)
 	if err != nil {
@@ -235,6 +270,11 @@ func (s *TranslationService) UpsertBatch(ctx context.Context, inputs []domain.Tr
 		return domain.ErrLanguageNotFound
 	}
 
+	// 检查字符串冻结和锁定
+	if err := s.checkInputs(ctx, inputs); err != nil {
+		return err
+	}
+
 	// 转换为 domain 对象
 	translations := make([]*domain.Translation, 0, len(inputs))
 	for _, input := range inputs {
@@ -320,7 +360,7 @@ func (s *TranslationService) GetByProjectID(ctx context.Context, projectID uint6
 	return s.translationRepo.GetByProjectID(ctx, projectID, limit, offset)
 }
 
-// GetMatrix 获取翻译矩阵
+// GetMatrix 获取翻译矩阵，项目继承了基础项目时返回合并后的矩阵，继承的单元格标注来源项目
 func (s *TranslationService) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
 	// 验证项目是否存在
 	_, err := s.projectRepo.GetByID(ctx, projectID)
@@ -328,6 +368,13 @@ func (s *TranslationService) GetMatrix(ctx context.Context, projectID uint64, li
 		return nil, 0, domain.ErrProjectNotFound
 	}
 
+	chain, err := s.inheritanceChain(ctx, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(chain) > 1 {
+		return s.inheritedMatrix(ctx, chain, limit, offset, keyword)
+	}
 	return s.translationRepo.GetMatrix(ctx, projectID, limit, offset, keyword)
 }
 
@@ -338,6 +385,20 @@ func (s *TranslationService) Update(ctx context.Context, id uint64, input domain
 	if err != nil {
 		return nil, err
 	}
+	original := *translation
+
+	// 在继承的项目中编辑基础项目的翻译时创建本地覆盖
+	if input.ProjectID != 0 && input.ProjectID != translation.ProjectID {
+		chain, err := s.inheritanceChain(ctx, input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, baseID := range chain[1:] {
+			if baseID == translation.ProjectID {
+				return s.overrideInherited(ctx, translation, input, userID)
+			}
+		}
+	}
 
 	// 如果项目ID改变，验证新项目
 	if input.ProjectID != 0 && input.ProjectID != translation.ProjectID {
@@ -367,7 +428,21 @@ func (s *TranslationService) Update(ctx context.Context, id uint64, input domain
 	}
 
 	if input.Value != "" {
+		project, err := s.projectRepo.GetByID(ctx, translation.ProjectID)
+		if err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		if err := validateValueType(project, translation.KeyName, strings.TrimSpace(input.Value)); err != nil {
+			return nil, err
+		}
 		translation.Value = strings.TrimSpace(input.Value)
+		// 人工保存即视为确认，不再是机器翻译草稿
+		translation.MachineTranslated = false
+	}
+
+	// 检查字符串冻结和锁定
+	if err := s.checkUpdate(ctx, &original, translation); err != nil {
+		return nil, err
 	}
 
 	// 更新UpdatedBy字段
@@ -384,10 +459,13 @@ func (s *TranslationService) Update(ctx context.Context, id uint64, input domain
 // Delete 删除翻译
 func (s *TranslationService) Delete(ctx context.Context, id uint64) error {
 	// 检查翻译是否存在
-	_, err := s.translationRepo.GetByID(ctx, id)
+	translation, err := s.translationRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := s.checkDeletes(ctx, []*domain.Translation{translation}); err != nil {
+		return err
+	}
 
 	return s.translationRepo.Delete(ctx, id)
 }
@@ -398,24 +476,64 @@ func (s *TranslationService) DeleteBatch(ctx context.Context, ids []uint64) erro
 		return nil
 	}
 
+	translations, err := s.translationRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if err := s.checkDeletes(ctx, translations); err != nil {
+		return err
+	}
+
 	return s.translationRepo.DeleteBatch(ctx, ids)
 }
 
 // Export 导出翻译
-func (s *TranslationService) Export(ctx context.Context, projectID uint64, format string) ([]byte, error) {
-	// 验证项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, projectID)
+func (s *TranslationService) Export(ctx context.Context, projectID uint64, format string, opts domain.ExportOptions) ([]byte, []*domain.UntranslatablePlaceholder, error) {
+	simpleMatrix, untranslatable, err := s.ExportMatrix(ctx, projectID, opts)
 	if err != nil {
-		return nil, domain.ErrProjectNotFound
+		return nil, nil, err
+	}
+
+	data, err := marshalExport(simpleMatrix, format)
+	if err != nil {
+		return nil, nil, err
 	}
+	return data, untranslatable, nil
+}
 
+// ExportMatrix 导出翻译矩阵 (key -> language -> value)，包含继承的翻译
+func (s *TranslationService) ExportMatrix(ctx context.Context, projectID uint64, opts domain.ExportOptions) (map[string]map[string]string, []*domain.UntranslatablePlaceholder, error) {
 	// 获取翻译矩阵（导出所有数据，不分页）
-	matrix, _, err := s.translationRepo.GetMatrix(ctx, projectID, -1, 0, "")
+	matrix, _, err := s.GetMatrix(ctx, projectID, -1, 0, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	simpleMatrix, err := s.applyBranch(ctx, projectID, toSimpleMatrix(matrix), opts.Branch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.applyExportOptions(ctx, projectID, simpleMatrix, opts)
+}
+
+// applyBranch 指定了分支时把分支的修改叠加到主干的译文上
+func (s *TranslationService) applyBranch(ctx context.Context, projectID uint64, simpleMatrix map[string]map[string]string, branchName string) (map[string]map[string]string, error) {
+	if branchName == "" {
+		return simpleMatrix, nil
+	}
+	branch, err := s.branchRepo.GetByName(ctx, projectID, branchName)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.branchRepo.GetChanges(ctx, branch.ID)
 	if err != nil {
 		return nil, err
 	}
+	return ApplyBranchChanges(simpleMatrix, changes), nil
+}
 
-	// 转换为简单格式 (key -> language -> value)
+// toSimpleMatrix 转换为简单格式 (key -> language -> value)
+func toSimpleMatrix(matrix map[string]map[string]domain.TranslationCell) map[string]map[string]string {
 	simpleMatrix := make(map[string]map[string]string)
 	for key, langs := range matrix {
 		simpleMatrix[key] = make(map[string]string)
@@ -423,7 +541,11 @@ func (s *TranslationService) Export(ctx context.Context, projectID uint64, forma
 			simpleMatrix[key][lang] = cell.Value
 		}
 	}
+	return simpleMatrix
+}
 
+// marshalExport 按指定格式序列化导出数据
+func marshalExport(simpleMatrix map[string]map[string]string, format string) ([]byte, error) {
 	switch format {
 	case "json":
 		return json.MarshalIndent(simpleMatrix, "", "  ")
@@ -433,32 +555,32 @@ func (s *TranslationService) Export(ctx context.Context, projectID uint64, forma
 }
 
 // Import 导入翻译
-func (s *TranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string) error {
+func (s *TranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string, opts domain.ImportOptions) ([]*domain.UntranslatablePlaceholder, error) {
 	// 验证项目是否存在
 	_, err := s.projectRepo.GetByID(ctx, projectID)
 	if err != nil {
-		return domain.ErrProjectNotFound
+		return nil, domain.ErrProjectNotFound
 	}
 
 	switch format {
 	case "json":
-		return s.importFromJSON(ctx, projectID, data)
+		return s.importFromJSON(ctx, projectID, data, opts)
 	default:
-		return fmt.Errorf("unsupported format: %s", format)
+		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
 // importFromJSON 从JSON导入翻译
-func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint64, data []byte) error {
+func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint64, data []byte, opts domain.ImportOptions) ([]*domain.UntranslatablePlaceholder, error) {
 	var rawData map[string]interface{}
 	if err := json.Unmarshal(data, &rawData); err != nil {
-		return fmt.Errorf("invalid JSON format: %w", err)
+		return nil, fmt.Errorf("invalid JSON format: %w", err)
 	}
 
 	// 获取所有语言
 	languages, err := s.languageRepo.GetAll(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 创建语言代码到ID的映射
@@ -473,6 +595,12 @@ func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint6
 	// 检测数据格式并转换
 	matrix := s.normalizeImportData(rawData)
 
+	// 将占位符转换为项目的规范语法
+	matrix, untranslatable, err := s.convertImportPlaceholders(ctx, projectID, matrix, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	for key, translations := range matrix {
 		for langCode, value := range translations {
 			if langID, exists := languageCodeToID[langCode]; exists {
@@ -487,10 +615,13 @@ func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint6
 	}
 
 	if len(inputs) == 0 {
-		return fmt.Errorf("no valid translations found in import data")
+		return nil, fmt.Errorf("no valid translations found in import data")
 	}
 
-	return s.CreateBatch(ctx, inputs)
+	if err := s.CreateBatch(ctx, inputs); err != nil {
+		return nil, err
+	}
+	return untranslatable, nil
 }
 
 // normalizeImportData 标准化导入数据格式
@@ -586,5 +717,5 @@ func isDuplicateKeyError(err error) bool {
 	return strings.Contains(errStr, "duplicate entry") ||
 		strings.Contains(errStr, "duplicate key") ||
 		strings.Contains(errStr, "unique constraint") ||
-		strings.Contains(errStr, "idx_translation_unique")
+		strings.Contains(errStr, "idx_translation_active_unique")
 }
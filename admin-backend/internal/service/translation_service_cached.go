@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"i18n-flow/internal/domain"
 	"strconv"
@@ -153,7 +152,16 @@ type MatrixCacheResult struct {
 }
 
 // GetMatrix 获取翻译矩阵（使用缓存）
+// 继承了基础项目的矩阵不缓存，否则基础项目的每次修改都要清除所有继承它的项目的缓存
 func (s *CachedTranslationService) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	inherits, err := s.translationService.hasBaseProjects(ctx, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if inherits {
+		return s.translationService.GetMatrix(ctx, projectID, limit, offset, keyword)
+	}
+
 	// 优化缓存键生成，区分搜索和非搜索查询
 	var cacheKey string
 	if keyword != "" {
@@ -174,7 +182,7 @@ func (s *CachedTranslationService) GetMatrix(ctx context.Context, projectID uint
 
 	// 尝试从缓存获取
 	var cachedResult MatrixCacheResult
-	err := s.cacheService.GetJSONWithEmptyCheck(ctx, cacheKey, &cachedResult)
+	err = s.cacheService.GetJSONWithEmptyCheck(ctx, cacheKey, &cachedResult)
 	if err == nil {
 		return cachedResult.Matrix, cachedResult.Total, nil
 	}
@@ -277,41 +285,98 @@ func (s *CachedTranslationService) DeleteBatch(ctx context.Context, ids []uint64
 }
 
 // Export 导出翻译
-func (s *CachedTranslationService) Export(ctx context.Context, projectID uint64, format string) ([]byte, error) {
+func (s *CachedTranslationService) Export(ctx context.Context, projectID uint64, format string, opts domain.ExportOptions) ([]byte, []*domain.UntranslatablePlaceholder, error) {
+	simpleMatrix, untranslatable, err := s.ExportMatrix(ctx, projectID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := marshalExport(simpleMatrix, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, untranslatable, nil
+}
+
+// ExportMatrix 导出翻译矩阵
+func (s *CachedTranslationService) ExportMatrix(ctx context.Context, projectID uint64, opts domain.ExportOptions) (map[string]map[string]string, []*domain.UntranslatablePlaceholder, error) {
 	// 使用缓存的矩阵数据
 	matrix, _, err := s.GetMatrix(ctx, projectID, -1, 0, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	simpleMatrix, err := s.translationService.applyBranch(ctx, projectID, toSimpleMatrix(matrix), opts.Branch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.translationService.applyExportOptions(ctx, projectID, simpleMatrix, opts)
+}
+
+// ExportDelta 导出增量变更，结果取决于 since，不缓存
+func (s *CachedTranslationService) ExportDelta(ctx context.Context, projectID, since uint64, opts domain.ExportOptions) (*domain.TranslationDelta, []*domain.UntranslatablePlaceholder, error) {
+	return s.translationService.ExportDelta(ctx, projectID, since, opts)
+}
+
+// Import 导入翻译（更新缓存）
+func (s *CachedTranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string, opts domain.ImportOptions) ([]*domain.UntranslatablePlaceholder, error) {
+	untranslatable, err := s.translationService.Import(ctx, projectID, data, format, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// 转换为简单格式 (key -> language -> value)
-	simpleMatrix := make(map[string]map[string]string)
-	for key, langs := range matrix {
-		simpleMatrix[key] = make(map[string]string)
-		for lang, cell := range langs {
-			simpleMatrix[key][lang] = cell.Value
-		}
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return untranslatable, nil
+}
+
+// PushKeys 推送新键（更新缓存）
+func (s *CachedTranslationService) PushKeys(ctx context.Context, params domain.PushKeysParams) (*domain.PushKeysResult, error) {
+	result, err := s.translationService.PushKeys(ctx, params)
+	if err != nil {
+		return nil, err
 	}
 
-	switch format {
-	case "json":
-		return json.MarshalIndent(simpleMatrix, "", "  ")
-	default:
-		return nil, fmt.Errorf("unsupported format: %s", format)
+	// 清除相关缓存
+	if len(result.Added) > 0 {
+		s.invalidateProjectCache(ctx, params.ProjectID)
 	}
+
+	return result, nil
 }
 
-// Import 导入翻译（更新缓存）
-func (s *CachedTranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string) error {
-	err := s.translationService.Import(ctx, projectID, data, format)
+// GetKeyTree 获取键树（不缓存，文件夹统计依赖实时数据）
+func (s *CachedTranslationService) GetKeyTree(ctx context.Context, projectID uint64, path string, limit, offset int) (*domain.KeyTree, error) {
+	return s.translationService.GetKeyTree(ctx, projectID, path, limit, offset)
+}
+
+// DeleteSubtree 删除文件夹下的所有键（更新缓存）
+func (s *CachedTranslationService) DeleteSubtree(ctx context.Context, projectID uint64, path string) (int64, error) {
+	deleted, err := s.translationService.DeleteSubtree(ctx, projectID, path)
 	if err != nil {
-		return err
+		return deleted, err
 	}
 
 	// 清除相关缓存
 	s.invalidateProjectCache(ctx, projectID)
 
-	return nil
+	return deleted, nil
+}
+
+// TagSubtree 为文件夹下的所有键添加标签
+func (s *CachedTranslationService) TagSubtree(ctx context.Context, projectID uint64, path string, tags []string, userID uint64) (int, error) {
+	return s.translationService.TagSubtree(ctx, projectID, path, tags, userID)
+}
+
+// ExportSubtree 导出文件夹下的翻译
+func (s *CachedTranslationService) ExportSubtree(ctx context.Context, projectID uint64, path string, format string, opts domain.ExportOptions) ([]byte, []*domain.UntranslatablePlaceholder, error) {
+	return s.translationService.ExportSubtree(ctx, projectID, path, format, opts)
+}
+
+// CheckWrites 检查写入是否违反字符串冻结或锁定（直接调用）
+func (s *CachedTranslationService) CheckWrites(ctx context.Context, projectID uint64, writes []domain.CellWrite) error {
+	return s.translationService.CheckWrites(ctx, projectID, writes)
 }
 
 // invalidateProjectCache 清除项目相关的所有缓存
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+)
+
+// ExportDelta 导出项目在修订号 since 之后变更和删除的单元格，导出选项与 ExportMatrix 相同但不支持分支
+// 占位符编号和伪本地化都以默认语言为准，因此按变更键的全部单元格转换后再挑出变更的单元格
+// 修订号只记录项目自身的写入，继承了基础项目的项目不支持增量同步
+func (s *TranslationService) ExportDelta(ctx context.Context, projectID, since uint64, opts domain.ExportOptions) (*domain.TranslationDelta, []*domain.UntranslatablePlaceholder, error) {
+	if opts.Branch != "" {
+		return nil, nil, domain.ErrDeltaWithBranch
+	}
+	inherits, err := s.hasBaseProjects(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inherits {
+		return nil, nil, domain.ErrInheritedRevision
+	}
+
+	changes, err := s.translationRepo.GetChangesSince(ctx, projectID, since)
+	if err != nil {
+		return nil, nil, err
+	}
+	if since > changes.Revision {
+		return nil, nil, domain.ErrRevisionAhead
+	}
+
+	keySet := make(map[string]bool, len(changes.Changed))
+	for keyName := range changes.Changed {
+		keySet[keyName] = true
+	}
+	for _, cell := range changes.Deleted {
+		keySet[cell.KeyName] = true
+	}
+	keyNames := make([]string, 0, len(keySet))
+	for keyName := range keySet {
+		keyNames = append(keyNames, keyName)
+	}
+
+	cells, err := s.translationRepo.GetCellsByKeys(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	exported, untranslatable, err := s.applyExportOptions(ctx, projectID, toSimpleMatrix(cells), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pseudoLocale := ""
+	if opts.Pseudo != nil {
+		pseudoLocale = opts.Pseudo.Locale()
+	}
+	return BuildTranslationDelta(since, changes, exported, pseudoLocale), untranslatable, nil
+}
+
+// BuildTranslationDelta 从按变更键导出的译文（键名 -> 语言代码 -> 译文）中挑出变更的单元格
+// pseudoLocale 不为空时，伪本地化语言随键的任一变更一起下发，键不再有伪本地化译文时下发删除
+// 删除的单元格按键名、语言代码排序
+func BuildTranslationDelta(since uint64, changes *domain.TranslationChanges, exported map[string]map[string]string, pseudoLocale string) *domain.TranslationDelta {
+	delta := &domain.TranslationDelta{
+		Since:    since,
+		Revision: changes.Revision,
+		Changed:  make(map[string]map[string]string),
+		Deleted:  append([]domain.TranslationCellRef{}, changes.Deleted...),
+	}
+	setChanged := func(keyName, code, value string) {
+		if delta.Changed[keyName] == nil {
+			delta.Changed[keyName] = make(map[string]string)
+		}
+		delta.Changed[keyName][code] = value
+	}
+
+	for keyName, cells := range changes.Changed {
+		for code := range cells {
+			if value, ok := exported[keyName][code]; ok {
+				setChanged(keyName, code, value)
+			}
+		}
+	}
+
+	if pseudoLocale != "" {
+		keySet := make(map[string]bool, len(changes.Changed))
+		for keyName := range changes.Changed {
+			keySet[keyName] = true
+		}
+		for _, cell := range changes.Deleted {
+			keySet[cell.KeyName] = true
+		}
+		for keyName := range keySet {
+			if value, ok := exported[keyName][pseudoLocale]; ok {
+				setChanged(keyName, pseudoLocale, value)
+			} else {
+				delta.Deleted = append(delta.Deleted, domain.TranslationCellRef{KeyName: keyName, LanguageCode: pseudoLocale})
+			}
+		}
+	}
+
+	sort.Slice(delta.Deleted, func(i, j int) bool {
+		if delta.Deleted[i].KeyName != delta.Deleted[j].KeyName {
+			return delta.Deleted[i].KeyName < delta.Deleted[j].KeyName
+		}
+		return delta.Deleted[i].LanguageCode < delta.Deleted[j].LanguageCode
+	})
+	return delta
+}
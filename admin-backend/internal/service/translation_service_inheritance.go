@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"strings"
+)
+
+// inheritanceChain 获取项目的翻译查找顺序，第一个为项目本身
+func (s *TranslationService) inheritanceChain(ctx context.Context, projectID uint64) ([]uint64, error) {
+	bases, err := loadBaseGraph(ctx, s.projectBaseRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveInheritance(projectID, bases), nil
+}
+
+// hasBaseProjects 判断项目是否继承了基础项目
+func (s *TranslationService) hasBaseProjects(ctx context.Context, projectID uint64) (bool, error) {
+	bases, err := s.projectBaseRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+	return len(bases) > 0, nil
+}
+
+// inheritedMatrix 获取合并了基础项目的翻译矩阵，键名按字母排序后分页
+func (s *TranslationService) inheritedMatrix(ctx context.Context, chain []uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	keyNames, err := s.translationRepo.GetKeyNames(ctx, chain, keyword)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int64(len(keyNames))
+
+	if limit > 0 && offset >= 0 {
+		if offset >= len(keyNames) {
+			return make(map[string]map[string]domain.TranslationCell), total, nil
+		}
+		end := offset + limit
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		keyNames = keyNames[offset:end]
+	}
+
+	matrix, err := s.inheritedCells(ctx, chain, keyNames)
+	if err != nil {
+		return nil, 0, err
+	}
+	return matrix, total, nil
+}
+
+// inheritedCells 按查找顺序读取并合并指定键的单元格
+func (s *TranslationService) inheritedCells(ctx context.Context, chain []uint64, keyNames []string) (map[string]map[string]domain.TranslationCell, error) {
+	cells := make(map[uint64]map[string]map[string]domain.TranslationCell, len(chain))
+	for _, projectID := range chain {
+		projectCells, err := s.translationRepo.GetCellsByKeys(ctx, projectID, keyNames)
+		if err != nil {
+			return nil, err
+		}
+		cells[projectID] = projectCells
+	}
+	return MergeInheritedCells(chain, cells), nil
+}
+
+// overrideInherited 在项目中为继承的翻译创建本地覆盖，基础项目的翻译保持不变
+func (s *TranslationService) overrideInherited(ctx context.Context, inherited *domain.Translation, input domain.TranslationInput, userID uint64) (*domain.Translation, error) {
+	override := domain.TranslationInput{
+		ProjectID:  input.ProjectID,
+		KeyName:    inherited.KeyName,
+		Context:    inherited.Context,
+		LanguageID: inherited.LanguageID,
+		Value:      inherited.Value,
+	}
+	if keyName := strings.TrimSpace(input.KeyName); keyName != "" {
+		override.KeyName = keyName
+	}
+	if input.Context != "" {
+		override.Context = input.Context
+	}
+	if input.LanguageID != 0 {
+		override.LanguageID = input.LanguageID
+	}
+	if input.Value != "" {
+		override.Value = input.Value
+	}
+	return s.Create(ctx, override, userID)
+}
+
+// loadBaseGraph 读取项目及其所有祖先项目的直接基础项目（项目ID -> 按优先级排列的基础项目ID）
+func loadBaseGraph(ctx context.Context, repo domain.ProjectBaseRepository, projectID uint64) (map[uint64][]uint64, error) {
+	bases := make(map[uint64][]uint64)
+	pending := []uint64{projectID}
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+		if _, loaded := bases[id]; loaded {
+			continue
+		}
+		links, err := repo.ListByProject(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		bases[id] = make([]uint64, 0, len(links))
+		for _, link := range links {
+			bases[id] = append(bases[id], link.BaseProjectID)
+			pending = append(pending, link.BaseProjectID)
+		}
+	}
+	return bases, nil
+}
+
+// ResolveInheritance 计算项目的翻译查找顺序：项目本身优先，然后依次是每个基础项目及其继承的项目（深度优先）
+// 同一个项目只出现一次，继承关系中的环被忽略
+func ResolveInheritance(projectID uint64, bases map[uint64][]uint64) []uint64 {
+	chain := make([]uint64, 0, len(bases))
+	visited := make(map[uint64]bool, len(bases))
+	var visit func(id uint64)
+	visit = func(id uint64) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		chain = append(chain, id)
+		for _, baseID := range bases[id] {
+			visit(baseID)
+		}
+	}
+	visit(projectID)
+	return chain
+}
+
+// MergeInheritedCells 按查找顺序合并各项目的单元格（项目ID -> 键名 -> 语言代码 -> 单元格）
+// 每个单元格取查找顺序中第一个有该单元格的项目，来自基础项目的单元格记录 InheritedFrom
+func MergeInheritedCells(chain []uint64, cells map[uint64]map[string]map[string]domain.TranslationCell) map[string]map[string]domain.TranslationCell {
+	merged := make(map[string]map[string]domain.TranslationCell)
+	for i, projectID := range chain {
+		for keyName, languageCells := range cells[projectID] {
+			if merged[keyName] == nil {
+				merged[keyName] = make(map[string]domain.TranslationCell, len(languageCells))
+			}
+			for code, cell := range languageCells {
+				if _, exists := merged[keyName][code]; exists {
+					continue
+				}
+				if i > 0 {
+					cell.InheritedFrom = projectID
+				}
+				merged[keyName][code] = cell
+			}
+		}
+	}
+	return merged
+}
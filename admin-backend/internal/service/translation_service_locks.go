@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"strings"
+)
+
+// checkWrites 检查对项目的写入是否违反字符串冻结或锁定，所有写入路径在写入前调用
+// 项目未冻结且没有锁定时不查询现有翻译
+func (s *TranslationService) checkWrites(ctx context.Context, projectID uint64, writes []domain.CellWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return domain.ErrProjectNotFound
+	}
+	locks, err := s.lockRepo.ListByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if !project.StringFreeze && len(locks) == 0 {
+		return nil
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	restrictions := domain.WriteRestrictions{StringFreeze: project.StringFreeze, Locks: locks}
+	languageIDs := make(map[string]uint64, len(languages))
+	for _, language := range languages {
+		languageIDs[language.Code] = language.ID
+		if language.IsDefault {
+			restrictions.SourceLanguageID = language.ID
+		}
+	}
+
+	keyNames := make([]string, 0, len(writes))
+	seen := make(map[string]bool, len(writes))
+	for _, write := range writes {
+		if !seen[write.KeyName] {
+			seen[write.KeyName] = true
+			keyNames = append(keyNames, write.KeyName)
+		}
+	}
+	// 继承的键视为已存在，写入与继承的值相同的值不算修改
+	chain, err := s.inheritanceChain(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	cells, err := s.inheritedCells(ctx, chain, keyNames)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]map[uint64]string, len(cells))
+	for keyName, languageCells := range cells {
+		existing[keyName] = make(map[uint64]string, len(languageCells))
+		for code, cell := range languageCells {
+			existing[keyName][languageIDs[code]] = cell.Value
+		}
+	}
+	return CheckCellWrites(restrictions, existing, writes)
+}
+
+// CheckWrites 检查不经过翻译服务的写入是否违反字符串冻结或锁定，如从回收站恢复翻译
+func (s *TranslationService) CheckWrites(ctx context.Context, projectID uint64, writes []domain.CellWrite) error {
+	return s.checkWrites(ctx, projectID, writes)
+}
+
+// checkInputs 按项目检查一批写入
+func (s *TranslationService) checkInputs(ctx context.Context, inputs []domain.TranslationInput) error {
+	writes := make(map[uint64][]domain.CellWrite)
+	projectIDs := make([]uint64, 0)
+	for _, input := range inputs {
+		if _, ok := writes[input.ProjectID]; !ok {
+			projectIDs = append(projectIDs, input.ProjectID)
+		}
+		writes[input.ProjectID] = append(writes[input.ProjectID], domain.CellWrite{
+			KeyName:    strings.TrimSpace(input.KeyName),
+			LanguageID: input.LanguageID,
+			Value:      strings.TrimSpace(input.Value),
+		})
+	}
+	for _, projectID := range projectIDs {
+		if err := s.checkWrites(ctx, projectID, writes[projectID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDeletes 按项目检查删除一批翻译
+func (s *TranslationService) checkDeletes(ctx context.Context, translations []*domain.Translation) error {
+	writes := make(map[uint64][]domain.CellWrite)
+	projectIDs := make([]uint64, 0)
+	for _, translation := range translations {
+		if _, ok := writes[translation.ProjectID]; !ok {
+			projectIDs = append(projectIDs, translation.ProjectID)
+		}
+		writes[translation.ProjectID] = append(writes[translation.ProjectID], domain.CellWrite{
+			KeyName:    translation.KeyName,
+			LanguageID: translation.LanguageID,
+			Delete:     true,
+		})
+	}
+	for _, projectID := range projectIDs {
+		if err := s.checkWrites(ctx, projectID, writes[projectID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUpdate 检查对单条翻译的修改，改到其他项目、键或语言视为删除原单元格并写入新单元格
+// 冻结时即使目标键已存在也不能修改键名
+func (s *TranslationService) checkUpdate(ctx context.Context, before, after *domain.Translation) error {
+	write := domain.CellWrite{KeyName: after.KeyName, LanguageID: after.LanguageID, Value: after.Value}
+	if before.ProjectID == after.ProjectID && before.KeyName == after.KeyName && before.LanguageID == after.LanguageID {
+		return s.checkWrites(ctx, after.ProjectID, []domain.CellWrite{write})
+	}
+	if before.KeyName != after.KeyName && before.Project.StringFreeze {
+		return writeViolation(domain.ErrStringFrozen, domain.CellWrite{KeyName: before.KeyName, LanguageID: before.LanguageID})
+	}
+	if err := s.checkDeletes(ctx, []*domain.Translation{before}); err != nil {
+		return err
+	}
+	return s.checkWrites(ctx, after.ProjectID, []domain.CellWrite{write})
+}
+
+// CheckCellWrites 检查写入是否违反项目的字符串冻结或锁定，existing 为写入涉及的键当前的有效单元格（键名 -> 语言ID -> 译文）
+// 写入与现有值相同的值或删除不存在的单元格不算修改，因此重新导入未修改的文件不受影响；
+// 锁定的单元格不能修改，冻结时不能为不存在的键写入，也不能修改或删除默认语言的单元格
+func CheckCellWrites(restrictions domain.WriteRestrictions, existing map[string]map[uint64]string, writes []domain.CellWrite) error {
+	for _, write := range writes {
+		current, exists := existing[write.KeyName][write.LanguageID]
+		if (write.Delete && !exists) || (!write.Delete && exists && current == write.Value) {
+			continue
+		}
+
+		if isLocked(restrictions.Locks, write.KeyName, write.LanguageID) {
+			return writeViolation(domain.ErrTranslationLocked, write)
+		}
+		if !restrictions.StringFreeze {
+			continue
+		}
+		if len(existing[write.KeyName]) == 0 || write.LanguageID == restrictions.SourceLanguageID {
+			return writeViolation(domain.ErrStringFrozen, write)
+		}
+	}
+	return nil
+}
+
+// isLocked 判断单元格是否被锁定
+func isLocked(locks []*domain.TranslationLock, keyName string, languageID uint64) bool {
+	for _, lock := range locks {
+		if (lock.KeyName == "" || lock.KeyName == keyName) && (lock.LanguageID == 0 || lock.LanguageID == languageID) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeViolation 在错误详情中注明被拒绝的单元格
+func writeViolation(base *domain.AppError, write domain.CellWrite) error {
+	return domain.NewAppErrorWithDetails(
+		base.Type,
+		base.Code,
+		base.Message,
+		fmt.Sprintf("键名: %s, 语言ID: %d", write.KeyName, write.LanguageID),
+	)
+}
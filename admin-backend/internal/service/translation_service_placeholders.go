@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// projectPlaceholderFormat 获取项目的占位符规范语法，未设置时为 brace
+func projectPlaceholderFormat(project *domain.Project) string {
+	if project == nil || project.PlaceholderFormat == "" {
+		return domain.PlaceholderFormatBrace
+	}
+	return project.PlaceholderFormat
+}
+
+// placeholderContext 获取项目的占位符规范语法和默认语言代码
+// 未设置默认语言时返回空语言代码，此时每条翻译以自身作为参考文本
+func (s *TranslationService) placeholderContext(ctx context.Context, projectID uint64) (string, string, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return "", "", domain.ErrProjectNotFound
+	}
+
+	defaultLanguage := ""
+	if language, err := s.languageRepo.GetDefault(ctx); err == nil && language != nil {
+		defaultLanguage = language.Code
+	}
+	return projectPlaceholderFormat(project), defaultLanguage, nil
+}
+
+// applyExportOptions 按导出选项附加伪本地化语言、转换占位符语法，并按需加上页内编辑标记
+func (s *TranslationService) applyExportOptions(
+	ctx context.Context,
+	projectID uint64,
+	matrix map[string]map[string]string,
+	opts domain.ExportOptions,
+) (map[string]map[string]string, []*domain.UntranslatablePlaceholder, error) {
+	if opts.Pseudo != nil {
+		if err := s.addPseudoLocale(ctx, projectID, matrix, opts.Pseudo); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	converted, untranslatable, err := s.convertExportPlaceholders(ctx, projectID, matrix, opts.Placeholders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.InContext {
+		for key, languages := range converted {
+			for language, value := range languages {
+				languages[language] = internal_utils.MarkInContext(value, projectID, key)
+			}
+		}
+	}
+	return converted, untranslatable, nil
+}
+
+// convertExportPlaceholders 将翻译中的占位符由规范语法转换为目标语法，目标语法为空时不转换
+// 参数编号以默认语言的翻译为准，返回的无法转换的占位符按键名、语言排序
+func (s *TranslationService) convertExportPlaceholders(
+	ctx context.Context,
+	projectID uint64,
+	matrix map[string]map[string]string,
+	placeholders string,
+) (map[string]map[string]string, []*domain.UntranslatablePlaceholder, error) {
+	if placeholders == "" {
+		return matrix, nil, nil
+	}
+	if !internal_utils.IsPlaceholderSyntax(placeholders) {
+		return nil, nil, domain.ErrInvalidPlaceholderFormat
+	}
+
+	canonical, defaultLanguage, err := s.placeholderContext(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if canonical == placeholders {
+		return matrix, nil, nil
+	}
+
+	converted := make(map[string]map[string]string, len(matrix))
+	untranslatable := make([]*domain.UntranslatablePlaceholder, 0)
+	for key, languages := range matrix {
+		reference, hasReference := languages[defaultLanguage]
+		converted[key] = make(map[string]string, len(languages))
+		for language, value := range languages {
+			if !hasReference {
+				reference = value
+			}
+			signature := internal_utils.PlaceholderSignatureOf(reference, canonical)
+			result, issues := internal_utils.ConvertPlaceholders(value, canonical, placeholders, signature)
+			converted[key][language] = result
+			untranslatable = appendUntranslatable(untranslatable, key, language, issues)
+		}
+	}
+
+	sortUntranslatable(untranslatable)
+	return converted, untranslatable, nil
+}
+
+// addPseudoLocale 由默认语言的翻译生成伪本地化语言并加入矩阵，占位符保持项目的规范语法
+// 没有默认语言翻译的键不生成
+func (s *TranslationService) addPseudoLocale(
+	ctx context.Context,
+	projectID uint64,
+	matrix map[string]map[string]string,
+	opts *domain.PseudoLocaleOptions,
+) error {
+	if opts.Expansion < 0 || opts.Expansion > domain.MaxPseudoExpansion {
+		return domain.ErrInvalidPseudoOptions
+	}
+
+	canonical, defaultLanguage, err := s.placeholderContext(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if defaultLanguage == "" {
+		return domain.ErrPseudoNoSource
+	}
+
+	pseudo := internal_utils.PseudoOptions{
+		Expansion: opts.Expansion,
+		Brackets:  opts.Brackets,
+		RTL:       opts.RTL,
+	}
+	locale := opts.Locale()
+	for _, languages := range matrix {
+		if source, ok := languages[defaultLanguage]; ok && source != "" {
+			languages[locale] = internal_utils.Pseudolocalize(source, canonical, pseudo)
+		}
+	}
+	return nil
+}
+
+// convertImportPlaceholders 将导入数据中的占位符由导入语法转换为项目的规范语法
+// 已有默认语言翻译的键以数据库中的翻译为参考，否则以导入数据中的默认语言翻译为参考
+func (s *TranslationService) convertImportPlaceholders(
+	ctx context.Context,
+	projectID uint64,
+	matrix map[string]map[string]string,
+	opts domain.ImportOptions,
+) (map[string]map[string]string, []*domain.UntranslatablePlaceholder, error) {
+	if opts.Placeholders == "" {
+		return matrix, nil, nil
+	}
+	if !internal_utils.IsPlaceholderSyntax(opts.Placeholders) {
+		return nil, nil, domain.ErrInvalidPlaceholderFormat
+	}
+
+	canonical, defaultLanguage, err := s.placeholderContext(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if canonical == opts.Placeholders {
+		return matrix, nil, nil
+	}
+
+	keyNames := make([]string, 0, len(matrix))
+	for key := range matrix {
+		keyNames = append(keyNames, key)
+	}
+	existing := make(map[string]map[string]domain.TranslationCell)
+	if defaultLanguage != "" && len(keyNames) > 0 {
+		existing, err = s.translationRepo.GetCellsByKeys(ctx, projectID, keyNames)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	converted := make(map[string]map[string]string, len(matrix))
+	untranslatable := make([]*domain.UntranslatablePlaceholder, 0)
+	for key, languages := range matrix {
+		var signature *internal_utils.PlaceholderSignature
+		if cell, ok := existing[key][defaultLanguage]; ok && cell.Value != "" {
+			value := internal_utils.PlaceholderSignatureOf(cell.Value, canonical)
+			signature = &value
+		} else if reference, ok := languages[defaultLanguage]; ok {
+			value := internal_utils.PlaceholderSignatureOf(reference, opts.Placeholders)
+			signature = &value
+		}
+
+		converted[key] = make(map[string]string, len(languages))
+		for language, value := range languages {
+			current := signature
+			if current == nil {
+				own := internal_utils.PlaceholderSignatureOf(value, opts.Placeholders)
+				current = &own
+			}
+			result, issues := internal_utils.ConvertPlaceholders(value, opts.Placeholders, canonical, *current)
+			converted[key][language] = result
+			untranslatable = appendUntranslatable(untranslatable, key, language, issues)
+		}
+	}
+
+	sortUntranslatable(untranslatable)
+	return converted, untranslatable, nil
+}
+
+// appendUntranslatable 记录一条翻译中无法转换的占位符
+func appendUntranslatable(list []*domain.UntranslatablePlaceholder, key, language string, placeholders []string) []*domain.UntranslatablePlaceholder {
+	for _, placeholder := range placeholders {
+		list = append(list, &domain.UntranslatablePlaceholder{
+			KeyName:     key,
+			Language:    language,
+			Placeholder: placeholder,
+		})
+	}
+	return list
+}
+
+// sortUntranslatable 按键名、语言排序，同一翻译中保持出现顺序
+func sortUntranslatable(list []*domain.UntranslatablePlaceholder) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].KeyName != list[j].KeyName {
+			return list[i].KeyName < list[j].KeyName
+		}
+		return list[i].Language < list[j].Language
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"strings"
+)
+
+// PushKeys 为项目添加新键，每个新键在所有语言下各创建一条翻译
+// 已存在的键（包括从基础项目继承的键）保持不变；某个键在任一语言下创建成功即视为已添加
+func (s *TranslationService) PushKeys(ctx context.Context, params domain.PushKeysParams) (*domain.PushKeysResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(languages) == 0 {
+		return nil, domain.ErrNoLanguages
+	}
+
+	// 默认语言，未标记时使用第一个语言
+	defaultLanguage := languages[0]
+	for _, language := range languages {
+		if language.IsDefault {
+			defaultLanguage = language
+			break
+		}
+	}
+
+	matrix, _, err := s.GetMatrix(ctx, params.ProjectID, -1, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 去掉空键、已存在的键和同一次推送中重复的键
+	result := &domain.PushKeysResult{
+		Added:   []string{},
+		Existed: []string{},
+		Failed:  []string{},
+	}
+	newKeys := make([]string, 0, len(params.Keys))
+	for _, key := range params.Keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, exists := matrix[key]; exists {
+			result.Existed = append(result.Existed, key)
+			continue
+		}
+		newKeys = append(newKeys, key)
+		matrix[key] = nil
+	}
+
+	// 新键的所有单元格先整体检查字符串冻结和锁定，避免部分键被拒绝后静默计入失败
+	inputs := make(map[string][]domain.TranslationInput, len(newKeys))
+	writes := make([]domain.CellWrite, 0, len(newKeys)*len(languages))
+	for _, key := range newKeys {
+		for _, language := range languages {
+			var value string
+			if params.Translations != nil {
+				value = params.Translations[language.Code][key]
+			} else if language.ID == defaultLanguage.ID {
+				value = params.Defaults[key]
+			}
+
+			inputs[key] = append(inputs[key], domain.TranslationInput{
+				ProjectID:  params.ProjectID,
+				KeyName:    key,
+				LanguageID: language.ID,
+				Value:      value,
+			})
+			writes = append(writes, domain.CellWrite{KeyName: key, LanguageID: language.ID, Value: strings.TrimSpace(value)})
+		}
+	}
+	if err := s.checkWrites(ctx, params.ProjectID, writes); err != nil {
+		return nil, err
+	}
+
+	for _, key := range newKeys {
+		keyAdded := false
+		for _, input := range inputs[key] {
+			if _, err := s.Create(ctx, input, params.UserID); err == nil {
+				keyAdded = true
+			}
+		}
+
+		if keyAdded {
+			result.Added = append(result.Added, key)
+		} else {
+			result.Failed = append(result.Failed, key)
+		}
+	}
+
+	return result, nil
+}
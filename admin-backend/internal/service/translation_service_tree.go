@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+)
+
+// GetKeyTree 获取键树中指定路径下的子文件夹和键
+// 键名按点号分隔为层级，例如 common.buttons.save 位于 common/buttons 文件夹下
+func (s *TranslationService) GetKeyTree(ctx context.Context, projectID uint64, path string, limit, offset int) (*domain.KeyTree, error) {
+	// 验证项目是否存在
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	path = normalizeKeyPath(path)
+	stats, err := s.translationRepo.GetKeyLanguageStats(ctx, projectID, keyPathPrefix(path))
+	if err != nil {
+		return nil, err
+	}
+
+	languageCodes, err := s.activeLanguageCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, directKeys := buildKeyTree(path, stats, languageCodes)
+
+	// 当前层级的键分页
+	tree.TotalKeys = int64(len(directKeys))
+	if limit > 0 && offset >= 0 {
+		if offset > len(directKeys) {
+			offset = len(directKeys)
+		}
+		end := offset + limit
+		if end > len(directKeys) {
+			end = len(directKeys)
+		}
+		directKeys = directKeys[offset:end]
+	}
+
+	cells, err := s.translationRepo.GetCellsByKeys(ctx, projectID, directKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.keyTagRepo.GetByProjectAndKeys(ctx, projectID, directKeys)
+	if err != nil {
+		return nil, err
+	}
+	tagsByKey := make(map[string][]string)
+	for _, tag := range tags {
+		tagsByKey[tag.KeyName] = append(tagsByKey[tag.KeyName], tag.Tag)
+	}
+
+	tree.Keys = make([]*domain.KeyTreeKey, 0, len(directKeys))
+	for _, keyName := range directKeys {
+		translations := cells[keyName]
+		if translations == nil {
+			translations = make(map[string]domain.TranslationCell)
+		}
+		keyTags := tagsByKey[keyName]
+		if keyTags == nil {
+			keyTags = []string{}
+		}
+		tree.Keys = append(tree.Keys, &domain.KeyTreeKey{
+			Name:         strings.TrimPrefix(keyName, keyPathPrefix(path)),
+			KeyName:      keyName,
+			Tags:         keyTags,
+			Translations: translations,
+		})
+	}
+
+	return tree, nil
+}
+
+// DeleteSubtree 删除指定文件夹下的所有键（软删除）
+func (s *TranslationService) DeleteSubtree(ctx context.Context, projectID uint64, path string) (int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return 0, domain.ErrProjectNotFound
+	}
+
+	path = normalizeKeyPath(path)
+	if path == "" {
+		return 0, domain.ErrInvalidKeyPath
+	}
+
+	if err := s.checkSubtreeDelete(ctx, projectID, path); err != nil {
+		return 0, err
+	}
+
+	deleted, err := s.translationRepo.DeleteByKeyPrefix(ctx, projectID, keyPathPrefix(path))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.keyTagRepo.DeleteByKeyPrefix(ctx, projectID, keyPathPrefix(path)); err != nil {
+		return deleted, err
+	}
+
+	return deleted, nil
+}
+
+// TagSubtree 为指定文件夹下的所有键添加标签，返回被标记的键数
+func (s *TranslationService) TagSubtree(ctx context.Context, projectID uint64, path string, tags []string, userID uint64) (int, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return 0, domain.ErrProjectNotFound
+	}
+
+	path = normalizeKeyPath(path)
+	if path == "" {
+		return 0, domain.ErrInvalidKeyPath
+	}
+
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		return 0, domain.ErrInvalidInput
+	}
+
+	keyNames, err := s.subtreeKeyNames(ctx, projectID, path)
+	if err != nil {
+		return 0, err
+	}
+
+	keyTags := make([]*domain.KeyTag, 0, len(keyNames)*len(tags))
+	for _, keyName := range keyNames {
+		for _, tag := range tags {
+			keyTags = append(keyTags, &domain.KeyTag{
+				ProjectID: projectID,
+				KeyName:   keyName,
+				Tag:       tag,
+				CreatedBy: userID,
+			})
+		}
+	}
+
+	if err := s.keyTagRepo.CreateBatch(ctx, keyTags); err != nil {
+		return 0, err
+	}
+
+	return len(keyNames), nil
+}
+
+// ExportSubtree 导出指定文件夹下的翻译
+func (s *TranslationService) ExportSubtree(ctx context.Context, projectID uint64, path string, format string, opts domain.ExportOptions) ([]byte, []*domain.UntranslatablePlaceholder, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, nil, domain.ErrProjectNotFound
+	}
+
+	keyNames, err := s.subtreeKeyNames(ctx, projectID, normalizeKeyPath(path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cells, err := s.translationRepo.GetCellsByKeys(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	simpleMatrix, untranslatable, err := s.applyExportOptions(ctx, projectID, toSimpleMatrix(cells), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := marshalExport(simpleMatrix, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, untranslatable, nil
+}
+
+// subtreeKeyNames 获取指定路径下（含子文件夹）的所有键名
+func (s *TranslationService) subtreeKeyNames(ctx context.Context, projectID uint64, path string) ([]string, error) {
+	stats, err := s.translationRepo.GetKeyLanguageStats(ctx, projectID, keyPathPrefix(path))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	keyNames := make([]string, 0)
+	for _, stat := range stats {
+		if !seen[stat.KeyName] {
+			seen[stat.KeyName] = true
+			keyNames = append(keyNames, stat.KeyName)
+		}
+	}
+	return keyNames, nil
+}
+
+// checkSubtreeDelete 检查删除文件夹下所有单元格是否违反字符串冻结或锁定
+func (s *TranslationService) checkSubtreeDelete(ctx context.Context, projectID uint64, path string) error {
+	keyNames, err := s.subtreeKeyNames(ctx, projectID, path)
+	if err != nil {
+		return err
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	writes := make([]domain.CellWrite, 0, len(keyNames)*len(languages))
+	for _, keyName := range keyNames {
+		for _, language := range languages {
+			writes = append(writes, domain.CellWrite{KeyName: keyName, LanguageID: language.ID, Delete: true})
+		}
+	}
+	return s.checkWrites(ctx, projectID, writes)
+}
+
+// activeLanguageCodes 获取所有启用语言的代码
+func (s *TranslationService) activeLanguageCodes(ctx context.Context) ([]string, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if lang.Status == "" || lang.Status == "active" {
+			codes = append(codes, lang.Code)
+		}
+	}
+	return codes, nil
+}
+
+// buildKeyTree 根据键的翻译状态构建指定路径下的一层键树
+// 返回的树不包含 Keys，直接位于该路径下的键名按字母序单独返回
+func buildKeyTree(path string, stats []domain.KeyLanguageStat, languageCodes []string) (*domain.KeyTree, []string) {
+	prefix := keyPathPrefix(path)
+
+	// 聚合每个键已翻译的语言
+	translatedByKey := make(map[string]map[string]bool)
+	for _, stat := range stats {
+		if !strings.HasPrefix(stat.KeyName, prefix) {
+			continue
+		}
+		if translatedByKey[stat.KeyName] == nil {
+			translatedByKey[stat.KeyName] = make(map[string]bool)
+		}
+		if stat.Translated {
+			translatedByKey[stat.KeyName][stat.LanguageCode] = true
+		}
+	}
+
+	folders := make(map[string]*domain.KeyTreeFolder)
+	folderTranslated := make(map[string]map[string]int)
+	totalTranslated := make(map[string]int)
+	directKeys := make([]string, 0)
+
+	for keyName, translated := range translatedByKey {
+		for code := range translated {
+			totalTranslated[code]++
+		}
+
+		rel := strings.TrimPrefix(keyName, prefix)
+		idx := strings.Index(rel, domain.KeyTreeSeparator)
+		if idx < 0 {
+			directKeys = append(directKeys, keyName)
+			continue
+		}
+
+		name := rel[:idx]
+		folder, ok := folders[name]
+		if !ok {
+			folder = &domain.KeyTreeFolder{Name: name, Path: prefix + name}
+			folders[name] = folder
+			folderTranslated[name] = make(map[string]int)
+		}
+		folder.KeyCount++
+		for code := range translated {
+			folderTranslated[name][code]++
+		}
+	}
+
+	tree := &domain.KeyTree{
+		Path:       path,
+		Folders:    make([]*domain.KeyTreeFolder, 0, len(folders)),
+		Keys:       []*domain.KeyTreeKey{},
+		Completion: buildCompletion(languageCodes, totalTranslated, len(translatedByKey)),
+	}
+	for name, folder := range folders {
+		folder.Completion = buildCompletion(languageCodes, folderTranslated[name], folder.KeyCount)
+		tree.Folders = append(tree.Folders, folder)
+	}
+
+	sort.Slice(tree.Folders, func(i, j int) bool { return tree.Folders[i].Name < tree.Folders[j].Name })
+	sort.Strings(directKeys)
+
+	return tree, directKeys
+}
+
+// buildCompletion 计算各语言的完成度
+func buildCompletion(languageCodes []string, translated map[string]int, total int) map[string]domain.LanguageCompletion {
+	completion := make(map[string]domain.LanguageCompletion, len(languageCodes))
+	for _, code := range languageCodes {
+		c := domain.LanguageCompletion{Translated: translated[code], Total: total}
+		if total > 0 {
+			c.Percent = float64(c.Translated) * 100 / float64(total)
+		}
+		completion[code] = c
+	}
+	return completion
+}
+
+// normalizeKeyPath 规范化键路径，去除首尾空白和多余的分隔符
+func normalizeKeyPath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), domain.KeyTreeSeparator)
+}
+
+// keyPathPrefix 返回路径对应的键名前缀，根路径返回空字符串
+func keyPathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + domain.KeyTreeSeparator
+}
+
+// normalizeTags 去除空白、空值和重复的标签
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > 50 || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"i18n-flow/internal/domain"
+	"sort"
+	"strings"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// projectValueType 获取项目的翻译值类型，未设置时为 plain
+func projectValueType(project *domain.Project) string {
+	if project == nil || project.ValueType == "" {
+		return domain.ValueTypePlain
+	}
+	return project.ValueType
+}
+
+// validateValueType 按项目的值类型校验翻译值
+// 翻译内容不经过全局的 XSS 清理和输入改写，由这里保证只包含项目允许的标记
+func validateValueType(project *domain.Project, keyName, value string) error {
+	if err := internal_utils.ValidateValueType(projectValueType(project), value); err != nil {
+		return domain.NewAppErrorWithDetails(
+			domain.ErrorTypeValidation,
+			"INVALID_TRANSLATION_VALUE",
+			"翻译值不符合项目的值类型",
+			fmt.Sprintf("键名: %s, %s", keyName, err.Error()),
+		)
+	}
+	return nil
+}
+
+// validateInputsValueType 批量校验翻译值，projects 为输入涉及的项目
+func validateInputsValueType(projects []*domain.Project, inputs []domain.TranslationInput) error {
+	projectMap := make(map[uint64]*domain.Project, len(projects))
+	for _, project := range projects {
+		projectMap[project.ID] = project
+	}
+	for _, input := range inputs {
+		if err := validateValueType(projectMap[input.ProjectID], input.KeyName, input.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxValueTypeViolations 切换值类型失败时最多列出的不符合项
+const maxValueTypeViolations = 20
+
+// validateMatrixValueType 校验项目现有的翻译值是否符合新的值类型，列出不符合的键名和语言
+func validateMatrixValueType(valueType string, matrix map[string]map[string]domain.TranslationCell) error {
+	keyNames := make([]string, 0, len(matrix))
+	for keyName := range matrix {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	violations := make([]string, 0)
+	total := 0
+	for _, keyName := range keyNames {
+		languageCodes := make([]string, 0, len(matrix[keyName]))
+		for languageCode := range matrix[keyName] {
+			languageCodes = append(languageCodes, languageCode)
+		}
+		sort.Strings(languageCodes)
+		for _, languageCode := range languageCodes {
+			if err := internal_utils.ValidateValueType(valueType, matrix[keyName][languageCode].Value); err != nil {
+				total++
+				if len(violations) < maxValueTypeViolations {
+					violations = append(violations, fmt.Sprintf("%s [%s]: %s", keyName, languageCode, err.Error()))
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	details := strings.Join(violations, "; ")
+	if total > len(violations) {
+		details += fmt.Sprintf("; 另有 %d 处", total-len(violations))
+	}
+	return domain.NewAppErrorWithDetails(
+		domain.ErrorTypeValidation,
+		"VALUE_TYPE_VIOLATION",
+		fmt.Sprintf("%d 个现有翻译值不符合新的值类型", total),
+		details,
+	)
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"i18n-flow/internal/domain"
+	"strings"
+	"time"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// maxRenameAttempts 以新键名恢复时尝试生成不冲突键名的最大次数
+const maxRenameAttempts = 100
+
+// TrashService 回收站服务实现
+type TrashService struct {
+	trashRepo          domain.TrashRepository
+	translationRepo    domain.TranslationRepository
+	projectRepo        domain.ProjectRepository
+	translationService domain.TranslationService
+	storage            domain.FileStorage
+	retentionDays      int
+}
+
+// NewTrashService 创建回收站服务实例
+// retentionDays 为回收站保留天数，小于等于0表示不自动清理
+func NewTrashService(
+	trashRepo domain.TrashRepository,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	translationService domain.TranslationService,
+	storage domain.FileStorage,
+	retentionDays int,
+) *TrashService {
+	return &TrashService{
+		trashRepo:          trashRepo,
+		translationRepo:    translationRepo,
+		projectRepo:        projectRepo,
+		translationService: translationService,
+		storage:            storage,
+		retentionDays:      retentionDays,
+	}
+}
+
+// ListDeletedKeys 列出项目回收站中的翻译键
+func (s *TrashService) ListDeletedKeys(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*domain.TrashedKey, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+
+	limit, offset = normalizeLimitOffset(limit, offset)
+	keys, total, err := s.trashRepo.ListDeletedKeys(ctx, projectID, strings.TrimSpace(keyword), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 标记与现有键同名的条目，恢复时需要处理冲突
+	keyNames := make([]string, len(keys))
+	for i, key := range keys {
+		keyNames[i] = key.KeyName
+	}
+	existing, err := s.trashRepo.GetExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, 0, err
+	}
+	existingSet := toStringSet(existing)
+	for _, key := range keys {
+		key.Conflict = existingSet[key.KeyName]
+	}
+
+	return keys, total, nil
+}
+
+// RestoreKeys 恢复项目回收站中的翻译键
+// 同一键同一语言在回收站中有多条记录时，只恢复最近删除的一条
+func (s *TrashService) RestoreKeys(ctx context.Context, projectID uint64, params domain.RestoreKeysParams) (*domain.RestoreKeysResult, error) {
+	strategy := params.Strategy
+	if strategy == "" {
+		strategy = domain.RestoreStrategySkip
+	}
+	if strategy != domain.RestoreStrategySkip &&
+		strategy != domain.RestoreStrategyOverwrite &&
+		strategy != domain.RestoreStrategyRename {
+		return nil, domain.ErrInvalidRestoreOption
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	keyNames := normalizeKeyNames(params.KeyNames)
+	if len(keyNames) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	deleted, err := s.trashRepo.GetDeletedTranslations(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	if len(deleted) == 0 {
+		return nil, domain.ErrTrashItemNotFound
+	}
+
+	// 按键分组，每种语言只取最近删除的一条（结果已按删除时间倒序）
+	latestByKey := make(map[string][]*domain.Translation)
+	seen := make(map[string]bool)
+	for _, translation := range deleted {
+		slot := fmt.Sprintf("%s:%d", translation.KeyName, translation.LanguageID)
+		if seen[slot] {
+			continue
+		}
+		seen[slot] = true
+		latestByKey[translation.KeyName] = append(latestByKey[translation.KeyName], translation)
+	}
+
+	existing, err := s.trashRepo.GetExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	existingSet := toStringSet(existing)
+
+	result := &domain.RestoreKeysResult{
+		Restored: []string{},
+		Renamed:  map[string]string{},
+		Skipped:  []string{},
+	}
+	var restores []domain.TranslationRestore
+	var writes []domain.CellWrite
+	var displaceIDs []uint64
+
+	// 预留本次要恢复的原键名，避免新生成的键名与之冲突
+	reserved := make(map[string]bool)
+	for keyName := range latestByKey {
+		reserved[keyName] = true
+	}
+
+	for _, keyName := range keyNames {
+		translations, ok := latestByKey[keyName]
+		if !ok {
+			result.Skipped = append(result.Skipped, keyName)
+			continue
+		}
+
+		targetName := keyName
+		if existingSet[keyName] {
+			switch strategy {
+			case domain.RestoreStrategySkip:
+				result.Skipped = append(result.Skipped, keyName)
+				continue
+			case domain.RestoreStrategyOverwrite:
+				ids, err := s.activeTranslationIDs(ctx, projectID, keyName, translations)
+				if err != nil {
+					return nil, err
+				}
+				displaceIDs = append(displaceIDs, ids...)
+			case domain.RestoreStrategyRename:
+				targetName, err = s.availableKeyName(ctx, projectID, keyName, reserved)
+				if err != nil {
+					return nil, err
+				}
+				result.Renamed[keyName] = targetName
+			}
+		}
+
+		reserved[targetName] = true
+		for _, translation := range translations {
+			restores = append(restores, domain.TranslationRestore{ID: translation.ID, KeyName: targetName})
+			writes = append(writes, domain.CellWrite{KeyName: targetName, LanguageID: translation.LanguageID, Value: translation.Value})
+		}
+		result.Restored = append(result.Restored, keyName)
+	}
+
+	// 恢复与其他写入一样受字符串冻结和锁定限制
+	// 被覆盖的翻译与恢复的翻译是同一键同一语言的单元格，检查恢复的写入即可覆盖
+	if err := s.translationService.CheckWrites(ctx, projectID, writes); err != nil {
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreTranslations(ctx, restores, displaceIDs); err != nil {
+		return nil, err
+	}
+
+	result.Translations = len(restores)
+	return result, nil
+}
+
+// activeTranslationIDs 获取与待恢复翻译在同一语言上冲突的现有翻译ID
+func (s *TrashService) activeTranslationIDs(ctx context.Context, projectID uint64, keyName string, translations []*domain.Translation) ([]uint64, error) {
+	keys := make([]domain.TranslationKey, len(translations))
+	for i, translation := range translations {
+		keys[i] = domain.TranslationKey{
+			ProjectID:  projectID,
+			KeyName:    keyName,
+			LanguageID: translation.LanguageID,
+		}
+	}
+
+	active, err := s.translationRepo.GetByProjectKeyLanguages(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, len(active))
+	for i, translation := range active {
+		ids[i] = translation.ID
+	}
+	return ids, nil
+}
+
+// availableKeyName 生成项目中未被占用的新键名，如 key_restored、key_restored_2
+func (s *TrashService) availableKeyName(ctx context.Context, projectID uint64, keyName string, reserved map[string]bool) (string, error) {
+	for attempt := 1; attempt <= maxRenameAttempts; attempt++ {
+		candidate := keyName + domain.RestoredKeySuffix
+		if attempt > 1 {
+			candidate = fmt.Sprintf("%s_%d", candidate, attempt)
+		}
+		if reserved[candidate] {
+			continue
+		}
+
+		existing, err := s.trashRepo.GetExistingKeyNames(ctx, projectID, []string{candidate})
+		if err != nil {
+			return "", err
+		}
+		if len(existing) == 0 {
+			return candidate, nil
+		}
+	}
+
+	return "", domain.NewAppErrorWithDetails(
+		domain.ErrorTypeConflict,
+		"RESTORE_NAME_UNAVAILABLE",
+		"无法为恢复的键生成不冲突的键名",
+		fmt.Sprintf("键名: %s", keyName),
+	)
+}
+
+// PurgeKeys 彻底删除项目回收站中的翻译键，keyNames 为空时清空整个项目回收站
+func (s *TrashService) PurgeKeys(ctx context.Context, projectID uint64, keyNames []string) (int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return 0, domain.ErrProjectNotFound
+	}
+
+	return s.trashRepo.PurgeDeletedKeys(ctx, projectID, normalizeKeyNames(keyNames))
+}
+
+// ListDeletedProjects 列出回收站中的项目
+func (s *TrashService) ListDeletedProjects(ctx context.Context, keyword string, limit, offset int) ([]*domain.TrashedProject, int64, error) {
+	limit, offset = normalizeLimitOffset(limit, offset)
+	return s.trashRepo.ListDeletedProjects(ctx, strings.TrimSpace(keyword), limit, offset)
+}
+
+// RestoreProject 恢复回收站中的项目
+func (s *TrashService) RestoreProject(ctx context.Context, id uint64) (*domain.Project, error) {
+	if err := s.trashRepo.RestoreProject(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.GetByID(ctx, id)
+}
+
+// PurgeProject 彻底删除回收站中的项目及其文件
+func (s *TrashService) PurgeProject(ctx context.Context, id uint64) error {
+	files, err := s.trashRepo.PurgeProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.deleteFiles(ctx, id, files)
+	return nil
+}
+
+// deleteFiles 删除彻底删除的项目留下的文件，删除失败不影响结果，残留文件不再被引用
+func (s *TrashService) deleteFiles(ctx context.Context, projectID uint64, files *domain.PurgedProjectFiles) {
+	for _, key := range files.StorageKeys {
+		s.storage.Delete(ctx, key)
+	}
+	for _, hash := range files.BundleHashes {
+		for _, encoding := range []string{internal_utils.EncodingIdentity, internal_utils.EncodingGzip, internal_utils.EncodingBrotli} {
+			s.storage.Delete(ctx, bundleStorageKey(projectID, hash, encoding))
+		}
+	}
+}
+
+// PurgeExpired 彻底删除超过保留期的回收站内容
+func (s *TrashService) PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	result := &domain.TrashPurgeResult{}
+	if s.retentionDays <= 0 {
+		return result, nil
+	}
+
+	before := time.Now().AddDate(0, 0, -s.retentionDays)
+
+	translations, err := s.trashRepo.PurgeTranslationsDeletedBefore(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	result.Translations = translations
+
+	projectIDs, err := s.trashRepo.GetProjectIDsDeletedBefore(ctx, before)
+	if err != nil {
+		return result, err
+	}
+	for _, id := range projectIDs {
+		if err := s.PurgeProject(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrTrashItemNotFound) {
+				continue
+			}
+			return result, err
+		}
+		result.Projects++
+	}
+
+	return result, nil
+}
+
+// normalizeLimitOffset 规范化分页参数
+func normalizeLimitOffset(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// normalizeKeyNames 去除键名首尾空白并去重，保持原有顺序
+func normalizeKeyNames(keyNames []string) []string {
+	result := make([]string, 0, len(keyNames))
+	seen := make(map[string]bool)
+	for _, keyName := range keyNames {
+		keyName = strings.TrimSpace(keyName)
+		if keyName == "" || seen[keyName] {
+			continue
+		}
+		seen[keyName] = true
+		result = append(result, keyName)
+	}
+	return result
+}
+
+// toStringSet 将字符串切片转换为集合
+func toStringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
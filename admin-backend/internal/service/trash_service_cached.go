@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"i18n-flow/internal/domain"
+)
+
+// CachedTrashService 带缓存失效处理的回收站服务实现
+// 回收站数据本身不缓存，恢复和清理时清除受影响的翻译、项目缓存
+type CachedTrashService struct {
+	trashService *TrashService
+	cacheService domain.CacheService
+}
+
+// NewCachedTrashService 创建带缓存失效处理的回收站服务实例
+func NewCachedTrashService(
+	trashService *TrashService,
+	cacheService domain.CacheService,
+) *CachedTrashService {
+	return &CachedTrashService{
+		trashService: trashService,
+		cacheService: cacheService,
+	}
+}
+
+// ListDeletedKeys 列出项目回收站中的翻译键（不缓存）
+func (s *CachedTrashService) ListDeletedKeys(ctx context.Context, projectID uint64, keyword string, limit, offset int) ([]*domain.TrashedKey, int64, error) {
+	return s.trashService.ListDeletedKeys(ctx, projectID, keyword, limit, offset)
+}
+
+// RestoreKeys 恢复翻译键（清除翻译缓存）
+func (s *CachedTrashService) RestoreKeys(ctx context.Context, projectID uint64, params domain.RestoreKeysParams) (*domain.RestoreKeysResult, error) {
+	result, err := s.trashService.RestoreKeys(ctx, projectID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidateTranslationCaches(ctx, s.cacheService, projectID)
+
+	return result, nil
+}
+
+// PurgeKeys 彻底删除翻译键（回收站数据未缓存，无需清除缓存）
+func (s *CachedTrashService) PurgeKeys(ctx context.Context, projectID uint64, keyNames []string) (int64, error) {
+	return s.trashService.PurgeKeys(ctx, projectID, keyNames)
+}
+
+// ListDeletedProjects 列出回收站中的项目（不缓存）
+func (s *CachedTrashService) ListDeletedProjects(ctx context.Context, keyword string, limit, offset int) ([]*domain.TrashedProject, int64, error) {
+	return s.trashService.ListDeletedProjects(ctx, keyword, limit, offset)
+}
+
+// RestoreProject 恢复项目（清除项目缓存）
+func (s *CachedTrashService) RestoreProject(ctx context.Context, id uint64) (*domain.Project, error) {
+	project, err := s.trashService.RestoreProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProjectCache(ctx, id)
+
+	return project, nil
+}
+
+// PurgeProject 彻底删除项目（清除项目缓存）
+func (s *CachedTrashService) PurgeProject(ctx context.Context, id uint64) error {
+	if err := s.trashService.PurgeProject(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidateProjectCache(ctx, id)
+
+	return nil
+}
+
+// PurgeExpired 清理过期的回收站内容（清除仪表板缓存）
+func (s *CachedTrashService) PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	result, err := s.trashService.PurgeExpired(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if result.Projects > 0 {
+		s.cacheService.DeleteByPattern(ctx, s.cacheService.GetProjectsKey()+"*")
+	}
+	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+
+	return result, nil
+}
+
+// invalidateProjectCache 清除项目及项目列表缓存
+func (s *CachedTrashService) invalidateProjectCache(ctx context.Context, projectID uint64) {
+	s.cacheService.Delete(ctx, s.cacheService.GetProjectKey(projectID))
+	s.cacheService.DeleteByPattern(ctx, s.cacheService.GetProjectsKey()+"*")
+	invalidateTranslationCaches(ctx, s.cacheService, projectID)
+}
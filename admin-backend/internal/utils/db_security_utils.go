@@ -374,3 +374,9 @@ func (m *DBSecurityMonitor) GetLogger() logger.Interface {
 func (m *DBSecurityMonitor) ValidateQuery(sql string) error {
 	return m.whitelist.ValidateQuery(sql)
 }
+
+// EscapeLike 转义 LIKE 查询中的通配符，键名中常见的下划线不应被当作通配符
+func EscapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
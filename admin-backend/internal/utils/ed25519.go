@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ParseEd25519PrivateKey 解析 base64 编码的 Ed25519 私钥，支持 32 字节种子或 64 字节私钥
+func ParseEd25519PrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("Ed25519 私钥不是有效的 base64")
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		key := ed25519.NewKeyFromSeed(raw[:ed25519.SeedSize])
+		if !bytes.Equal(key.Public().(ed25519.PublicKey), raw[ed25519.SeedSize:]) {
+			return nil, errors.New("Ed25519 私钥与其公钥不匹配")
+		}
+		return key, nil
+	default:
+		return nil, errors.New("Ed25519 私钥长度应为 32 字节种子或 64 字节私钥")
+	}
+}
+
+// Ed25519KeyID 公钥的标识：公钥 SHA-256 摘要的前 8 字节
+func Ed25519KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
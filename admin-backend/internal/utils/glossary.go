@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 术语检查问题类型
+const (
+	GlossaryIssueMissingTerm    = "missing_term"     // 原文包含术语，译文未使用批准的译法
+	GlossaryIssueForbiddenTerm  = "forbidden_term"   // 译文使用了禁用的译法
+	GlossaryIssueDoNotTranslate = "do_not_translate" // 原文包含不可翻译的术语，译文中未原样保留
+)
+
+// GlossaryRule 单个术语在某种目标语言下的检查规则
+type GlossaryRule struct {
+	Term           string
+	CaseSensitive  bool     // 是否区分大小写，同时作用于原文、批准和禁用的译法
+	DoNotTranslate bool     // 译文中必须原样保留术语
+	Approved       []string // 批准的译法，满足其一即可
+	Forbidden      []string // 禁用的译法
+}
+
+// GlossaryIssue 术语检查发现的问题
+type GlossaryIssue struct {
+	Term     string
+	Type     string
+	Found    string   // 译文中出现的禁用译法
+	Expected []string // 期望的译法
+}
+
+// CheckGlossary 按术语规则检查译文
+// 术语按完整单词匹配（中日韩文字除外）：原文包含术语时译文须包含批准的译法，不可翻译的术语须原样出现；禁用的译法出现即报告
+func CheckGlossary(source, target string, rules []GlossaryRule) []GlossaryIssue {
+	var issues []GlossaryIssue
+	for _, rule := range rules {
+		if rule.Term != "" && ContainsTerm(source, rule.Term, rule.CaseSensitive) {
+			switch {
+			case rule.DoNotTranslate:
+				if !ContainsTerm(target, rule.Term, rule.CaseSensitive) {
+					issues = append(issues, GlossaryIssue{Term: rule.Term, Type: GlossaryIssueDoNotTranslate, Expected: []string{rule.Term}})
+				}
+			case len(rule.Approved) > 0:
+				if !containsAnyTerm(target, rule.Approved, rule.CaseSensitive) {
+					issues = append(issues, GlossaryIssue{Term: rule.Term, Type: GlossaryIssueMissingTerm, Expected: rule.Approved})
+				}
+			}
+		}
+
+		for _, forbidden := range rule.Forbidden {
+			if forbidden != "" && ContainsTerm(target, forbidden, rule.CaseSensitive) {
+				issues = append(issues, GlossaryIssue{
+					Term:     rule.Term,
+					Type:     GlossaryIssueForbiddenTerm,
+					Found:    forbidden,
+					Expected: rule.Approved,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// ContainsTerm 判断文本是否包含术语
+// 术语首尾为字母或数字时要求两侧不是字母或数字，避免 "cart" 匹配 "cartoon"；中日韩文字不分词，直接按子串匹配
+func ContainsTerm(text, term string, caseSensitive bool) bool {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return false
+	}
+	if !caseSensitive {
+		text, term = strings.ToLower(text), strings.ToLower(term)
+	}
+
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+	checkStart, checkEnd := isWordRune(first), isWordRune(last)
+
+	for offset := 0; offset <= len(text)-len(term); {
+		index := strings.Index(text[offset:], term)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (!checkStart || start == 0 || !isWordRune(before)) && (!checkEnd || end == len(text) || !isWordRune(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+// containsAnyTerm 判断文本是否包含任一术语
+func containsAnyTerm(text string, terms []string, caseSensitive bool) bool {
+	for _, term := range terms {
+		if ContainsTerm(text, term, caseSensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordRune 判断字符是否构成以空格分词的单词（中日韩文字不分词）
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_') && !isCJKRune(r)
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// 响应内容编码
+const (
+	EncodingIdentity = "identity"
+	EncodingGzip     = "gzip"
+	EncodingBrotli   = "br"
+)
+
+// NegotiateEncoding 根据 Accept-Encoding 选择内容编码，优先 br，其次 gzip，都不接受时返回 identity
+// q=0 表示明确拒绝该编码，"*" 匹配未列出的编码
+func NegotiateEncoding(acceptEncoding string) string {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		weight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		weights[name] = weight
+	}
+
+	accepts := func(encoding string) bool {
+		if weight, ok := weights[encoding]; ok {
+			return weight > 0
+		}
+		weight, ok := weights["*"]
+		return ok && weight > 0
+	}
+	for _, encoding := range []string{EncodingBrotli, EncodingGzip} {
+		if accepts(encoding) {
+			return encoding
+		}
+	}
+	return EncodingIdentity
+}
+
+// ETagMatches 判断 If-None-Match 是否匹配 etag，按弱比较忽略 W/ 前缀，"*" 匹配任意值
+func ETagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
+
+// StrongETag 按内容的 SHA-256 生成强 ETag，同一内容的不同编码使用不同的 ETag
+func StrongETag(hash, encoding string) string {
+	if encoding == "" || encoding == EncodingIdentity {
+		return `"` + hash + `"`
+	}
+	return `"` + hash + "-" + encoding + `"`
+}
+
+// GzipBytes 以最高压缩率 gzip 压缩
+func GzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BrotliBytes 以最高压缩率 brotli 压缩
+func BrotliBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := brotli.NewWriterLevel(&buf, brotli.BestCompression)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
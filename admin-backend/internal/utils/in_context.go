@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 页内编辑标记使用的零宽字符：开始、值开始、结束，以及表示 2 位二进制的四个数字
+const (
+	inContextStart = '\u2063' // INVISIBLE SEPARATOR
+	inContextValue = '\u2064' // INVISIBLE PLUS
+	inContextEnd   = '\u2062' // INVISIBLE TIMES
+)
+
+var inContextDigits = [4]rune{'\u200b', '\u200c', '\u200d', '\u2060'}
+
+// InContextMarker 页内编辑标记中编码的项目和键
+type InContextMarker struct {
+	ProjectID uint64
+	KeyName   string
+}
+
+// MarkInContext 用零宽标记包裹翻译值：开始符 + 编码的“项目ID:键名” + 值开始符 + 值 + 结束符
+// 标记在页面上不可见，页内编辑脚本可从选中文本中解析出项目和键
+func MarkInContext(value string, projectID uint64, keyName string) string {
+	payload := strconv.FormatUint(projectID, 10) + ":" + keyName
+
+	var builder strings.Builder
+	builder.Grow(len(value) + len(payload)*12 + 9)
+	builder.WriteRune(inContextStart)
+	for i := 0; i < len(payload); i++ {
+		b := payload[i]
+		for shift := 6; shift >= 0; shift -= 2 {
+			builder.WriteRune(inContextDigits[(b>>shift)&3])
+		}
+	}
+	builder.WriteRune(inContextValue)
+	builder.WriteString(value)
+	builder.WriteRune(inContextEnd)
+	return builder.String()
+}
+
+// ParseInContextMarkers 解析文本中的所有页内编辑标记，按出现顺序去重；无法解码的标记忽略
+func ParseInContextMarkers(text string) []InContextMarker {
+	markers := make([]InContextMarker, 0)
+	seen := make(map[InContextMarker]bool)
+	for {
+		start := strings.IndexRune(text, inContextStart)
+		if start < 0 {
+			return markers
+		}
+		text = text[start+len(string(inContextStart)):]
+		end := strings.IndexRune(text, inContextValue)
+		if end < 0 {
+			return markers
+		}
+		marker, ok := decodeInContextPayload(text[:end])
+		text = text[end+len(string(inContextValue)):]
+		if ok && !seen[marker] {
+			seen[marker] = true
+			markers = append(markers, marker)
+		}
+	}
+}
+
+// decodeInContextPayload 将零宽数字还原为“项目ID:键名”
+func decodeInContextPayload(encoded string) (InContextMarker, bool) {
+	var payload []byte
+	var current byte
+	count := 0
+	for _, r := range encoded {
+		digit := -1
+		for i, d := range inContextDigits {
+			if r == d {
+				digit = i
+				break
+			}
+		}
+		if digit < 0 {
+			return InContextMarker{}, false
+		}
+		current = current<<2 | byte(digit)
+		count++
+		if count == 4 {
+			payload = append(payload, current)
+			current, count = 0, 0
+		}
+	}
+	if count != 0 {
+		return InContextMarker{}, false
+	}
+
+	projectPart, keyName, found := strings.Cut(string(payload), ":")
+	if !found || keyName == "" {
+		return InContextMarker{}, false
+	}
+	projectID, err := strconv.ParseUint(projectPart, 10, 64)
+	if err != nil {
+		return InContextMarker{}, false
+	}
+	return InContextMarker{ProjectID: projectID, KeyName: keyName}, true
+}
+
+// StripInContextMarkers 去除文本中的页内编辑标记，只保留翻译值
+func StripInContextMarkers(text string) string {
+	var builder strings.Builder
+	inPayload := false
+	for _, r := range text {
+		switch {
+		case r == inContextStart:
+			inPayload = true
+		case r == inContextValue:
+			inPayload = false
+		case r == inContextEnd:
+		case !inPayload:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
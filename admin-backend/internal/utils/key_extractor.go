@@ -0,0 +1,243 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractedKey 从源码中提取到的翻译键
+type ExtractedKey struct {
+	Key  string
+	Line int
+}
+
+// KeyExtractor 翻译键提取器
+type KeyExtractor interface {
+	Extract(filename string, src []byte) ([]ExtractedKey, error)
+}
+
+// GoKeyExtractor 基于Go语法树提取翻译键
+// 匹配第一个参数为字符串字面量的函数调用，如 i18n.T("key")
+type GoKeyExtractor struct {
+	functions []string
+}
+
+// NewGoKeyExtractor 创建Go提取器
+// functions 为要匹配的函数，如 "i18n.T"；调用链以其结尾即匹配，因此 "T" 同时匹配 T()、i18n.T() 和 h.i18n.T()
+func NewGoKeyExtractor(functions []string) (*GoKeyExtractor, error) {
+	var cleaned []string
+	for _, function := range functions {
+		function = strings.TrimSpace(function)
+		if function != "" {
+			cleaned = append(cleaned, function)
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, errors.New("go extractor requires at least one function")
+	}
+	return &GoKeyExtractor{functions: cleaned}, nil
+}
+
+// Extract 提取Go源码中的翻译键
+func (e *GoKeyExtractor) Extract(filename string, src []byte) ([]ExtractedKey, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []ExtractedKey
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || !e.matches(callName(call.Fun)) {
+			return true
+		}
+
+		literal, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+		key, err := strconv.Unquote(literal.Value)
+		if err != nil || strings.TrimSpace(key) == "" {
+			return true
+		}
+
+		keys = append(keys, ExtractedKey{Key: key, Line: fset.Position(literal.Pos()).Line})
+		return true
+	})
+	return keys, nil
+}
+
+// matches 判断调用链是否匹配配置的函数
+func (e *GoKeyExtractor) matches(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, function := range e.functions {
+		if name == function || strings.HasSuffix(name, "."+function) {
+			return true
+		}
+	}
+	return false
+}
+
+// callName 将被调用的表达式还原为点号分隔的调用链，如 h.i18n.T，无法还原时返回空字符串
+func callName(expr ast.Expr) string {
+	switch fn := expr.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		prefix := callName(fn.X)
+		if prefix == "" {
+			return ""
+		}
+		return prefix + "." + fn.Sel.Name
+	case *ast.IndexExpr:
+		// 泛型函数调用，如 T[string]("key")
+		return callName(fn.X)
+	default:
+		return ""
+	}
+}
+
+// RegexKeyExtractor 基于正则表达式提取翻译键
+// 键名取命名捕获组 key，没有时取第一个非空的捕获组
+type RegexKeyExtractor struct {
+	patterns []*regexp.Regexp
+	unescape bool
+}
+
+// NewRegexKeyExtractor 创建正则提取器，每个模式至少包含一个捕获组
+func NewRegexKeyExtractor(patterns ...string) (*RegexKeyExtractor, error) {
+	if len(patterns) == 0 {
+		return nil, errors.New("regex extractor requires at least one pattern")
+	}
+
+	extractor := &RegexKeyExtractor{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if re.NumSubexp() == 0 {
+			return nil, fmt.Errorf("pattern %q has no capture group", pattern)
+		}
+		extractor.patterns = append(extractor.patterns, re)
+	}
+	return extractor, nil
+}
+
+// i18nextPatterns i18next 调用的匹配模式：t('key')、i18n.t("key")、$t(`key`) 以及 <Trans i18nKey="key">
+var i18nextPatterns = []string{
+	`(?:^|[^\w$.])(?:(?:i18n|i18next|this)\.)?\$?t\(\s*(?:'((?:[^'\\\n]|\\.)+)'|"((?:[^"\\\n]|\\.)+)"|` + "`([^`$\\\\]+)`" + `)`,
+	`\bi18nKey\s*=\s*(?:\{\s*)?(?:'([^'\n]+)'|"([^"\n]+)")`,
+}
+
+// NewI18nextKeyExtractor 创建 i18next 提取器，适用于 JavaScript、TypeScript 和 Vue 源码
+func NewI18nextKeyExtractor() *RegexKeyExtractor {
+	extractor, err := NewRegexKeyExtractor(i18nextPatterns...)
+	if err != nil {
+		panic(err)
+	}
+	extractor.unescape = true
+	return extractor
+}
+
+// Extract 提取源码中匹配的翻译键，结果按出现位置排序
+func (e *RegexKeyExtractor) Extract(filename string, src []byte) ([]ExtractedKey, error) {
+	content := string(src)
+	lineStarts := lineOffsets(content)
+
+	type match struct {
+		offset int
+		key    string
+	}
+	var matches []match
+	for _, re := range e.patterns {
+		keyGroup := re.SubexpIndex("key")
+		for _, loc := range re.FindAllStringSubmatchIndex(content, -1) {
+			group := keyGroup
+			if group < 0 {
+				group = firstMatchedGroup(loc)
+			}
+			if group < 0 || loc[2*group] < 0 {
+				continue
+			}
+
+			key := content[loc[2*group]:loc[2*group+1]]
+			if e.unescape {
+				key = unescapeJSString(key)
+			}
+			if strings.TrimSpace(key) == "" {
+				continue
+			}
+			matches = append(matches, match{offset: loc[2*group], key: key})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].offset < matches[j].offset })
+
+	keys := make([]ExtractedKey, len(matches))
+	for i, m := range matches {
+		keys[i] = ExtractedKey{Key: m.key, Line: lineAt(lineStarts, m.offset)}
+	}
+	return keys, nil
+}
+
+// firstMatchedGroup 返回第一个参与匹配的捕获组序号，没有时返回 -1
+func firstMatchedGroup(loc []int) int {
+	for group := 1; group < len(loc)/2; group++ {
+		if loc[2*group] >= 0 {
+			return group
+		}
+	}
+	return -1
+}
+
+// lineOffsets 返回每一行起始位置的字节偏移
+func lineOffsets(content string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// lineAt 返回偏移所在的行号（从1开始）
+func lineAt(lineStarts []int, offset int) int {
+	return sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset })
+}
+
+// unescapeJSString 还原JavaScript字符串字面量中的简单转义
+func unescapeJSString(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				builder.WriteByte('\n')
+			case 't':
+				builder.WriteByte('\t')
+			default:
+				builder.WriteByte(value[i])
+			}
+			continue
+		}
+		builder.WriteByte(value[i])
+	}
+	return builder.String()
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// 翻译文件格式，每种语言一个文件
+const (
+	LocaleFormatJSON     = "json"      // 按键名中的 . 嵌套的 JSON，与 CLI 拉取的文件相同
+	LocaleFormatJSONFlat = "json_flat" // 键名 -> 译文的平铺 JSON
+)
+
+// PathTemplateLanguage 路径模板中语言代码的占位符
+const PathTemplateLanguage = "{lang}"
+
+// IsLocaleFormat 判断是否为支持的翻译文件格式
+func IsLocaleFormat(format string) bool {
+	switch format {
+	case LocaleFormatJSON, LocaleFormatJSONFlat:
+		return true
+	}
+	return false
+}
+
+// ParseLocaleFile 解析翻译文件，返回键名 -> 译文
+// 两种格式都接受嵌套对象，嵌套的键名用 . 连接；非字符串的值被忽略
+func ParseLocaleFile(format string, data []byte) (map[string]string, error) {
+	if !IsLocaleFormat(format) {
+		return nil, fmt.Errorf("unsupported locale format: %s", format)
+	}
+	values := make(map[string]string)
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return values, nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	flattenLocaleValues(raw, "", values)
+	return values, nil
+}
+
+// flattenLocaleValues 把嵌套对象展开为键名 -> 译文
+func flattenLocaleValues(node map[string]interface{}, prefix string, values map[string]string) {
+	for key, value := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case map[string]interface{}:
+			flattenLocaleValues(v, key, values)
+		}
+	}
+}
+
+// MarshalLocaleFile 按格式序列化翻译文件，键按字母排序，两个空格缩进，不转义 HTML 字符，以换行结尾
+// 嵌套格式中，如果键名的前缀本身也是一个键（如 a 和 a.b），剩余部分作为带 . 的键名保留在该层，解析后得到相同的键
+func MarshalLocaleFile(format string, values map[string]string) ([]byte, error) {
+	var document interface{}
+	switch format {
+	case LocaleFormatJSON:
+		document = nestLocaleValues(values)
+	case LocaleFormatJSONFlat:
+		document = values
+	default:
+		return nil, fmt.Errorf("unsupported locale format: %s", format)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// nestLocaleValues 按键名中的 . 构造嵌套对象
+func nestLocaleValues(values map[string]string) map[string]interface{} {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	root := make(map[string]interface{})
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		node := root
+		for i, part := range parts {
+			if part == "" {
+				// 空的键名段无法嵌套，整个剩余部分作为键名
+				node[strings.Join(parts[i:], ".")] = values[key]
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = values[key]
+				break
+			}
+			if _, isKey := values[strings.Join(parts[:i+1], ".")]; isKey {
+				node[strings.Join(parts[i:], ".")] = values[key]
+				break
+			}
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+// IsPathTemplate 判断是否为合法的翻译文件路径模板：仓库内规范的相对路径，包含 {lang}，不指向 .git 目录
+func IsPathTemplate(template string) bool {
+	if !strings.Contains(template, PathTemplateLanguage) || strings.ContainsAny(template, "\\\x00") {
+		return false
+	}
+	if path.IsAbs(template) || path.Clean(template) != template {
+		return false
+	}
+	for _, segment := range strings.Split(template, "/") {
+		if segment == ".." || strings.EqualFold(segment, ".git") {
+			return false
+		}
+	}
+	return true
+}
+
+// RenderPathTemplate 把路径模板中的 {lang} 替换为文件使用的语言代码
+func RenderPathTemplate(template, languageCode string) string {
+	return strings.ReplaceAll(template, PathTemplateLanguage, languageCode)
+}
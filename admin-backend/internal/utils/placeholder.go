@@ -0,0 +1,339 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 占位符语法
+const (
+	PlaceholderBrace       = "brace"        // {name}
+	PlaceholderDoubleBrace = "double_brace" // {{name}}
+	PlaceholderAndroid     = "android"      // %1$s
+	PlaceholderIOS         = "ios"          // %@、%1$@
+	PlaceholderGettext     = "gettext"      // %(name)s
+)
+
+var placeholderPatterns = map[string]*regexp.Regexp{
+	PlaceholderBrace:       regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_.-]*|\d+)\}`),
+	PlaceholderDoubleBrace: regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`),
+	PlaceholderAndroid:     regexp.MustCompile(`%%|%(?:([1-9]\d*)\$)?([-+ 0#]*\d*(?:\.\d+)?(?:hh|h|ll|l|q|z|j|t|L)?[sSdiuoxXfFeEgGaAcCp@])`),
+	PlaceholderIOS:         regexp.MustCompile(`%%|%(?:([1-9]\d*)\$)?([-+ 0#]*\d*(?:\.\d+)?(?:hh|h|ll|l|q|z|j|t|L)?[sSdiuoxXfFeEgGaAcCp@])`),
+	PlaceholderGettext:     regexp.MustCompile(`%%|%\(([A-Za-z_]\w*)\)([-+ 0#]*\d*(?:\.\d+)?(?:hh|h|ll|l|q|z|j|t|L)?[sdiouxXeEfFgGcr])`),
+}
+
+// IsPlaceholderSyntax 判断是否为支持的占位符语法
+func IsPlaceholderSyntax(syntax string) bool {
+	_, ok := placeholderPatterns[syntax]
+	return ok
+}
+
+// PlaceholderArg 按位置编号的占位符参数
+type PlaceholderArg struct {
+	Name string // 参数名，位置语法中为 argN
+	Spec string // printf 类型说明，如 s、d、.2f，花括号语法中为空
+}
+
+// PlaceholderSignature 参考文本的占位符参数表，决定位置编号与参数名的对应关系
+type PlaceholderSignature struct {
+	Syntax     string
+	Args       []PlaceholderArg
+	Positional bool // 参考文本是否使用了显式位置编号，如 %1$s
+}
+
+type placeholderToken struct {
+	start, end int
+	raw        string
+	escape     bool // %% 转义
+	name       string
+	index      int // 位置编号，显式或按出现顺序
+	explicit   bool
+	spec       string
+}
+
+// PlaceholderSignatureOf 解析参考文本的占位符参数表
+// 命名语法按首次出现顺序编号；位置语法以编号为准，参数名为 arg1、arg2……
+func PlaceholderSignatureOf(reference, syntax string) PlaceholderSignature {
+	signature := PlaceholderSignature{Syntax: syntax}
+	seen := make(map[string]bool)
+	for _, token := range parsePlaceholders(reference, syntax) {
+		if token.escape {
+			continue
+		}
+		if isPositionalSyntax(syntax) {
+			for len(signature.Args) < token.index {
+				signature.Args = append(signature.Args, PlaceholderArg{Name: fmt.Sprintf("arg%d", len(signature.Args)+1)})
+			}
+			if signature.Args[token.index-1].Spec == "" {
+				signature.Args[token.index-1].Spec = token.spec
+			}
+			signature.Positional = signature.Positional || token.explicit
+			continue
+		}
+		if !seen[token.name] {
+			seen[token.name] = true
+			signature.Args = append(signature.Args, PlaceholderArg{Name: token.name, Spec: token.spec})
+		}
+	}
+	return signature
+}
+
+// indexOf 返回参数名对应的位置编号，不存在时返回0
+func (s PlaceholderSignature) indexOf(name string) int {
+	for i, arg := range s.Args {
+		if arg.Name == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ConvertPlaceholders 将文本中的占位符从 from 语法改写为 to 语法
+// 参数编号和名称以 signature 为准，使 规范语法 -> 目标语法 -> 规范语法 的转换不丢失信息。
+// 返回无法转换的占位符（原样保留在结果中），以及改写后会被误认为占位符的字面文本
+func ConvertPlaceholders(value, from, to string, signature PlaceholderSignature) (string, []string) {
+	if from == to || value == "" || !IsPlaceholderSyntax(from) || !IsPlaceholderSyntax(to) {
+		return value, nil
+	}
+
+	tokens := parsePlaceholders(value, from)
+
+	// 解析每个占位符对应的参数
+	type resolvedToken struct {
+		placeholderToken
+		index      int
+		name       string
+		spec       string
+		specSyntax string
+	}
+	resolved := make([]resolvedToken, 0, len(tokens))
+	for _, token := range tokens {
+		item := resolvedToken{placeholderToken: token}
+		if token.escape {
+			resolved = append(resolved, item)
+			continue
+		}
+		if isPositionalSyntax(from) {
+			item.index = token.index
+			if item.index <= len(signature.Args) {
+				item.name = signature.Args[item.index-1].Name
+			}
+		} else {
+			item.name = token.name
+			item.index = signature.indexOf(token.name)
+		}
+		switch {
+		case token.spec != "":
+			item.spec, item.specSyntax = token.spec, from
+		case item.index > 0 && signature.Args[item.index-1].Spec != "":
+			item.spec, item.specSyntax = signature.Args[item.index-1].Spec, signature.Syntax
+		}
+		resolved = append(resolved, item)
+	}
+
+	// 目标为位置语法时，顺序与编号不一致或参考文本使用了编号时输出显式编号
+	explicit := false
+	if isPositionalSyntax(to) {
+		if to == signature.Syntax {
+			explicit = signature.Positional
+		} else {
+			explicit = len(signature.Args) > 1
+		}
+		position := 0
+		for _, item := range resolved {
+			if item.escape {
+				continue
+			}
+			position++
+			if item.index != position {
+				explicit = true
+			}
+		}
+	}
+
+	var builder strings.Builder
+	var issues []string
+	appendLiteral := func(text string) {
+		if text == "" {
+			return
+		}
+		escaped := escapePlaceholderLiteral(text, to)
+		for _, token := range parsePlaceholders(escaped, to) {
+			if !token.escape {
+				issues = append(issues, token.raw)
+			}
+		}
+		builder.WriteString(escaped)
+	}
+
+	last := 0
+	for _, item := range resolved {
+		appendLiteral(value[last:item.start])
+		last = item.end
+		if item.escape {
+			appendLiteral("%")
+			continue
+		}
+
+		rendered, ok := "", false
+		switch to {
+		case PlaceholderBrace, PlaceholderDoubleBrace, PlaceholderGettext:
+			if item.name != "" {
+				ok = true
+				switch to {
+				case PlaceholderBrace:
+					rendered = "{" + item.name + "}"
+				case PlaceholderDoubleBrace:
+					rendered = "{{" + item.name + "}}"
+				default:
+					rendered = "%(" + item.name + ")" + convertPlaceholderSpec(item.spec, item.specSyntax, to)
+				}
+			}
+			// 花括号语法无法表达类型，转换回来时以参考文本的类型为准，两者不一致会丢失信息
+			if ok && to != PlaceholderGettext && item.specSyntax == from {
+				restored := ""
+				if item.index > 0 && signature.Args[item.index-1].Spec != "" {
+					restored = convertPlaceholderSpec(signature.Args[item.index-1].Spec, signature.Syntax, from)
+				}
+				if restored == "" {
+					restored = convertPlaceholderSpec("", "", from)
+				}
+				ok = restored == item.spec
+			}
+		case PlaceholderAndroid, PlaceholderIOS:
+			if item.index > 0 {
+				ok = true
+				rendered = "%"
+				if explicit {
+					rendered += strconv.Itoa(item.index) + "$"
+				}
+				rendered += convertPlaceholderSpec(item.spec, item.specSyntax, to)
+			}
+		}
+
+		if !ok {
+			issues = append(issues, item.raw)
+			rendered = item.raw
+		}
+		builder.WriteString(rendered)
+	}
+	appendLiteral(value[last:])
+
+	return builder.String(), issues
+}
+
+// SamePlaceholders 判断译文与原文使用的占位符参数是否相同，忽略顺序和重复出现
+// 命名语法比较参数名，位置语法比较位置编号，%% 转义不计；不支持的语法视为相同
+func SamePlaceholders(source, target, syntax string) bool {
+	if !IsPlaceholderSyntax(syntax) {
+		return true
+	}
+	sourceArgs, targetArgs := placeholderArgs(source, syntax), placeholderArgs(target, syntax)
+	if len(sourceArgs) != len(targetArgs) {
+		return false
+	}
+	for arg := range sourceArgs {
+		if !targetArgs[arg] {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholderArgs 返回文本中使用的占位符参数集合
+func placeholderArgs(value, syntax string) map[string]bool {
+	args := make(map[string]bool)
+	for _, token := range parsePlaceholders(value, syntax) {
+		switch {
+		case token.escape:
+		case isPositionalSyntax(syntax):
+			args[strconv.Itoa(token.index)] = true
+		default:
+			args[token.name] = true
+		}
+	}
+	return args
+}
+
+// parsePlaceholders 按语法解析文本中的占位符
+func parsePlaceholders(value, syntax string) []placeholderToken {
+	pattern, ok := placeholderPatterns[syntax]
+	if !ok {
+		return nil
+	}
+
+	var tokens []placeholderToken
+	sequence := 0
+	for _, loc := range pattern.FindAllStringSubmatchIndex(value, -1) {
+		token := placeholderToken{start: loc[0], end: loc[1], raw: value[loc[0]:loc[1]]}
+		switch syntax {
+		case PlaceholderBrace:
+			// {{name}} 中的 {name} 不视为单花括号占位符
+			if token.start > 0 && value[token.start-1] == '{' && token.end < len(value) && value[token.end] == '}' {
+				continue
+			}
+			token.name = value[loc[2]:loc[3]]
+		case PlaceholderDoubleBrace:
+			token.name = value[loc[2]:loc[3]]
+		case PlaceholderAndroid, PlaceholderIOS:
+			if token.raw == "%%" {
+				token.escape = true
+				break
+			}
+			if loc[2] >= 0 {
+				token.index, _ = strconv.Atoi(value[loc[2]:loc[3]])
+				token.explicit = true
+			} else {
+				sequence++
+				token.index = sequence
+			}
+			token.spec = value[loc[4]:loc[5]]
+		case PlaceholderGettext:
+			if token.raw == "%%" {
+				token.escape = true
+				break
+			}
+			token.name = value[loc[2]:loc[3]]
+			token.spec = value[loc[4]:loc[5]]
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// isPositionalSyntax 判断是否为按位置编号的语法
+func isPositionalSyntax(syntax string) bool {
+	return syntax == PlaceholderAndroid || syntax == PlaceholderIOS
+}
+
+// escapePlaceholderLiteral 转义字面文本中与目标语法冲突的字符
+func escapePlaceholderLiteral(text, syntax string) string {
+	switch syntax {
+	case PlaceholderAndroid, PlaceholderIOS, PlaceholderGettext:
+		return strings.ReplaceAll(text, "%", "%%")
+	default:
+		return text
+	}
+}
+
+// convertPlaceholderSpec 转换 printf 类型说明，iOS 的字符串为 @，其他语法为 s
+func convertPlaceholderSpec(spec, specSyntax, to string) string {
+	if spec == "" {
+		if to == PlaceholderIOS {
+			return "@"
+		}
+		return "s"
+	}
+	if specSyntax == to {
+		return spec
+	}
+	if to == PlaceholderIOS && spec == "s" {
+		return "@"
+	}
+	if to != PlaceholderIOS && spec == "@" {
+		return "s"
+	}
+	return spec
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrProtectedTokenMismatch 机器翻译结果中的保护标记丢失、重复或无法识别
+var ErrProtectedTokenMismatch = errors.New("protected tokens were altered by translation")
+
+var (
+	htmlTagPattern        = regexp.MustCompile(`<!--[\s\S]*?-->|</?[A-Za-z][^<>]*>`)
+	protectedTokenPattern = regexp.MustCompile(`<x\s+id\s*=\s*"(\d+)"\s*(?:/>|>\s*</x>)`)
+)
+
+// ProtectMarkup 将占位符和 HTML 标签替换为 <x id="n"/> 标记，其余文本按 XML 转义
+// 返回的文本可以 XML/HTML 模式交给机器翻译，tokens 为按编号排列的原始片段；syntax 为空时只保护 HTML 标签
+func ProtectMarkup(text, syntax string) (string, []string) {
+	patterns := []*regexp.Regexp{htmlTagPattern}
+	if pattern, ok := placeholderPatterns[syntax]; ok {
+		patterns = append(patterns, pattern)
+	}
+
+	var spans [][]int
+	for _, pattern := range patterns {
+		spans = append(spans, pattern.FindAllStringIndex(text, -1)...)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	var builder strings.Builder
+	tokens := make([]string, 0, len(spans))
+	last := 0
+	for _, span := range spans {
+		if span[0] < last {
+			continue // 与前一个片段重叠，如标签属性中的占位符
+		}
+		builder.WriteString(html.EscapeString(text[last:span[0]]))
+		fmt.Fprintf(&builder, `<x id="%d"/>`, len(tokens))
+		tokens = append(tokens, text[span[0]:span[1]])
+		last = span[1]
+	}
+	builder.WriteString(html.EscapeString(text[last:]))
+	return builder.String(), tokens
+}
+
+// RestoreMarkup 将机器翻译结果中的 <x id="n"/> 标记还原为原始片段，其余文本反转义
+// 每个标记必须恰好出现一次，否则返回 ErrProtectedTokenMismatch
+func RestoreMarkup(translated string, tokens []string) (string, error) {
+	var builder strings.Builder
+	seen := make([]bool, len(tokens))
+	last := 0
+	for _, match := range protectedTokenPattern.FindAllStringSubmatchIndex(translated, -1) {
+		index, err := strconv.Atoi(translated[match[2]:match[3]])
+		if err != nil || index >= len(tokens) || seen[index] {
+			return "", ErrProtectedTokenMismatch
+		}
+		seen[index] = true
+		builder.WriteString(html.UnescapeString(translated[last:match[0]]))
+		builder.WriteString(tokens[index])
+		last = match[1]
+	}
+	for _, ok := range seen {
+		if !ok {
+			return "", ErrProtectedTokenMismatch
+		}
+	}
+	builder.WriteString(html.UnescapeString(translated[last:]))
+	return builder.String(), nil
+}
@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PseudoOptions 伪本地化选项
+type PseudoOptions struct {
+	Expansion int  // 长度扩展百分比，如 30 表示文本变长 30%
+	Brackets  bool // 用 [ ] 包裹整条文本，便于发现被截断或拼接的文本
+	RTL       bool // 用从右到左覆盖控制符包裹文本，模拟从右到左的语言
+}
+
+const (
+	rtlOverride       = "\u202e" // RIGHT-TO-LEFT OVERRIDE
+	popDirectional    = "\u202c" // POP DIRECTIONAL FORMATTING
+	pseudoPaddingText = " one two three four five six seven eight nine ten"
+)
+
+// pseudoAccents 伪本地化使用的带重音字母
+var pseudoAccents = map[rune]rune{
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Đ', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î',
+	'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ',
+	'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î',
+	'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ',
+	's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+}
+
+var (
+	htmlTagPrefix    = regexp.MustCompile(`^(?:<!--[\s\S]*?-->|</?[A-Za-z][^<>]*>)`)
+	htmlEntityPrefix = regexp.MustCompile(`^&(?:#\d+|#[xX][0-9A-Fa-f]+|[A-Za-z][A-Za-z0-9]*);`)
+	icuArgNamePrefix = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*|\d+)\s*`)
+	icuSelectorName  = regexp.MustCompile(`^\s*(=\d+|[A-Za-z_][A-Za-z0-9_-]*)\s*`)
+	icuOffsetPrefix  = regexp.MustCompile(`^\s*offset:\s*\d+\s*`)
+)
+
+// placeholderPrefixes 锚定在开头的占位符正则，用于逐字符扫描
+var placeholderPrefixes = func() map[string]*regexp.Regexp {
+	prefixes := make(map[string]*regexp.Regexp, len(placeholderPatterns))
+	for syntax, pattern := range placeholderPatterns {
+		prefixes[syntax] = regexp.MustCompile(`^(?:` + pattern.String() + `)`)
+	}
+	return prefixes
+}()
+
+// Pseudolocalize 将文本转换为伪本地化文本：字母替换为带重音的字母，按比例扩展长度，可选加括号和从右到左覆盖
+// 占位符（syntax 为项目的占位符语法）、ICU 参数和 plural/select 结构、HTML 标签和实体保持不变，只转换其中的文本
+func Pseudolocalize(text, syntax string, opts PseudoOptions) string {
+	if text == "" {
+		return text
+	}
+
+	p := &pseudolocalizer{placeholder: placeholderPrefixes[syntax], opts: opts}
+	result := p.message(text, false)
+
+	if padding := pseudoPadding(p.letters, opts.Expansion); padding != "" {
+		result += p.literal(padding)
+	}
+	if opts.Brackets {
+		result = "[" + result + "]"
+	}
+	return result
+}
+
+// pseudoPadding 按字母数和扩展比例生成填充文本
+func pseudoPadding(letters, expansion int) string {
+	if expansion <= 0 || letters == 0 {
+		return ""
+	}
+	length := (letters*expansion + 99) / 100
+	var builder strings.Builder
+	for builder.Len() < length {
+		remaining := length - builder.Len()
+		if remaining >= len(pseudoPaddingText) {
+			builder.WriteString(pseudoPaddingText)
+		} else {
+			builder.WriteString(pseudoPaddingText[:remaining])
+		}
+	}
+	return builder.String()
+}
+
+// pseudolocalizer 伪本地化的扫描状态
+type pseudolocalizer struct {
+	placeholder *regexp.Regexp
+	opts        PseudoOptions
+	letters     int // 已转换的字母数，用于计算扩展长度
+}
+
+// message 转换一条消息，inPlural 为 true 时 # 表示 plural 的数值，保持不变
+func (p *pseudolocalizer) message(text string, inPlural bool) string {
+	var builder, run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			builder.WriteString(p.literal(run.String()))
+			run.Reset()
+		}
+	}
+	keep := func(segment string) {
+		flush()
+		builder.WriteString(segment)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch rest[0] {
+		case '<':
+			if loc := htmlTagPrefix.FindStringIndex(rest); loc != nil {
+				keep(rest[:loc[1]])
+				i += loc[1]
+				continue
+			}
+		case '&':
+			if loc := htmlEntityPrefix.FindStringIndex(rest); loc != nil {
+				keep(rest[:loc[1]])
+				i += loc[1]
+				continue
+			}
+		case '#':
+			if inPlural {
+				keep("#")
+				i++
+				continue
+			}
+		case '\'':
+			// ICU 转义：'' 表示单引号，'{...}' 中的内容按原样输出
+			if strings.HasPrefix(rest, "''") {
+				keep("''")
+				i += 2
+				continue
+			}
+			if len(rest) > 1 && (rest[1] == '{' || rest[1] == '}' || (inPlural && rest[1] == '#')) {
+				end := strings.IndexByte(rest[1:], '\'')
+				if end < 0 {
+					keep(rest)
+					i = len(text)
+				} else {
+					keep(rest[:end+2])
+					i += end + 2
+				}
+				continue
+			}
+		}
+
+		if p.placeholder != nil {
+			if loc := p.placeholder.FindStringIndex(rest); loc != nil && loc[1] > 0 {
+				keep(rest[:loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		if rest[0] == '{' {
+			if converted, length, ok := p.icuArgument(rest); ok {
+				keep(converted)
+				i += length
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		run.WriteRune(r)
+		i += size
+	}
+	flush()
+	return builder.String()
+}
+
+// icuArgument 解析开头的 ICU 参数，如 {name}、{n, number}、{count, plural, one {# item} other {# items}}
+// plural/select 的子消息会被转换，其余部分原样保留；返回转换结果和消耗的字节数
+func (p *pseudolocalizer) icuArgument(text string) (string, int, bool) {
+	i := 1
+	loc := icuArgNamePrefix.FindStringIndex(text[i:])
+	if loc == nil {
+		return "", 0, false
+	}
+	i += loc[1]
+	if i < len(text) && text[i] == '}' {
+		return text[:i+1], i + 1, true
+	}
+	if i >= len(text) || text[i] != ',' {
+		return "", 0, false
+	}
+	i++
+
+	typeLoc := icuArgNamePrefix.FindStringSubmatchIndex(text[i:])
+	if typeLoc == nil {
+		return "", 0, false
+	}
+	argType := text[i+typeLoc[2] : i+typeLoc[3]]
+	i += typeLoc[1]
+
+	switch argType {
+	case "plural", "selectordinal", "select":
+	default:
+		// 简单参数（number、date 等）的样式部分不需要转换，匹配括号后整体保留
+		end := matchingBrace(text)
+		if end < 0 {
+			return "", 0, false
+		}
+		return text[:end+1], end + 1, true
+	}
+
+	if i >= len(text) || text[i] != ',' {
+		return "", 0, false
+	}
+	i++
+
+	var builder strings.Builder
+	builder.WriteString(text[:i])
+	if argType != "select" {
+		if offset := icuOffsetPrefix.FindStringIndex(text[i:]); offset != nil {
+			builder.WriteString(text[i : i+offset[1]])
+			i += offset[1]
+		}
+	}
+
+	cases := 0
+	for {
+		selector := icuSelectorName.FindStringIndex(text[i:])
+		if selector == nil {
+			break
+		}
+		if i+selector[1] >= len(text) || text[i+selector[1]] != '{' {
+			return "", 0, false
+		}
+		builder.WriteString(text[i : i+selector[1]])
+		i += selector[1]
+
+		end := matchingBrace(text[i:])
+		if end < 0 {
+			return "", 0, false
+		}
+		builder.WriteString("{")
+		builder.WriteString(p.message(text[i+1:i+end], argType != "select"))
+		builder.WriteString("}")
+		i += end + 1
+		cases++
+	}
+
+	for i < len(text) && unicode.IsSpace(rune(text[i])) {
+		builder.WriteByte(text[i])
+		i++
+	}
+	if cases == 0 || i >= len(text) || text[i] != '}' {
+		return "", 0, false
+	}
+	builder.WriteString("}")
+	return builder.String(), i + 1, true
+}
+
+// matchingBrace 返回与开头 { 匹配的 } 的位置，跳过 ICU 引号转义，未找到时返回 -1
+func matchingBrace(text string) int {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\'':
+			if i+1 < len(text) && text[i+1] == '\'' {
+				i++
+			} else if quoted || (i+1 < len(text) && (text[i+1] == '{' || text[i+1] == '}')) {
+				quoted = !quoted
+			}
+		case '{':
+			if !quoted {
+				depth++
+			}
+		case '}':
+			if !quoted {
+				depth--
+				if depth == 0 {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// literal 转换一段普通文本：替换字母并计数，开启从右到左时用覆盖控制符包裹
+func (p *pseudolocalizer) literal(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if accented, ok := pseudoAccents[r]; ok {
+			builder.WriteRune(accented)
+			p.letters++
+			continue
+		}
+		if unicode.IsLetter(r) {
+			p.letters++
+		}
+		builder.WriteRune(r)
+	}
+	if p.opts.RTL && strings.TrimSpace(text) != "" {
+		return rtlOverride + builder.String() + popDirectional
+	}
+	return builder.String()
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NormalizeSegment 规范化文本片段用于比较：去除首尾空白并将连续空白合并为一个空格
+func NormalizeSegment(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// EditDistance 计算两个字符串按字符（rune）计的编辑距离
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// Similarity 基于编辑距离的相似度，取值 0~1，1 表示完全相同
+// 比较前规范化空白；仅大小写不同的字符按半个编辑计，使其排在完全匹配之后、其他模糊匹配之前
+func Similarity(a, b string) float64 {
+	a, b = NormalizeSegment(a), NormalizeSegment(b)
+	if a == b {
+		return 1
+	}
+
+	length := max(len([]rune(a)), len([]rune(b)))
+	if length == 0 {
+		return 1
+	}
+
+	folded := EditDistance(strings.ToLower(a), strings.ToLower(b))
+	exact := EditDistance(a, b)
+	distance := float64(folded) + float64(max(exact-folded, 0))/2
+	return max(1-distance/float64(length), 0)
+}
+
+// LengthWindow 返回与 text 相似度可能达到 minScore 的文本长度范围（按字符计）
+// 长度差不超过编辑距离，因此范围外的文本无需比较；范围两端各放宽2个字符以容纳空白差异
+func LengthWindow(text string, minScore float64) (int, int) {
+	minScore = max(minScore, 0.01)
+	minScore = min(minScore, 1)
+
+	length := float64(len([]rune(NormalizeSegment(text))))
+	lower := max(int(length*minScore)-2, 0)
+	upper := int(length/minScore) + 2
+	return lower, upper
+}
+
+// SearchTerms 从文本中选出用于预筛选候选的检索词，按长度降序，最多 limit 个
+// 拉丁等以空格分词的文字取较长的词；中日韩文字取二元组
+func SearchTerms(text string, limit int) []string {
+	seen := make(map[string]bool)
+	var words, grams []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		runes := []rune(word)
+		if isCJKRune(runes[0]) {
+			for i := 0; i+1 < len(runes); i++ {
+				gram := string(runes[i : i+2])
+				if !seen[gram] {
+					seen[gram] = true
+					grams = append(grams, gram)
+				}
+			}
+			continue
+		}
+		if len(runes) >= 3 && !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	sort.SliceStable(words, func(i, j int) bool { return len([]rune(words[i])) > len([]rune(words[j])) })
+	terms := append(words, spread(grams, limit)...)
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
+
+// spread 从列表中均匀取出最多 n 个元素，使二元组覆盖文本的不同位置
+func spread(items []string, n int) []string {
+	if n <= 0 || len(items) <= n {
+		return items
+	}
+	result := make([]string, 0, n)
+	step := float64(len(items)) / float64(n)
+	for i := 0; i < n; i++ {
+		result = append(result, items[int(float64(i)*step)])
+	}
+	return result
+}
+
+// isCJKRune 判断字符是否属于不以空格分词的中日韩文字
+func isCJKRune(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedArchive 不支持的压缩包格式
+	ErrUnsupportedArchive = errors.New("unsupported archive format, expected zip, tar or tar.gz")
+	// ErrArchiveTooLarge 压缩包解压后的文件数或总大小超出限制
+	ErrArchiveTooLarge = errors.New("archive exceeds the file count or size limit")
+)
+
+// SourceArchiveLimits 读取源码压缩包的限制，防止压缩炸弹
+type SourceArchiveLimits struct {
+	MaxFiles     int   // 最多读取的文件数
+	MaxFileSize  int64 // 单个文件超过该大小时跳过
+	MaxTotalSize int64 // 读取的文件总大小上限，tar.gz 解压后的数据总量同样受此限制
+}
+
+// SourceArchiveStats 读取源码压缩包的统计
+type SourceArchiveStats struct {
+	Files   int // 读取的文件数
+	Skipped int // 因过大或被过滤而跳过的文件数
+}
+
+// WalkSourceArchive 遍历 zip、tar 或 tar.gz 压缩包中的普通文件
+// accept 返回 false 的文件不会被读取；fn 收到的路径已规范化为不以 / 开头的相对路径
+func WalkSourceArchive(
+	data []byte,
+	limits SourceArchiveLimits,
+	accept func(filePath string) bool,
+	fn func(filePath string, content []byte) error,
+) (SourceArchiveStats, error) {
+	walker := &archiveWalker{limits: limits, accept: accept, fn: fn}
+
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		err = walker.walkZip(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, gzErr := gzip.NewReader(bytes.NewReader(data))
+		if gzErr != nil {
+			return walker.stats, ErrUnsupportedArchive
+		}
+		defer gz.Close()
+		// 跳过的文件同样需要解压，限制解压总量，避免高压缩比的压缩包耗尽资源
+		var r io.Reader = gz
+		if limits.MaxTotalSize > 0 {
+			r = &sizeLimitedReader{r: gz, remaining: limits.MaxTotalSize}
+		}
+		err = walker.walkTar(r)
+	case len(data) > 262 && string(data[257:262]) == "ustar":
+		err = walker.walkTar(bytes.NewReader(data))
+	default:
+		err = ErrUnsupportedArchive
+	}
+	return walker.stats, err
+}
+
+type archiveWalker struct {
+	limits    SourceArchiveLimits
+	accept    func(filePath string) bool
+	fn        func(filePath string, content []byte) error
+	stats     SourceArchiveStats
+	totalSize int64
+}
+
+func (w *archiveWalker) walkZip(data []byte) error {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return ErrUnsupportedArchive
+	}
+
+	for _, file := range reader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		filePath, ok := w.admit(file.Name, int64(file.UncompressedSize64))
+		if !ok {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = w.read(filePath, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *archiveWalker) walkTar(r io.Reader) error {
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if errors.Is(err, ErrArchiveTooLarge) {
+			return err
+		}
+		if err != nil {
+			return ErrUnsupportedArchive
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		filePath, ok := w.admit(header.Name, header.Size)
+		if !ok {
+			continue
+		}
+		if err := w.read(filePath, reader); err != nil {
+			return err
+		}
+	}
+}
+
+// admit 规范化路径并判断文件是否需要读取
+func (w *archiveWalker) admit(name string, size int64) (string, bool) {
+	filePath := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(name, "\\", "/")), "/")
+	if filePath == "" || (w.accept != nil && !w.accept(filePath)) {
+		return "", false
+	}
+	if w.limits.MaxFileSize > 0 && size > w.limits.MaxFileSize {
+		w.stats.Skipped++
+		return "", false
+	}
+	return filePath, true
+}
+
+// read 读取文件内容并回调，实际读取的大小同样受限制约束
+func (w *archiveWalker) read(filePath string, r io.Reader) error {
+	if w.limits.MaxFiles > 0 && w.stats.Files >= w.limits.MaxFiles {
+		return ErrArchiveTooLarge
+	}
+
+	limit := w.limits.MaxFileSize
+	if limit <= 0 {
+		limit = 1 << 30
+	}
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(content)) > limit {
+		w.stats.Skipped++
+		return nil
+	}
+
+	w.totalSize += int64(len(content))
+	if w.limits.MaxTotalSize > 0 && w.totalSize > w.limits.MaxTotalSize {
+		return ErrArchiveTooLarge
+	}
+
+	w.stats.Files++
+	return w.fn(filePath, content)
+}
+
+// sizeLimitedReader 限制读取的总字节数，数据超出限制时返回 ErrArchiveTooLarge
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// 恰好读完限制时再探测一个字节，区分数据结束和超出限制
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrArchiveTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidTBX 无法解析的 TBX 文件
+var ErrInvalidTBX = errors.New("invalid TBX document")
+
+// TBX 术语状态，对应 administrativeStatus
+const (
+	TBXStatusPreferred  = "preferred"  // preferredTerm-admn-sts
+	TBXStatusAdmitted   = "admitted"   // admittedTerm-admn-sts
+	TBXStatusDeprecated = "deprecated" // deprecatedTerm-admn-sts、supersededTerm-admn-sts
+)
+
+// TBXTerm 术语
+type TBXTerm struct {
+	Text   string
+	Status string // 为空时视为 admitted
+}
+
+// TBXEntry 术语条目，同一概念在各语言下的术语
+type TBXEntry struct {
+	ID           string
+	Descriptions map[string]string    // <descrip type="..."> 属性，如 definition
+	Languages    map[string][]TBXTerm // 语言代码 -> 术语
+}
+
+// TBXFile TBX 文件内容
+type TBXFile struct {
+	Language string // 文件的主语言
+	Entries  []TBXEntry
+}
+
+// tbxDocument 同时兼容 TBX v2（martif/termEntry/langSet/tig）和 TBX v3（tbx/conceptEntry/langSec/termSec）
+type tbxDocument struct {
+	XMLName  xml.Name
+	Lang     string     `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries  []tbxEntry `xml:"text>body>termEntry"`
+	Concepts []tbxEntry `xml:"text>body>conceptEntry"`
+}
+
+type tbxEntry struct {
+	ID          string       `xml:"id,attr"`
+	Descrips    []tbxNote    `xml:"descrip"`
+	DescripGrps []tbxNote    `xml:"descripGrp>descrip"`
+	LangSets    []tbxLangSet `xml:"langSet"`
+	LangSecs    []tbxLangSet `xml:"langSec"`
+}
+
+type tbxLangSet struct {
+	Lang     string   `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Tigs     []tbxTig `xml:"tig"`
+	TermGrps []tbxTig `xml:"ntig>termGrp"`
+	TermSecs []tbxTig `xml:"termSec"`
+}
+
+type tbxTig struct {
+	Term  string    `xml:"term"`
+	Notes []tbxNote `xml:"termNote"`
+}
+
+type tbxNote struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ParseTBX 解析 TBX 文件，支持 TBX v2（ISO 30042:2008）和 TBX v3（ISO 30042:2019）
+func ParseTBX(data []byte) (*TBXFile, error) {
+	var document tbxDocument
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTBX, err)
+	}
+	if document.XMLName.Local != "martif" && document.XMLName.Local != "tbx" {
+		return nil, fmt.Errorf("%w: unexpected root element <%s>", ErrInvalidTBX, document.XMLName.Local)
+	}
+
+	file := &TBXFile{Language: document.Lang}
+	for _, raw := range append(document.Entries, document.Concepts...) {
+		entry := TBXEntry{
+			ID:           raw.ID,
+			Descriptions: make(map[string]string),
+			Languages:    make(map[string][]TBXTerm),
+		}
+		for _, descrip := range append(raw.Descrips, raw.DescripGrps...) {
+			entry.Descriptions[descrip.Type] = strings.TrimSpace(descrip.Value)
+		}
+		for _, langSet := range append(raw.LangSets, raw.LangSecs...) {
+			if langSet.Lang == "" {
+				continue
+			}
+			tigs := append(append(langSet.Tigs, langSet.TermGrps...), langSet.TermSecs...)
+			for _, tig := range tigs {
+				text := strings.TrimSpace(tig.Term)
+				if text == "" {
+					continue
+				}
+				entry.Languages[langSet.Lang] = append(entry.Languages[langSet.Lang], TBXTerm{Text: text, Status: tbxStatus(tig.Notes)})
+			}
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+	return file, nil
+}
+
+// tbxStatus 解析术语状态，兼容 administrativeStatus 和旧版 normativeAuthorization
+func tbxStatus(notes []tbxNote) string {
+	for _, note := range notes {
+		if note.Type != "administrativeStatus" && note.Type != "normativeAuthorization" {
+			continue
+		}
+		value := strings.TrimSuffix(strings.TrimSpace(note.Value), "-admn-sts")
+		switch value {
+		case "preferredTerm":
+			return TBXStatusPreferred
+		case "admittedTerm":
+			return TBXStatusAdmitted
+		case "deprecatedTerm", "supersededTerm":
+			return TBXStatusDeprecated
+		}
+	}
+	return ""
+}
+
+type tbxMartif struct {
+	XMLName xml.Name        `xml:"martif"`
+	Type    string          `xml:"type,attr"`
+	Lang    string          `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Header  tbxMartifHeader `xml:"martifHeader"`
+	Entries []tbxTermEntry  `xml:"text>body>termEntry"`
+}
+
+type tbxMartifHeader struct {
+	Source string `xml:"fileDesc>sourceDesc>p"`
+}
+
+type tbxTermEntry struct {
+	ID       string       `xml:"id,attr,omitempty"`
+	Descrips []tbxNote    `xml:"descrip"`
+	LangSets []tbxLangSet `xml:"langSet"`
+}
+
+// MarshalTBX 生成 TBX v2（TBX-Basic）文件，主语言的术语在前，其余语言按代码排序
+func MarshalTBX(file *TBXFile, source string) ([]byte, error) {
+	document := tbxMartif{
+		Type:    "TBX-Basic",
+		Lang:    file.Language,
+		Header:  tbxMartifHeader{Source: source},
+		Entries: make([]tbxTermEntry, 0, len(file.Entries)),
+	}
+	for _, entry := range file.Entries {
+		raw := tbxTermEntry{ID: entry.ID}
+		for _, descripType := range sortedKeys(entry.Descriptions) {
+			raw.Descrips = append(raw.Descrips, tbxNote{Type: descripType, Value: entry.Descriptions[descripType]})
+		}
+
+		languages := make([]string, 0, len(entry.Languages))
+		if _, ok := entry.Languages[file.Language]; ok {
+			languages = append(languages, file.Language)
+		}
+		others := make(map[string]string)
+		for lang := range entry.Languages {
+			if lang != file.Language {
+				others[lang] = lang
+			}
+		}
+		languages = append(languages, sortedKeys(others)...)
+
+		for _, lang := range languages {
+			langSet := tbxLangSet{Lang: lang}
+			for _, term := range entry.Languages[lang] {
+				tig := tbxTig{Term: term.Text}
+				if term.Status != "" {
+					tig.Notes = []tbxNote{{Type: "administrativeStatus", Value: term.Status + "Term-admn-sts"}}
+				}
+				langSet.Tigs = append(langSet.Tigs, tig)
+			}
+			raw.LangSets = append(raw.LangSets, langSet)
+		}
+		document.Entries = append(document.Entries, raw)
+	}
+
+	output, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+)
+
+// ErrUnsupportedImage 不支持的图片格式
+var ErrUnsupportedImage = errors.New("unsupported image format, only PNG, JPEG and GIF are supported")
+
+// maxImagePixels 允许解码的最大像素数，防止超大尺寸的图片耗尽内存
+const maxImagePixels = 50_000_000
+
+// ImageInfo 图片的格式和尺寸
+type ImageInfo struct {
+	Format      string // png, jpeg, gif
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// InspectImage 读取图片头部信息，不解码像素
+func InspectImage(data []byte) (*ImageInfo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	contentType, ok := imageContentTypes[format]
+	if !ok {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, errors.New("image dimensions are out of range")
+	}
+	return &ImageInfo{Format: format, ContentType: contentType, Width: config.Width, Height: config.Height}, nil
+}
+
+var imageContentTypes = map[string]string{
+	"png":  "image/png",
+	"jpeg": "image/jpeg",
+	"gif":  "image/gif",
+}
+
+// Thumbnail 生成宽度不超过 maxWidth 的缩略图，保持宽高比
+// JPEG 原图输出 JPEG，其余输出 PNG 以保留透明度；返回缩略图内容和类型
+func Thumbnail(data []byte, maxWidth int) ([]byte, string, error) {
+	info, err := InspectImage(data)
+	if err != nil {
+		return nil, "", err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	width, height := info.Width, info.Height
+	if width > maxWidth {
+		height = max(1, height*maxWidth/width)
+		width = maxWidth
+	}
+	thumb := resizeBox(src, width, height)
+
+	var buf bytes.Buffer
+	if info.Format == "jpeg" {
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	if err := png.Encode(&buf, thumb); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// resizeBox 按区域平均缩放图片，每个目标像素取其覆盖的源像素的平均值
+func resizeBox(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pixel := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(pixel.R)
+					g += uint64(pixel.G)
+					b += uint64(pixel.B)
+					a += uint64(pixel.A)
+					count++
+				}
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / count >> 8),
+				G: uint8(g / count >> 8),
+				B: uint8(b / count >> 8),
+				A: uint8(a / count >> 8),
+			})
+		}
+	}
+	return dst
+}
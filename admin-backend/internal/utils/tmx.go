@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTMX 无法解析的 TMX 文件
+var ErrInvalidTMX = errors.New("invalid TMX document")
+
+// TMXUnit 翻译单元
+type TMXUnit struct {
+	ID             string
+	SourceLanguage string            // 单元的源语言，为空时使用文件的源语言
+	Segments       map[string]string // 语言代码 -> 文本
+	Properties     map[string]string // <prop type="..."> 属性
+}
+
+// TMXFile TMX 文件内容
+type TMXFile struct {
+	SourceLanguage string // 文件的源语言，*all* 表示任意语言
+	Units          []TMXUnit
+}
+
+type tmxDocument struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OriginalFormat      string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SourceLanguage      string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+	CreationDate        string `xml:"creationdate,attr,omitempty"`
+}
+
+type tmxUnit struct {
+	ID             string       `xml:"tuid,attr,omitempty"`
+	SourceLanguage string       `xml:"srclang,attr,omitempty"`
+	Properties     []tmxProp    `xml:"prop"`
+	Variants       []tmxVariant `xml:"tuv"`
+}
+
+type tmxProp struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type tmxVariant struct {
+	Lang       string     `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	LegacyLang string     `xml:"lang,attr,omitempty"` // TMX 1.1 使用 lang 属性
+	Segment    tmxSegment `xml:"seg"`
+}
+
+type tmxSegment struct {
+	Inner string `xml:",innerxml"`
+	Text  string `xml:",chardata"`
+}
+
+// ParseTMX 解析 TMX 文件
+// 片段中的行内标记（bpt、ept、ph、it 等）保留其原始代码文本，hi、sub 等保留其内容
+func ParseTMX(data []byte) (*TMXFile, error) {
+	var document tmxDocument
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "utf8") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTMX, err)
+	}
+
+	file := &TMXFile{
+		SourceLanguage: document.Header.SourceLanguage,
+		Units:          make([]TMXUnit, 0, len(document.Units)),
+	}
+	for _, raw := range document.Units {
+		unit := TMXUnit{
+			ID:             raw.ID,
+			SourceLanguage: raw.SourceLanguage,
+			Segments:       make(map[string]string, len(raw.Variants)),
+		}
+		for _, prop := range raw.Properties {
+			if unit.Properties == nil {
+				unit.Properties = make(map[string]string)
+			}
+			unit.Properties[prop.Type] = prop.Value
+		}
+		for _, variant := range raw.Variants {
+			lang := variant.Lang
+			if lang == "" {
+				lang = variant.LegacyLang
+			}
+			text, err := segmentText(variant.Segment.Inner)
+			if lang == "" || err != nil {
+				continue
+			}
+			unit.Segments[lang] = text
+		}
+		file.Units = append(file.Units, unit)
+	}
+	return file, nil
+}
+
+// segmentText 提取片段的全部文本内容
+func segmentText(inner string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader("<seg>" + inner + "</seg>"))
+	var builder strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return builder.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if data, ok := token.(xml.CharData); ok {
+			builder.Write(data)
+		}
+	}
+}
+
+// MarshalTMX 生成 TMX 1.4 文件，单元按传入顺序输出，属性按类型排序
+func MarshalTMX(file *TMXFile, creationTool string, createdAt time.Time) ([]byte, error) {
+	sourceLanguage := file.SourceLanguage
+	if sourceLanguage == "" {
+		sourceLanguage = "*all*"
+	}
+
+	document := tmxDocument{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool:        creationTool,
+			CreationToolVersion: "1.0",
+			SegType:             "sentence",
+			OriginalFormat:      creationTool,
+			AdminLang:           "en",
+			SourceLanguage:      sourceLanguage,
+			DataType:            "plaintext",
+			CreationDate:        createdAt.UTC().Format("20060102T150405Z"),
+		},
+		Units: make([]tmxUnit, 0, len(file.Units)),
+	}
+	for _, unit := range file.Units {
+		raw := tmxUnit{ID: unit.ID}
+		if unit.SourceLanguage != "" && unit.SourceLanguage != file.SourceLanguage {
+			raw.SourceLanguage = unit.SourceLanguage
+		}
+		for _, propType := range sortedKeys(unit.Properties) {
+			raw.Properties = append(raw.Properties, tmxProp{Type: propType, Value: unit.Properties[propType]})
+		}
+
+		// 源语言在前，其余语言按代码排序
+		languages := sortedKeys(unit.Segments)
+		source := unit.SourceLanguage
+		if source == "" {
+			source = file.SourceLanguage
+		}
+		for i, lang := range languages {
+			if lang == source {
+				copy(languages[1:i+1], languages[:i])
+				languages[0] = lang
+				break
+			}
+		}
+		for _, lang := range languages {
+			raw.Variants = append(raw.Variants, tmxVariant{Lang: lang, Segment: tmxSegment{Text: unit.Segments[lang]}})
+		}
+		document.Units = append(document.Units, raw)
+	}
+
+	output, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+// sortedKeys 返回按字母序排列的键
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// 翻译值类型
+const (
+	ValueTypePlain      = "plain"       // 纯文本，渲染时按文本转义，不限制内容
+	ValueTypeHTMLSubset = "html_subset" // 允许有限的 HTML 标签和属性
+	ValueTypeMarkdown   = "markdown"    // Markdown，不允许内嵌 HTML
+)
+
+// IsValueType 判断是否为支持的值类型
+func IsValueType(valueType string) bool {
+	switch valueType {
+	case ValueTypePlain, ValueTypeHTMLSubset, ValueTypeMarkdown:
+		return true
+	}
+	return false
+}
+
+// htmlSubsetTags html_subset 允许的标签
+var htmlSubsetTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "br": true, "code": true, "em": true, "i": true,
+	"kbd": true, "li": true, "mark": true, "ol": true, "p": true, "s": true, "small": true,
+	"span": true, "strong": true, "sub": true, "sup": true, "u": true, "ul": true,
+}
+
+// htmlSubsetAttributes html_subset 允许的属性，"*" 表示所有标签通用
+var htmlSubsetAttributes = map[string]map[string]bool{
+	"*": {"class": true, "title": true, "lang": true, "dir": true},
+	"a": {"href": true, "target": true, "rel": true},
+}
+
+var (
+	// i18next Trans 组件使用的编号标签，如 <0>、</0>、<1/>
+	transTagPattern = regexp.MustCompile(`</?\d+\s*/?>`)
+	// Markdown 链接和图片的目标地址，如 [text](url "title")
+	markdownLinkPattern = regexp.MustCompile(`\]\(\s*<?([^)\s>]*)`)
+	// Markdown 引用式链接定义，如 [id]: url
+	markdownReferencePattern = regexp.MustCompile(`(?m)^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s|$)`)
+	urlSchemePattern         = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*):`)
+)
+
+// safeURLSchemes 链接允许的协议，无协议的相对地址和锚点也允许
+var safeURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "tel": true}
+
+// ValidateValueType 按值类型校验翻译值，返回第一个不符合的问题
+func ValidateValueType(valueType, value string) error {
+	switch valueType {
+	case "", ValueTypePlain:
+		return nil
+	case ValueTypeHTMLSubset:
+		return validateHTMLSubset(value)
+	case ValueTypeMarkdown:
+		return validateMarkdown(value)
+	default:
+		return fmt.Errorf("unsupported value type %q", valueType)
+	}
+}
+
+// validateHTMLSubset 校验标签、属性和链接地址都在允许范围内
+func validateHTMLSubset(value string) error {
+	tokenizer := html.NewTokenizer(strings.NewReader(transTagPattern.ReplaceAllString(value, "")))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return err
+			}
+			return nil
+		case html.CommentToken:
+			return fmt.Errorf("HTML comments are not allowed")
+		case html.DoctypeToken:
+			return fmt.Errorf("doctype is not allowed")
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			token := tokenizer.Token()
+			if !htmlSubsetTags[token.Data] {
+				return fmt.Errorf("tag <%s> is not allowed", token.Data)
+			}
+			for _, attr := range token.Attr {
+				if !htmlSubsetAttributes["*"][attr.Key] && !htmlSubsetAttributes[token.Data][attr.Key] {
+					return fmt.Errorf("attribute %q is not allowed on <%s>", attr.Key, token.Data)
+				}
+				if attr.Key == "href" && !isSafeURL(attr.Val) {
+					return fmt.Errorf("link target %q is not allowed", attr.Val)
+				}
+			}
+		}
+	}
+}
+
+// validateMarkdown 校验不含内嵌 HTML（换行和编号标签除外），链接地址使用安全的协议
+func validateMarkdown(value string) error {
+	tokenizer := html.NewTokenizer(strings.NewReader(transTagPattern.ReplaceAllString(value, "")))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); err != io.EOF {
+				return err
+			}
+			break
+		}
+		switch tokenType {
+		case html.CommentToken:
+			return fmt.Errorf("HTML comments are not allowed in markdown")
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			if name, _ := tokenizer.TagName(); string(name) != "br" {
+				return fmt.Errorf("inline HTML <%s> is not allowed in markdown", name)
+			}
+		}
+	}
+
+	for _, pattern := range []*regexp.Regexp{markdownLinkPattern, markdownReferencePattern} {
+		for _, match := range pattern.FindAllStringSubmatch(value, -1) {
+			if !isSafeURL(match[1]) {
+				return fmt.Errorf("link target %q is not allowed", match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// isSafeURL 判断链接地址是否为允许的协议或相对地址，忽略协议中混入的空白和控制字符
+func isSafeURL(raw string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(raw))
+
+	match := urlSchemePattern.FindStringSubmatch(cleaned)
+	if match == nil {
+		return true
+	}
+	return safeURLSchemes[strings.ToLower(match[1])]
+}
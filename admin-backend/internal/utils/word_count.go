@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"unicode"
+)
+
+// TextCount 文本的词数和字符数
+type TextCount struct {
+	Words      int
+	Characters int
+}
+
+// CountText 统计文本的词数和字符数，HTML 标签和 syntax 语法的占位符不计
+// 汉字和日文假名不以空格分词，每个字计一个词；其他文字以连续的字母和数字为一个词，
+// 词内的撇号、连字符和数字中的小数点、千分位不拆分词；字符数不含空白
+func CountText(text, syntax string) TextCount {
+	var count TextCount
+	runes := []rune(html.UnescapeString(stripMarkup(text, syntax)))
+	inWord := false
+	for i, r := range runes {
+		switch {
+		case isIdeographic(r):
+			count.Words++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if !inWord {
+				count.Words++
+				inWord = true
+			}
+		case inWord && isWordJoiner(r) && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// 词内连接符，如 don't、e-mail、3.14
+		default:
+			inWord = false
+		}
+		if !unicode.IsSpace(r) {
+			count.Characters++
+		}
+	}
+	return count
+}
+
+// CountWords 统计文本的词数，规则同 CountText
+func CountWords(text, syntax string) int {
+	return CountText(text, syntax).Words
+}
+
+// stripMarkup 将 HTML 标签和占位符替换为空格
+func stripMarkup(text, syntax string) string {
+	patterns := []*regexp.Regexp{htmlTagPattern}
+	if pattern, ok := placeholderPatterns[syntax]; ok {
+		patterns = append(patterns, pattern)
+	}
+
+	var spans [][]int
+	for _, pattern := range patterns {
+		spans = append(spans, pattern.FindAllStringIndex(text, -1)...)
+	}
+	if len(spans) == 0 {
+		return text
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	result := make([]byte, 0, len(text))
+	last := 0
+	for _, span := range spans {
+		if span[0] < last {
+			continue
+		}
+		result = append(result, text[last:span[0]]...)
+		result = append(result, ' ')
+		last = span[1]
+	}
+	return string(append(result, text[last:]...))
+}
+
+// isIdeographic 判断是否为按字计词的文字：汉字、日文平假名和片假名，韩文以空格分词不在其列
+func isIdeographic(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)
+}
+
+// isWordJoiner 判断是否为词内连接符
+func isWordJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '‐', '.', ',', '_':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"i18n-flow/internal/api/middleware"
+	"i18n-flow/internal/api/routes"
+)
+
+// plainBodyRoutes 接收请求体但不含翻译内容的路由，请求体照常经过 XSS 清理和输入改写
+var plainBodyRoutes = map[string]bool{
+	"POST /api/translations/batch-delete":                                true,
+	"POST /api/translations/tree/by-project/:project_id/tags":            true,
+	"POST /api/translation-memory/import":                                true,
+	"POST /api/trash/by-project/:project_id/restore":                     true,
+	"POST /api/trash/by-project/:project_id/purge":                       true,
+	"POST /api/trash/projects/:id/restore":                               true,
+	"POST /api/glossary/by-project/:project_id":                          true,
+	"POST /api/glossary/by-project/:project_id/import":                   true,
+	"POST /api/glossary/global":                                          true,
+	"POST /api/glossary/global/import":                                   true,
+	"PUT /api/glossary/by-project/:project_id/:id":                       true,
+	"PUT /api/glossary/global/:id":                                       true,
+	"POST /api/git-sync/by-project/:project_id/import":                   true,
+	"POST /api/git-sync/by-project/:project_id/export":                   true,
+	"PUT /api/git-sync/by-project/:project_id":                           true,
+	"POST /api/cli/references":                                           true,
+	"POST /api/cli/extract":                                              true,
+	"POST /api/consistency/by-project/:project_id/merge":                 true,
+	"POST /api/in-context/by-project/:project_id/token":                  true,
+	"POST /api/invitations":                                              true,
+	"POST /api/projects":                                                 true,
+	"POST /api/projects/:project_id/clone":                               true,
+	"POST /api/projects/:project_id/members":                             true,
+	"PUT /api/projects/update/:id":                                       true,
+	"PUT /api/projects/:project_id/members/:user_id":                     true,
+	"POST /api/users":                                                    true,
+	"POST /api/users/:id/reset-password":                                 true,
+	"POST /api/user/change-password":                                     true,
+	"PUT /api/users/:id":                                                 true,
+	"POST /api/branches/by-project/:project_id":                          true,
+	"POST /api/branches/by-project/:project_id/:id/revert":               true,
+	"POST /api/login":                                                    true,
+	"POST /api/refresh":                                                  true,
+	"POST /api/register":                                                 true,
+	"POST /api/locks/by-project/:project_id":                             true,
+	"PUT /api/locks/by-project/:project_id/freeze":                       true,
+	"POST /api/languages":                                                true,
+	"PUT /api/languages/:id":                                             true,
+	"POST /api/key-usage/by-project/:project_id/deprecate":               true,
+	"POST /api/key-extraction/by-project/:project_id":                    true,
+	"POST /api/screenshots/by-project/:project_id":                       true,
+	"PUT /api/screenshots/by-project/:project_id/:id/regions":            true,
+	"POST /api/snapshots/by-project/:project_id":                         true,
+	"POST /api/distribution/by-project/:project_id/tokens":               true,
+	"POST /api/distribution/by-project/:project_id/releases":             true,
+	"POST /api/machine-translation/by-project/:project_id/pre-translate": true,
+	"PUT /api/release-gate/by-project/:project_id/criteria":              true,
+	"PUT /api/inheritance/by-project/:project_id":                        true,
+}
+
+// registeredBodyRoutes 注册所有路由，返回接收请求体的路由（“方法 路由模板”）
+func registeredBodyRoutes() map[string]bool {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	routes.NewRouter(routes.RouterDeps{Logger: zap.NewNop()}).SetupRoutes(engine, nil)
+
+	registered := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		switch route.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			registered[route.Method+" "+route.Path] = true
+		}
+	}
+	return registered
+}
+
+func TestTranslationContentRoutesCovered(t *testing.T) {
+	registered := registeredBodyRoutes()
+	content := make(map[string]bool)
+	for _, route := range middleware.TranslationContentRoutes() {
+		content[route] = true
+		// 登记的路由必须存在，路由改名后登记随之更新
+		assert.True(t, registered[route], "%s 登记了翻译内容字段但未注册", route)
+		assert.False(t, plainBodyRoutes[route], "%s 同时声明为不含翻译内容", route)
+	}
+
+	// 新增接收请求体的路由必须登记翻译内容字段，或明确声明为不含翻译内容
+	for route := range registered {
+		assert.True(t, content[route] || plainBodyRoutes[route],
+			"%s 接收请求体，需要在 translationContentFields 中登记翻译内容字段，或加入 plainBodyRoutes", route)
+	}
+	for route := range plainBodyRoutes {
+		assert.True(t, registered[route], "%s 声明为不含翻译内容但未注册", route)
+	}
+}
@@ -0,0 +1,140 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"i18n-flow/internal/api/middleware"
+)
+
+// newSQLSecurityRouter 注册几个声明了参数规则的路由和一个没有规则的路由
+func newSQLSecurityRouter(mode string) (*gin.Engine, *observer.ObservedLogs) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.WarnLevel)
+
+	config := middleware.DefaultSQLSecurityConfig()
+	config.Mode = mode
+	router := gin.New()
+	router.Use(middleware.SQLSecurityMiddlewareWithConfig(zap.New(core), config))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/translations/matrix/by-project/:project_id", ok)
+	router.GET("/api/key-usage/by-project/:project_id", ok)
+	router.GET("/api/exports/project/:project_id", ok)
+	router.GET("/api/translation-memory/suggestions/by-project/:project_id", ok)
+	router.GET("/api/cli/translations", ok)
+	router.GET("/api/languages", ok)
+	return router, logs
+}
+
+func serveQuery(router *gin.Engine, path string, query url.Values) int {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, path+"?"+query.Encode(), nil)
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestSQLSecurityInjectionPatterns(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		query      url.Values
+		suspicious bool
+	}{
+		{"keyword with keywords", "/api/translations/matrix/by-project/1", url.Values{"keyword": {"Create account"}}, false},
+		{"key name with keywords", "/api/key-usage/by-project/1", url.Values{"key_name": {"button.update"}}, false},
+		{"plain undeclared text", "/api/languages", url.Values{"filter": {"Select all, then update"}}, false},
+		{"or 1=1", "/api/languages", url.Values{"filter": {"' OR 1=1"}}, true},
+		{"union select", "/api/languages", url.Values{"filter": {"1 UNION SELECT password FROM users"}}, true},
+		{"undeclared on schema route", "/api/translations/matrix/by-project/1", url.Values{"filter": {"x' OR 'a'='a"}}, true},
+		{"drop table", "/api/languages", url.Values{"q": {"1; DROP TABLE users"}}, true},
+		{"sleep", "/api/languages", url.Values{"q": {"sleep(5)"}}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router, logs := newSQLSecurityRouter(middleware.SQLFilterModeBlock)
+			code := serveQuery(router, c.path, c.query)
+			if c.suspicious {
+				assert.Equal(t, http.StatusBadRequest, code)
+				assert.Equal(t, 1, logs.FilterLevelExact(zapcore.ErrorLevel).Len())
+			} else {
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, 0, logs.Len())
+			}
+
+			// detect 模式下只记录日志，不拒绝请求
+			router, logs = newSQLSecurityRouter(middleware.SQLFilterModeDetect)
+			assert.Equal(t, http.StatusOK, serveQuery(router, c.path, c.query))
+			if c.suspicious {
+				assert.Equal(t, 1, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+			} else {
+				assert.Equal(t, 0, logs.Len())
+			}
+		})
+	}
+}
+
+func TestSQLSecurityQueryParamSchema(t *testing.T) {
+	cases := []struct {
+		name  string
+		path  string
+		query url.Values
+		valid bool
+	}{
+		{"page", "/api/translations/matrix/by-project/1", url.Values{"page": {"2"}, "page_size": {"100"}}, true},
+		{"page not int", "/api/translations/matrix/by-project/1", url.Values{"page": {"abc"}}, false},
+		{"page below min", "/api/translations/matrix/by-project/1", url.Values{"page": {"0"}}, false},
+		{"page size above max", "/api/translations/matrix/by-project/1", url.Values{"page_size": {"101"}}, false},
+		{"keyword too long", "/api/translations/matrix/by-project/1", url.Values{"keyword": {strings.Repeat("键", 201)}}, false},
+		{"text not pattern checked", "/api/translations/matrix/by-project/1", url.Values{"keyword": {"' OR 1=1"}}, true},
+		{"enum", "/api/exports/project/1", url.Values{"placeholders": {"ios"}, "format": {"json"}}, true},
+		{"enum unknown", "/api/exports/project/1", url.Values{"placeholders": {"printf"}}, false},
+		{"bool", "/api/exports/project/1", url.Values{"pseudo": {"true"}}, true},
+		{"bool invalid", "/api/exports/project/1", url.Values{"pseudo": {"maybe"}}, false},
+		{"int range", "/api/exports/project/1", url.Values{"pseudo_expansion": {"301"}}, false},
+		{"float", "/api/translation-memory/suggestions/by-project/1", url.Values{"min_score": {"0.75"}}, true},
+		{"float above max", "/api/translation-memory/suggestions/by-project/1", url.Values{"min_score": {"1.5"}}, false},
+		{"float not number", "/api/translation-memory/suggestions/by-project/1", url.Values{"min_score": {"high"}}, false},
+		{"token", "/api/cli/translations", url.Values{"locale": {"zh-Hans_CN"}, "project_id": {"3"}}, true},
+		{"token with space", "/api/cli/translations", url.Values{"locale": {"en US"}}, false},
+		{"id below min", "/api/cli/translations", url.Values{"project_id": {"0"}}, false},
+		{"undeclared sort", "/api/languages", url.Values{"sort": {"name desc"}}, true},
+		{"undeclared sort field", "/api/languages", url.Values{"sort": {"password"}}, false},
+		{"undeclared limit", "/api/languages", url.Values{"limit": {"5000"}}, false},
+		{"invalid path id", "/api/translations/matrix/by-project/abc", url.Values{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// 类型、枚举和范围校验不受 detect 模式影响
+			for _, mode := range []string{middleware.SQLFilterModeBlock, middleware.SQLFilterModeDetect} {
+				router, _ := newSQLSecurityRouter(mode)
+				code := serveQuery(router, c.path, c.query)
+				if c.valid {
+					assert.Equal(t, http.StatusOK, code, mode)
+				} else {
+					assert.Equal(t, http.StatusBadRequest, code, mode)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLSecurityInvalidPattern(t *testing.T) {
+	config := middleware.DefaultSQLSecurityConfig()
+	config.InjectionPatterns = append(config.InjectionPatterns, `(UNION`)
+
+	assert.Panics(t, func() {
+		middleware.SQLSecurityMiddlewareWithConfig(zap.NewNop(), config)
+	})
+}
@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestApplyBranchChanges(t *testing.T) {
+	matrix := map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Start"},
+		"home.old":   {"en": "Old"},
+	}
+	changes := []*domain.BranchChange{
+		{KeyName: "home.title", LanguageCode: "de", Value: "Startseite"},
+		{KeyName: "home.old", LanguageCode: "en", Deleted: true},
+		{KeyName: "checkout.pay", LanguageCode: "en", Value: "Pay"},
+	}
+
+	result := service.ApplyBranchChanges(matrix, changes)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title":   {"en": "Home", "de": "Startseite"},
+		"checkout.pay": {"en": "Pay"},
+	}, result)
+}
+
+func TestPlanBranchMerge(t *testing.T) {
+	main := map[string]map[string]string{
+		"home.title":  {"en": "Home", "de": "Startseite"},
+		"home.body":   {"en": "Welcome back"},
+		"home.footer": {"en": "Footer"},
+	}
+	changes := []*domain.BranchChange{
+		// 主干未变，采用分支的修改
+		{KeyName: "home.footer", LanguageCode: "en", Value: "New footer", BaseValue: "Footer", BaseExists: true},
+		// 分支新增的键
+		{KeyName: "checkout.pay", LanguageCode: "en", Value: "Pay"},
+		// 主干与分支改成了相同的译文
+		{KeyName: "home.title", LanguageCode: "de", Value: "Startseite", BaseValue: "Start", BaseExists: true},
+		// 双方都修改了
+		{KeyName: "home.body", LanguageCode: "en", Value: "Welcome", BaseValue: "Hello", BaseExists: true},
+		// 分支删除，主干修改了
+		{KeyName: "home.title", LanguageCode: "en", Deleted: true, BaseValue: "Start page", BaseExists: true},
+	}
+
+	apply, conflicts := service.PlanBranchMerge(main, changes)
+	require.Len(t, apply, 2)
+	assert.Equal(t, "home.footer", apply[0].KeyName)
+	assert.Equal(t, "checkout.pay", apply[1].KeyName)
+
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "home.body", conflicts[0].KeyName)
+	assert.Equal(t, "Hello", *conflicts[0].Base)
+	assert.Equal(t, "Welcome back", *conflicts[0].Main)
+	assert.Equal(t, "Welcome", *conflicts[0].Branch)
+
+	assert.Equal(t, "home.title", conflicts[1].KeyName)
+	assert.Equal(t, "Home", *conflicts[1].Main)
+	assert.Nil(t, conflicts[1].Branch)
+}
+
+func TestPlanBranchMergeMainDeleted(t *testing.T) {
+	changes := []*domain.BranchChange{
+		{KeyName: "home.title", LanguageCode: "en", Value: "Homepage", BaseValue: "Home", BaseExists: true},
+	}
+
+	apply, conflicts := service.PlanBranchMerge(map[string]map[string]string{}, changes)
+	assert.Empty(t, apply)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "Home", *conflicts[0].Base)
+	assert.Nil(t, conflicts[0].Main)
+	assert.Equal(t, "Homepage", *conflicts[0].Branch)
+}
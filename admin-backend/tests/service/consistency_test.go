@@ -0,0 +1,256 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubProjectTranslationRepo 在现有翻译上补充按项目列出翻译
+type stubProjectTranslationRepo struct {
+	stubActiveTranslationRepo
+}
+
+func (r *stubProjectTranslationRepo) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
+	return r.active, int64(len(r.active)), nil
+}
+
+// stubKeyMergeRepo 记录执行的键合并
+type stubKeyMergeRepo struct {
+	merges []domain.KeyMerge
+}
+
+func (r *stubKeyMergeRepo) MergeKeys(ctx context.Context, merge domain.KeyMerge) error {
+	r.merges = append(r.merges, merge)
+	return nil
+}
+
+// stubTranslationWriter 记录通过翻译服务的写入，写入前按 CheckWrites 检查
+type stubTranslationWriter struct {
+	stubWriteChecker
+	upserted   []domain.TranslationInput
+	deletedIDs []uint64
+}
+
+func (w *stubTranslationWriter) UpsertBatch(ctx context.Context, inputs []domain.TranslationInput) error {
+	w.upserted = append(w.upserted, inputs...)
+	return nil
+}
+
+func (w *stubTranslationWriter) DeleteBatch(ctx context.Context, ids []uint64) error {
+	w.deletedIDs = append(w.deletedIDs, ids...)
+	return nil
+}
+
+type consistencyFixture struct {
+	service *service.ConsistencyService
+	writer  *stubTranslationWriter
+	merges  *stubKeyMergeRepo
+}
+
+// newConsistencyFixture 三个键的英文原文相同（空白不同），中文译文不一致，法语只有 cancel 有译文；
+// title 的原文不同，legacy 已废弃
+func newConsistencyFixture() *consistencyFixture {
+	english := &domain.Language{ID: 1, Code: "en", IsDefault: true, Status: "active"}
+	chinese := &domain.Language{ID: 2, Code: "zh-CN", Status: "active"}
+	french := &domain.Language{ID: 3, Code: "fr", Status: "active"}
+	german := &domain.Language{ID: 4, Code: "de", Status: "inactive"}
+
+	id := uint64(0)
+	translation := func(keyName string, language *domain.Language, value string) *domain.Translation {
+		id++
+		return &domain.Translation{
+			ID: id, ProjectID: 1, KeyName: keyName, LanguageID: language.ID, Language: *language,
+			Value: value, Status: "active", Context: keyName + " context",
+		}
+	}
+	legacy := translation("legacy.close", english, "Close")
+	legacy.Status = "deprecated"
+	translations := []*domain.Translation{
+		translation("common.close", english, "Close"),
+		translation("common.close", chinese, "关闭"),
+		translation("dialog.close", english, " Close "),
+		translation("dialog.close", chinese, "关掉"),
+		translation("dialog.close", german, "Schließen"),
+		translation("modal.close", english, "Close"),
+		translation("modal.close", chinese, "关闭"),
+		translation("modal.close", french, "Fermer"),
+		translation("title", english, "Title"),
+		translation("title", chinese, "标题"),
+		legacy,
+	}
+
+	f := &consistencyFixture{
+		writer: &stubTranslationWriter{},
+		merges: &stubKeyMergeRepo{},
+	}
+	f.writer.existing = make(map[string]map[uint64]string)
+	for _, t := range translations {
+		if f.writer.existing[t.KeyName] == nil {
+			f.writer.existing[t.KeyName] = make(map[uint64]string)
+		}
+		f.writer.existing[t.KeyName][t.LanguageID] = t.Value
+	}
+	f.service = service.NewConsistencyService(
+		&stubProjectRepo{projects: map[uint64]*domain.Project{1: {ID: 1, Name: "App", Slug: "app"}}},
+		&stubLanguageRepo{languages: []*domain.Language{english, chinese, french, german}},
+		&stubProjectTranslationRepo{stubActiveTranslationRepo{active: translations}},
+		f.merges,
+		f.writer,
+	)
+	return f
+}
+
+func TestAnalyzeDuplicates(t *testing.T) {
+	f := newConsistencyFixture()
+
+	report, err := f.service.Analyze(context.Background(), 1, false)
+	require.NoError(t, err)
+
+	// 原文忽略首尾空白后相同的有效键为一组，废弃的键和停用的语言不参与
+	assert.Equal(t, "en", report.SourceLanguage)
+	assert.Equal(t, 3, report.DuplicateKeys)
+	assert.Equal(t, 1, report.InconsistentGroups)
+	require.Len(t, report.Groups, 1)
+	group := report.Groups[0]
+	assert.Equal(t, "Close", group.Source)
+	assert.Equal(t, []string{"common.close", "dialog.close", "modal.close"}, group.Keys)
+	assert.Equal(t, []string{"zh-CN"}, group.InconsistentLanguages)
+	// 使用最多的译文排在前面
+	if assert.Len(t, group.Variants["zh-CN"], 2) {
+		assert.Equal(t, "关闭", group.Variants["zh-CN"][0].Value)
+		assert.ElementsMatch(t, []string{"common.close", "modal.close"}, group.Variants["zh-CN"][0].Keys)
+		assert.Equal(t, "关掉", group.Variants["zh-CN"][1].Value)
+	}
+}
+
+func TestMergeKeys(t *testing.T) {
+	f := newConsistencyFixture()
+
+	result, err := f.service.MergeKeys(context.Background(), domain.MergeKeysParams{
+		ProjectID:  1,
+		TargetKey:  "dialog.close",
+		SourceKeys: []string{"modal.close", " common.close ", "dialog.close"},
+	})
+	require.NoError(t, err)
+
+	// 目标键缺少的法语从被合并的键补充，与目标不同的中文译文被丢弃
+	assert.Equal(t, []string{"modal.close", "common.close"}, result.MergedKeys)
+	assert.Equal(t, []string{"fr"}, result.FilledLanguages)
+	assert.ElementsMatch(t, []*domain.DiscardedValue{
+		{KeyName: "modal.close", Language: "zh-CN", Value: "关闭"},
+		{KeyName: "common.close", Language: "zh-CN", Value: "关闭"},
+	}, result.Discarded)
+	// 补充译文、改写引用和删除在一次合并中写入，不经过翻译服务分别写入
+	require.Len(t, f.merges.merges, 1)
+	merge := f.merges.merges[0]
+	assert.Equal(t, uint64(1), merge.ProjectID)
+	assert.Equal(t, "dialog.close", merge.TargetKey)
+	assert.Equal(t, []string{"modal.close", "common.close"}, merge.SourceKeys)
+	assert.Equal(t, []*domain.Translation{
+		{ProjectID: 1, KeyName: "dialog.close", Context: "dialog.close context", LanguageID: 3, Value: "Fermer", Status: "active"},
+	}, merge.Fills)
+	assert.ElementsMatch(t, []uint64{2, 3, 7, 8, 9}, merge.DeleteIDs)
+	assert.Empty(t, f.writer.upserted)
+	assert.Empty(t, f.writer.deletedIDs)
+}
+
+func TestMergeKeysChecksDeletesFirst(t *testing.T) {
+	f := newConsistencyFixture()
+	// 被合并的键的中文被锁定，整个合并都不应执行
+	f.writer.restrictions = domain.WriteRestrictions{
+		Locks: []*domain.TranslationLock{{ProjectID: 1, KeyName: "modal.close", LanguageID: 2}},
+	}
+
+	_, err := f.service.MergeKeys(context.Background(), domain.MergeKeysParams{
+		ProjectID:  1,
+		TargetKey:  "dialog.close",
+		SourceKeys: []string{"modal.close"},
+	})
+	var appErr *domain.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "TRANSLATION_LOCKED", appErr.Code)
+	}
+	assert.Empty(t, f.merges.merges)
+
+	// 字符串冻结期间删除默认语言的单元格同样被拒绝
+	f.writer.restrictions = domain.WriteRestrictions{StringFreeze: true, SourceLanguageID: 1}
+	_, err = f.service.MergeKeys(context.Background(), domain.MergeKeysParams{
+		ProjectID:  1,
+		TargetKey:  "dialog.close",
+		SourceKeys: []string{"common.close"},
+	})
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "STRING_FROZEN", appErr.Code)
+	}
+	assert.Empty(t, f.merges.merges)
+}
+
+func TestMergeKeysNotDuplicates(t *testing.T) {
+	f := newConsistencyFixture()
+	ctx := context.Background()
+
+	_, err := f.service.MergeKeys(ctx, domain.MergeKeysParams{ProjectID: 1, TargetKey: "common.close", SourceKeys: []string{"title"}})
+	assert.Equal(t, domain.ErrKeysNotDuplicates, err)
+
+	_, err = f.service.MergeKeys(ctx, domain.MergeKeysParams{ProjectID: 1, TargetKey: "common.close", SourceKeys: []string{"legacy.close"}})
+	var appErr *domain.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "UNKNOWN_MERGE_KEYS", appErr.Code)
+	}
+
+	_, err = f.service.MergeKeys(ctx, domain.MergeKeysParams{ProjectID: 1, TargetKey: "common.close", SourceKeys: []string{"common.close"}})
+	assert.Equal(t, domain.ErrInvalidInput, err)
+	assert.Empty(t, f.merges.merges)
+}
+
+func TestHarmonize(t *testing.T) {
+	f := newConsistencyFixture()
+
+	result, err := f.service.Harmonize(context.Background(), domain.HarmonizeParams{
+		ProjectID: 1,
+		KeyNames:  []string{"common.close", "dialog.close", "modal.close"},
+		Language:  "zh-CN",
+		FromKey:   "common.close",
+	})
+	require.NoError(t, err)
+
+	// 只写入译文不同的键
+	assert.Equal(t, "关闭", result.Value)
+	assert.Equal(t, []string{"dialog.close"}, result.Updated)
+	assert.Equal(t, []domain.TranslationInput{
+		{ProjectID: 1, LanguageID: 2, KeyName: "dialog.close", Context: "dialog.close context", Value: "关闭"},
+	}, f.writer.upserted)
+
+	// 没有译文时使用默认语言翻译的上下文
+	result, err = f.service.Harmonize(context.Background(), domain.HarmonizeParams{
+		ProjectID: 1,
+		KeyNames:  []string{"common.close", "modal.close"},
+		Language:  "fr",
+		Value:     "Fermer",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"common.close"}, result.Updated)
+	assert.Equal(t, "common.close context", f.writer.upserted[1].Context)
+}
+
+func TestHarmonizeInvalid(t *testing.T) {
+	f := newConsistencyFixture()
+	ctx := context.Background()
+
+	_, err := f.service.Harmonize(ctx, domain.HarmonizeParams{ProjectID: 1, KeyNames: []string{"common.close", "dialog.close"}, Language: "fr", FromKey: "common.close"})
+	assert.Equal(t, domain.ErrHarmonizeNoValue, err)
+
+	_, err = f.service.Harmonize(ctx, domain.HarmonizeParams{ProjectID: 1, KeyNames: []string{"common.close"}, Language: "en", Value: "Close"})
+	assert.Equal(t, domain.ErrInvalidInput, err)
+
+	_, err = f.service.Harmonize(ctx, domain.HarmonizeParams{ProjectID: 1, KeyNames: []string{"common.close"}, Language: "de", Value: "Schließen"})
+	assert.Equal(t, domain.ErrLanguageNotFound, err)
+	assert.Empty(t, f.writer.upserted)
+}
@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubDistributionRepo 和数据库事务一样依次为发布分配版本号
+type stubDistributionRepo struct {
+	domain.DistributionRepository
+	mu       sync.Mutex
+	releases []*domain.DistributionRelease
+}
+
+func (r *stubDistributionRepo) CreateRelease(ctx context.Context, release *domain.DistributionRelease, sign func(release *domain.DistributionRelease) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	release.Version = 1
+	for _, existing := range r.releases {
+		if existing.ProjectID == release.ProjectID && existing.Environment == release.Environment && existing.Version >= release.Version {
+			release.Version = existing.Version + 1
+		}
+	}
+	if err := sign(release); err != nil {
+		return err
+	}
+	r.releases = append(r.releases, release)
+	return nil
+}
+
+// stubBundleStorage 丢弃写入的译文包
+type stubBundleStorage struct {
+	domain.FileStorage
+}
+
+func (s *stubBundleStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return nil
+}
+
+func TestPublishAllocatesVersions(t *testing.T) {
+	_, signingKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	releases := &stubDistributionRepo{}
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{2: {ID: 2, Name: "App", Slug: "app"}}}
+	distributionService := service.NewDistributionService(releases, projects, newInheritedTranslationService(), nil, &stubBundleStorage{}, signingKey)
+
+	// 并发发布到同一环境，版本号依次分配，签名的清单带有各自的版本号
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := distributionService.Publish(context.Background(), domain.PublishDistributionParams{ProjectID: 2, Environment: "production", UserID: 1})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	versions := make([]int, 0, len(releases.releases))
+	for _, release := range releases.releases {
+		var manifest domain.DistributionManifest
+		assert.NoError(t, json.Unmarshal([]byte(release.Manifest), &manifest))
+		assert.Equal(t, release.Version, manifest.Version)
+
+		signature, err := base64.StdEncoding.DecodeString(release.Signature)
+		assert.NoError(t, err)
+		assert.True(t, ed25519.Verify(signingKey.Public().(ed25519.PublicKey), []byte(release.Manifest), signature))
+		versions = append(versions, release.Version)
+	}
+	sort.Ints(versions)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, versions)
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// newObjectStoreServer 创建内存中的 S3 兼容服务，按路径保存对象，并检查请求已签名
+func newObjectStoreServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio/") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, objects
+}
+
+func TestS3FileStorage(t *testing.T) {
+	ctx := context.Background()
+	server, objects := newObjectStoreServer(t)
+	storage := service.NewS3FileStorage(server.URL, "us-east-1", "i18n", "minio", "minio-secret", true, server.Client())
+
+	require.NoError(t, storage.Put(ctx, "screenshots/1/a.png", []byte("png-data"), "image/png"))
+	assert.Equal(t, []byte("png-data"), objects["/i18n/screenshots/1/a.png"])
+
+	data, err := storage.Get(ctx, "screenshots/1/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-data"), data)
+
+	require.NoError(t, storage.Delete(ctx, "screenshots/1/a.png"))
+	_, err = storage.Get(ctx, "screenshots/1/a.png")
+	assert.ErrorIs(t, err, domain.ErrStoredFileNotFound)
+
+	// 凭证错误时返回包含状态码的错误
+	denied := service.NewS3FileStorage(server.URL, "us-east-1", "i18n", "other", "secret", true, server.Client())
+	err = denied.Put(ctx, "screenshots/1/b.png", []byte("x"), "image/png")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func TestLocalFileStorage(t *testing.T) {
+	ctx := context.Background()
+	storage := service.NewLocalFileStorage(t.TempDir())
+
+	require.NoError(t, storage.Put(ctx, "screenshots/1/a.png", []byte("png-data"), "image/png"))
+	data, err := storage.Get(ctx, "screenshots/1/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-data"), data)
+
+	require.NoError(t, storage.Delete(ctx, "screenshots/1/a.png"))
+	_, err = storage.Get(ctx, "screenshots/1/a.png")
+	assert.ErrorIs(t, err, domain.ErrStoredFileNotFound)
+	assert.NoError(t, storage.Delete(ctx, "screenshots/1/a.png"))
+
+	// 不允许跳出存储目录
+	for _, key := range []string{"../escape.png", "/etc/passwd", "a/../../b", ""} {
+		assert.Error(t, storage.Put(ctx, key, []byte("x"), ""), key)
+	}
+}
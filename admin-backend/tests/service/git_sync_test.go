@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestPlanGitExport(t *testing.T) {
+	str := func(s string) *string { return &s }
+	server := map[string]map[string]string{
+		"title":   {"en": "Title", "de": "Titel", "fr": ""},
+		"save":    {"en": "Save", "de": "Speichern (Server)"},
+		"removed": {"de": "Entfernt"},
+	}
+	files := map[string]map[string]string{
+		"en": {"title": "Title", "save": "Save"},
+		"de": {"title": "Titel", "save": "Speichern (Repo)"},
+	}
+	conflicts := []*domain.SyncConflict{
+		{KeyName: "save", LanguageCode: "de", Server: str("Speichern (Server)"), Local: str("Speichern (Repo)")},
+		{KeyName: "removed", LanguageCode: "de", Server: str("Entfernt"), Local: nil},
+	}
+
+	planned := service.PlanGitExport(server, files, []string{"de", "en", "fr", "ja"}, conflicts)
+
+	// en 与仓库一致不改写；冲突的单元格保留仓库中的值，de 没有变化；ja 两边都没有译文不创建文件
+	assert.Equal(t, map[string]map[string]string{}, planned)
+
+	// 没有冲突时导出项目的译文，新增有译文的语言文件，空译文不导出
+	planned = service.PlanGitExport(server, files, []string{"de", "en", "fr"}, nil)
+	assert.Equal(t, map[string]map[string]string{
+		"de": {"title": "Titel", "save": "Speichern (Server)", "removed": "Entfernt"},
+	}, planned)
+
+	server["title"]["fr"] = "Titre"
+	planned = service.PlanGitExport(server, files, []string{"fr"}, nil)
+	assert.Equal(t, map[string]map[string]string{"fr": {"title": "Titre"}}, planned)
+}
+
+func TestPlanGitExportDeletesKeysRemovedFromProject(t *testing.T) {
+	files := map[string]map[string]string{"en": {"title": "Title", "old": "Old"}}
+	server := map[string]map[string]string{"title": {"en": "Title"}}
+
+	planned := service.PlanGitExport(server, files, []string{"en"}, nil)
+	assert.Equal(t, map[string]map[string]string{"en": {"title": "Title"}}, planned)
+
+	// 仓库中已有的文件即使没有译文也写成空文件，不删除文件
+	planned = service.PlanGitExport(map[string]map[string]string{}, files, []string{"en"}, nil)
+	assert.Equal(t, map[string]map[string]string{"en": {}}, planned)
+}
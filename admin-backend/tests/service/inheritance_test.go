@@ -0,0 +1,159 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubInheritedProjectBaseRepo 按项目返回固定的基础项目
+type stubInheritedProjectBaseRepo struct {
+	domain.ProjectBaseRepository
+	bases map[uint64][]uint64
+}
+
+func (r *stubInheritedProjectBaseRepo) ListByProject(ctx context.Context, projectID uint64) ([]*domain.ProjectBase, error) {
+	links := make([]*domain.ProjectBase, 0, len(r.bases[projectID]))
+	for position, baseID := range r.bases[projectID] {
+		links = append(links, &domain.ProjectBase{ProjectID: projectID, BaseProjectID: baseID, Position: position})
+	}
+	return links, nil
+}
+
+// stubInheritedTranslationRepo 按项目保存单元格（键名 -> 语言代码 -> 单元格）
+type stubInheritedTranslationRepo struct {
+	domain.TranslationRepository
+	cells map[uint64]map[string]map[string]domain.TranslationCell
+}
+
+func (r *stubInheritedTranslationRepo) GetKeyNames(ctx context.Context, projectIDs []uint64, keyword string) ([]string, error) {
+	seen := make(map[string]bool)
+	keyNames := make([]string, 0)
+	for _, projectID := range projectIDs {
+		for keyName := range r.cells[projectID] {
+			if !seen[keyName] {
+				seen[keyName] = true
+				keyNames = append(keyNames, keyName)
+			}
+		}
+	}
+	sort.Strings(keyNames)
+	return keyNames, nil
+}
+
+func (r *stubInheritedTranslationRepo) GetCellsByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]domain.TranslationCell, error) {
+	cells := make(map[string]map[string]domain.TranslationCell)
+	for _, keyName := range keyNames {
+		if languageCells, ok := r.cells[projectID][keyName]; ok {
+			cells[keyName] = languageCells
+		}
+	}
+	return cells, nil
+}
+
+func (r *stubInheritedTranslationRepo) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	return r.cells[projectID], int64(len(r.cells[projectID])), nil
+}
+
+// newInheritedTranslationService 项目 2 继承项目 1 的 common.ok，并覆盖了中文
+func newInheritedTranslationService() *service.TranslationService {
+	translations := &stubInheritedTranslationRepo{cells: map[uint64]map[string]map[string]domain.TranslationCell{
+		1: {"common.ok": {"en": {ID: 1, Value: "OK"}, "zh-CN": {ID: 2, Value: "好"}}},
+		2: {
+			"common.ok":  {"zh-CN": {ID: 3, Value: "确定"}},
+			"home.title": {"en": {ID: 4, Value: "Home"}},
+		},
+	}}
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{
+		1: {ID: 1, Name: "Common", Slug: "common"},
+		2: {ID: 2, Name: "App", Slug: "app"},
+	}}
+	languages := &stubLanguageRepo{languages: []*domain.Language{
+		{ID: 1, Code: "en", IsDefault: true, Status: "active"},
+		{ID: 2, Code: "zh-CN", Status: "active"},
+	}}
+	bases := &stubInheritedProjectBaseRepo{bases: map[uint64][]uint64{2: {1}}}
+	return service.NewTranslationService(translations, projects, languages, nil, nil, &stubLockRepo{}, bases)
+}
+
+func TestResolveInheritance(t *testing.T) {
+	// 4 继承 3 和 2，3 继承 1，2 也继承 1
+	bases := map[uint64][]uint64{
+		4: {3, 2},
+		3: {1},
+		2: {1},
+	}
+	assert.Equal(t, []uint64{4, 3, 1, 2}, service.ResolveInheritance(4, bases))
+	assert.Equal(t, []uint64{1}, service.ResolveInheritance(1, bases))
+
+	// 继承关系中的环被忽略
+	assert.Equal(t, []uint64{1, 2}, service.ResolveInheritance(1, map[uint64][]uint64{1: {2}, 2: {1}}))
+}
+
+func TestMergeInheritedCells(t *testing.T) {
+	cells := map[uint64]map[string]map[string]domain.TranslationCell{
+		3: {
+			"common.ok":  {"de": {ID: 30, Value: "Okay"}},
+			"home.title": {"en": {ID: 31, Value: "Home"}},
+		},
+		1: {
+			"common.ok":     {"en": {ID: 10, Value: "OK"}, "de": {ID: 11, Value: "OK"}},
+			"common.cancel": {"en": {ID: 12, Value: "Cancel"}},
+		},
+		2: {
+			"common.cancel": {"en": {ID: 20, Value: "Abort"}, "fr": {ID: 21, Value: "Annuler"}},
+		},
+	}
+
+	merged := service.MergeInheritedCells([]uint64{3, 1, 2}, cells)
+	assert.Equal(t, map[string]map[string]domain.TranslationCell{
+		"common.ok": {
+			"de": {ID: 30, Value: "Okay"},
+			"en": {ID: 10, Value: "OK", InheritedFrom: 1},
+		},
+		"common.cancel": {
+			"en": {ID: 12, Value: "Cancel", InheritedFrom: 1},
+			"fr": {ID: 21, Value: "Annuler", InheritedFrom: 2},
+		},
+		"home.title": {"en": {ID: 31, Value: "Home"}},
+	}, merged)
+}
+
+// stubGitSyncRepo 返回固定的 git 仓库同步设置
+type stubGitSyncRepo struct {
+	domain.GitSyncRepository
+	gitSyncs map[uint64]*domain.GitSync
+}
+
+func (r *stubGitSyncRepo) GetByProject(ctx context.Context, projectID uint64) (*domain.GitSync, error) {
+	if gitSync, ok := r.gitSyncs[projectID]; ok {
+		return gitSync, nil
+	}
+	return nil, domain.ErrGitSyncNotConfigured
+}
+
+func TestInheritanceExcludesGitSync(t *testing.T) {
+	ctx := context.Background()
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{
+		1: {ID: 1, Name: "Common", Slug: "common"},
+		2: {ID: 2, Name: "App", Slug: "app"},
+		3: {ID: 3, Name: "Web", Slug: "web"},
+	}}
+	bases := &stubInheritedProjectBaseRepo{bases: map[uint64][]uint64{3: {1}}}
+	gitSyncs := &stubGitSyncRepo{gitSyncs: map[uint64]*domain.GitSync{2: {ProjectID: 2}}}
+
+	// 配置了 git 仓库同步的项目不能设置基础项目
+	inheritanceService := service.NewInheritanceService(projects, bases, gitSyncs, nil)
+	_, err := inheritanceService.SetBases(ctx, domain.SetBaseProjectsParams{ProjectID: 2, BaseProjectIDs: []uint64{1}, UserID: 1})
+	assert.Equal(t, domain.ErrInheritanceGitSync, err)
+
+	// 继承了基础项目的项目不能配置 git 仓库同步
+	gitSyncService := service.NewGitSyncService(gitSyncs, projects, bases, nil, nil, nil, nil, nil)
+	_, err = gitSyncService.Save(ctx, domain.SaveGitSyncParams{ProjectID: 3, RepositoryURL: "git@example.com:org/web.git", UserID: 1})
+	assert.Equal(t, domain.ErrInheritanceGitSync, err)
+}
@@ -0,0 +1,314 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubProjectRepo 按ID返回项目，回收站中的项目按名称或标识查找
+type stubProjectRepo struct {
+	domain.ProjectRepository
+	projects map[uint64]*domain.Project
+	trashed  []*domain.Project
+}
+
+func (r *stubProjectRepo) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if project, ok := r.projects[id]; ok {
+		return project, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r *stubProjectRepo) GetBySlug(ctx context.Context, slug string) (*domain.Project, error) {
+	for _, project := range r.projects {
+		if project.Slug == slug {
+			return project, nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r *stubProjectRepo) FindDeletedByNameOrSlug(ctx context.Context, name, slug string) (*domain.Project, error) {
+	for _, project := range r.trashed {
+		if project.Name == name || project.Slug == slug {
+			return project, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubProjectRepo) Update(ctx context.Context, project *domain.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+// stubLanguageRepo 返回固定的语言列表
+type stubLanguageRepo struct {
+	domain.LanguageRepository
+	languages []*domain.Language
+}
+
+func (r *stubLanguageRepo) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return r.languages, nil
+}
+
+func (r *stubLanguageRepo) GetDefault(ctx context.Context) (*domain.Language, error) {
+	for _, language := range r.languages {
+		if language.IsDefault {
+			return language, nil
+		}
+	}
+	return nil, domain.ErrLanguageNotFound
+}
+
+func (r *stubLanguageRepo) GetByCode(ctx context.Context, code string) (*domain.Language, error) {
+	for _, language := range r.languages {
+		if language.Code == code {
+			return language, nil
+		}
+	}
+	return nil, domain.ErrLanguageNotFound
+}
+
+// stubLockRepo 返回固定的锁定列表
+type stubLockRepo struct {
+	domain.TranslationLockRepository
+	locks []*domain.TranslationLock
+}
+
+func (r *stubLockRepo) ListByProject(ctx context.Context, projectID uint64) ([]*domain.TranslationLock, error) {
+	return r.locks, nil
+}
+
+// stubProjectBaseRepo 没有基础项目
+type stubProjectBaseRepo struct {
+	domain.ProjectBaseRepository
+}
+
+func (r *stubProjectBaseRepo) ListByProject(ctx context.Context, projectID uint64) ([]*domain.ProjectBase, error) {
+	return nil, nil
+}
+
+// treeTranslationRepo 按键名前缀过滤，并记录查询和删除使用的前缀
+type treeTranslationRepo struct {
+	domain.TranslationRepository
+	stats         []domain.KeyLanguageStat
+	cells         map[string]map[string]domain.TranslationCell
+	statsPrefix   string
+	deletedPrefix string
+}
+
+func (r *treeTranslationRepo) GetKeyLanguageStats(ctx context.Context, projectID uint64, prefix string) ([]domain.KeyLanguageStat, error) {
+	r.statsPrefix = prefix
+	stats := make([]domain.KeyLanguageStat, 0, len(r.stats))
+	for _, stat := range r.stats {
+		if strings.HasPrefix(stat.KeyName, prefix) {
+			stats = append(stats, stat)
+		}
+	}
+	return stats, nil
+}
+
+func (r *treeTranslationRepo) GetCellsByKeys(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]domain.TranslationCell, error) {
+	cells := make(map[string]map[string]domain.TranslationCell)
+	for _, keyName := range keyNames {
+		if languageCells, ok := r.cells[keyName]; ok {
+			cells[keyName] = languageCells
+		}
+	}
+	return cells, nil
+}
+
+func (r *treeTranslationRepo) DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error) {
+	r.deletedPrefix = prefix
+	var deleted int64
+	for _, stat := range r.stats {
+		if strings.HasPrefix(stat.KeyName, prefix) {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// treeKeyTagRepo 记录写入的标签和删除使用的前缀
+type treeKeyTagRepo struct {
+	domain.KeyTagRepository
+	tags          []*domain.KeyTag
+	created       []*domain.KeyTag
+	deletedPrefix string
+}
+
+func (r *treeKeyTagRepo) GetByProjectAndKeys(ctx context.Context, projectID uint64, keyNames []string) ([]*domain.KeyTag, error) {
+	return r.tags, nil
+}
+
+func (r *treeKeyTagRepo) CreateBatch(ctx context.Context, tags []*domain.KeyTag) error {
+	r.created = append(r.created, tags...)
+	return nil
+}
+
+func (r *treeKeyTagRepo) DeleteByKeyPrefix(ctx context.Context, projectID uint64, prefix string) error {
+	r.deletedPrefix = prefix
+	return nil
+}
+
+type keyTreeFixture struct {
+	service     *service.TranslationService
+	translation *treeTranslationRepo
+	keyTags     *treeKeyTagRepo
+	locks       *stubLockRepo
+	project     *domain.Project
+}
+
+func newKeyTreeFixture() *keyTreeFixture {
+	stat := func(keyName, code string, translated bool) domain.KeyLanguageStat {
+		return domain.KeyLanguageStat{KeyName: keyName, LanguageCode: code, Translated: translated}
+	}
+	f := &keyTreeFixture{
+		translation: &treeTranslationRepo{
+			stats: []domain.KeyLanguageStat{
+				stat("common.save", "en", true),
+				stat("common.save", "zh-CN", true),
+				stat("common.buttons.ok", "en", true),
+				stat("common.buttons.ok", "zh-CN", false),
+				stat("common.buttons.cancel", "en", true),
+				stat("commonx.title", "en", true),
+				stat("home.title", "en", true),
+			},
+			cells: map[string]map[string]domain.TranslationCell{
+				"common.save": {"en": {ID: 1, Value: "Save"}, "zh-CN": {ID: 2, Value: "保存"}},
+			},
+		},
+		keyTags: &treeKeyTagRepo{
+			tags: []*domain.KeyTag{{ProjectID: 1, KeyName: "common.save", Tag: "ui"}},
+		},
+		locks:   &stubLockRepo{},
+		project: &domain.Project{ID: 1, Name: "App", Slug: "app"},
+	}
+	languages := &stubLanguageRepo{languages: []*domain.Language{
+		{ID: 1, Code: "en", IsDefault: true, Status: "active"},
+		{ID: 2, Code: "zh-CN", Status: "active"},
+		{ID: 3, Code: "fr", Status: "inactive"},
+	}}
+	f.service = service.NewTranslationService(
+		f.translation,
+		&stubProjectRepo{projects: map[uint64]*domain.Project{1: f.project}},
+		languages,
+		f.keyTags,
+		nil,
+		f.locks,
+		&stubProjectBaseRepo{},
+	)
+	return f
+}
+
+func TestGetKeyTreeFolders(t *testing.T) {
+	f := newKeyTreeFixture()
+
+	tree, err := f.service.GetKeyTree(context.Background(), 1, " common. ", 0, 0)
+	require.NoError(t, err)
+
+	// 路径去掉多余的分隔符后按 "common." 前缀查询，commonx.title 不属于 common 文件夹
+	assert.Equal(t, "common.", f.translation.statsPrefix)
+	assert.Equal(t, "common", tree.Path)
+	if assert.Len(t, tree.Folders, 1) {
+		folder := tree.Folders[0]
+		assert.Equal(t, "buttons", folder.Name)
+		assert.Equal(t, "common.buttons", folder.Path)
+		assert.Equal(t, 2, folder.KeyCount)
+		assert.Equal(t, domain.LanguageCompletion{Translated: 2, Total: 2, Percent: 100}, folder.Completion["en"])
+		assert.Equal(t, domain.LanguageCompletion{Translated: 0, Total: 2, Percent: 0}, folder.Completion["zh-CN"])
+	}
+
+	assert.Equal(t, int64(1), tree.TotalKeys)
+	if assert.Len(t, tree.Keys, 1) {
+		key := tree.Keys[0]
+		assert.Equal(t, "save", key.Name)
+		assert.Equal(t, "common.save", key.KeyName)
+		assert.Equal(t, []string{"ui"}, key.Tags)
+		assert.Equal(t, "保存", key.Translations["zh-CN"].Value)
+	}
+
+	// 完成度只统计启用的语言
+	assert.Equal(t, domain.LanguageCompletion{Translated: 3, Total: 3, Percent: 100}, tree.Completion["en"])
+	assert.Equal(t, 1, tree.Completion["zh-CN"].Translated)
+	assert.NotContains(t, tree.Completion, "fr")
+}
+
+func TestGetKeyTreeRoot(t *testing.T) {
+	f := newKeyTreeFixture()
+
+	tree, err := f.service.GetKeyTree(context.Background(), 1, "", 0, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", f.translation.statsPrefix)
+	names := make([]string, 0, len(tree.Folders))
+	for _, folder := range tree.Folders {
+		names = append(names, folder.Name)
+	}
+	assert.Equal(t, []string{"common", "commonx", "home"}, names)
+	assert.Empty(t, tree.Keys)
+	assert.Equal(t, 3, tree.Folders[0].KeyCount)
+
+	_, err = f.service.GetKeyTree(context.Background(), 2, "", 0, 0)
+	assert.Equal(t, domain.ErrProjectNotFound, err)
+}
+
+func TestDeleteSubtree(t *testing.T) {
+	f := newKeyTreeFixture()
+
+	deleted, err := f.service.DeleteSubtree(context.Background(), 1, "common.buttons.")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.Equal(t, "common.buttons.", f.translation.deletedPrefix)
+	assert.Equal(t, "common.buttons.", f.keyTags.deletedPrefix)
+
+	// 根路径不能整体删除
+	_, err = f.service.DeleteSubtree(context.Background(), 1, " . ")
+	assert.Equal(t, domain.ErrInvalidKeyPath, err)
+}
+
+func TestDeleteSubtreeLocked(t *testing.T) {
+	f := newKeyTreeFixture()
+	f.locks.locks = []*domain.TranslationLock{{ProjectID: 1, KeyName: "common.save", LanguageID: 2}}
+
+	_, err := f.service.DeleteSubtree(context.Background(), 1, "common")
+	var appErr *domain.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "TRANSLATION_LOCKED", appErr.Code)
+	}
+	assert.Empty(t, f.translation.deletedPrefix)
+	assert.Empty(t, f.keyTags.deletedPrefix)
+}
+
+func TestTagSubtree(t *testing.T) {
+	f := newKeyTreeFixture()
+
+	tagged, err := f.service.TagSubtree(context.Background(), 1, "common.buttons", []string{" review ", "", "review", "v2"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tagged)
+	assert.Equal(t, "common.buttons.", f.translation.statsPrefix)
+
+	created := make([]string, 0, len(f.keyTags.created))
+	for _, tag := range f.keyTags.created {
+		assert.Equal(t, uint64(7), tag.CreatedBy)
+		created = append(created, tag.KeyName+":"+tag.Tag)
+	}
+	assert.ElementsMatch(t, []string{
+		"common.buttons.ok:review", "common.buttons.ok:v2",
+		"common.buttons.cancel:review", "common.buttons.cancel:v2",
+	}, created)
+
+	_, err = f.service.TagSubtree(context.Background(), 1, "common", []string{" "}, 7)
+	assert.Equal(t, domain.ErrInvalidInput, err)
+	_, err = f.service.TagSubtree(context.Background(), 1, "", []string{"review"}, 7)
+	assert.Equal(t, domain.ErrInvalidKeyPath, err)
+}
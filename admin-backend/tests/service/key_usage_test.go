@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubKeyUsageRepo 按项目返回扫描记录和未被引用的键，记录被废弃的键
+type stubKeyUsageRepo struct {
+	domain.KeyUsageRepository
+	scannedProjectIDs []uint64
+	scans             map[uint64][]*domain.KeyScan
+	unreferenced      map[uint64][]*domain.UnusedKey
+	deprecated        map[uint64][]string
+}
+
+func (r *stubKeyUsageRepo) GetScannedProjectIDs(ctx context.Context) ([]uint64, error) {
+	return r.scannedProjectIDs, nil
+}
+
+func (r *stubKeyUsageRepo) GetLatestScans(ctx context.Context, projectID uint64) ([]*domain.KeyScan, error) {
+	return r.scans[projectID], nil
+}
+
+func (r *stubKeyUsageRepo) GetFirstScanAt(ctx context.Context, projectID uint64) (*time.Time, error) {
+	return nil, nil
+}
+
+func (r *stubKeyUsageRepo) GetUnreferencedKeys(ctx context.Context, projectID uint64) ([]*domain.UnusedKey, error) {
+	return r.unreferenced[projectID], nil
+}
+
+func (r *stubKeyUsageRepo) MarkKeysDeprecated(ctx context.Context, projectID uint64, keyNames []string) (int64, error) {
+	r.deprecated[projectID] = append(r.deprecated[projectID], keyNames...)
+	return int64(len(keyNames)), nil
+}
+
+// newKeyUsageFixture 项目 1 有超过宽限期的未使用键，项目 2 的键仍在宽限期内，
+// 项目 3 没有扫描记录，项目 4 已删除
+func newKeyUsageFixture() (*service.KeyUsageService, *stubKeyUsageRepo) {
+	old := time.Now().AddDate(0, 0, -60)
+	recent := time.Now().AddDate(0, 0, -1)
+	repo := &stubKeyUsageRepo{
+		scannedProjectIDs: []uint64{1, 2, 3, 4},
+		scans: map[uint64][]*domain.KeyScan{
+			1: {{ID: 1, ProjectID: 1}},
+			2: {{ID: 2, ProjectID: 2}},
+		},
+		unreferenced: map[uint64][]*domain.UnusedKey{
+			1: {{KeyName: "legacy.title", CreatedAt: old}, {KeyName: "new.title", CreatedAt: recent}},
+			2: {{KeyName: "new.body", CreatedAt: recent}},
+		},
+		deprecated: map[uint64][]string{},
+	}
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{
+		1: {ID: 1}, 2: {ID: 2}, 3: {ID: 3},
+	}}
+	return service.NewKeyUsageService(repo, projects, nil, 30), repo
+}
+
+func TestDeprecateAllUnusedKeys(t *testing.T) {
+	base, repo := newKeyUsageFixture()
+
+	total, err := base.DeprecateAllUnusedKeys(context.Background())
+	require.NoError(t, err)
+
+	// 没有扫描记录和已删除的项目跳过
+	assert.Equal(t, 1, total)
+	assert.Equal(t, map[uint64][]string{1: {"legacy.title"}}, repo.deprecated)
+}
+
+func TestCachedDeprecateAllUnusedKeys(t *testing.T) {
+	base, repo := newKeyUsageFixture()
+	mockCache := new(MockCacheService)
+	mockCache.On("GetTranslationKey", uint64(1)).Return("translation:1")
+	mockCache.On("GetTranslationMatrixKey", uint64(1), "").Return("translation_matrix:1")
+	mockCache.On("GetDashboardStatsKey").Return("dashboard:stats")
+	mockCache.On("DeleteByPattern", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, "dashboard:stats").Return(nil)
+
+	total, err := service.NewCachedKeyUsageService(base, mockCache).DeprecateAllUnusedKeys(context.Background())
+	require.NoError(t, err)
+
+	// 只清除有键被废弃的项目的缓存
+	assert.Equal(t, 1, total)
+	assert.Equal(t, map[uint64][]string{1: {"legacy.title"}}, repo.deprecated)
+	mockCache.AssertExpectations(t)
+	mockCache.AssertCalled(t, "DeleteByPattern", mock.Anything, "translation:1*")
+	mockCache.AssertCalled(t, "DeleteByPattern", mock.Anything, "translation_matrix:1*")
+	mockCache.AssertNumberOfCalls(t, "DeleteByPattern", 2)
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestCheckCellWritesStringFreeze(t *testing.T) {
+	restrictions := domain.WriteRestrictions{StringFreeze: true, SourceLanguageID: 1}
+	existing := map[string]map[uint64]string{
+		"home.title": {1: "Home", 2: "Start"},
+	}
+
+	// 冻结期间仍可翻译其他语言，重新导入未修改的默认语言值不受影响
+	assert.NoError(t, service.CheckCellWrites(restrictions, existing, []domain.CellWrite{
+		{KeyName: "home.title", LanguageID: 2, Value: "Startseite"},
+		{KeyName: "home.title", LanguageID: 3, Value: "Accueil"},
+		{KeyName: "home.title", LanguageID: 1, Value: "Home"},
+		{KeyName: "home.body", LanguageID: 1, Delete: true},
+	}))
+
+	rejected := []domain.CellWrite{
+		{KeyName: "home.body", LanguageID: 2, Value: "Hallo"},
+		{KeyName: "home.title", LanguageID: 1, Value: "Homepage"},
+		{KeyName: "home.title", LanguageID: 1, Delete: true},
+	}
+	for _, write := range rejected {
+		err := service.CheckCellWrites(restrictions, existing, []domain.CellWrite{write})
+		var appErr *domain.AppError
+		if assert.True(t, errors.As(err, &appErr), write.KeyName) {
+			assert.Equal(t, "STRING_FROZEN", appErr.Code)
+		}
+	}
+}
+
+func TestCheckCellWritesLocks(t *testing.T) {
+	restrictions := domain.WriteRestrictions{
+		SourceLanguageID: 1,
+		Locks: []*domain.TranslationLock{
+			{KeyName: "legal.terms"},
+			{LanguageID: 3},
+			{KeyName: "home.title", LanguageID: 2},
+		},
+	}
+	existing := map[string]map[uint64]string{
+		"home.title":  {1: "Home", 2: "Start"},
+		"legal.terms": {1: "Terms"},
+	}
+
+	assert.NoError(t, service.CheckCellWrites(restrictions, existing, []domain.CellWrite{
+		{KeyName: "home.title", LanguageID: 1, Value: "Homepage"},
+		{KeyName: "home.title", LanguageID: 2, Value: "Start"},
+		{KeyName: "home.body", LanguageID: 2, Value: "Hallo"},
+	}))
+
+	rejected := []domain.CellWrite{
+		{KeyName: "home.title", LanguageID: 2, Value: "Startseite"},
+		{KeyName: "legal.terms", LanguageID: 1, Delete: true},
+		{KeyName: "home.body", LanguageID: 3, Value: "Bonjour"},
+	}
+	for _, write := range rejected {
+		err := service.CheckCellWrites(restrictions, existing, []domain.CellWrite{write})
+		var appErr *domain.AppError
+		if assert.True(t, errors.As(err, &appErr), write.KeyName) {
+			assert.Equal(t, "TRANSLATION_LOCKED", appErr.Code)
+		}
+	}
+}
+
+func TestRestoreKeysChecksWrites(t *testing.T) {
+	assertRejected := func(t *testing.T, f *trashFixture, err error, code string) {
+		var appErr *domain.AppError
+		if assert.True(t, errors.As(err, &appErr)) {
+			assert.Equal(t, code, appErr.Code)
+		}
+		assert.False(t, f.trash.restored)
+	}
+
+	t.Run("overwrite locked", func(t *testing.T) {
+		f := newTrashFixture(0)
+		f.checker.restrictions = domain.WriteRestrictions{
+			Locks: []*domain.TranslationLock{{ProjectID: 1, KeyName: "home.title", LanguageID: 1}},
+		}
+
+		_, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+			KeyNames: []string{"home.title"},
+			Strategy: domain.RestoreStrategyOverwrite,
+		})
+		assertRejected(t, f, err, "TRANSLATION_LOCKED")
+		assert.Contains(t, f.checker.writes, domain.CellWrite{KeyName: "home.title", LanguageID: 1, Value: "Home"})
+	})
+
+	t.Run("rename frozen", func(t *testing.T) {
+		f := newTrashFixture(0)
+		f.checker.restrictions = domain.WriteRestrictions{StringFreeze: true, SourceLanguageID: 1}
+
+		_, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+			KeyNames: []string{"home.title"},
+			Strategy: domain.RestoreStrategyRename,
+		})
+		assertRejected(t, f, err, "STRING_FROZEN")
+		assert.Contains(t, f.checker.writes, domain.CellWrite{KeyName: "home.title_restored", LanguageID: 1, Value: "Home"})
+	})
+
+	t.Run("translation allowed while frozen", func(t *testing.T) {
+		f := newTrashFixture(0)
+		f.checker.restrictions = domain.WriteRestrictions{StringFreeze: true, SourceLanguageID: 1}
+		// 只恢复现有键的非默认语言翻译
+		f.trash.deleted = f.trash.deleted[1:2]
+
+		result, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+			KeyNames: []string{"home.title"},
+			Strategy: domain.RestoreStrategyOverwrite,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"home.title"}, result.Restored)
+		assert.True(t, f.trash.restored)
+	})
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/service"
+)
+
+// newProviderServer 创建模拟提供方接口，记录请求头和请求体并返回固定响应
+func newProviderServer(t *testing.T, status int, reply string, header *http.Header, body *map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*header = r.Header.Clone()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDeepLTranslator(t *testing.T) {
+	var header http.Header
+	var body map[string]interface{}
+	server := newProviderServer(t, http.StatusOK,
+		`{"translations":[{"text":"Hallo <x id=\"0\"/>"},{"text":"Tschüss"}]}`, &header, &body)
+
+	translator := service.NewDeepLTranslator("secret", server.URL, server.Client())
+	result, err := translator.Translate(context.Background(), []string{`Hello <x id="0"/>`, "Bye"}, "en", "de")
+	require.NoError(t, err)
+	assert.Equal(t, []string{`Hallo <x id="0"/>`, "Tschüss"}, result)
+	assert.Equal(t, "DeepL-Auth-Key secret", header.Get("Authorization"))
+	assert.Equal(t, "EN", body["source_lang"])
+	assert.Equal(t, "DE", body["target_lang"])
+	assert.Equal(t, "xml", body["tag_handling"])
+
+	// 英语和中文需要指定变体
+	_, err = translator.Translate(context.Background(), []string{"a", "b"}, "de", "en")
+	require.NoError(t, err)
+	assert.Equal(t, "EN-US", body["target_lang"])
+	_, err = translator.Translate(context.Background(), []string{"a", "b"}, "en", "zh-TW")
+	require.NoError(t, err)
+	assert.Equal(t, "ZH-HANT", body["target_lang"])
+
+	// 返回数量不一致视为失败
+	_, err = translator.Translate(context.Background(), []string{"a"}, "en", "de")
+	assert.Error(t, err)
+}
+
+func TestGoogleTranslator(t *testing.T) {
+	var header http.Header
+	var body map[string]interface{}
+	server := newProviderServer(t, http.StatusOK,
+		`{"data":{"translations":[{"translatedText":"Bonjour"}]}}`, &header, &body)
+
+	translator := service.NewGoogleTranslator("secret", server.URL, server.Client())
+	result, err := translator.Translate(context.Background(), []string{"Hello"}, "en-US", "fr_FR")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bonjour"}, result)
+	assert.Equal(t, "secret", header.Get("X-Goog-Api-Key"))
+	assert.Equal(t, "en", body["source"])
+	assert.Equal(t, "fr", body["target"])
+	assert.Equal(t, "html", body["format"])
+
+	_, err = translator.Translate(context.Background(), []string{"Hello"}, "en", "zh-Hant")
+	require.NoError(t, err)
+	assert.Equal(t, "zh-TW", body["target"])
+}
+
+func TestOpenAITranslator(t *testing.T) {
+	var header http.Header
+	var body map[string]interface{}
+	server := newProviderServer(t, http.StatusOK,
+		`{"choices":[{"message":{"content":"`+"```json\\n[\\\"Hola\\\", \\\"Adiós\\\"]\\n```"+`"}}]}`, &header, &body)
+
+	translator := service.NewOpenAITranslator("secret", server.URL+"/v1/", "test-model", server.Client())
+	result, err := translator.Translate(context.Background(), []string{"Hello", "Bye"}, "en", "es")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hola", "Adiós"}, result)
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+	assert.Equal(t, "test-model", body["model"])
+}
+
+func TestMachineTranslatorErrors(t *testing.T) {
+	var header http.Header
+	var body map[string]interface{}
+	server := newProviderServer(t, http.StatusForbidden, `{"message":"quota exceeded"}`, &header, &body)
+
+	translator := service.NewDeepLTranslator("secret", server.URL, server.Client())
+	_, err := translator.Translate(context.Background(), []string{"Hello"}, "en", "de")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 403")
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	fake := service.NewFakeTranslator()
+	result, err := fake.Translate(context.Background(), []string{"Hello"}, "en", "de")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"[de] Hello"}, result)
+}
@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestValidateProjectTemplate(t *testing.T) {
+	valid := domain.ProjectTemplateParams{
+		Name:              "Mobile App",
+		PlaceholderFormat: domain.PlaceholderFormatBrace,
+		ValueType:         domain.ValueTypePlain,
+		Languages:         []string{"en", "zh-CN"},
+		StarterKeys: []domain.TemplateKey{
+			{KeyName: "common.ok", Values: map[string]string{"en": "OK", "zh-CN": "确定"}},
+			{KeyName: "common.cancel"},
+		},
+		DefaultMembers: []domain.TemplateMember{
+			{UserID: 1, Role: "owner"},
+			{UserID: 2, Role: "viewer"},
+		},
+	}
+	assert.NoError(t, service.ValidateProjectTemplate(valid))
+
+	cases := map[string]func(p *domain.ProjectTemplateParams){
+		"empty name": func(p *domain.ProjectTemplateParams) { p.Name = " " },
+		"empty key":  func(p *domain.ProjectTemplateParams) { p.StarterKeys = []domain.TemplateKey{{KeyName: ""}} },
+		"padded key": func(p *domain.ProjectTemplateParams) { p.StarterKeys = []domain.TemplateKey{{KeyName: " common.ok"}} },
+		"duplicate key": func(p *domain.ProjectTemplateParams) {
+			p.StarterKeys = append(p.StarterKeys, domain.TemplateKey{KeyName: "common.ok"})
+		},
+		"invalid role": func(p *domain.ProjectTemplateParams) {
+			p.DefaultMembers = []domain.TemplateMember{{UserID: 1, Role: "admin"}}
+		},
+		"duplicate user": func(p *domain.ProjectTemplateParams) {
+			p.DefaultMembers = append(p.DefaultMembers, domain.TemplateMember{UserID: 1, Role: "editor"})
+		},
+		"too many bases":  func(p *domain.ProjectTemplateParams) { p.BaseProjectIDs = []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11} },
+		"bad value type":  func(p *domain.ProjectTemplateParams) { p.ValueType = "rich" },
+		"bad placeholder": func(p *domain.ProjectTemplateParams) { p.PlaceholderFormat = "percent" },
+	}
+	for name, mutate := range cases {
+		params := valid
+		params.StarterKeys = append([]domain.TemplateKey(nil), valid.StarterKeys...)
+		params.DefaultMembers = append([]domain.TemplateMember(nil), valid.DefaultMembers...)
+		mutate(&params)
+
+		err := service.ValidateProjectTemplate(params)
+		var appErr *domain.AppError
+		assert.True(t, errors.As(err, &appErr), name)
+	}
+}
+
+func TestValidateProjectTemplateStarterValues(t *testing.T) {
+	params := domain.ProjectTemplateParams{
+		Name:              "Marketing Site",
+		PlaceholderFormat: domain.PlaceholderFormatBrace,
+		ValueType:         domain.ValueTypeHTMLSubset,
+		StarterKeys: []domain.TemplateKey{
+			{KeyName: "footer.legal", Values: map[string]string{"en": "<b>Terms</b>"}},
+		},
+	}
+	assert.NoError(t, service.ValidateProjectTemplate(params))
+
+	params.StarterKeys[0].Values["en"] = "<script>alert(1)</script>"
+	err := service.ValidateProjectTemplate(params)
+	var appErr *domain.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "INVALID_TRANSLATION_VALUE", appErr.Code)
+	}
+}
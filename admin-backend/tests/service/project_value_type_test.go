@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestUpdateValueTypeValidatesExisting(t *testing.T) {
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{
+		1: {ID: 1, Name: "App", Slug: "app", ValueType: domain.ValueTypePlain},
+	}}
+	translations := &stubInheritedTranslationRepo{cells: map[uint64]map[string]map[string]domain.TranslationCell{
+		1: {
+			"home.link":  {"en": {ID: 1, Value: `<a href="https://example.com">Docs</a>`}},
+			"home.embed": {"en": {ID: 2, Value: `<iframe src="https://example.com"></iframe>`}, "zh-CN": {ID: 3, Value: "文档"}},
+		},
+	}}
+	projectService := service.NewProjectService(projects, nil, nil, nil, translations, nil, nil, nil)
+
+	// 现有值不符合 html_subset 时拒绝切换，并列出不符合的键名和语言
+	_, err := projectService.Update(context.Background(), 1, domain.UpdateProjectParams{ValueType: domain.ValueTypeHTMLSubset}, 1)
+	appErr, ok := domain.IsAppError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "VALUE_TYPE_VIOLATION", appErr.Code)
+		assert.Contains(t, appErr.Details, "home.embed [en]")
+		assert.NotContains(t, appErr.Details, "home.link")
+		assert.NotContains(t, appErr.Details, "zh-CN")
+	}
+	assert.Equal(t, domain.ValueTypePlain, projects.projects[1].ValueType)
+
+	// 修正后可以切换
+	translations.cells[1]["home.embed"]["en"] = domain.TranslationCell{ID: 2, Value: `<a href="https://example.com">Embed</a>`}
+	project, err := projectService.Update(context.Background(), 1, domain.UpdateProjectParams{ValueType: domain.ValueTypeHTMLSubset}, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.ValueTypeHTMLSubset, project.ValueType)
+	}
+}
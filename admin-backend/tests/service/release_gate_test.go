@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestEvaluateReleaseLanguage(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	input := domain.ReleaseLanguageInput{
+		Language: "de",
+		Sources: map[string]domain.ReleaseCell{
+			"home.title":  {Value: "Home", UpdatedAt: earlier},
+			"home.body":   {Value: "Hello {name}", UpdatedAt: earlier},
+			"home.footer": {Value: "Footer", UpdatedAt: now},
+			"home.promo":  {Value: "Promo", UpdatedAt: earlier},
+			"home.empty":  {Value: "Empty", UpdatedAt: earlier},
+		},
+		Targets: map[string]domain.ReleaseCell{
+			"home.title":  {Value: "Start", UpdatedAt: now},
+			"home.body":   {Value: "Hallo {user}", UpdatedAt: now},
+			"home.footer": {Value: "Fußzeile", UpdatedAt: earlier},
+			"home.promo":  {Value: "Aktion", MachineTranslated: true, UpdatedAt: now},
+		},
+		PlaceholderFormat: "brace",
+		ValueType:         domain.ValueTypePlain,
+		Glossary: []*domain.GlossaryViolation{
+			{KeyName: "home.title", Language: "de", Term: "Home", Type: "missing_term"},
+			{KeyName: "home.promo", Language: "de", Term: "Promo", Type: "forbidden_term"},
+		},
+	}
+
+	report := service.EvaluateReleaseLanguage(&domain.ReleaseCriteria{MinCompletion: 100}, input)
+	assert.False(t, report.Passed)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 4, report.Translated)
+	assert.Equal(t, 80.0, report.Completion)
+	assert.Equal(t, []string{"home.empty"}, report.Untranslated)
+	assert.Equal(t, []string{"home.footer"}, report.Outdated)
+	assert.Equal(t, []string{"home.promo"}, report.Unapproved)
+	// 缺少批准译法的术语问题不阻断发布
+	assert.Equal(t, []*domain.ReleaseQAIssue{
+		{KeyName: "home.body", Type: domain.ReleaseIssuePlaceholderMismatch},
+		{KeyName: "home.promo", Type: "forbidden_term", Detail: "Promo"},
+	}, report.QAErrors)
+	assert.Equal(t, []string{
+		domain.ReleaseFailureCompletion,
+		domain.ReleaseFailureOutdated,
+		domain.ReleaseFailureUnapproved,
+		domain.ReleaseFailureQAErrors,
+	}, report.Failures)
+
+	// 语言的最低完成度覆盖全局设置，允许的问题仍然列出但不计入失败
+	relaxed := &domain.ReleaseCriteria{
+		MinCompletion:    100,
+		LanguageMinimums: map[string]float64{"de": 80},
+		AllowOutdated:    true,
+		AllowUnapproved:  true,
+		AllowQAErrors:    true,
+	}
+	report = service.EvaluateReleaseLanguage(relaxed, input)
+	assert.True(t, report.Passed)
+	assert.Equal(t, 80.0, report.MinCompletion)
+	assert.Empty(t, report.Failures)
+	assert.Equal(t, []string{"home.footer"}, report.Outdated)
+}
+
+func TestEvaluateReleaseLanguageWithoutSources(t *testing.T) {
+	report := service.EvaluateReleaseLanguage(&domain.ReleaseCriteria{MinCompletion: 100}, domain.ReleaseLanguageInput{Language: "fr"})
+	assert.True(t, report.Passed)
+	assert.Equal(t, 100.0, report.Completion)
+	assert.Empty(t, report.Untranslated)
+}
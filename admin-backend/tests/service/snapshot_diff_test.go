@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestDiffTranslationMatrices(t *testing.T) {
+	from := map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Start"},
+		"home.old":   {"en": "Old", "de": "Alt"},
+		"home.empty": {"en": "Empty", "de": ""},
+	}
+	to := map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Startseite", "fr": "Accueil"},
+		"home.new":   {"en": "New"},
+		"home.empty": {"en": "Empty"},
+	}
+
+	diff := service.DiffTranslationMatrices(from, to)
+	assert.Equal(t, []string{"home.new"}, diff.AddedKeys)
+	assert.Equal(t, []string{"home.old"}, diff.RemovedKeys)
+
+	assert.Equal(t, []*domain.ValueChange{{KeyName: "home.new", New: "New"}}, diff.Languages["en"].Added)
+	assert.Equal(t, []*domain.ValueChange{{KeyName: "home.old", Old: "Old"}}, diff.Languages["en"].Removed)
+	assert.Empty(t, diff.Languages["en"].Changed)
+
+	assert.Equal(t, []*domain.ValueChange{{KeyName: "home.title", Old: "Start", New: "Startseite"}}, diff.Languages["de"].Changed)
+	assert.Equal(t, []*domain.ValueChange{{KeyName: "home.old", Old: "Alt"}}, diff.Languages["de"].Removed)
+	assert.Equal(t, []*domain.ValueChange{{KeyName: "home.title", New: "Accueil"}}, diff.Languages["fr"].Added)
+}
+
+func TestDiffTranslationMatricesUnchanged(t *testing.T) {
+	values := map[string]map[string]string{"home.title": {"en": "Home"}}
+
+	diff := service.DiffTranslationMatrices(values, values)
+	assert.Empty(t, diff.AddedKeys)
+	assert.Empty(t, diff.RemovedKeys)
+	assert.Empty(t, diff.Languages)
+}
+
+// stubSnapshotRepo 按ID返回快照和快照中的译文
+type stubSnapshotRepo struct {
+	domain.SnapshotRepository
+	snapshots map[uint64]*domain.Snapshot
+	values    map[uint64]map[string]map[string]string
+}
+
+func (r *stubSnapshotRepo) GetByID(ctx context.Context, id uint64) (*domain.Snapshot, error) {
+	if snapshot, ok := r.snapshots[id]; ok {
+		return snapshot, nil
+	}
+	return nil, domain.ErrSnapshotNotFound
+}
+
+func (r *stubSnapshotRepo) GetValues(ctx context.Context, snapshotID uint64, languageCode string) (map[string]map[string]string, error) {
+	return r.values[snapshotID], nil
+}
+
+func TestSnapshotDiffIncludesInherited(t *testing.T) {
+	snapshots := &stubSnapshotRepo{
+		snapshots: map[uint64]*domain.Snapshot{7: {ID: 7, ProjectID: 2, Name: "v1"}},
+		values: map[uint64]map[string]map[string]string{
+			7: {
+				"common.ok":  {"en": "OK", "zh-CN": "确定"},
+				"home.title": {"en": "Home"},
+			},
+		},
+	}
+	snapshotService := service.NewSnapshotService(snapshots, &stubProjectRepo{}, newInheritedTranslationService())
+
+	// 当前译文包含从基础项目继承的 common.ok 英文，与快照相同时没有差异
+	diff, err := snapshotService.Diff(context.Background(), 2, 7, 0)
+	require.NoError(t, err)
+	assert.Empty(t, diff.AddedKeys)
+	assert.Empty(t, diff.RemovedKeys)
+	assert.Empty(t, diff.Languages)
+}
@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestPlanSync(t *testing.T) {
+	base := map[string]map[string]string{
+		"home.title":  {"en": "Home", "de": "Start"},
+		"home.body":   {"en": "Welcome"},
+		"home.footer": {"en": "Footer"},
+		"home.old":    {"en": "Old"},
+		"home.same":   {"en": "Same"},
+	}
+	server := map[string]map[string]string{
+		// de 只在服务端修改
+		"home.title": {"en": "Home", "de": "Startseite"},
+		// 两边都修改且不同
+		"home.body": {"en": "Welcome back"},
+		// 只在服务端删除 home.old 的 en
+		"home.footer": {"en": "Footer"},
+		// 两边改成相同的译文
+		"home.same": {"en": "Same!"},
+		// 服务端新增的键
+		"home.new": {"en": "New"},
+	}
+	local := map[string]map[string]string{
+		"home.title":  {"en": "Home", "de": "Start"},
+		"home.body":   {"en": "Hello"},
+		"home.footer": {"en": "New footer"},
+		"home.old":    {"en": "Old"},
+		"home.same":   {"en": "Same!"},
+		// 本地新增的键，空译文视为不存在
+		"checkout.pay": {"en": "Pay", "de": ""},
+	}
+
+	push, result := service.PlanSync(base, server, local)
+	assert.Equal(t, []*domain.BranchChange{
+		{KeyName: "checkout.pay", LanguageCode: "en", Value: "Pay"},
+		{KeyName: "home.footer", LanguageCode: "en", Value: "New footer"},
+	}, push)
+	assert.Equal(t, map[string]map[string]string{
+		"home.new":   {"en": "New"},
+		"home.title": {"de": "Startseite"},
+	}, result.Changed)
+	assert.Equal(t, []domain.TranslationCellRef{{KeyName: "home.old", LanguageCode: "en"}}, result.Deleted)
+
+	require.Len(t, result.Conflicts, 1)
+	conflict := result.Conflicts[0]
+	assert.Equal(t, "home.body", conflict.KeyName)
+	assert.Equal(t, "Welcome", *conflict.Base)
+	assert.Equal(t, "Welcome back", *conflict.Server)
+	assert.Equal(t, "Hello", *conflict.Local)
+}
+
+func TestPlanSyncLocalDeletion(t *testing.T) {
+	base := map[string]map[string]string{
+		"home.title": {"en": "Home"},
+		"home.body":  {"en": "Welcome"},
+	}
+	server := map[string]map[string]string{
+		"home.title": {"en": "Home"},
+		"home.body":  {"en": "Welcome back"},
+	}
+	// 本地删除了两个键：服务端未修改的删除，服务端修改过的为冲突
+	local := map[string]map[string]string{}
+
+	push, result := service.PlanSync(base, server, local)
+	assert.Equal(t, []*domain.BranchChange{
+		{KeyName: "home.title", LanguageCode: "en", Deleted: true},
+	}, push)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, "home.body", result.Conflicts[0].KeyName)
+	assert.Nil(t, result.Conflicts[0].Local)
+	assert.Empty(t, result.Changed)
+	assert.Empty(t, result.Deleted)
+}
+
+// stubSyncTranslationRepo 返回固定的主干单元格和基准修订的译文
+type stubSyncTranslationRepo struct {
+	stubActiveTranslationRepo
+	cells map[string]map[string]domain.TranslationCell
+	base  map[string]map[string]string
+}
+
+func (r *stubSyncTranslationRepo) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	return r.cells, int64(len(r.cells)), nil
+}
+
+func (r *stubSyncTranslationRepo) GetValuesAt(ctx context.Context, projectID, revision uint64) (map[string]map[string]string, error) {
+	return r.base, nil
+}
+
+func TestSyncChecksAllWritesFirst(t *testing.T) {
+	languages := &stubLanguageRepo{languages: []*domain.Language{
+		{ID: 1, Code: "en", IsDefault: true, Status: "active"},
+		{ID: 2, Code: "zh-CN", Status: "active"},
+	}}
+	translations := &stubSyncTranslationRepo{
+		cells: map[string]map[string]domain.TranslationCell{
+			"home.title": {"en": {ID: 1, Value: "Home"}, "zh-CN": {ID: 2, Value: "首页"}},
+		},
+		base: map[string]map[string]string{"home.title": {"en": "Home", "zh-CN": "首页"}},
+	}
+	writer := &stubTranslationWriter{}
+	writer.existing = map[string]map[uint64]string{"home.title": {1: "Home", 2: "首页"}}
+	// 中文单元格被锁定，客户端修改英文并删除中文
+	writer.restrictions.Locks = []*domain.TranslationLock{{ProjectID: 1, KeyName: "home.title", LanguageID: 2}}
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{1: {ID: 1, Revision: 3}}}
+	syncService := service.NewSyncService(projects, languages, translations, writer, &stubProjectBaseRepo{})
+
+	_, err := syncService.Sync(context.Background(), domain.SyncParams{
+		ProjectID:    1,
+		BaseRevision: 3,
+		Languages:    []string{"en", "zh-CN"},
+		Values:       map[string]map[string]string{"home.title": {"en": "Start"}},
+	})
+	var appErr *domain.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, "TRANSLATION_LOCKED", appErr.Code)
+	}
+	// 删除被拒绝时英文的修改也没有写入
+	assert.Empty(t, writer.upserted)
+	assert.Empty(t, writer.deletedIDs)
+	assert.ElementsMatch(t, []domain.CellWrite{
+		{KeyName: "home.title", LanguageID: 1, Value: "Start"},
+		{KeyName: "home.title", LanguageID: 2, Delete: true},
+	}, writer.writes)
+}
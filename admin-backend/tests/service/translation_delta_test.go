@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+func TestBuildTranslationDelta(t *testing.T) {
+	changes := &domain.TranslationChanges{
+		Revision: 12,
+		Changed: map[string]map[string]domain.TranslationCell{
+			"home.title": {"de": {ID: 2, Value: "Startseite"}},
+		},
+		Deleted: []domain.TranslationCellRef{
+			{KeyName: "home.old", LanguageCode: "en"},
+			{KeyName: "home.body", LanguageCode: "de"},
+		},
+	}
+	// 导出结果包含变更键的全部单元格，只有变更的单元格应被下发
+	exported := map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Startseite"},
+		"home.body":  {"en": "Welcome"},
+	}
+
+	delta := service.BuildTranslationDelta(7, changes, exported, "")
+	assert.Equal(t, uint64(7), delta.Since)
+	assert.Equal(t, uint64(12), delta.Revision)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title": {"de": "Startseite"},
+	}, delta.Changed)
+	assert.Equal(t, []domain.TranslationCellRef{
+		{KeyName: "home.body", LanguageCode: "de"},
+		{KeyName: "home.old", LanguageCode: "en"},
+	}, delta.Deleted)
+}
+
+func TestBuildTranslationDeltaPseudoLocale(t *testing.T) {
+	changes := &domain.TranslationChanges{
+		Revision: 3,
+		Changed: map[string]map[string]domain.TranslationCell{
+			"home.title": {"en": {ID: 1, Value: "Home"}},
+		},
+		Deleted: []domain.TranslationCellRef{{KeyName: "home.old", LanguageCode: "en"}},
+	}
+	exported := map[string]map[string]string{
+		"home.title": {"en": "Home", "en-XA": "[Ĥöɱé]"},
+	}
+
+	delta := service.BuildTranslationDelta(2, changes, exported, "en-XA")
+	assert.Equal(t, map[string]map[string]string{
+		"home.title": {"en": "Home", "en-XA": "[Ĥöɱé]"},
+	}, delta.Changed)
+	// 删除了默认语言译文的键不再有伪本地化译文
+	assert.Equal(t, []domain.TranslationCellRef{
+		{KeyName: "home.old", LanguageCode: "en"},
+		{KeyName: "home.old", LanguageCode: "en-XA"},
+	}, delta.Deleted)
+}
@@ -0,0 +1,296 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/domain"
+	"i18n-flow/internal/service"
+)
+
+// stubTrashRepo 记录恢复和彻底删除的操作
+type stubTrashRepo struct {
+	domain.TrashRepository
+	deleted  []*domain.Translation
+	existing map[string]bool
+
+	restores    []domain.TranslationRestore
+	displaceIDs []uint64
+	restored    bool
+
+	expiredTranslations int64
+	expiredProjects     []uint64
+	missingProjects     map[uint64]bool
+	files               map[uint64]*domain.PurgedProjectFiles
+	purgedBefore        time.Time
+	purgedProjects      []uint64
+}
+
+func (r *stubTrashRepo) GetDeletedTranslations(ctx context.Context, projectID uint64, keyNames []string) ([]*domain.Translation, error) {
+	wanted := make(map[string]bool, len(keyNames))
+	for _, keyName := range keyNames {
+		wanted[keyName] = true
+	}
+	result := make([]*domain.Translation, 0)
+	for _, translation := range r.deleted {
+		if wanted[translation.KeyName] {
+			result = append(result, translation)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubTrashRepo) GetExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	result := make([]string, 0)
+	for _, keyName := range keyNames {
+		if r.existing[keyName] {
+			result = append(result, keyName)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubTrashRepo) RestoreTranslations(ctx context.Context, restores []domain.TranslationRestore, displaceIDs []uint64) error {
+	r.restored = true
+	r.restores = restores
+	r.displaceIDs = displaceIDs
+	return nil
+}
+
+func (r *stubTrashRepo) PurgeTranslationsDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.purgedBefore = before
+	return r.expiredTranslations, nil
+}
+
+func (r *stubTrashRepo) GetProjectIDsDeletedBefore(ctx context.Context, before time.Time) ([]uint64, error) {
+	return r.expiredProjects, nil
+}
+
+func (r *stubTrashRepo) PurgeProject(ctx context.Context, id uint64) (*domain.PurgedProjectFiles, error) {
+	if r.missingProjects[id] {
+		return nil, domain.ErrTrashItemNotFound
+	}
+	r.purgedProjects = append(r.purgedProjects, id)
+	if files, ok := r.files[id]; ok {
+		return files, nil
+	}
+	return &domain.PurgedProjectFiles{}, nil
+}
+
+// stubActiveTranslationRepo 返回与查询的键和语言匹配的现有翻译
+type stubActiveTranslationRepo struct {
+	domain.TranslationRepository
+	active []*domain.Translation
+}
+
+func (r *stubActiveTranslationRepo) GetByProjectKeyLanguages(ctx context.Context, keys []domain.TranslationKey) ([]*domain.Translation, error) {
+	result := make([]*domain.Translation, 0)
+	for _, key := range keys {
+		for _, translation := range r.active {
+			if translation.KeyName == key.KeyName && translation.LanguageID == key.LanguageID {
+				result = append(result, translation)
+			}
+		}
+	}
+	return result, nil
+}
+
+// stubFileStorage 记录被删除的文件
+type stubFileStorage struct {
+	domain.FileStorage
+	deleted []string
+}
+
+func (s *stubFileStorage) Delete(ctx context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+// stubWriteChecker 用给定的限制和现有值检查写入
+type stubWriteChecker struct {
+	domain.TranslationService
+	restrictions domain.WriteRestrictions
+	existing     map[string]map[uint64]string
+	writes       []domain.CellWrite
+}
+
+func (c *stubWriteChecker) CheckWrites(ctx context.Context, projectID uint64, writes []domain.CellWrite) error {
+	c.writes = writes
+	return service.CheckCellWrites(c.restrictions, c.existing, writes)
+}
+
+type trashFixture struct {
+	service *service.TrashService
+	trash   *stubTrashRepo
+	checker *stubWriteChecker
+	storage *stubFileStorage
+}
+
+// newTrashFixture 回收站中有 home.title 的两种语言（英文删除过两次）和 home.body，项目中已存在 home.title
+func newTrashFixture(retentionDays int) *trashFixture {
+	f := &trashFixture{
+		trash: &stubTrashRepo{
+			deleted: []*domain.Translation{
+				{ID: 11, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Home"},
+				{ID: 12, ProjectID: 1, KeyName: "home.title", LanguageID: 2, Value: "首页"},
+				{ID: 10, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Old home"},
+				{ID: 21, ProjectID: 1, KeyName: "home.body", LanguageID: 1, Value: "Welcome"},
+			},
+			existing: map[string]bool{"home.title": true},
+		},
+		checker: &stubWriteChecker{existing: map[string]map[uint64]string{
+			"home.title": {1: "Start"},
+		}},
+		storage: &stubFileStorage{},
+	}
+	translations := &stubActiveTranslationRepo{active: []*domain.Translation{
+		{ID: 31, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Start"},
+	}}
+	projects := &stubProjectRepo{projects: map[uint64]*domain.Project{1: {ID: 1, Name: "App", Slug: "app"}}}
+	f.service = service.NewTrashService(f.trash, translations, projects, f.checker, f.storage, retentionDays)
+	return f
+}
+
+func TestRestoreKeysSkip(t *testing.T) {
+	f := newTrashFixture(0)
+
+	result, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+		KeyNames: []string{"home.title", " home.body ", "home.missing"},
+	})
+	require.NoError(t, err)
+
+	// 默认跳过冲突的键，回收站中不存在的键也计入跳过
+	assert.Equal(t, []string{"home.body"}, result.Restored)
+	assert.Equal(t, []string{"home.title", "home.missing"}, result.Skipped)
+	assert.Empty(t, result.Renamed)
+	assert.Equal(t, 1, result.Translations)
+	assert.Equal(t, []domain.TranslationRestore{{ID: 21, KeyName: "home.body"}}, f.trash.restores)
+	assert.Empty(t, f.trash.displaceIDs)
+}
+
+func TestRestoreKeysOverwrite(t *testing.T) {
+	f := newTrashFixture(0)
+
+	result, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+		KeyNames: []string{"home.title"},
+		Strategy: domain.RestoreStrategyOverwrite,
+	})
+	require.NoError(t, err)
+
+	// 每种语言只恢复最近删除的一条，同语言的现有翻译移入回收站
+	assert.Equal(t, []string{"home.title"}, result.Restored)
+	assert.Equal(t, 2, result.Translations)
+	assert.Equal(t, []domain.TranslationRestore{
+		{ID: 11, KeyName: "home.title"},
+		{ID: 12, KeyName: "home.title"},
+	}, f.trash.restores)
+	assert.Equal(t, []uint64{31}, f.trash.displaceIDs)
+}
+
+func TestRestoreKeysRename(t *testing.T) {
+	f := newTrashFixture(0)
+	// home.title_restored 已被占用，新键名依次尝试 _restored_2
+	f.trash.existing["home.title_restored"] = true
+
+	result, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+		KeyNames: []string{"home.title", "home.body"},
+		Strategy: domain.RestoreStrategyRename,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"home.title": "home.title_restored_2"}, result.Renamed)
+	assert.Equal(t, []string{"home.title", "home.body"}, result.Restored)
+	assert.Equal(t, []domain.TranslationRestore{
+		{ID: 11, KeyName: "home.title_restored_2"},
+		{ID: 12, KeyName: "home.title_restored_2"},
+		{ID: 21, KeyName: "home.body"},
+	}, f.trash.restores)
+	assert.Empty(t, f.trash.displaceIDs)
+}
+
+func TestRestoreKeysRenameReserved(t *testing.T) {
+	f := newTrashFixture(0)
+	// 同时恢复的 home.title_restored 占用了第一个候选键名
+	f.trash.deleted = append(f.trash.deleted,
+		&domain.Translation{ID: 41, ProjectID: 1, KeyName: "home.title_restored", LanguageID: 1, Value: "Restored"})
+
+	result, err := f.service.RestoreKeys(context.Background(), 1, domain.RestoreKeysParams{
+		KeyNames: []string{"home.title", "home.title_restored"},
+		Strategy: domain.RestoreStrategyRename,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"home.title": "home.title_restored_2"}, result.Renamed)
+	assert.Contains(t, f.trash.restores, domain.TranslationRestore{ID: 41, KeyName: "home.title_restored"})
+}
+
+func TestRestoreKeysInvalid(t *testing.T) {
+	f := newTrashFixture(0)
+	ctx := context.Background()
+
+	_, err := f.service.RestoreKeys(ctx, 1, domain.RestoreKeysParams{KeyNames: []string{"home.title"}, Strategy: "merge"})
+	assert.Equal(t, domain.ErrInvalidRestoreOption, err)
+
+	_, err = f.service.RestoreKeys(ctx, 2, domain.RestoreKeysParams{KeyNames: []string{"home.title"}})
+	assert.Equal(t, domain.ErrProjectNotFound, err)
+
+	_, err = f.service.RestoreKeys(ctx, 1, domain.RestoreKeysParams{KeyNames: []string{" "}})
+	assert.Equal(t, domain.ErrInvalidInput, err)
+
+	_, err = f.service.RestoreKeys(ctx, 1, domain.RestoreKeysParams{KeyNames: []string{"home.missing"}})
+	assert.Equal(t, domain.ErrTrashItemNotFound, err)
+	assert.False(t, f.trash.restored)
+}
+
+func TestPurgeExpired(t *testing.T) {
+	f := newTrashFixture(30)
+	f.trash.expiredTranslations = 5
+	// 项目 6 已被其他请求彻底删除
+	f.trash.expiredProjects = []uint64{5, 6}
+	f.trash.missingProjects = map[uint64]bool{6: true}
+	f.trash.files = map[uint64]*domain.PurgedProjectFiles{
+		5: {StorageKeys: []string{"screenshots/5/a.png", "screenshots/5/a_thumb.png"}, BundleHashes: []string{"abc"}},
+	}
+
+	result, err := f.service.PurgeExpired(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), result.Translations)
+	assert.Equal(t, int64(1), result.Projects)
+	assert.Equal(t, []uint64{5}, f.trash.purgedProjects)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), f.trash.purgedBefore, time.Minute)
+	assert.Equal(t, []string{
+		"screenshots/5/a.png",
+		"screenshots/5/a_thumb.png",
+		"distribution/5/abc.json",
+		"distribution/5/abc.json.gz",
+		"distribution/5/abc.json.br",
+	}, f.storage.deleted)
+}
+
+func TestPurgeExpiredDisabled(t *testing.T) {
+	f := newTrashFixture(0)
+
+	result, err := f.service.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.TrashPurgeResult{}, result)
+	assert.True(t, f.trash.purgedBefore.IsZero())
+}
+
+func TestCreateProjectInTrash(t *testing.T) {
+	projects := &stubProjectRepo{
+		projects: map[uint64]*domain.Project{},
+		trashed:  []*domain.Project{{ID: 3, Name: "Mobile App", Slug: "mobile-app"}},
+	}
+	projectService := service.NewProjectService(projects, nil, nil, nil, nil, nil, nil, nil)
+
+	// 回收站中的项目仍占用名称和标识
+	_, err := projectService.Create(context.Background(), domain.CreateProjectParams{Name: "Mobile App"}, 1)
+	assert.Equal(t, domain.ErrProjectInTrash, err)
+	_, err = projectService.Create(context.Background(), domain.CreateProjectParams{Name: "mobile app"}, 1)
+	assert.Equal(t, domain.ErrProjectInTrash, err)
+}
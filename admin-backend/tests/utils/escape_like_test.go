@@ -0,0 +1,25 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestEscapeLike(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"common.buttons.", "common.buttons."},
+		{"user_profile.", `user\_profile.`},
+		{"100%", `100\%`},
+		{`C:\path_`, `C:\\path\_`},
+		{"", ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, internal_utils.EscapeLike(c.input), c.input)
+	}
+}
@@ -0,0 +1,118 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestContainsTerm(t *testing.T) {
+	assert.True(t, internal_utils.ContainsTerm("Add to cart", "cart", false))
+	assert.True(t, internal_utils.ContainsTerm("Add to Cart.", "cart", false))
+	assert.False(t, internal_utils.ContainsTerm("Add to Cart", "cart", true))
+	assert.False(t, internal_utils.ContainsTerm("Watch a cartoon", "cart", false))
+	assert.True(t, internal_utils.ContainsTerm("cartoon cart", "cart", false))
+	assert.True(t, internal_utils.ContainsTerm("加入购物车", "购物车", false))
+	assert.True(t, internal_utils.ContainsTerm("Use C++ here", "C++", false))
+	assert.False(t, internal_utils.ContainsTerm("Add to cart", " ", false))
+}
+
+func TestCheckGlossary(t *testing.T) {
+	rules := []internal_utils.GlossaryRule{
+		{Term: "cart", Approved: []string{"购物车"}, Forbidden: []string{"手推车"}},
+		{Term: "i18n-flow", DoNotTranslate: true},
+		{Term: "checkout", Approved: []string{"结账", "结算"}},
+	}
+
+	assert.Empty(t, internal_utils.CheckGlossary("Add to cart", "加入购物车", rules))
+	assert.Empty(t, internal_utils.CheckGlossary("Go to checkout", "去结算", rules))
+	assert.Empty(t, internal_utils.CheckGlossary("Save file", "保存文件", rules))
+
+	issues := internal_utils.CheckGlossary("Add to cart", "加入手推车", rules)
+	require.Len(t, issues, 2)
+	assert.Equal(t, internal_utils.GlossaryIssueMissingTerm, issues[0].Type)
+	assert.Equal(t, []string{"购物车"}, issues[0].Expected)
+	assert.Equal(t, internal_utils.GlossaryIssueForbiddenTerm, issues[1].Type)
+	assert.Equal(t, "手推车", issues[1].Found)
+
+	issues = internal_utils.CheckGlossary("Welcome to i18n-flow", "欢迎使用国际化流程", rules)
+	require.Len(t, issues, 1)
+	assert.Equal(t, internal_utils.GlossaryIssueDoNotTranslate, issues[0].Type)
+	assert.Equal(t, "i18n-flow", issues[0].Term)
+}
+
+func TestParseTBX(t *testing.T) {
+	v2 := `<?xml version="1.0" encoding="UTF-8"?>
+<martif type="TBX" xml:lang="en">
+  <martifHeader><fileDesc><sourceDesc><p>test</p></sourceDesc></fileDesc></martifHeader>
+  <text><body>
+    <termEntry id="c1">
+      <descrip type="definition">Shopping basket</descrip>
+      <langSet xml:lang="en"><tig><term>cart</term></tig></langSet>
+      <langSet xml:lang="zh-CN">
+        <tig><term>购物车</term><termNote type="administrativeStatus">preferredTerm-admn-sts</termNote></tig>
+        <ntig><termGrp><term>手推车</term><termNote type="administrativeStatus">deprecatedTerm-admn-sts</termNote></termGrp></ntig>
+      </langSet>
+    </termEntry>
+  </body></text>
+</martif>`
+	file, err := internal_utils.ParseTBX([]byte(v2))
+	require.NoError(t, err)
+	assert.Equal(t, "en", file.Language)
+	require.Len(t, file.Entries, 1)
+	entry := file.Entries[0]
+	assert.Equal(t, "c1", entry.ID)
+	assert.Equal(t, "Shopping basket", entry.Descriptions["definition"])
+	assert.Equal(t, []internal_utils.TBXTerm{{Text: "cart"}}, entry.Languages["en"])
+	assert.Equal(t, []internal_utils.TBXTerm{
+		{Text: "购物车", Status: internal_utils.TBXStatusPreferred},
+		{Text: "手推车", Status: internal_utils.TBXStatusDeprecated},
+	}, entry.Languages["zh-CN"])
+
+	v3 := `<?xml version="1.0" encoding="UTF-8"?>
+<tbx type="TBX-Basic" style="dca" xml:lang="en" xmlns="urn:iso:std:iso:30042:ed-2">
+  <tbxHeader><fileDesc><sourceDesc><p>test</p></sourceDesc></fileDesc></tbxHeader>
+  <text><body>
+    <conceptEntry id="c2">
+      <langSec xml:lang="en"><termSec><term>checkout</term></termSec></langSec>
+      <langSec xml:lang="de"><termSec><term>Kasse</term><termNote type="administrativeStatus">admittedTerm-admn-sts</termNote></termSec></langSec>
+    </conceptEntry>
+  </body></text>
+</tbx>`
+	file, err = internal_utils.ParseTBX([]byte(v3))
+	require.NoError(t, err)
+	require.Len(t, file.Entries, 1)
+	assert.Equal(t, []internal_utils.TBXTerm{{Text: "Kasse", Status: internal_utils.TBXStatusAdmitted}}, file.Entries[0].Languages["de"])
+
+	_, err = internal_utils.ParseTBX([]byte(`<tmx version="1.4"></tmx>`))
+	assert.ErrorIs(t, err, internal_utils.ErrInvalidTBX)
+	_, err = internal_utils.ParseTBX([]byte(`not xml`))
+	assert.ErrorIs(t, err, internal_utils.ErrInvalidTBX)
+}
+
+func TestMarshalTBXRoundTrip(t *testing.T) {
+	original := &internal_utils.TBXFile{
+		Language: "en",
+		Entries: []internal_utils.TBXEntry{{
+			ID:           "g1",
+			Descriptions: map[string]string{"definition": "Shopping basket", "x-caseSensitive": "true"},
+			Languages: map[string][]internal_utils.TBXTerm{
+				"zh-CN": {{Text: "购物车", Status: internal_utils.TBXStatusPreferred}},
+				"en":    {{Text: "cart", Status: internal_utils.TBXStatusPreferred}},
+				"de":    {{Text: "Karren", Status: internal_utils.TBXStatusDeprecated}},
+			},
+		}},
+	}
+
+	data, err := internal_utils.MarshalTBX(original, "test")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<martif type="TBX-Basic" xml:lang="en">`)
+	assert.Contains(t, string(data), "preferredTerm-admn-sts")
+
+	parsed, err := internal_utils.ParseTBX(data)
+	require.NoError(t, err)
+	assert.Equal(t, original.Entries, parsed.Entries)
+}
@@ -0,0 +1,96 @@
+package utils_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", internal_utils.EncodingIdentity},
+		{"gzip, deflate, br", internal_utils.EncodingBrotli},
+		{"gzip", internal_utils.EncodingGzip},
+		{"br;q=0, gzip;q=0.8", internal_utils.EncodingGzip},
+		{"GZIP;q=0.5", internal_utils.EncodingGzip},
+		{"*", internal_utils.EncodingBrotli},
+		{"*;q=0", internal_utils.EncodingIdentity},
+		{"br;q=0, *", internal_utils.EncodingGzip},
+		{"deflate", internal_utils.EncodingIdentity},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, internal_utils.NegotiateEncoding(tt.acceptEncoding), tt.acceptEncoding)
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := internal_utils.StrongETag("abc", internal_utils.EncodingGzip)
+	assert.Equal(t, `"abc-gzip"`, etag)
+	assert.Equal(t, `"abc"`, internal_utils.StrongETag("abc", internal_utils.EncodingIdentity))
+
+	assert.True(t, internal_utils.ETagMatches(`"abc-gzip"`, etag))
+	assert.True(t, internal_utils.ETagMatches(`"x", W/"abc-gzip"`, etag))
+	assert.True(t, internal_utils.ETagMatches("*", etag))
+	assert.False(t, internal_utils.ETagMatches("", etag))
+	assert.False(t, internal_utils.ETagMatches(`"abc"`, etag))
+}
+
+func TestCompressBytes(t *testing.T) {
+	data := []byte(strings.Repeat(`{"home.title":"Welcome"}`, 100))
+
+	gzipped, err := internal_utils.GzipBytes(data)
+	require.NoError(t, err)
+	assert.Less(t, len(gzipped), len(data))
+	gzipReader, err := gzip.NewReader(bytes.NewReader(gzipped))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	compressed, err := internal_utils.BrotliBytes(data)
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(data))
+	decoded, err = io.ReadAll(brotli.NewReader(bytes.NewReader(compressed)))
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestParseEd25519PrivateKey(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, ed25519.SeedSize)
+	expected := ed25519.NewKeyFromSeed(seed)
+
+	fromSeed, err := internal_utils.ParseEd25519PrivateKey(base64.StdEncoding.EncodeToString(seed))
+	require.NoError(t, err)
+	assert.Equal(t, expected, fromSeed)
+
+	fromKey, err := internal_utils.ParseEd25519PrivateKey(base64.StdEncoding.EncodeToString(expected))
+	require.NoError(t, err)
+	assert.Equal(t, expected, fromKey)
+
+	tampered := append([]byte{}, expected...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = internal_utils.ParseEd25519PrivateKey(base64.StdEncoding.EncodeToString(tampered))
+	assert.Error(t, err)
+
+	_, err = internal_utils.ParseEd25519PrivateKey("not base64!")
+	assert.Error(t, err)
+	_, err = internal_utils.ParseEd25519PrivateKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+
+	keyID := internal_utils.Ed25519KeyID(expected.Public().(ed25519.PublicKey))
+	assert.Len(t, keyID, 16)
+}
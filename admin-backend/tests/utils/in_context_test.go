@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestInContextMarkers(t *testing.T) {
+	title := internal_utils.MarkInContext("Welcome, {name}", 12, "home.title")
+	button := internal_utils.MarkInContext("保存", 12, "common.save")
+
+	// 标记只包含零宽字符，去掉标记后为原始值
+	assert.Equal(t, "Welcome, {name}", internal_utils.StripInContextMarkers(title))
+	assert.NotContains(t, strings.ReplaceAll(title, "Welcome, {name}", ""), "home")
+
+	page := "<h1>" + title + "</h1><button>" + button + "</button>" + title
+	assert.Equal(t, []internal_utils.InContextMarker{
+		{ProjectID: 12, KeyName: "home.title"},
+		{ProjectID: 12, KeyName: "common.save"},
+	}, internal_utils.ParseInContextMarkers(page))
+	assert.Equal(t, "<h1>Welcome, {name}</h1><button>保存</button>Welcome, {name}", internal_utils.StripInContextMarkers(page))
+}
+
+func TestParseInContextMarkersInvalid(t *testing.T) {
+	assert.Empty(t, internal_utils.ParseInContextMarkers("plain text"))
+
+	// 截断的标记和混入其他字符的标记被忽略
+	marked := internal_utils.MarkInContext("Hi", 3, "greeting")
+	assert.Empty(t, internal_utils.ParseInContextMarkers(marked[:10]))
+	runes := []rune(marked)
+	corrupted := string(runes[:5]) + "x" + string(runes[5:])
+	assert.Empty(t, internal_utils.ParseInContextMarkers(corrupted))
+}
@@ -0,0 +1,157 @@
+package utils_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestGoKeyExtractor(t *testing.T) {
+	src := []byte(`package main
+
+func handler() {
+	i18n.T("greeting.hello")
+	s.i18n.T(` + "`raw.key`" + `, name)
+	T("bare.key")
+	i18n.T(dynamicKey)
+	fmt.Println("not.a.key")
+}
+`)
+
+	extractor, err := internal_utils.NewGoKeyExtractor([]string{"i18n.T", "T"})
+	require.NoError(t, err)
+
+	keys, err := extractor.Extract("main.go", src)
+	require.NoError(t, err)
+	assert.Equal(t, []internal_utils.ExtractedKey{
+		{Key: "greeting.hello", Line: 4},
+		{Key: "raw.key", Line: 5},
+		{Key: "bare.key", Line: 6},
+	}, keys)
+
+	_, err = extractor.Extract("broken.go", []byte("package main\nfunc {"))
+	assert.Error(t, err)
+
+	_, err = internal_utils.NewGoKeyExtractor(nil)
+	assert.Error(t, err)
+}
+
+func TestI18nextKeyExtractor(t *testing.T) {
+	src := []byte(`const a = t('home.title');
+const b = i18n.t("home.subtitle", { count });
+<p>{$t(` + "`vue.key`" + `)}</p>
+<Trans i18nKey="trans.key" />
+const c = format('not.a.key');
+const d = t('it\'s.escaped');
+const e = t(` + "`tpl.${name}`" + `);
+`)
+
+	keys, err := internal_utils.NewI18nextKeyExtractor().Extract("app.tsx", src)
+	require.NoError(t, err)
+	assert.Equal(t, []internal_utils.ExtractedKey{
+		{Key: "home.title", Line: 1},
+		{Key: "home.subtitle", Line: 2},
+		{Key: "vue.key", Line: 3},
+		{Key: "trans.key", Line: 4},
+		{Key: "it's.escaped", Line: 6},
+	}, keys)
+}
+
+func TestRegexKeyExtractor(t *testing.T) {
+	src := []byte("<?php\necho __('php.welcome');\necho trans(\"php.bye\");\n")
+
+	extractor, err := internal_utils.NewRegexKeyExtractor(`(?:__|trans)\(\s*['"](?P<key>[^'"]+)['"]`)
+	require.NoError(t, err)
+
+	keys, err := extractor.Extract("index.php", src)
+	require.NoError(t, err)
+	assert.Equal(t, []internal_utils.ExtractedKey{
+		{Key: "php.welcome", Line: 2},
+		{Key: "php.bye", Line: 3},
+	}, keys)
+
+	_, err = internal_utils.NewRegexKeyExtractor(`no-group`)
+	assert.Error(t, err)
+	_, err = internal_utils.NewRegexKeyExtractor(`(`)
+	assert.Error(t, err)
+}
+
+func TestWalkSourceArchive(t *testing.T) {
+	files := map[string]string{
+		"src/main.go":               "package main",
+		"src/big.js":                "0123456789abcdef",
+		"node_modules/lib/index.js": "ignored",
+	}
+	accept := func(filePath string) bool { return filePath[:4] == "src/" }
+	limits := internal_utils.SourceArchiveLimits{MaxFiles: 10, MaxFileSize: 12, MaxTotalSize: 1 << 20}
+
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	var tgzBuf bytes.Buffer
+	gz := gzip.NewWriter(&tgzBuf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./" + name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	for name, data := range map[string][]byte{"zip": zipBuf.Bytes(), "tar.gz": tgzBuf.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			read := map[string]string{}
+			stats, err := internal_utils.WalkSourceArchive(data, limits, accept, func(filePath string, content []byte) error {
+				read[filePath] = string(content)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"src/main.go": "package main"}, read)
+			assert.Equal(t, 1, stats.Files)
+			assert.Equal(t, 1, stats.Skipped)
+		})
+	}
+
+	t.Run("limits", func(t *testing.T) {
+		_, err := internal_utils.WalkSourceArchive(zipBuf.Bytes(), internal_utils.SourceArchiveLimits{MaxTotalSize: 5}, nil,
+			func(string, []byte) error { return nil })
+		assert.ErrorIs(t, err, internal_utils.ErrArchiveTooLarge)
+	})
+
+	t.Run("gzip total", func(t *testing.T) {
+		// 被过滤的文件同样计入解压总量
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		padding := bytes.Repeat([]byte{0}, 1<<20)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "node_modules/blob.bin", Mode: 0644, Size: int64(len(padding)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(padding)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+
+		_, err = internal_utils.WalkSourceArchive(buf.Bytes(), internal_utils.SourceArchiveLimits{MaxTotalSize: 64 << 10}, accept,
+			func(string, []byte) error { return nil })
+		assert.ErrorIs(t, err, internal_utils.ErrArchiveTooLarge)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := internal_utils.WalkSourceArchive([]byte("plain text"), limits, nil, func(string, []byte) error { return nil })
+		assert.ErrorIs(t, err, internal_utils.ErrUnsupportedArchive)
+	})
+}
@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"i18n-flow/internal/utils"
+)
+
+func TestParseLocaleFile(t *testing.T) {
+	values, err := utils.ParseLocaleFile(utils.LocaleFormatJSON,
+		[]byte("\xef\xbb\xbf{\"app\": {\"title\": \"App\", \"menu\": {\"open\": \"Open\"}}, \"ok\": \"OK\", \"count\": 3}"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app.title": "App", "app.menu.open": "Open", "ok": "OK"}, values)
+
+	values, err = utils.ParseLocaleFile(utils.LocaleFormatJSONFlat, []byte("  \n"))
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = utils.ParseLocaleFile(utils.LocaleFormatJSON, []byte("[1, 2]"))
+	assert.Error(t, err)
+	_, err = utils.ParseLocaleFile("yaml", []byte("{}"))
+	assert.Error(t, err)
+}
+
+func TestMarshalLocaleFile(t *testing.T) {
+	values := map[string]string{"app.title": "<App>", "app.menu.open": "Open", "ok": "OK"}
+
+	data, err := utils.MarshalLocaleFile(utils.LocaleFormatJSON, values)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"app\": {\n    \"menu\": {\n      \"open\": \"Open\"\n    },\n    \"title\": \"<App>\"\n  },\n  \"ok\": \"OK\"\n}\n", string(data))
+
+	data, err = utils.MarshalLocaleFile(utils.LocaleFormatJSONFlat, values)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"app.menu.open\": \"Open\",\n  \"app.title\": \"<App>\",\n  \"ok\": \"OK\"\n}\n", string(data))
+}
+
+func TestMarshalLocaleFileRoundTrip(t *testing.T) {
+	// 键名的前缀也是键、键名中有空的段时，嵌套格式也能还原出相同的键
+	values := map[string]string{"a": "A", "a.b": "AB", "a.b.c": "ABC", "x..y": "XY", "x.": "X", "m.n": "MN"}
+	for _, format := range []string{utils.LocaleFormatJSON, utils.LocaleFormatJSONFlat} {
+		data, err := utils.MarshalLocaleFile(format, values)
+		require.NoError(t, err)
+		parsed, err := utils.ParseLocaleFile(format, data)
+		require.NoError(t, err)
+		assert.Equal(t, values, parsed, format)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	for _, template := range []string{"locales/{lang}.json", "{lang}/messages.json", "src/i18n/{lang}/{lang}.json"} {
+		assert.True(t, utils.IsPathTemplate(template), template)
+	}
+	for _, template := range []string{"", "locales/en.json", "/locales/{lang}.json", "../{lang}.json", "locales/../../{lang}.json",
+		"./locales/{lang}.json", ".git/{lang}.json", "locales\\{lang}.json", "locales//{lang}.json"} {
+		assert.False(t, utils.IsPathTemplate(template), template)
+	}
+
+	assert.Equal(t, "src/i18n/zh-CN/zh-CN.json", utils.RenderPathTemplate("src/i18n/{lang}/{lang}.json", "zh-CN"))
+}
@@ -0,0 +1,114 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+var placeholderSyntaxes = []string{
+	internal_utils.PlaceholderBrace,
+	internal_utils.PlaceholderDoubleBrace,
+	internal_utils.PlaceholderAndroid,
+	internal_utils.PlaceholderIOS,
+	internal_utils.PlaceholderGettext,
+}
+
+func TestConvertPlaceholders(t *testing.T) {
+	reference := "Hello {name}, you have {count} messages"
+	signature := internal_utils.PlaceholderSignatureOf(reference, internal_utils.PlaceholderBrace)
+
+	tests := []struct {
+		to       string
+		value    string
+		expected string
+	}{
+		{internal_utils.PlaceholderDoubleBrace, reference, "Hello {{name}}, you have {{count}} messages"},
+		{internal_utils.PlaceholderAndroid, reference, "Hello %1$s, you have %2$s messages"},
+		{internal_utils.PlaceholderIOS, reference, "Hello %1$@, you have %2$@ messages"},
+		{internal_utils.PlaceholderGettext, reference, "Hello %(name)s, you have %(count)s messages"},
+		// 译文中参数顺序不同时使用参考文本的编号
+		{internal_utils.PlaceholderAndroid, "{count} Nachrichten für {name}, 100%", "%2$s Nachrichten für %1$s, 100%%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.to, func(t *testing.T) {
+			converted, issues := internal_utils.ConvertPlaceholders(tt.value, internal_utils.PlaceholderBrace, tt.to, signature)
+			assert.Empty(t, issues)
+			assert.Equal(t, tt.expected, converted)
+
+			back, issues := internal_utils.ConvertPlaceholders(converted, tt.to, internal_utils.PlaceholderBrace, signature)
+			assert.Empty(t, issues)
+			assert.Equal(t, tt.value, back)
+		})
+	}
+}
+
+func TestConvertPlaceholdersRoundTrip(t *testing.T) {
+	values := map[string]string{
+		internal_utils.PlaceholderBrace:       "{user} uploaded {count} files ({count}) at 50%",
+		internal_utils.PlaceholderDoubleBrace: "{{user}} uploaded {{count}} files",
+		internal_utils.PlaceholderAndroid:     "%1$s uploaded %2$d files, 50%% done",
+		internal_utils.PlaceholderIOS:         "%@ uploaded %ld files",
+		internal_utils.PlaceholderGettext:     "%(user)s uploaded %(count)d files, 50%% done",
+	}
+
+	for canonical, value := range values {
+		signature := internal_utils.PlaceholderSignatureOf(value, canonical)
+		for _, target := range placeholderSyntaxes {
+			t.Run(canonical+"->"+target, func(t *testing.T) {
+				converted, issues := internal_utils.ConvertPlaceholders(value, canonical, target, signature)
+				assert.Empty(t, issues, converted)
+
+				back, issues := internal_utils.ConvertPlaceholders(converted, target, canonical, signature)
+				assert.Empty(t, issues)
+				assert.Equal(t, value, back)
+			})
+		}
+	}
+}
+
+func TestConvertPlaceholdersUntranslatable(t *testing.T) {
+	signature := internal_utils.PlaceholderSignatureOf("%1$s", internal_utils.PlaceholderAndroid)
+
+	// 参考文本中不存在的参数无法编号
+	converted, issues := internal_utils.ConvertPlaceholders("{name} {extra}", internal_utils.PlaceholderBrace, internal_utils.PlaceholderAndroid,
+		internal_utils.PlaceholderSignatureOf("{name}", internal_utils.PlaceholderBrace))
+	assert.Equal(t, "%1$s {extra}", converted)
+	assert.Equal(t, []string{"{extra}"}, issues)
+
+	// 超出参考文本的编号没有参数名
+	converted, issues = internal_utils.ConvertPlaceholders("%1$s %3$s", internal_utils.PlaceholderAndroid, internal_utils.PlaceholderBrace, signature)
+	assert.Equal(t, "{arg1} %3$s", converted)
+	assert.Equal(t, []string{"%3$s"}, issues)
+
+	// 花括号语法无法保留与参考文本不同的类型
+	_, issues = internal_utils.ConvertPlaceholders("%1$d", internal_utils.PlaceholderAndroid, internal_utils.PlaceholderBrace, signature)
+	assert.Equal(t, []string{"%1$d"}, issues)
+
+	// 字面文本在目标语法中会被识别为占位符
+	_, issues = internal_utils.ConvertPlaceholders("%1$s {literal}", internal_utils.PlaceholderAndroid, internal_utils.PlaceholderBrace, signature)
+	assert.Equal(t, []string{"{literal}"}, issues)
+}
+
+func TestSamePlaceholders(t *testing.T) {
+	tests := []struct {
+		source, target, syntax string
+		expected               bool
+	}{
+		{"Hello {name}", "Hallo {name}", internal_utils.PlaceholderBrace, true},
+		{"{count} of {total}", "{total} von {count} ({count})", internal_utils.PlaceholderBrace, true},
+		{"Hello {name}", "Hallo {nome}", internal_utils.PlaceholderBrace, false},
+		{"Hello {name}", "Hallo", internal_utils.PlaceholderBrace, false},
+		{"%1$s of %2$d", "%2$d von %1$s", internal_utils.PlaceholderAndroid, true},
+		{"%s of %d", "%1$s von %2$d", internal_utils.PlaceholderAndroid, true},
+		{"%1$s of %2$d", "%2$d", internal_utils.PlaceholderAndroid, false},
+		{"100%% done", "fertig", internal_utils.PlaceholderAndroid, true},
+		{"Hello {name}", "Hallo", "unknown", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, internal_utils.SamePlaceholders(tt.source, tt.target, tt.syntax), "%s / %s", tt.source, tt.target)
+	}
+}
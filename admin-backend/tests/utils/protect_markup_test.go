@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestProtectMarkup(t *testing.T) {
+	text, tokens := internal_utils.ProtectMarkup(`Hi <b>{name}</b> & welcome`, internal_utils.PlaceholderBrace)
+	assert.Equal(t, `Hi <x id="0"/><x id="1"/><x id="2"/> &amp; welcome`, text)
+	assert.Equal(t, []string{"<b>", "{name}", "</b>"}, tokens)
+
+	// 未指定占位符语法时只保护 HTML 标签
+	text, tokens = internal_utils.ProtectMarkup(`{name} <br/>`, "")
+	assert.Equal(t, `{name} <x id="0"/>`, text)
+	assert.Equal(t, []string{"<br/>"}, tokens)
+
+	// 标签属性中的占位符随标签一起保护
+	text, tokens = internal_utils.ProtectMarkup(`<a href="{url}">Open</a>`, internal_utils.PlaceholderBrace)
+	assert.Equal(t, `<x id="0"/>Open<x id="1"/>`, text)
+	assert.Equal(t, []string{`<a href="{url}">`, "</a>"}, tokens)
+}
+
+func TestRestoreMarkup(t *testing.T) {
+	_, tokens := internal_utils.ProtectMarkup(`Hi <b>%1$s</b>`, internal_utils.PlaceholderAndroid)
+
+	value, err := internal_utils.RestoreMarkup(`Hallo <x id="0"/><x id="1"></x><x id="2"/> &amp; mehr`, tokens)
+	require.NoError(t, err)
+	assert.Equal(t, `Hallo <b>%1$s</b> & mehr`, value)
+
+	// 顺序可以调整
+	value, err = internal_utils.RestoreMarkup(`<x id="1"/> <x id="0"/><x id="2"/>`, tokens)
+	require.NoError(t, err)
+	assert.Equal(t, `%1$s <b></b>`, value)
+
+	_, err = internal_utils.RestoreMarkup(`Hallo <x id="0"/><x id="2"/>`, tokens)
+	assert.ErrorIs(t, err, internal_utils.ErrProtectedTokenMismatch)
+
+	_, err = internal_utils.RestoreMarkup(`<x id="0"/><x id="1"/><x id="1"/><x id="2"/>`, tokens)
+	assert.ErrorIs(t, err, internal_utils.ErrProtectedTokenMismatch)
+
+	_, err = internal_utils.RestoreMarkup(`<x id="0"/><x id="1"/><x id="2"/><x id="3"/>`, tokens)
+	assert.ErrorIs(t, err, internal_utils.ErrProtectedTokenMismatch)
+}
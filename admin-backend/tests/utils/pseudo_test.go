@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestPseudolocalize(t *testing.T) {
+	plain := internal_utils.PseudoOptions{}
+	assert.Equal(t, "Ĥéļļö, ŵöŕļð!", internal_utils.Pseudolocalize("Hello, world!", "", plain))
+	assert.Equal(t, "", internal_utils.Pseudolocalize("", "", internal_utils.PseudoOptions{Brackets: true}))
+
+	// 占位符、HTML 标签和实体保持不变
+	assert.Equal(t, `Ĥîîî <b>{name}</b> &amp; ţĥéɱ`,
+		internal_utils.Pseudolocalize(`Hiii <b>{name}</b> &amp; them`, internal_utils.PlaceholderBrace, plain))
+	assert.Equal(t, `Šåý %1$s <a href="/x">ĥéŕé</a>`,
+		internal_utils.Pseudolocalize(`Say %1$s <a href="/x">here</a>`, internal_utils.PlaceholderAndroid, plain))
+	assert.Equal(t, `Ĥîîî {{ user }}`,
+		internal_utils.Pseudolocalize(`Hiii {{ user }}`, internal_utils.PlaceholderDoubleBrace, plain))
+}
+
+func TestPseudolocalizeICU(t *testing.T) {
+	plain := internal_utils.PseudoOptions{}
+
+	assert.Equal(t, "{count, plural, =0 {Ñö ƒîļéš} one {# ƒîļé} other {# ƒîļéš}}",
+		internal_utils.Pseudolocalize("{count, plural, =0 {No files} one {# file} other {# files}}", internal_utils.PlaceholderBrace, plain))
+	assert.Equal(t, "{gender, select, female {Šĥé} other {Ţĥéý}} ļîķéš {n, number, percent}",
+		internal_utils.Pseudolocalize("{gender, select, female {She} other {They}} likes {n, number, percent}", internal_utils.PlaceholderBrace, plain))
+	assert.Equal(t, "{n, plural, offset:1 one {{name}} other {{name} åñð #}}",
+		internal_utils.Pseudolocalize("{n, plural, offset:1 one {{name}} other {{name} and #}}", internal_utils.PlaceholderBrace, plain))
+
+	// 引号转义的内容保持不变，未闭合的花括号按普通文本处理
+	assert.Equal(t, "Ûšé '{braces}' {", internal_utils.Pseudolocalize("Use '{braces}' {", internal_utils.PlaceholderBrace, plain))
+}
+
+func TestPseudolocalizeExpansionBracketsRTL(t *testing.T) {
+	assert.Equal(t, "[Ĥéļļö öñé]",
+		internal_utils.Pseudolocalize("Hello", "", internal_utils.PseudoOptions{Expansion: 80, Brackets: true}))
+	assert.Equal(t, "[Ĥéļļö]",
+		internal_utils.Pseudolocalize("Hello", "", internal_utils.PseudoOptions{Brackets: true}))
+
+	// 从右到左覆盖只包裹文本，不包裹占位符
+	assert.Equal(t, "\u202eĤî \u202c{name}",
+		internal_utils.Pseudolocalize("Hi {name}", internal_utils.PlaceholderBrace, internal_utils.PseudoOptions{RTL: true}))
+}
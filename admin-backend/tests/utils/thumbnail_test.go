@@ -0,0 +1,71 @@
+package utils_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+// encodeTestImage 生成纯色测试图片
+func encodeTestImage(t *testing.T, width, height int, asJPEG bool) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if asJPEG {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+func TestInspectImage(t *testing.T) {
+	info, err := internal_utils.InspectImage(encodeTestImage(t, 640, 360, false))
+	require.NoError(t, err)
+	assert.Equal(t, "png", info.Format)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, 640, info.Width)
+	assert.Equal(t, 360, info.Height)
+
+	_, err = internal_utils.InspectImage([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"))
+	assert.ErrorIs(t, err, internal_utils.ErrUnsupportedImage)
+}
+
+func TestThumbnail(t *testing.T) {
+	// 按宽度等比缩小，PNG 输出 PNG
+	data, contentType, err := internal_utils.Thumbnail(encodeTestImage(t, 640, 360, false), 320)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 320, img.Bounds().Dx())
+	assert.Equal(t, 180, img.Bounds().Dy())
+	r, g, b, _ := img.At(10, 10).RGBA()
+	assert.InDelta(t, 200, r>>8, 1)
+	assert.InDelta(t, 40, g>>8, 1)
+	assert.InDelta(t, 40, b>>8, 1)
+
+	// JPEG 输出 JPEG
+	_, contentType, err = internal_utils.Thumbnail(encodeTestImage(t, 400, 100, true), 100)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+
+	// 比缩略图小的图片不放大
+	data, _, err = internal_utils.Thumbnail(encodeTestImage(t, 50, 20, false), 320)
+	require.NoError(t, err)
+	img, _, err = image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 50, img.Bounds().Dx())
+}
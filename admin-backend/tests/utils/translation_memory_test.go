@@ -0,0 +1,99 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 3, internal_utils.EditDistance("kitten", "sitting"))
+	assert.Equal(t, 1, internal_utils.EditDistance("保存文件", "保存文档"))
+	assert.Equal(t, 4, internal_utils.EditDistance("", "保存文件"))
+
+	assert.Equal(t, 1.0, internal_utils.Similarity("Save  file ", "Save file"))
+	caseOnly := internal_utils.Similarity("Save file", "save file")
+	fuzzy := internal_utils.Similarity("Save file", "Save fire")
+	assert.Less(t, caseOnly, 1.0)
+	assert.Greater(t, caseOnly, fuzzy)
+	assert.InDelta(t, 1-1.0/9, fuzzy, 1e-9)
+	assert.Equal(t, 0.0, internal_utils.Similarity("abc", "xyzxyz"))
+}
+
+func TestLengthWindow(t *testing.T) {
+	lower, upper := internal_utils.LengthWindow("0123456789", 0.8)
+	assert.Equal(t, 6, lower)
+	assert.Equal(t, 14, upper)
+}
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"translation", "memory", "Open", "the"},
+		internal_utils.SearchTerms("Open the translation memory, or go", 5))
+	assert.Equal(t, []string{"保存", "存文", "文件"}, internal_utils.SearchTerms("保存文件", 5))
+	assert.Len(t, internal_utils.SearchTerms("请在保存之前检查所有的翻译内容", 4), 4)
+}
+
+func TestParseTMX(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE tmx SYSTEM "tmx14.dtd">
+<tmx version="1.4">
+  <header creationtool="Agency" creationtoolversion="1" segtype="sentence" o-tmf="x" adminlang="en" srclang="en-US" datatype="plaintext"/>
+  <body>
+    <tu tuid="1">
+      <prop type="x-domain">ui</prop>
+      <tuv xml:lang="en-US"><seg>Hello <ph x="1">{name}</ph> &amp; welcome</seg></tuv>
+      <tuv xml:lang="de-DE"><seg>Hallo <ph x="1">{name}</ph> &amp; willkommen</seg></tuv>
+    </tu>
+    <tu srclang="fr">
+      <tuv lang="fr"><seg>Bonjour</seg></tuv>
+      <tuv lang="en"><seg>Hello</seg></tuv>
+    </tu>
+  </body>
+</tmx>`)
+
+	file, err := internal_utils.ParseTMX(data)
+	require.NoError(t, err)
+	assert.Equal(t, "en-US", file.SourceLanguage)
+	require.Len(t, file.Units, 2)
+	assert.Equal(t, "1", file.Units[0].ID)
+	assert.Equal(t, map[string]string{"x-domain": "ui"}, file.Units[0].Properties)
+	assert.Equal(t, map[string]string{
+		"en-US": "Hello {name} & welcome",
+		"de-DE": "Hallo {name} & willkommen",
+	}, file.Units[0].Segments)
+	assert.Equal(t, "fr", file.Units[1].SourceLanguage)
+	assert.Equal(t, map[string]string{"fr": "Bonjour", "en": "Hello"}, file.Units[1].Segments)
+
+	_, err = internal_utils.ParseTMX([]byte("<tmx><body><tu>"))
+	assert.ErrorIs(t, err, internal_utils.ErrInvalidTMX)
+}
+
+func TestMarshalTMXRoundTrip(t *testing.T) {
+	original := &internal_utils.TMXFile{
+		SourceLanguage: "en",
+		Units: []internal_utils.TMXUnit{
+			{
+				ID:         "web:home.title",
+				Segments:   map[string]string{"zh-CN": "欢迎 <b>{name}</b>", "en": "Welcome <b>{name}</b>", "de": "Willkommen"},
+				Properties: map[string]string{"x-key": "home.title"},
+			},
+		},
+	}
+
+	data, err := internal_utils.MarshalTMX(original, "i18n-flow", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `creationdate="20260102T030405Z"`)
+	assert.Contains(t, string(data), `xml:lang="en"`)
+	assert.Less(t, strings.Index(string(data), `xml:lang="en"`), strings.Index(string(data), `xml:lang="de"`))
+
+	parsed, err := internal_utils.ParseTMX(data)
+	require.NoError(t, err)
+	require.Len(t, parsed.Units, 1)
+	assert.Equal(t, original.Units[0].Segments, parsed.Units[0].Segments)
+	assert.Equal(t, original.Units[0].Properties, parsed.Units[0].Properties)
+}
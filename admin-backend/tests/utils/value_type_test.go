@@ -0,0 +1,75 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestValidateValueTypePlain(t *testing.T) {
+	// 纯文本不限制内容，输出时按文本转义
+	assert.NoError(t, internal_utils.ValidateValueType(internal_utils.ValueTypePlain, `<script>alert(1)</script>`))
+	assert.NoError(t, internal_utils.ValidateValueType("", "a &nbsp; b"))
+	assert.Error(t, internal_utils.ValidateValueType("rich", "text"))
+}
+
+func TestValidateValueTypeHTMLSubset(t *testing.T) {
+	valid := []string{
+		`Read the <a href="https://example.com/terms" target="_blank" rel="noopener">terms</a>`,
+		`<span class="price">{amount}</span>&nbsp;total`,
+		`Click <0>here</0> or <1/> to continue`,
+		`<strong>Note:</strong> line one<br/>line two`,
+		`<a href="/settings#profile">profile</a> <a href="mailto:help@example.com">mail</a>`,
+	}
+	for _, value := range valid {
+		assert.NoError(t, internal_utils.ValidateValueType(internal_utils.ValueTypeHTMLSubset, value), value)
+	}
+
+	invalid := []string{
+		`<script>alert(1)</script>`,
+		`<img src="x.png">`,
+		`<span onclick="steal()">x</span>`,
+		`<a href="javascript:alert(1)">x</a>`,
+		`<a href="java&#x09;script:alert(1)">x</a>`,
+		`<a href=" JavaScript:alert(1)">x</a>`,
+		`<!-- hidden -->text`,
+		`<span style="color:red">x</span>`,
+	}
+	for _, value := range invalid {
+		assert.Error(t, internal_utils.ValidateValueType(internal_utils.ValueTypeHTMLSubset, value), value)
+	}
+}
+
+func TestValidateValueTypeMarkdown(t *testing.T) {
+	valid := []string{
+		"**Bold** and _italic_ with [docs](https://example.com \"Docs\")",
+		"Line one<br>line two, see [settings](/settings)",
+		"Press <0>Save</0> to continue",
+		"[ref]: https://example.com/ref",
+		"Use `a < b` in code",
+	}
+	for _, value := range valid {
+		assert.NoError(t, internal_utils.ValidateValueType(internal_utils.ValueTypeMarkdown, value), value)
+	}
+
+	invalid := []string{
+		"[click](javascript:alert(1))",
+		"![img](data:image/svg+xml;base64,AAAA)",
+		"[ref]: javascript:alert(1)",
+		"Some <span class=\"x\">html</span>",
+		"<script>alert(1)</script>",
+	}
+	for _, value := range invalid {
+		assert.Error(t, internal_utils.ValidateValueType(internal_utils.ValueTypeMarkdown, value), value)
+	}
+}
+
+func TestIsValueType(t *testing.T) {
+	assert.True(t, internal_utils.IsValueType("plain"))
+	assert.True(t, internal_utils.IsValueType("html_subset"))
+	assert.True(t, internal_utils.IsValueType("markdown"))
+	assert.False(t, internal_utils.IsValueType(""))
+	assert.False(t, internal_utils.IsValueType("html"))
+}
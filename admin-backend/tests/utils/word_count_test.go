@@ -0,0 +1,36 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internal_utils "i18n-flow/internal/utils"
+)
+
+func TestCountText(t *testing.T) {
+	cases := []struct {
+		text       string
+		syntax     string
+		words      int
+		characters int
+	}{
+		{"Save changes", "", 2, 11},
+		{"Don't use e-mail, it costs 3.50 USD.", "", 7, 30},
+		{"保存更改", "", 4, 4},
+		{"設定を保存しました", "", 9, 9},
+		{"변경 사항 저장", "", 3, 6},
+		{"Hello {name}, you have {count} messages", internal_utils.PlaceholderBrace, 4, 21},
+		{"<strong>Note:</strong> read&nbsp;the <a href=\"/terms\">terms</a>", "", 4, 17},
+		{"打开 Settings 页面", "", 5, 12},
+		{"  ", "", 0, 0},
+	}
+	for _, c := range cases {
+		count := internal_utils.CountText(c.text, c.syntax)
+		assert.Equal(t, c.words, count.Words, c.text)
+		assert.Equal(t, c.characters, count.Characters, c.text)
+	}
+
+	// 未指定语法时占位符按普通文本计数
+	assert.Equal(t, 2, internal_utils.CountWords("Hello {name}", ""))
+}